@@ -5,8 +5,10 @@ import (
 	"log"
 	"os"
 	"os/signal"
+	"slices"
 	"syscall"
 	"time"
+	_ "time/tzdata" // Embed timezone data, the runtime image has none
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
@@ -15,19 +17,50 @@ import (
 	"github.com/Arlan-Z/def-betting-api/internal/app/connections"
 	"github.com/Arlan-Z/def-betting-api/internal/app/start"
 	"github.com/Arlan-Z/def-betting-api/internal/app/store"
+	"github.com/Arlan-Z/def-betting-api/internal/data"
+	"github.com/Arlan-Z/def-betting-api/internal/pkg/breaker"
+	"github.com/Arlan-Z/def-betting-api/internal/pkg/webhook"
 
 	bet_delivery "github.com/Arlan-Z/def-betting-api/internal/deliveries/bet/http"
+	confirmation_delivery "github.com/Arlan-Z/def-betting-api/internal/deliveries/confirmation/http"
+	conflict_delivery "github.com/Arlan-Z/def-betting-api/internal/deliveries/conflict/http"
+	eventbroker "github.com/Arlan-Z/def-betting-api/internal/deliveries/event/broker"
 	event_delivery "github.com/Arlan-Z/def-betting-api/internal/deliveries/event/http"
+	eventsource_file "github.com/Arlan-Z/def-betting-api/internal/deliveries/eventsource/file"
 	eventsource_client "github.com/Arlan-Z/def-betting-api/internal/deliveries/eventsource/http"
 	health_delivery "github.com/Arlan-Z/def-betting-api/internal/deliveries/health/http"
-	payout_client "github.com/Arlan-Z/def-betting-api/internal/deliveries/payout/http"
+	ingest_delivery "github.com/Arlan-Z/def-betting-api/internal/deliveries/ingest/http"
+	payout_delivery "github.com/Arlan-Z/def-betting-api/internal/deliveries/payout/http"
+	quarantine_delivery "github.com/Arlan-Z/def-betting-api/internal/deliveries/quarantine/http"
+	resettlement_delivery "github.com/Arlan-Z/def-betting-api/internal/deliveries/resettlement/http"
+	review_delivery "github.com/Arlan-Z/def-betting-api/internal/deliveries/review/http"
+	syncrun_delivery "github.com/Arlan-Z/def-betting-api/internal/deliveries/syncrun/http"
+	team_delivery "github.com/Arlan-Z/def-betting-api/internal/deliveries/team/http"
 
 	bet_service "github.com/Arlan-Z/def-betting-api/internal/services/bet"
+	confirmation_service "github.com/Arlan-Z/def-betting-api/internal/services/confirmation"
+	conflict_service "github.com/Arlan-Z/def-betting-api/internal/services/conflict"
 	event_service "github.com/Arlan-Z/def-betting-api/internal/services/event"
+	leader_service "github.com/Arlan-Z/def-betting-api/internal/services/leader"
+	market_service "github.com/Arlan-Z/def-betting-api/internal/services/market"
+	payout_service "github.com/Arlan-Z/def-betting-api/internal/services/payout"
+	quarantine_service "github.com/Arlan-Z/def-betting-api/internal/services/quarantine"
+	resettlement_service "github.com/Arlan-Z/def-betting-api/internal/services/resettlement"
+	review_service "github.com/Arlan-Z/def-betting-api/internal/services/review"
 	sync_service "github.com/Arlan-Z/def-betting-api/internal/services/sync"
+	syncrun_service "github.com/Arlan-Z/def-betting-api/internal/services/syncrun"
+	team_service "github.com/Arlan-Z/def-betting-api/internal/services/team"
 
 	bet_uc "github.com/Arlan-Z/def-betting-api/internal/usecases/bet"
+	confirmation_uc "github.com/Arlan-Z/def-betting-api/internal/usecases/confirmation"
+	conflict_uc "github.com/Arlan-Z/def-betting-api/internal/usecases/conflict"
 	event_uc "github.com/Arlan-Z/def-betting-api/internal/usecases/event"
+	payout_uc "github.com/Arlan-Z/def-betting-api/internal/usecases/payout"
+	quarantine_uc "github.com/Arlan-Z/def-betting-api/internal/usecases/quarantine"
+	resettlement_uc "github.com/Arlan-Z/def-betting-api/internal/usecases/resettlement"
+	review_uc "github.com/Arlan-Z/def-betting-api/internal/usecases/review"
+	syncrun_uc "github.com/Arlan-Z/def-betting-api/internal/usecases/syncrun"
+	team_uc "github.com/Arlan-Z/def-betting-api/internal/usecases/team"
 
 	"go.uber.org/zap"
 )
@@ -46,10 +79,22 @@ func main() {
 	sugar.Infof("Database path: %s", cfg.Database.Path)
 	sugar.Infof("Payout service URL: %s", cfg.PayoutService.URL)
 	sugar.Infof("Server port: %s", cfg.HTTPServer.Port)
-	sugar.Infof("Event Source API URL: %s", cfg.EventSourceAPI.URL)
-	sugar.Infof("Event Sync Interval: %s", cfg.EventSourceAPI.SyncInterval)
 
-	db, err := connections.NewSQLiteConnection(cfg.Database.Path)
+	providerSettings, err := cfg.Providers()
+	if err != nil {
+		sugar.Fatalf("Invalid event provider configuration: %v", err)
+	}
+	if cfg.EventBroker.Enabled && !slices.ContainsFunc(providerSettings, func(p config.EventProvider) bool {
+		return p.Name == cfg.EventBroker.Provider
+	}) {
+		sugar.Fatalf("Event broker provider '%s' is not configured", cfg.EventBroker.Provider)
+	}
+
+	db, err := connections.NewSQLiteConnection(cfg.Database.Path, connections.SQLiteOptions{
+		WAL:          true,
+		BusyTimeout:  cfg.Database.BusyTimeout,
+		MaxOpenConns: cfg.Database.MaxOpenConns,
+	})
 	if err != nil {
 		sugar.Fatalf("Failed to connect to database: %v", err)
 	}
@@ -57,40 +102,241 @@ func main() {
 	repositoryStore := store.NewStore(db, logger)
 	sugar.Info("Repository store initialized")
 
-	payoutClient := payout_client.NewRestyPayoutClient(cfg.PayoutService.URL, cfg.PayoutService.Timeout, logger)
-	eventSourceClient := eventsource_client.NewRestyEventSourceClient(cfg.EventSourceAPI.URL, cfg.EventSourceAPI.Timeout, logger)
+	payoutClient := payout_delivery.NewRestyPayoutClient(cfg.PayoutService.URL, cfg.PayoutService.Timeout, logger)
+	providers := make([]sync_service.Provider, 0, len(providerSettings))
+	webhookSecrets := make(map[string]string)
+	for _, p := range providerSettings {
+		mapper, err := data.NewEventMapperForTimezone(p.Timezone, p.DateLayouts)
+		if err != nil {
+			sugar.Fatalf("Failed to configure event mapping for provider %s: %v", p.Name, err)
+		}
+		var client eventsource_client.EventSourceClient
+		var sourceBreaker *breaker.Breaker
+		source := p.URL
+		if p.File != "" {
+			client = eventsource_file.NewClient(p.File, logger.With(zap.String("provider", p.Name)))
+			source = "file " + p.File
+		} else {
+			auth, err := start.NewEventSourceAuth(p, logger)
+			if err != nil {
+				sugar.Fatalf("Failed to configure authentication of event provider %s: %v", p.Name, err)
+			}
+			sourceBreaker = breaker.New(cfg.EventSourceResilience.BreakerFailures, cfg.EventSourceResilience.BreakerOpenFor)
+			client = eventsource_client.NewResilientClient(
+				eventsource_client.NewRestyEventSourceClient(p.URL, p.Timeout, auth, logger),
+				eventsource_client.RetryPolicy{
+					Retries: cfg.EventSourceResilience.RetryCount,
+					Wait:    cfg.EventSourceResilience.RetryWait,
+					MaxWait: cfg.EventSourceResilience.RetryMaxWait,
+				},
+				sourceBreaker,
+				logger.With(zap.String("provider", p.Name)),
+			)
+		}
+		if p.RecordDir != "" {
+			recorder, err := eventsource_file.NewRecorder(client, p.RecordDir, logger.With(zap.String("provider", p.Name)))
+			if err != nil {
+				sugar.Fatalf("Failed to set up recording of event provider %s: %v", p.Name, err)
+			}
+			client = recorder
+			sugar.Infof("Recording fetches of event provider %s to %s", p.Name, p.RecordDir)
+		}
+		providers = append(providers, sync_service.Provider{
+			Name:     p.Name,
+			Client:   client,
+			Mapper:   mapper,
+			Interval: p.SyncInterval,
+			Breaker:  sourceBreaker,
+		})
+		if p.WebhookSecret != "" {
+			webhookSecrets[p.Name] = p.WebhookSecret
+		}
+		sugar.Infof("Event provider %s: %s (interval %s, timezone %s)", p.Name, source, p.SyncInterval, p.Timezone)
+	}
 	sugar.Info("External clients initialized")
 
 	eventUseCase := event_uc.NewUseCase(
 		repositoryStore.Event,
 		repositoryStore.Bet,
-		payoutClient,
 		logger,
 	)
+	sportCutoffs, err := cfg.SportCutoffs()
+	if err != nil {
+		sugar.Fatalf("Invalid betting configuration: %v", err)
+	}
+	betCutoff := data.BetCutoffPolicy{
+		Default: cfg.Betting.DefaultCutoff,
+		BySport: sportCutoffs,
+	}
 	betUseCase := bet_uc.NewUseCase(
 		repositoryStore.Bet,
 		repositoryStore.Event,
+		betCutoff,
+		logger,
+	)
+	reviewUseCase := review_uc.NewUseCase(
+		repositoryStore.Review,
+		repositoryStore.Bet,
+		eventUseCase,
+		logger,
+	)
+	teamUseCase := team_uc.NewUseCase(
+		repositoryStore.Team,
+		repositoryStore.Competition,
+		repositoryStore.Event,
+		logger,
+	)
+	conflictUseCase := conflict_uc.NewUseCase(
+		repositoryStore.Provider,
+		eventUseCase,
+		logger,
+	)
+	resettlementUseCase := resettlement_uc.NewUseCase(
+		repositoryStore.Event,
+		repositoryStore.Bet,
+		repositoryStore.Resettlement,
 		logger,
 	)
+	confirmationUseCase := confirmation_uc.NewUseCase(
+		repositoryStore.Provider,
+		eventUseCase,
+		resettlementUseCase,
+		logger,
+	)
+	syncRunUseCase := syncrun_uc.NewUseCase(repositoryStore.SyncRun, repositoryStore.Provider, logger)
 	sugar.Info("Use cases initialized")
 
+	syncPolicy := sync_service.Policy{
+		RescheduleThreshold: cfg.EventSync.RescheduleThreshold,
+		PostponedVoidAfter:  cfg.EventSync.PostponedVoidAfter,
+		LateBetPolicy:       cfg.EventSync.LateBetPolicy,
+		RunRetention:        cfg.EventSync.RunRetention,
+		MissingSuspendAfter: cfg.EventSync.MissingSuspendAfter,
+		MissingVoidAfter:    cfg.EventSync.MissingVoidAfter,
+		ResultConfirmation: data.ResultConfirmationPolicy{
+			Cycles:    cfg.EventSync.ResultConfirmCycles,
+			StableFor: cfg.EventSync.ResultConfirmAfter,
+		},
+		ResettleOnCorrection: cfg.EventSync.ResettleOnCorrection,
+		StaleSuspendAfter:    cfg.EventSync.StaleSuspendAfter,
+		Cutoff:               betCutoff,
+		Workers:              cfg.EventSync.Workers,
+		CycleTimeout:         cfg.EventSync.CycleTimeout,
+		Merge: data.MergeRules{
+			Odds:     cfg.EventMerge.Odds,
+			Schedule: cfg.EventMerge.Schedule,
+			Results:  cfg.EventMerge.Results,
+		},
+		MatchWindow: cfg.EventMerge.MatchWindow,
+	}
+	if err := syncPolicy.Validate(); err != nil {
+		sugar.Fatalf("Invalid event_sync configuration: %v", err)
+	}
 	eventSyncer := sync_service.NewEventSyncer(
-		eventSourceClient,
+		providers,
 		repositoryStore.Event,
+		repositoryStore.Provider,
+		repositoryStore.SyncRun,
+		repositoryStore.Quarantine,
 		eventUseCase,
 		betUseCase,
-		cfg.EventSourceAPI.SyncInterval,
+		reviewUseCase,
+		teamUseCase,
+		resettlementUseCase,
+		syncPolicy,
 		logger,
 	)
 	sugar.Info("Event syncer service initialized")
 
+	// Replays go through the syncer, so this use case is built after it.
+	quarantineUseCase := quarantine_uc.NewUseCase(repositoryStore.Quarantine, eventSyncer, logger)
+
+	marketCloser := market_service.NewMarketCloser(
+		repositoryStore.Event,
+		betCutoff,
+		cfg.Betting.CloseCheckInterval,
+		logger,
+	)
+	sugar.Info("Market closer service initialized")
+
+	payoutDispatcher := payout_service.NewDispatcher(
+		repositoryStore.Outbox,
+		payoutClient,
+		payout_service.DispatchPolicy{
+			Interval:     cfg.PayoutOutbox.DispatchInterval,
+			BatchSize:    cfg.PayoutOutbox.BatchSize,
+			MaxAttempts:  cfg.PayoutOutbox.MaxAttempts,
+			RetryBackoff: cfg.PayoutOutbox.RetryBackoff,
+			MaxBackoff:   cfg.PayoutOutbox.MaxBackoff,
+			Currency:     cfg.PayoutService.Currency,
+		},
+		logger,
+	)
+	sugar.Info("Payout dispatcher initialized")
+
+	payoutUseCase := payout_uc.NewUseCase(repositoryStore.Outbox, logger)
+
+	var eventConsumer *eventbroker.Consumer
+	if cfg.EventBroker.Enabled {
+		eventConsumer = eventbroker.NewConsumer(
+			repositoryStore.Broker,
+			eventSyncer,
+			repositoryStore.Broker,
+			eventbroker.Config{
+				Topic:           cfg.EventBroker.Topic,
+				DeadLetterTopic: cfg.EventBroker.DeadLetterTopic,
+				Group:           cfg.EventBroker.Group,
+				Provider:        cfg.EventBroker.Provider,
+				MaxAttempts:     cfg.EventBroker.MaxAttempts,
+				RetryBackoff:    cfg.EventBroker.RetryBackoff,
+				BatchSize:       cfg.EventBroker.BatchSize,
+				PollWait:        cfg.EventBroker.PollWait,
+				Retention:       cfg.EventBroker.ProcessedRetention,
+			},
+			logger,
+		)
+		sugar.Infof("Event consumer initialized for topic %s", cfg.EventBroker.Topic)
+	}
+
 	eventService := event_service.NewService(eventUseCase, logger)
 	betService := bet_service.NewService(betUseCase, logger)
+	reviewService := review_service.NewService(reviewUseCase, logger)
+	teamService := team_service.NewService(teamUseCase, logger)
+	conflictService := conflict_service.NewService(conflictUseCase, logger)
+	confirmationService := confirmation_service.NewService(confirmationUseCase, logger)
+	syncRunService := syncrun_service.NewService(syncRunUseCase, logger)
+	quarantineService := quarantine_service.NewService(quarantineUseCase, logger)
+	resettlementService := resettlement_service.NewService(resettlementUseCase, logger)
+	payoutService := payout_service.NewService(payoutUseCase, logger)
 	sugar.Info("Services initialized")
 
 	eventHandler := event_delivery.NewHandler(eventService, logger)
 	betHandler := bet_delivery.NewHandler(betService, logger)
-	healthHandler := health_delivery.NewHandler(db, logger)
+	reviewHandler := review_delivery.NewHandler(reviewService, logger)
+	teamHandler := team_delivery.NewHandler(teamService, logger)
+	conflictHandler := conflict_delivery.NewHandler(conflictService, logger)
+	confirmationHandler := confirmation_delivery.NewHandler(confirmationService, logger)
+	syncRunHandler := syncrun_delivery.NewHandler(syncRunService, eventSyncer, logger)
+	quarantineHandler := quarantine_delivery.NewHandler(quarantineService, logger)
+	resettlementHandler := resettlement_delivery.NewHandler(resettlementService, logger)
+	payoutHandler := payout_delivery.NewHandler(payoutService, logger)
+	holderID := leader_service.NewHolderID()
+	var elector leader_service.Elector = leader_service.NewStaticElector(holderID)
+	if cfg.LeaderElection.Enabled {
+		elector = leader_service.NewLeaseElector(
+			repositoryStore.Lease,
+			cfg.LeaderElection.LeaseName,
+			holderID,
+			cfg.LeaderElection.TTL,
+			cfg.LeaderElection.RenewInterval,
+			logger,
+		)
+	} else {
+		sugar.Warn("Leader election disabled, background workers run on every replica")
+	}
+
+	healthHandler := health_delivery.NewHandler(db, syncRunUseCase, cfg.EventSync.ReadyMaxAge, eventSyncer, elector, payoutUseCase, logger)
+	ingestHandler := ingest_delivery.NewHandler(eventSyncer, webhook.NewVerifier(webhookSecrets, cfg.EventIngest.Tolerance), logger)
 	sugar.Info("HTTP handlers initialized")
 
 	r := chi.NewRouter()
@@ -107,14 +353,38 @@ func main() {
 		healthHandler.RegisterRoutes(r)
 		eventHandler.RegisterRoutes(r)
 		betHandler.RegisterRoutes(r)
+		reviewHandler.RegisterRoutes(r)
+		teamHandler.RegisterRoutes(r)
+		conflictHandler.RegisterRoutes(r)
+		confirmationHandler.RegisterRoutes(r)
+		syncRunHandler.RegisterRoutes(r)
+		quarantineHandler.RegisterRoutes(r)
+		resettlementHandler.RegisterRoutes(r)
+		payoutHandler.RegisterRoutes(r)
+		if len(webhookSecrets) > 0 {
+			ingestHandler.RegisterRoutes(r)
+		} else {
+			sugar.Info("No webhook secret configured, event ingestion endpoint disabled")
+		}
 	})
 	sugar.Info("All routes registered")
 
 	appCtx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	go eventSyncer.Start(appCtx)
-	sugar.Info("Event syncer worker started")
+	// Only the leader runs the background workers; they stop when leadership is lost.
+	workers := []leader_service.Worker{eventSyncer, marketCloser, payoutDispatcher}
+	if eventConsumer != nil {
+		workers = append(workers, eventConsumer)
+	}
+	electorDone := make(chan struct{})
+	go func() {
+		defer close(electorDone)
+		elector.Run(appCtx, func(ctx context.Context) {
+			leader_service.RunWorkers(ctx, workers...)
+		})
+	}()
+	sugar.Infof("Leader election started as %s", holderID)
 
 	httpServerErrChan := make(chan error, 1)
 	go func() {
@@ -136,6 +406,9 @@ func main() {
 		cancel()
 	}
 
+	// Wait for the workers to stop, so the leadership lease is released before the DB closes.
+	<-electorDone
+
 	// Optional short delay for goroutines to finish
 	// time.Sleep(2 * time.Second)
 
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Arlan-Z/def-betting-api/internal/app/store"
+	"github.com/Arlan-Z/def-betting-api/internal/data"
+	eventsource_client "github.com/Arlan-Z/def-betting-api/internal/deliveries/eventsource/http"
+)
+
+// differ compares a provider's feed with the local events. It only reads.
+type differ struct {
+	events    store.EventRepository
+	providers store.ProviderRepository
+	bets      store.BetRepository
+}
+
+// Diff fetches the provider's full feed and lists what syncing it would change. Events
+// are compared with the local event they are mapped to; events that the syncer would
+// match to another provider's event by their fixture are listed as new.
+func (d *differ) Diff(ctx context.Context, provider string, client eventsource_client.EventSourceClient, mapper *data.EventMapper) (*data.SyncDiff, error) {
+	batch, err := client.FetchActiveEvents(ctx, data.SourceSyncState{Provider: provider})
+	if err != nil {
+		return nil, err
+	}
+
+	diff := &data.SyncDiff{
+		Provider:  provider,
+		FetchedAt: time.Now().UTC(),
+		Received:  len(batch.Events),
+		Changes:   make([]data.SyncDiffChange, 0),
+	}
+	for _, extEvent := range batch.Events {
+		incoming, err := mapper.Map(extEvent)
+		if err != nil {
+			diff.Changes = append(diff.Changes, data.SyncDiffChange{
+				Kind:       data.SyncDiffUnmappable,
+				ExternalID: extEvent.APIEventID,
+				EventName:  extEvent.Name,
+				To:         err.Error(),
+			})
+			continue
+		}
+
+		local, err := d.findLocal(ctx, provider, extEvent.APIEventID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find local event of %s: %w", extEvent.APIEventID, err)
+		}
+		if local != nil {
+			incoming.ID = local.ID
+		}
+		canceled := extEvent.Result != nil && *extEvent.Result == data.ResultCanceled
+
+		changes := data.DiffEvent(extEvent.APIEventID, local, incoming, canceled)
+		if len(changes) == 0 {
+			diff.Unchanged++
+			continue
+		}
+		for _, change := range changes {
+			if local != nil && (change.Kind == data.SyncDiffFinalize || change.Kind == data.SyncDiffCancel || change.Kind == data.SyncDiffResettle) {
+				if err := d.addBets(ctx, &change); err != nil {
+					return nil, err
+				}
+			}
+			diff.Changes = append(diff.Changes, change)
+		}
+	}
+	return diff, nil
+}
+
+// findLocal returns the local event the provider event is mapped to, or nil when the
+// syncer would create a new event for it.
+func (d *differ) findLocal(ctx context.Context, provider string, providerEventID string) (*data.Event, error) {
+	snapshot, err := d.providers.FindSnapshot(ctx, provider, providerEventID)
+	if err != nil || snapshot == nil {
+		return nil, err
+	}
+	return d.events.FindByID(ctx, snapshot.EventID)
+}
+
+// addBets adds the bets the change settles: the pending ones, or the settled ones of an
+// event that would be resettled.
+func (d *differ) addBets(ctx context.Context, change *data.SyncDiffChange) error {
+	find := d.bets.FindPendingByEventID
+	if change.Kind == data.SyncDiffResettle {
+		find = d.bets.FindSettledByEventID
+	}
+	bets, err := find(ctx, change.EventID)
+	if err != nil {
+		return fmt.Errorf("failed to find bets of event %s: %w", change.EventID, err)
+	}
+	change.Bets = len(bets)
+	for _, bet := range bets {
+		change.Stake += bet.Amount
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+// Command sync fetches the feed of every configured event provider and prints what a
+// sync cycle would change in the local database, without writing anything.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Arlan-Z/def-betting-api/internal/app/config"
+	"github.com/Arlan-Z/def-betting-api/internal/app/connections"
+	"github.com/Arlan-Z/def-betting-api/internal/app/start"
+	"github.com/Arlan-Z/def-betting-api/internal/app/store"
+	"github.com/Arlan-Z/def-betting-api/internal/data"
+	eventsource_file "github.com/Arlan-Z/def-betting-api/internal/deliveries/eventsource/file"
+	eventsource_client "github.com/Arlan-Z/def-betting-api/internal/deliveries/eventsource/http"
+	"go.uber.org/zap"
+)
+
+func main() {
+	var configPath, dbPath, providerName string
+	var asJSON bool
+	var timeout time.Duration
+
+	flag.StringVar(&configPath, "config", "", "Path to the config file (default: CONFIG_PATH or config.yaml)")
+	flag.StringVar(&dbPath, "dbpath", "", "Path to the SQLite database file (default: database.path of the config)")
+	flag.StringVar(&providerName, "provider", "", "Only diff this event provider (default: all)")
+	flag.BoolVar(&asJSON, "json", false, "Print the diff as JSON")
+	flag.DurationVar(&timeout, "timeout", time.Minute, "Timeout for fetching and comparing all providers")
+	flag.Parse()
+
+	if configPath != "" {
+		os.Setenv("CONFIG_PATH", configPath)
+	}
+	cfg := config.Load()
+	if dbPath == "" {
+		dbPath = cfg.Database.Path
+	}
+
+	providerSettings, err := cfg.Providers()
+	if err != nil {
+		log.Fatalf("Invalid event provider configuration: %v", err)
+	}
+
+	// Read-only mode guarantees the diff never changes the database.
+	db, err := connections.NewSQLiteConnection(fmt.Sprintf("file:%s?mode=ro", dbPath), connections.SQLiteOptions{})
+	if err != nil {
+		log.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	logger := zap.NewNop()
+	repositoryStore := store.NewStore(db, logger)
+	differ := &differ{events: repositoryStore.Event, providers: repositoryStore.Provider, bets: repositoryStore.Bet}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	diffs := make([]data.SyncDiff, 0, len(providerSettings))
+	for _, p := range providerSettings {
+		if providerName != "" && p.Name != providerName {
+			continue
+		}
+		mapper, err := data.NewEventMapperForTimezone(p.Timezone, p.DateLayouts)
+		if err != nil {
+			log.Fatalf("Failed to configure event mapping for provider %s: %v", p.Name, err)
+		}
+		var client eventsource_client.EventSourceClient
+		if p.File != "" {
+			client = eventsource_file.NewClient(p.File, logger)
+		} else {
+			auth, err := start.NewEventSourceAuth(p, logger)
+			if err != nil {
+				log.Fatalf("Failed to configure authentication of provider %s: %v", p.Name, err)
+			}
+			client = eventsource_client.NewRestyEventSourceClient(p.URL, p.Timeout, auth, logger)
+		}
+
+		diff, err := differ.Diff(ctx, p.Name, client, mapper)
+		if err != nil {
+			log.Fatalf("Failed to diff provider %s: %v", p.Name, err)
+		}
+		diffs = append(diffs, *diff)
+	}
+	if providerName != "" && len(diffs) == 0 {
+		log.Fatalf("Event provider %s is not configured", providerName)
+	}
+
+	if asJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(diffs); err != nil {
+			log.Fatalf("Failed to encode diff: %v", err)
+		}
+		return
+	}
+	printDiffs(os.Stdout, diffs)
+}
+
+var diffMarks = map[data.SyncDiffKind]string{
+	data.SyncDiffNew:        "+",
+	data.SyncDiffOdds:       "~",
+	data.SyncDiffSchedule:   "~",
+	data.SyncDiffFinalize:   "!",
+	data.SyncDiffResettle:   "!",
+	data.SyncDiffCancel:     "!",
+	data.SyncDiffUnmappable: "?",
+}
+
+func printDiffs(out io.Writer, diffs []data.SyncDiff) {
+	for _, diff := range diffs {
+		fmt.Fprintf(out, "Provider %s: %d events fetched, %d unchanged, %d new, %d odds, %d schedule, %d finalize, %d resettle, %d cancel, %d unmappable\n",
+			diff.Provider, diff.Received, diff.Unchanged,
+			diff.Count(data.SyncDiffNew), diff.Count(data.SyncDiffOdds), diff.Count(data.SyncDiffSchedule),
+			diff.Count(data.SyncDiffFinalize), diff.Count(data.SyncDiffResettle), diff.Count(data.SyncDiffCancel),
+			diff.Count(data.SyncDiffUnmappable),
+		)
+
+		w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		for _, c := range diff.Changes {
+			detail := c.To
+			if c.From != "" {
+				detail = c.From + " -> " + c.To
+			}
+			if c.Bets > 0 {
+				detail += fmt.Sprintf(" (%d bets, %.2f staked)", c.Bets, c.Stake)
+			}
+			fmt.Fprintf(w, "  %s %s\t%s\t%s\t%s\n", diffMarks[c.Kind], c.Kind, c.ExternalID, c.EventName, detail)
+		}
+		w.Flush()
+	}
+}
@@ -1,8 +1,10 @@
 package config
 
 import (
+	"fmt"
 	"log"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/ilyakaznacheev/cleanenv"
@@ -14,17 +16,214 @@ type Config struct {
 		Timeout time.Duration `yaml:"timeout" env:"HTTP_TIMEOUT" env-default:"5s"`
 	} `yaml:"http_server"`
 	Database struct {
-		Path string `yaml:"path" env:"DB_PATH" env-required:"true"`
+		Path         string        `yaml:"path" env:"DB_PATH" env-required:"true"`
+		BusyTimeout  time.Duration `yaml:"busy_timeout" env:"DB_BUSY_TIMEOUT" env-default:"5s"`
+		MaxOpenConns int           `yaml:"max_open_conns" env:"DB_MAX_OPEN_CONNS" env-default:"8"`
 	} `yaml:"database"`
 	PayoutService struct {
 		URL     string        `yaml:"url" env:"PAYOUT_SVC_URL" env-required:"true"`
 		Timeout time.Duration `yaml:"timeout" env:"PAYOUT_SVC_TIMEOUT" env-default:"3s"`
+		// Currency of stakes and payouts; the service keeps a single currency.
+		Currency string `yaml:"currency" env:"PAYOUT_SVC_CURRENCY" env-default:"USD"`
 	} `yaml:"payout_service"`
+	// PayoutOutbox controls the delivery of queued payouts and refunds to the payout service.
+	PayoutOutbox struct {
+		DispatchInterval time.Duration `yaml:"dispatch_interval" env:"PAYOUT_OUTBOX_DISPATCH_INTERVAL" env-default:"5s"`
+		BatchSize        int           `yaml:"batch_size" env:"PAYOUT_OUTBOX_BATCH_SIZE" env-default:"50"`
+		MaxAttempts      int           `yaml:"max_attempts" env:"PAYOUT_OUTBOX_MAX_ATTEMPTS" env-default:"8"`
+		RetryBackoff     time.Duration `yaml:"retry_backoff" env:"PAYOUT_OUTBOX_RETRY_BACKOFF" env-default:"10s"`
+		MaxBackoff       time.Duration `yaml:"max_backoff" env:"PAYOUT_OUTBOX_MAX_BACKOFF" env-default:"1h"`
+	} `yaml:"payout_outbox"`
 	EventSourceAPI struct { // <-- Новый раздел
-		URL          string        `yaml:"url" env:"EVENT_SOURCE_URL" env-required:"true"`
+		URL          string        `yaml:"url" env:"EVENT_SOURCE_URL"`
 		Timeout      time.Duration `yaml:"timeout" env:"EVENT_SOURCE_TIMEOUT" env-default:"10s"`
 		SyncInterval time.Duration `yaml:"sync_interval" env:"EVENT_SYNC_INTERVAL" env-default:"5m"`
+		// Optional overrides of event_mapping for this source
+		Timezone    string   `yaml:"timezone" env:"EVENT_SOURCE_TIMEZONE"`
+		DateLayouts []string `yaml:"date_layouts" env:"EVENT_SOURCE_DATE_LAYOUTS" env-separator:"|"`
+		// Enables POST /ingest/events for this source
+		WebhookSecret string `yaml:"webhook_secret" env:"EVENT_SOURCE_WEBHOOK_SECRET"`
+		// Reads events from a local file or replays a directory of snapshots instead of calling url
+		File string `yaml:"file" env:"EVENT_SOURCE_FILE"`
+		// Saves every fetch as a snapshot in this directory
+		RecordDir string `yaml:"record_dir" env:"EVENT_SOURCE_RECORD_DIR"`
+		// Credentials sent to the source
+		Auth SourceAuth `yaml:"auth" env-prefix:"EVENT_SOURCE_AUTH_"`
 	} `yaml:"event_source_api"`
+	// EventProviders replace event_source_api when several sources are synced.
+	EventProviders []EventProvider `yaml:"event_providers"`
+	// EventSourceResilience applies to the requests of every provider.
+	EventSourceResilience struct {
+		RetryCount      int           `yaml:"retry_count" env:"EVENT_SOURCE_RETRY_COUNT" env-default:"3"`
+		RetryWait       time.Duration `yaml:"retry_wait" env:"EVENT_SOURCE_RETRY_WAIT" env-default:"1s"`
+		RetryMaxWait    time.Duration `yaml:"retry_max_wait" env:"EVENT_SOURCE_RETRY_MAX_WAIT" env-default:"30s"`
+		BreakerFailures int           `yaml:"breaker_failures" env:"EVENT_SOURCE_BREAKER_FAILURES" env-default:"5"`
+		BreakerOpenFor  time.Duration `yaml:"breaker_open_for" env:"EVENT_SOURCE_BREAKER_OPEN_FOR" env-default:"1m"`
+	} `yaml:"event_source_resilience"`
+	EventMerge struct {
+		Odds        []string      `yaml:"odds" env:"EVENT_MERGE_ODDS" env-separator:","`
+		Schedule    []string      `yaml:"schedule" env:"EVENT_MERGE_SCHEDULE" env-separator:","`
+		Results     []string      `yaml:"results" env:"EVENT_MERGE_RESULTS" env-separator:","`
+		MatchWindow time.Duration `yaml:"match_window" env:"EVENT_MERGE_MATCH_WINDOW" env-default:"3h"`
+	} `yaml:"event_merge"`
+	EventIngest struct {
+		Tolerance time.Duration `yaml:"tolerance" env:"EVENT_INGEST_TOLERANCE" env-default:"5m"`
+	} `yaml:"event_ingest"`
+	EventMapping struct {
+		Timezone    string   `yaml:"timezone" env:"EVENT_MAPPING_TIMEZONE" env-default:"UTC"`
+		DateLayouts []string `yaml:"date_layouts" env:"EVENT_MAPPING_DATE_LAYOUTS" env-separator:"|"`
+	} `yaml:"event_mapping"`
+	EventSync struct {
+		RescheduleThreshold  time.Duration `yaml:"reschedule_threshold" env:"EVENT_RESCHEDULE_THRESHOLD" env-default:"1h"`
+		PostponedVoidAfter   time.Duration `yaml:"postponed_void_after" env:"EVENT_POSTPONED_VOID_AFTER" env-default:"72h"`
+		LateBetPolicy        string        `yaml:"late_bet_policy" env:"LATE_BET_POLICY" env-default:"review"`
+		RunRetention         time.Duration `yaml:"run_retention" env:"EVENT_SYNC_RUN_RETENTION" env-default:"168h"`
+		ReadyMaxAge          time.Duration `yaml:"ready_max_age" env:"EVENT_SYNC_READY_MAX_AGE" env-default:"15m"`
+		MissingSuspendAfter  int           `yaml:"missing_suspend_after" env:"EVENT_MISSING_SUSPEND_AFTER" env-default:"3"`
+		MissingVoidAfter     time.Duration `yaml:"missing_void_after" env:"EVENT_MISSING_VOID_AFTER" env-default:"24h"`
+		ResultConfirmCycles  int           `yaml:"result_confirm_cycles" env:"EVENT_RESULT_CONFIRM_CYCLES" env-default:"2"`
+		ResultConfirmAfter   time.Duration `yaml:"result_confirm_after" env:"EVENT_RESULT_CONFIRM_AFTER" env-default:"10m"`
+		ResettleOnCorrection bool          `yaml:"resettle_on_correction" env:"EVENT_RESETTLE_ON_CORRECTION" env-default:"true"`
+		StaleSuspendAfter    time.Duration `yaml:"stale_suspend_after" env:"EVENT_STALE_SUSPEND_AFTER" env-default:"15m"`
+		Workers              int           `yaml:"workers" env:"EVENT_SYNC_WORKERS" env-default:"4"`
+		CycleTimeout         time.Duration `yaml:"cycle_timeout" env:"EVENT_SYNC_CYCLE_TIMEOUT" env-default:"2m"`
+	} `yaml:"event_sync"`
+	// EventBroker consumes event updates from a topic of the embedded broker.
+	EventBroker struct {
+		Enabled            bool          `yaml:"enabled" env:"EVENT_BROKER_ENABLED" env-default:"false"`
+		Topic              string        `yaml:"topic" env:"EVENT_BROKER_TOPIC" env-default:"event-updates"`
+		DeadLetterTopic    string        `yaml:"dead_letter_topic" env:"EVENT_BROKER_DEAD_LETTER_TOPIC" env-default:"event-updates.dlq"`
+		Group              string        `yaml:"group" env:"EVENT_BROKER_GROUP" env-default:"betting-client"`
+		Provider           string        `yaml:"provider" env:"EVENT_BROKER_PROVIDER" env-default:"default"`
+		MaxAttempts        int           `yaml:"max_attempts" env:"EVENT_BROKER_MAX_ATTEMPTS" env-default:"5"`
+		RetryBackoff       time.Duration `yaml:"retry_backoff" env:"EVENT_BROKER_RETRY_BACKOFF" env-default:"1s"`
+		BatchSize          int           `yaml:"batch_size" env:"EVENT_BROKER_BATCH_SIZE" env-default:"100"`
+		PollWait           time.Duration `yaml:"poll_wait" env:"EVENT_BROKER_POLL_WAIT" env-default:"5s"`
+		ProcessedRetention time.Duration `yaml:"processed_retention" env:"EVENT_BROKER_PROCESSED_RETENTION" env-default:"168h"`
+	} `yaml:"event_broker"`
+	// LeaderElection makes sure only one replica runs the background workers: the event
+	// syncer, the market closer, the payout dispatcher and the event consumer.
+	LeaderElection struct {
+		Enabled       bool          `yaml:"enabled" env:"LEADER_ELECTION_ENABLED" env-default:"true"`
+		LeaseName     string        `yaml:"lease_name" env:"LEADER_LEASE_NAME" env-default:"background-workers"`
+		TTL           time.Duration `yaml:"ttl" env:"LEADER_LEASE_TTL" env-default:"15s"`
+		RenewInterval time.Duration `yaml:"renew_interval" env:"LEADER_RENEW_INTERVAL" env-default:"5s"`
+	} `yaml:"leader_election"`
+	Betting struct {
+		DefaultCutoff      time.Duration            `yaml:"default_cutoff" env:"BET_CUTOFF" env-default:"0s"`
+		SportCutoffs       map[string]time.Duration `yaml:"sport_cutoffs" env:"BET_SPORT_CUTOFFS"`
+		CloseCheckInterval time.Duration            `yaml:"close_check_interval" env:"BET_CLOSE_CHECK_INTERVAL" env-default:"30s"`
+	} `yaml:"betting"`
+}
+
+// DefaultProviderName is the name of the provider built from event_source_api.
+const DefaultProviderName = "default"
+
+// EventProvider configures one source of events. Its name is the namespace of the
+// provider's event IDs, so it must stay stable once events were synced.
+type EventProvider struct {
+	Name         string        `yaml:"name"`
+	URL          string        `yaml:"url"`
+	Timeout      time.Duration `yaml:"timeout"`
+	SyncInterval time.Duration `yaml:"sync_interval"`
+	Timezone     string        `yaml:"timezone"`
+	DateLayouts  []string      `yaml:"date_layouts"`
+	// WebhookSecret enables pushing events of this provider to POST /ingest/events.
+	WebhookSecret string `yaml:"webhook_secret"`
+	// File replaces URL with a local JSON or NDJSON file, or a directory of snapshots
+	// that is replayed one snapshot per sync cycle.
+	File string `yaml:"file"`
+	// RecordDir is where every fetch of the provider is saved for replaying it later.
+	RecordDir string `yaml:"record_dir"`
+	// Auth holds the credentials sent to the provider.
+	Auth SourceAuth `yaml:"auth"`
+}
+
+// Types of event source authentication.
+const (
+	SourceAuthNone         = ""
+	SourceAuthAPIKey       = "api_key"
+	SourceAuthBasic        = "basic"
+	SourceAuthOAuth2Client = "oauth2_client_credentials"
+)
+
+// SourceAuth configures how requests to an event source are authenticated.
+type SourceAuth struct {
+	Type string `yaml:"type" env:"TYPE"`
+	// Header (X-API-Key by default) and APIKey are sent with every request by api_key.
+	Header string `yaml:"header" env:"HEADER"`
+	APIKey Secret `yaml:"api_key" env-prefix:"API_KEY_"`
+	// Username and Password are sent by basic.
+	Username string `yaml:"username" env:"USERNAME"`
+	Password Secret `yaml:"password" env-prefix:"PASSWORD_"`
+	// TokenURL, ClientID, ClientSecret and Scopes obtain bearer tokens for
+	// oauth2_client_credentials. Tokens are renewed RefreshBefore (1m by default) they expire.
+	TokenURL      string        `yaml:"token_url" env:"TOKEN_URL"`
+	ClientID      string        `yaml:"client_id" env:"CLIENT_ID"`
+	ClientSecret  Secret        `yaml:"client_secret" env-prefix:"CLIENT_SECRET_"`
+	Scopes        []string      `yaml:"scopes" env:"SCOPES" env-separator:" "`
+	RefreshBefore time.Duration `yaml:"refresh_before" env:"REFRESH_BEFORE"`
+}
+
+// Secret is a credential given inline, read from a file, or taken from an environment
+// variable, so it does not have to be written into the config file.
+type Secret struct {
+	Value string `yaml:"value" env:"VALUE"`
+	File  string `yaml:"file" env:"FILE"`
+	Env   string `yaml:"env"`
+}
+
+// Resolve returns the secret. A file's trailing newline is dropped.
+func (s Secret) Resolve() (string, error) {
+	switch {
+	case s.File != "":
+		content, err := os.ReadFile(s.File)
+		if err != nil {
+			return "", fmt.Errorf("cannot read secret file: %w", err)
+		}
+		return strings.TrimRight(string(content), "\r\n"), nil
+	case s.Env != "":
+		value, ok := os.LookupEnv(s.Env)
+		if !ok {
+			return "", fmt.Errorf("environment variable %s is not set", s.Env)
+		}
+		return value, nil
+	default:
+		return s.Value, nil
+	}
+}
+
+// validate checks that the credentials of the configured type are present.
+func (a SourceAuth) validate() error {
+	required := map[string]Secret{}
+	switch a.Type {
+	case SourceAuthNone:
+		return nil
+	case SourceAuthAPIKey:
+		required["api_key"] = a.APIKey
+	case SourceAuthBasic:
+		if a.Username == "" {
+			return fmt.Errorf("basic auth needs a username")
+		}
+		required["password"] = a.Password
+	case SourceAuthOAuth2Client:
+		if a.TokenURL == "" || a.ClientID == "" {
+			return fmt.Errorf("%s needs a token_url and a client_id", a.Type)
+		}
+		required["client_secret"] = a.ClientSecret
+	default:
+		return fmt.Errorf("unknown auth type '%s'", a.Type)
+	}
+	for name, secret := range required {
+		value, err := secret.Resolve()
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		if value == "" {
+			return fmt.Errorf("%s auth needs a %s", a.Type, name)
+		}
+	}
+	return nil
 }
 
 func Load() *Config {
@@ -46,3 +245,87 @@ func Load() *Config {
 
 	return &cfg
 }
+
+// SportCutoffs returns betting.sport_cutoffs keyed by lowercase sport name. Sports whose
+// names differ only in case are rejected, since either cutoff could apply to them.
+func (c *Config) SportCutoffs() (map[string]time.Duration, error) {
+	cutoffs := make(map[string]time.Duration, len(c.Betting.SportCutoffs))
+	for sport, cutoff := range c.Betting.SportCutoffs {
+		key := strings.ToLower(strings.TrimSpace(sport))
+		if _, ok := cutoffs[key]; ok {
+			return nil, fmt.Errorf("sport '%s' is configured twice in betting.sport_cutoffs", key)
+		}
+		cutoffs[key] = cutoff
+	}
+	return cutoffs, nil
+}
+
+// Providers returns the configured event providers with defaults applied. Without
+// event_providers, event_source_api is used as a single provider named "default".
+func (c *Config) Providers() ([]EventProvider, error) {
+	providers := c.EventProviders
+	if len(providers) == 0 {
+		if c.EventSourceAPI.URL == "" && c.EventSourceAPI.File == "" {
+			return nil, fmt.Errorf("either event_source_api.url, event_source_api.file or event_providers must be configured")
+		}
+		providers = []EventProvider{{
+			Name:          DefaultProviderName,
+			URL:           c.EventSourceAPI.URL,
+			Timeout:       c.EventSourceAPI.Timeout,
+			SyncInterval:  c.EventSourceAPI.SyncInterval,
+			Timezone:      c.EventSourceAPI.Timezone,
+			DateLayouts:   c.EventSourceAPI.DateLayouts,
+			WebhookSecret: c.EventSourceAPI.WebhookSecret,
+			File:          c.EventSourceAPI.File,
+			RecordDir:     c.EventSourceAPI.RecordDir,
+			Auth:          c.EventSourceAPI.Auth,
+		}}
+	}
+
+	names := make(map[string]bool)
+	result := make([]EventProvider, len(providers))
+	for i, p := range providers {
+		if p.Name == "" || (p.URL == "" && p.File == "") {
+			return nil, fmt.Errorf("event provider #%d needs a name and a url or file", i+1)
+		}
+		if p.URL != "" && p.File != "" {
+			return nil, fmt.Errorf("event provider '%s' has both a url and a file", p.Name)
+		}
+		if names[p.Name] {
+			return nil, fmt.Errorf("event provider '%s' is configured twice", p.Name)
+		}
+		names[p.Name] = true
+		if err := p.Auth.validate(); err != nil {
+			return nil, fmt.Errorf("event provider '%s': %w", p.Name, err)
+		}
+
+		if p.Timeout <= 0 {
+			p.Timeout = 10 * time.Second
+		}
+		if p.SyncInterval <= 0 {
+			p.SyncInterval = 5 * time.Minute
+		}
+		if p.Timezone == "" {
+			p.Timezone = c.EventMapping.Timezone
+		}
+		if len(p.DateLayouts) == 0 {
+			p.DateLayouts = c.EventMapping.DateLayouts
+		}
+		if p.Auth.Header == "" {
+			p.Auth.Header = "X-API-Key"
+		}
+		if p.Auth.RefreshBefore <= 0 {
+			p.Auth.RefreshBefore = time.Minute
+		}
+		result[i] = p
+	}
+
+	for _, rule := range [][]string{c.EventMerge.Odds, c.EventMerge.Schedule, c.EventMerge.Results} {
+		for _, name := range rule {
+			if !names[name] {
+				return nil, fmt.Errorf("event_merge refers to unknown provider '%s'", name)
+			}
+		}
+	}
+	return result, nil
+}
@@ -3,21 +3,53 @@ package connections
 import (
 	"fmt"
 	"log"
+	"strings"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 	_ "github.com/mattn/go-sqlite3" // Import driver
 )
 
-func NewSQLiteConnection(dbPath string) (*sqlx.DB, error) {
-	db, err := sqlx.Connect("sqlite3", dbPath)
+// SQLiteOptions tunes the connection for concurrent use. The sync worker, the payout
+// dispatcher and the HTTP handlers write at the same time, and SQLite allows only one
+// writer, so without a busy timeout a concurrent write fails with "database is locked".
+type SQLiteOptions struct {
+	// WAL switches the database to write-ahead logging, so readers do not block the writer.
+	WAL bool
+	// BusyTimeout is how long a connection waits for the lock held by another one.
+	BusyTimeout time.Duration
+	// MaxOpenConns bounds the pool, zero leaves it unbounded.
+	MaxOpenConns int
+}
+
+func NewSQLiteConnection(dbPath string, opts SQLiteOptions) (*sqlx.DB, error) {
+	params := make([]string, 0, 3)
+	if opts.WAL {
+		params = append(params, "_journal_mode=WAL")
+	}
+	if opts.BusyTimeout > 0 {
+		// Transactions take the write lock when they begin, so a writer waits for the
+		// busy timeout instead of failing when it upgrades a read lock.
+		params = append(params, fmt.Sprintf("_busy_timeout=%d", opts.BusyTimeout.Milliseconds()), "_txlock=immediate")
+	}
+	dsn := dbPath
+	if len(params) > 0 {
+		separator := "?"
+		if strings.Contains(dbPath, "?") {
+			separator = "&"
+		}
+		dsn += separator + strings.Join(params, "&")
+	}
+
+	db, err := sqlx.Connect("sqlite3", dsn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to sqlite database: %w", err)
 	}
 
-	// Optional: Configure connection pool settings if needed
-	// db.SetMaxOpenConns(10)
-	// db.SetMaxIdleConns(5)
-	// db.SetConnMaxLifetime(time.Hour)
+	if opts.MaxOpenConns > 0 {
+		db.SetMaxOpenConns(opts.MaxOpenConns)
+		db.SetMaxIdleConns(opts.MaxOpenConns)
+	}
 
 	if err = db.Ping(); err != nil {
 		return nil, fmt.Errorf("failed to ping sqlite database: %w", err)
@@ -0,0 +1,46 @@
+package start
+
+import (
+	"fmt"
+
+	"github.com/Arlan-Z/def-betting-api/internal/app/config"
+	eventsource "github.com/Arlan-Z/def-betting-api/internal/deliveries/eventsource/http"
+	"go.uber.org/zap"
+)
+
+// NewEventSourceAuth builds the authenticator of an event provider, nil when it needs no
+// credentials.
+func NewEventSourceAuth(p config.EventProvider, logger *zap.Logger) (eventsource.Authenticator, error) {
+	switch p.Auth.Type {
+	case config.SourceAuthNone:
+		return nil, nil
+	case config.SourceAuthAPIKey:
+		key, err := p.Auth.APIKey.Resolve()
+		if err != nil {
+			return nil, fmt.Errorf("api_key: %w", err)
+		}
+		return eventsource.NewAPIKeyAuth(p.Auth.Header, key), nil
+	case config.SourceAuthBasic:
+		password, err := p.Auth.Password.Resolve()
+		if err != nil {
+			return nil, fmt.Errorf("password: %w", err)
+		}
+		return eventsource.NewBasicAuth(p.Auth.Username, password), nil
+	case config.SourceAuthOAuth2Client:
+		secret, err := p.Auth.ClientSecret.Resolve()
+		if err != nil {
+			return nil, fmt.Errorf("client_secret: %w", err)
+		}
+		return eventsource.NewClientCredentialsAuth(
+			p.Auth.TokenURL,
+			p.Auth.ClientID,
+			secret,
+			p.Auth.Scopes,
+			p.Auth.RefreshBefore,
+			p.Timeout,
+			logger.With(zap.String("provider", p.Name)),
+		), nil
+	default:
+		return nil, fmt.Errorf("unknown auth type '%s'", p.Auth.Type)
+	}
+}
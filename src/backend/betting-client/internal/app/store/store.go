@@ -2,10 +2,22 @@ package store
 
 import (
 	"context"
+	"time"
 
 	"github.com/Arlan-Z/def-betting-api/internal/data"
+	"github.com/Arlan-Z/def-betting-api/internal/pkg/mq"
 	betrepo "github.com/Arlan-Z/def-betting-api/internal/repositories/bet/sqlite"
+	brokerrepo "github.com/Arlan-Z/def-betting-api/internal/repositories/broker/sqlite"
+	competitionrepo "github.com/Arlan-Z/def-betting-api/internal/repositories/competition/sqlite"
 	eventrepo "github.com/Arlan-Z/def-betting-api/internal/repositories/event/sqlite"
+	leaserepo "github.com/Arlan-Z/def-betting-api/internal/repositories/lease/sqlite"
+	outboxrepo "github.com/Arlan-Z/def-betting-api/internal/repositories/outbox/sqlite"
+	providerrepo "github.com/Arlan-Z/def-betting-api/internal/repositories/provider/sqlite"
+	quarantinerepo "github.com/Arlan-Z/def-betting-api/internal/repositories/quarantine/sqlite"
+	resettlementrepo "github.com/Arlan-Z/def-betting-api/internal/repositories/resettlement/sqlite"
+	reviewrepo "github.com/Arlan-Z/def-betting-api/internal/repositories/review/sqlite"
+	syncrunrepo "github.com/Arlan-Z/def-betting-api/internal/repositories/syncrun/sqlite"
+	teamrepo "github.com/Arlan-Z/def-betting-api/internal/repositories/team/sqlite"
 	"github.com/jmoiron/sqlx"
 	"go.uber.org/zap"
 )
@@ -14,21 +26,152 @@ type EventRepository interface {
 	FindActiveEvents(ctx context.Context) ([]data.Event, error)
 	FindByID(ctx context.Context, eventID string) (*data.Event, error)
 	UpdateResultAndStatus(ctx context.Context, eventID string, result data.Outcome) error
+	ReplaceResult(ctx context.Context, eventID string, result data.Outcome) error
 	Upsert(ctx context.Context, event *data.Event) error
+	MarkPostponed(ctx context.Context, eventID string, postponedAt time.Time) error
+	MarkCanceled(ctx context.Context, eventID string) error
+	FindPostponedBefore(ctx context.Context, before time.Time) ([]data.Event, error)
+	RecordScheduleChange(ctx context.Context, change *data.EventScheduleChange) error
+	MarkBettingClosed(ctx context.Context, eventID string, closedAt time.Time) error
+	ReopenBetting(ctx context.Context, eventID string) (bool, error)
+	FindUpcomingByTeam(ctx context.Context, teamID string, from time.Time) ([]data.Event, error)
+	AssignTeamByName(ctx context.Context, sport string, name string, teamID string) (int64, error)
+	Search(ctx context.Context, terms []string, limit int) ([]data.EventSearchResult, error)
+	FindStartingBetween(ctx context.Context, sport string, from time.Time, to time.Time) ([]data.Event, error)
+	MarkSuspended(ctx context.Context, eventID string, suspendedAt time.Time) error
+	ResumeSuspended(ctx context.Context, eventID string) (bool, error)
+	FindSuspendedBefore(ctx context.Context, before time.Time) ([]data.Event, error)
 }
 
 type BetRepository interface {
 	Save(ctx context.Context, bet *data.Bet) error
 	FindPendingByEventID(ctx context.Context, eventID string) ([]data.Bet, error)
+	FindSettledByEventID(ctx context.Context, eventID string) ([]data.Bet, error)
 	UpdateStatusAndPayout(ctx context.Context, betID string, status data.BetStatus, payout float64) error
+	SettleWithPayout(ctx context.Context, betID string, from data.BetStatus, status data.BetStatus, payout float64, intent *data.PayoutIntent) (bool, error)
 	UpdateStatus(ctx context.Context, betID string, status data.BetStatus) error
+	FindByID(ctx context.Context, betID string) (*data.Bet, error)
+	FlagPlacedAfter(ctx context.Context, eventID string, after time.Time, reason string) ([]data.Bet, error)
+	ClearVoidFlag(ctx context.Context, betID string) error
+}
+
+type ReviewRepository interface {
+	Enqueue(ctx context.Context, review *data.BetReview) error
+	FindByStatus(ctx context.Context, status data.ReviewStatus) ([]data.BetReview, error)
+	FindByID(ctx context.Context, reviewID string) (*data.BetReview, error)
+	Resolve(ctx context.Context, reviewID string, status data.ReviewStatus, note string, resolvedAt time.Time) error
+}
+
+type TeamRepository interface {
+	Create(ctx context.Context, team *data.Team) error
+	FindByID(ctx context.Context, teamID string) (*data.Team, error)
+	FindBySport(ctx context.Context, sport string) ([]data.Team, error)
+	FindByAlias(ctx context.Context, sport string, aliasKey string) (*data.Team, error)
+	FindAliasKeys(ctx context.Context, sport string) (map[string][]string, error)
+	AddAlias(ctx context.Context, sport string, aliasKey string, teamID string) error
+	EnqueueUnresolved(ctx context.Context, alias *data.UnresolvedTeamAlias) error
+	FindUnresolvedByStatus(ctx context.Context, status data.AliasStatus) ([]data.UnresolvedTeamAlias, error)
+	FindUnresolvedByID(ctx context.Context, aliasID string) (*data.UnresolvedTeamAlias, error)
+	MarkAliasResolved(ctx context.Context, aliasID string, teamID string, resolvedAt time.Time) error
+}
+
+type CompetitionRepository interface {
+	Create(ctx context.Context, competition *data.Competition) error
+	FindByKey(ctx context.Context, sport string, nameKey string) (*data.Competition, error)
+	FindBySport(ctx context.Context, sport string) ([]data.Competition, error)
+}
+
+type ProviderRepository interface {
+	FindSnapshot(ctx context.Context, provider string, providerEventID string) (*data.ProviderEventSnapshot, error)
+	SaveSnapshot(ctx context.Context, snapshot *data.ProviderEventSnapshot) error
+	FindSnapshotsByEvent(ctx context.Context, eventID string) ([]data.ProviderEventSnapshot, error)
+	OpenResultConflict(ctx context.Context, conflict *data.ResultConflict) error
+	FindResultConflict(ctx context.Context, eventID string) (*data.ResultConflict, error)
+	FindOpenResultConflicts(ctx context.Context) ([]data.ResultConflict, error)
+	ResolveResultConflict(ctx context.Context, eventID string, result string, resolvedAt time.Time) error
+	RecordResultSighting(ctx context.Context, eventID string, result data.Outcome, runID string, seenAt time.Time) (*data.ResultConfirmation, error)
+	RecordConfirmationCycle(ctx context.Context, provider string, runID string, seenAt time.Time) error
+	FindResultConfirmation(ctx context.Context, eventID string) (*data.ResultConfirmation, error)
+	FindPendingResultConfirmations(ctx context.Context) ([]data.ResultConfirmation, error)
+	ConfirmResult(ctx context.Context, eventID string, confirmedBy string, confirmedAt time.Time) error
+	DiscardResultConfirmation(ctx context.Context, eventID string) error
+	FindSyncState(ctx context.Context, provider string) (*data.SourceSyncState, error)
+	SaveSyncState(ctx context.Context, state *data.SourceSyncState) error
+	MarkSeen(ctx context.Context, provider string, providerEventID string, seenAt time.Time) error
+	RecordMissedCycle(ctx context.Context, provider string, fetchStartedAt time.Time) (int64, error)
+	FindMissingEvents(ctx context.Context, minMissedCycles int) ([]data.MissingEvent, error)
+	FindEventsReportedOnlyBy(ctx context.Context, providers []string) ([]string, error)
+}
+
+type SyncRunRepository interface {
+	Create(ctx context.Context, run *data.SyncRun) error
+	Finish(ctx context.Context, run *data.SyncRun) error
+	FindByID(ctx context.Context, runID string) (*data.SyncRun, error)
+	FindRecent(ctx context.Context, provider string, limit int) ([]data.SyncRun, error)
+	FindLastSuccessful(ctx context.Context) (*data.SyncRun, error)
+	DeleteStartedBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+type QuarantineRepository interface {
+	Save(ctx context.Context, entry *data.QuarantinedEvent) error
+	FindByID(ctx context.Context, entryID string) (*data.QuarantinedEvent, error)
+	FindByStatus(ctx context.Context, status data.QuarantineStatus) ([]data.QuarantinedEvent, error)
+	Resolve(ctx context.Context, entryID string, status data.QuarantineStatus, payload string, note string, resolvedAt time.Time) error
+	CountByErrorCode(ctx context.Context) ([]data.QuarantineStat, error)
+}
+
+type ResettlementRepository interface {
+	Create(ctx context.Context, resettlement *data.Resettlement) error
+	Finish(ctx context.Context, resettlement *data.Resettlement) error
+	FindByID(ctx context.Context, resettlementID string) (*data.Resettlement, error)
+	FindByEvent(ctx context.Context, eventID string) ([]data.Resettlement, error)
+}
+
+type LeaseRepository interface {
+	TryAcquire(ctx context.Context, name string, holder string, ttl time.Duration, now time.Time) (*data.Lease, error)
+	Renew(ctx context.Context, name string, holder string, token int64, ttl time.Duration, now time.Time) (bool, error)
+	Release(ctx context.Context, name string, holder string, token int64, now time.Time) error
+	FindByName(ctx context.Context, name string) (*data.Lease, error)
+}
+
+// BrokerRepository is the embedded message broker, together with the IDs of the
+// messages each consumer group processed.
+type BrokerRepository interface {
+	mq.Broker
+	IsProcessed(ctx context.Context, group string, messageID string) (bool, error)
+	MarkProcessed(ctx context.Context, group string, msg mq.Message, processedAt time.Time) error
+	DeleteProcessedBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+// OutboxRepository holds the payout intents written by settlements until they are delivered.
+type OutboxRepository interface {
+	FindDue(ctx context.Context, now time.Time, limit int) ([]data.PayoutIntent, error)
+	FindByID(ctx context.Context, intentID string) (*data.PayoutIntent, error)
+	MarkDelivered(ctx context.Context, intentID string, deliveredAt time.Time) (bool, error)
+	MarkFailed(ctx context.Context, intentID string, lastError string, failedAt time.Time) (bool, error)
+	FindStuck(ctx context.Context) ([]data.PayoutIntent, error)
+	CountStuck(ctx context.Context) (int, error)
+	FindAttempts(ctx context.Context, intentID string) ([]data.PayoutAttempt, error)
+	Requeue(ctx context.Context, intentID string, now time.Time) (bool, error)
+	Resolve(ctx context.Context, intentID string, note string, resolvedAt time.Time) (bool, error)
+	RecordFailedAttempt(ctx context.Context, intentID string, lastError string, attemptedAt time.Time, nextAttemptAt time.Time) error
 }
 
 type Store struct {
-	db     *sqlx.DB
-	logger *zap.Logger
-	Event  EventRepository
-	Bet    BetRepository
+	db           *sqlx.DB
+	logger       *zap.Logger
+	Event        EventRepository
+	Bet          BetRepository
+	Review       ReviewRepository
+	Team         TeamRepository
+	Competition  CompetitionRepository
+	Provider     ProviderRepository
+	SyncRun      SyncRunRepository
+	Quarantine   QuarantineRepository
+	Resettlement ResettlementRepository
+	Lease        LeaseRepository
+	Broker       BrokerRepository
+	Outbox       OutboxRepository
 }
 
 func NewStore(db *sqlx.DB, logger *zap.Logger) *Store {
@@ -37,12 +180,32 @@ func NewStore(db *sqlx.DB, logger *zap.Logger) *Store {
 
 	eventRepoImpl := eventrepo.NewEventRepository(db)
 	betRepoImpl := betrepo.NewBetRepository(db)
+	reviewRepoImpl := reviewrepo.NewReviewRepository(db)
+	teamRepoImpl := teamrepo.NewTeamRepository(db)
+	competitionRepoImpl := competitionrepo.NewCompetitionRepository(db)
+	providerRepoImpl := providerrepo.NewProviderRepository(db)
+	syncRunRepoImpl := syncrunrepo.NewSyncRunRepository(db)
+	quarantineRepoImpl := quarantinerepo.NewQuarantineRepository(db)
+	resettlementRepoImpl := resettlementrepo.NewResettlementRepository(db)
+	leaseRepoImpl := leaserepo.NewLeaseRepository(db)
+	brokerRepoImpl := brokerrepo.NewBrokerRepository(db)
+	outboxRepoImpl := outboxrepo.NewOutboxRepository(db)
 
 	return &Store{
-		db:     db,
-		logger: log,
-		Event:  eventRepoImpl,
-		Bet:    betRepoImpl,
+		db:           db,
+		logger:       log,
+		Event:        eventRepoImpl,
+		Bet:          betRepoImpl,
+		Review:       reviewRepoImpl,
+		Team:         teamRepoImpl,
+		Competition:  competitionRepoImpl,
+		Provider:     providerRepoImpl,
+		SyncRun:      syncRunRepoImpl,
+		Quarantine:   quarantineRepoImpl,
+		Resettlement: resettlementRepoImpl,
+		Lease:        leaseRepoImpl,
+		Broker:       brokerRepoImpl,
+		Outbox:       outboxRepoImpl,
 	}
 }
 
@@ -1,6 +1,9 @@
 package data
 
-import "time"
+import (
+	"math"
+	"time"
+)
 
 type BetStatus string
 
@@ -11,20 +14,45 @@ const (
 	StatusPaid     BetStatus = "Paid"
 	StatusFailed   BetStatus = "Failed"
 	StatusCanceled BetStatus = "Canceled"
+	StatusVoided   BetStatus = "Voided"
+	StatusRefunded BetStatus = "Refunded"
 )
 
 type Bet struct {
-	ID                    string    `db:"id"`
-	UserID                string    `db:"user_id"`
-	EventID               string    `db:"event_id"`
-	Amount                float64   `db:"amount"`
-	PredictedOutcome      Outcome   `db:"predicted_outcome"`
-	RecordedHomeWinChance float64   `db:"recorded_home_win_chance"`
-	RecordedAwayWinChance float64   `db:"recorded_away_win_chance"`
-	RecordedDrawChance    float64   `db:"recorded_draw_chance"`
-	PlacedAt              time.Time `db:"placed_at"`
-	Status                BetStatus `db:"status"`
-	PayoutAmount          float64   `db:"payout_amount"`
+	ID                    string     `db:"id"`
+	UserID                string     `db:"user_id"`
+	EventID               string     `db:"event_id"`
+	Amount                float64    `db:"amount"`
+	PredictedOutcome      Outcome    `db:"predicted_outcome"`
+	RecordedHomeWinChance float64    `db:"recorded_home_win_chance"`
+	RecordedAwayWinChance float64    `db:"recorded_away_win_chance"`
+	RecordedDrawChance    float64    `db:"recorded_draw_chance"`
+	PlacedAt              time.Time  `db:"placed_at"`
+	Status                BetStatus  `db:"status"`
+	PayoutAmount          float64    `db:"payout_amount"`
+	VoidFlaggedAt         *time.Time `db:"void_flagged_at"`
+	VoidFlagReason        *string    `db:"void_flag_reason"`
+}
+
+// Odds returns the odds recorded for the predicted outcome when the bet was placed.
+func (b Bet) Odds() float64 {
+	switch b.PredictedOutcome {
+	case HomeWin:
+		return b.RecordedHomeWinChance
+	case AwayWin:
+		return b.RecordedAwayWinChance
+	case Draw:
+		return b.RecordedDrawChance
+	}
+	return 0
+}
+
+// PayoutFor returns what the bet pays if the event ends with the result, rounded to cents.
+func (b Bet) PayoutFor(result Outcome) float64 {
+	if b.PredictedOutcome != result {
+		return 0
+	}
+	return math.Round(b.Amount*b.Odds()*100) / 100
 }
 
 type PlaceBetRequest struct {
@@ -34,9 +62,25 @@ type PlaceBetRequest struct {
 	PredictedOutcome Outcome `json:"predictedOutcome" validate:"required,oneof=HomeWin AwayWin Draw"`
 }
 
+// PayoutNotificationSchemaVersion is increased with every incompatible change of the
+// payload. Version 1 only carried userId and amount. Version 2 added the bet details and
+// a reason: win, refund, cashout or adjustment.
+const PayoutNotificationSchemaVersion = 2
+
 type PayoutNotification struct {
-	UserID string  `json:"userId"`
-	Amount float64 `json:"amount"`
+	SchemaVersion int `json:"schemaVersion"`
+	// IdempotencyKey stays the same for every delivery of a payout, so the payout
+	// service can drop repeats. It is also sent as the Idempotency-Key header.
+	IdempotencyKey string     `json:"idempotencyKey"`
+	UserID         string     `json:"userId"`
+	Amount         float64    `json:"amount"`
+	Currency       string     `json:"currency"`
+	Reason         PayoutKind `json:"reason"`
+	BetID          string     `json:"betId"`
+	EventID        string     `json:"eventId"`
+	Outcome        Outcome    `json:"outcome"`
+	Odds           float64    `json:"odds"`
+	Stake          float64    `json:"stake"`
 }
 
 type BetDTO struct {
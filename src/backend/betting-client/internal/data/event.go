@@ -1,6 +1,12 @@
 package data
 
-import "time"
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+)
 
 type Outcome string
 
@@ -10,48 +16,155 @@ const (
 	Draw    Outcome = "Draw"
 )
 
+type EventStatus string
+
+const (
+	EventStatusScheduled EventStatus = "Scheduled"
+	EventStatusPostponed EventStatus = "Postponed"
+	EventStatusClosed    EventStatus = "Closed"
+	EventStatusCanceled  EventStatus = "Canceled"
+	// EventStatusSuspended stops betting on an event that vanished from the source feed.
+	EventStatusSuspended EventStatus = "Suspended"
+)
+
 type Event struct {
-	ID             string    `db:"id"`
-	EventName      string    `db:"event_name"`
-	HomeTeam       string    `db:"home_team"`
-	AwayTeam       string    `db:"away_team"`
-	HomeWinChance  float64   `db:"home_win_chance"`
-	AwayWinChance  float64   `db:"away_win_chance"`
-	DrawChance     float64   `db:"draw_chance"`
-	EventStartDate time.Time `db:"event_start_date"`
-	EventEndDate   time.Time `db:"event_end_date"`
-	EventResult    *Outcome  `db:"event_result"`
-	Type           string    `db:"type"`
-	IsActive       bool      `db:"is_active"`
+	ID              string      `db:"id"`
+	EventName       string      `db:"event_name"`
+	HomeTeam        string      `db:"home_team"`
+	AwayTeam        string      `db:"away_team"`
+	HomeWinChance   float64     `db:"home_win_chance"`
+	AwayWinChance   float64     `db:"away_win_chance"`
+	DrawChance      float64     `db:"draw_chance"`
+	EventStartDate  time.Time   `db:"event_start_date"`
+	EventEndDate    time.Time   `db:"event_end_date"`
+	EventResult     *Outcome    `db:"event_result"`
+	Type            string      `db:"type"`
+	IsActive        bool        `db:"is_active"`
+	Status          EventStatus `db:"status"`
+	PostponedAt     *time.Time  `db:"postponed_at"`
+	BettingClosedAt *time.Time  `db:"betting_closed_at"`
+	SuspendedAt     *time.Time  `db:"suspended_at"`
+	Competition     string      `db:"competition"`
+	CompetitionID   *string     `db:"competition_id"`
+	HomeTeamID      *string     `db:"home_team_id"`
+	AwayTeamID      *string     `db:"away_team_id"`
+	ContentHash     string      `db:"content_hash"`
+}
+
+// ComputeContentHash hashes the fields the syncer writes, so an event whose source data
+// did not change can be skipped instead of rewritten.
+func (e Event) ComputeContentHash() string {
+	deref := func(s *string) string {
+		if s == nil {
+			return ""
+		}
+		return *s
+	}
+	result := ""
+	if e.EventResult != nil {
+		result = string(*e.EventResult)
+	}
+
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\x1f%s\x1f%s\x1f%g\x1f%g\x1f%g\x1f%s\x1f%s\x1f%s\x1f%s\x1f%t\x1f%s\x1f%s\x1f%s\x1f%s",
+		e.EventName, e.HomeTeam, e.AwayTeam,
+		e.HomeWinChance, e.AwayWinChance, e.DrawChance,
+		e.EventStartDate.UTC().Format(time.RFC3339Nano), e.EventEndDate.UTC().Format(time.RFC3339Nano),
+		result, e.Type, e.IsActive, e.Competition,
+		deref(e.CompetitionID), deref(e.HomeTeamID), deref(e.AwayTeamID),
+	)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// IsOpenForBetting reports whether new bets may be accepted for the event.
+// An empty status is treated as Scheduled.
+func (e Event) IsOpenForBetting() bool {
+	return e.IsActive && (e.Status == "" || e.Status == EventStatusScheduled)
+}
+
+// BetCutoffPolicy defines how long before the start of an event betting closes.
+type BetCutoffPolicy struct {
+	Default time.Duration
+	// BySport is keyed by lowercase sport name.
+	BySport map[string]time.Duration
+}
+
+// For returns the cutoff of the sport, whose name is matched ignoring case.
+func (p BetCutoffPolicy) For(sportType string) time.Duration {
+	if cutoff, ok := p.BySport[strings.ToLower(sportType)]; ok {
+		return cutoff
+	}
+	return p.Default
+}
+
+// ClosesAt returns the moment after which no more bets are accepted for the event.
+func (p BetCutoffPolicy) ClosesAt(e Event) time.Time {
+	return e.EventStartDate.Add(-p.For(e.Type))
+}
+
+type EventScheduleChange struct {
+	ID           int64     `db:"id"`
+	EventID      string    `db:"event_id"`
+	OldStartDate time.Time `db:"old_start_date"`
+	NewStartDate time.Time `db:"new_start_date"`
+	OldEndDate   time.Time `db:"old_end_date"`
+	NewEndDate   time.Time `db:"new_end_date"`
+	DetectedAt   time.Time `db:"detected_at"`
+}
+
+// EventSearchResult is an event matched by a full-text search. Highlights contain the
+// matched fields with the matching words wrapped in <mark></mark>.
+type EventSearchResult struct {
+	Event
+	Score      float64
+	Highlights EventHighlights
+}
+
+type EventHighlights struct {
+	EventName string `json:"eventName"`
+	HomeTeam  string `json:"homeTeam"`
+	AwayTeam  string `json:"awayTeam"`
 }
 
 type EventDTO struct {
-	ID             string    `json:"id"`
-	EventName      string    `json:"eventName"`
-	HomeTeam       string    `json:"homeTeam"`
-	AwayTeam       string    `json:"awayTeam"`
-	HomeWinChance  float64   `json:"homeWinChance"`
-	AwayWinChance  float64   `json:"awayWinChance"`
-	DrawChance     float64   `json:"drawChance"`
-	EventStartDate time.Time `json:"eventStartDate"`
-	EventEndDate   time.Time `json:"eventEndDate"`
-	EventResult    *Outcome  `json:"eventResult,omitempty"`
-	Type           string    `json:"type"`
+	ID              string      `json:"id"`
+	EventName       string      `json:"eventName"`
+	HomeTeam        string      `json:"homeTeam"`
+	AwayTeam        string      `json:"awayTeam"`
+	HomeWinChance   float64     `json:"homeWinChance"`
+	AwayWinChance   float64     `json:"awayWinChance"`
+	DrawChance      float64     `json:"drawChance"`
+	EventStartDate  time.Time   `json:"eventStartDate"`
+	EventEndDate    time.Time   `json:"eventEndDate"`
+	EventResult     *Outcome    `json:"eventResult,omitempty"`
+	Type            string      `json:"type"`
+	Status          EventStatus `json:"status,omitempty"`
+	BettingClosedAt *time.Time  `json:"bettingClosedAt,omitempty"`
+	Competition     string      `json:"competition,omitempty"`
+	CompetitionID   *string     `json:"competitionId,omitempty"`
+	HomeTeamID      *string     `json:"homeTeamId,omitempty"`
+	AwayTeamID      *string     `json:"awayTeamId,omitempty"`
 }
 
 func MapEventToDTO(e Event) EventDTO {
 	return EventDTO{
-		ID:             e.ID,
-		EventName:      e.EventName,
-		HomeTeam:       e.HomeTeam,
-		AwayTeam:       e.AwayTeam,
-		HomeWinChance:  e.HomeWinChance,
-		AwayWinChance:  e.AwayWinChance,
-		DrawChance:     e.DrawChance,
-		EventStartDate: e.EventStartDate,
-		EventEndDate:   e.EventEndDate,
-		EventResult:    e.EventResult,
-		Type:           e.Type,
+		ID:              e.ID,
+		EventName:       e.EventName,
+		HomeTeam:        e.HomeTeam,
+		AwayTeam:        e.AwayTeam,
+		HomeWinChance:   e.HomeWinChance,
+		AwayWinChance:   e.AwayWinChance,
+		DrawChance:      e.DrawChance,
+		EventStartDate:  e.EventStartDate,
+		EventEndDate:    e.EventEndDate,
+		EventResult:     e.EventResult,
+		Type:            e.Type,
+		Status:          e.Status,
+		BettingClosedAt: e.BettingClosedAt,
+		Competition:     e.Competition,
+		CompetitionID:   e.CompetitionID,
+		HomeTeamID:      e.HomeTeamID,
+		AwayTeamID:      e.AwayTeamID,
 	}
 }
 
@@ -62,3 +175,21 @@ func MapEventsToDTOs(events []Event) []EventDTO {
 	}
 	return dtos
 }
+
+type EventSearchResultDTO struct {
+	EventDTO
+	Score      float64         `json:"score"`
+	Highlights EventHighlights `json:"highlights"`
+}
+
+func MapSearchResultsToDTOs(results []EventSearchResult) []EventSearchResultDTO {
+	dtos := make([]EventSearchResultDTO, len(results))
+	for i, r := range results {
+		dtos[i] = EventSearchResultDTO{
+			EventDTO:   MapEventToDTO(r.Event),
+			Score:      r.Score,
+			Highlights: r.Highlights,
+		}
+	}
+	return dtos
+}
@@ -0,0 +1,58 @@
+package data_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Arlan-Z/def-betting-api/internal/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvent_ComputeContentHash(t *testing.T) {
+	start := time.Date(2030, 6, 1, 18, 0, 0, 0, time.UTC)
+	event := data.Event{
+		ID:             "event-1",
+		EventName:      "Kairat vs Astana",
+		HomeTeam:       "Kairat",
+		AwayTeam:       "Astana",
+		HomeWinChance:  0.5,
+		AwayWinChance:  0.3,
+		DrawChance:     0.2,
+		EventStartDate: start,
+		EventEndDate:   start.Add(2 * time.Hour),
+		Type:           "Football",
+		IsActive:       true,
+	}
+	hash := event.ComputeContentHash()
+
+	sameInOtherZone := event
+	sameInOtherZone.EventStartDate = start.In(time.FixedZone("UTC+5", 5*60*60))
+	sameInOtherZone.Status = data.EventStatusClosed
+	assert.Equal(t, hash, sameInOtherZone.ComputeContentHash(), "zone and locally managed fields do not matter")
+
+	newOdds := event
+	newOdds.HomeWinChance = 0.55
+	assert.NotEqual(t, hash, newOdds.ComputeContentHash())
+
+	finished := event
+	result := data.HomeWin
+	finished.EventResult = &result
+	assert.NotEqual(t, hash, finished.ComputeContentHash())
+
+	linked := event
+	teamID := "team-1"
+	linked.HomeTeamID = &teamID
+	assert.NotEqual(t, hash, linked.ComputeContentHash())
+}
+
+func TestBetCutoffPolicy_For(t *testing.T) {
+	policy := data.BetCutoffPolicy{
+		Default: time.Minute,
+		BySport: map[string]time.Duration{"football": 5 * time.Minute},
+	}
+
+	assert.Equal(t, 5*time.Minute, policy.For("Football"))
+	assert.Equal(t, 5*time.Minute, policy.For("FOOTBALL"))
+	assert.Equal(t, time.Minute, policy.For("Tennis"))
+	assert.Equal(t, time.Minute, policy.For(""))
+}
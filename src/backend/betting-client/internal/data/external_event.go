@@ -1,7 +1,10 @@
 package data
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 )
 
@@ -17,9 +20,151 @@ type ExternalEventDTO struct {
 	EndsAt          string   `json:"eventEndDate"`
 	SportType       string   `json:"type"`
 	Result          *string  `json:"eventResult"`
+	Competition     *string  `json:"competition"`
+
+	// Raw is the payload as received, kept for quarantining events that cannot be mapped.
+	Raw json.RawMessage `json:"-"`
+	// decodeErr is set when a field has the wrong JSON type. The event is still decoded,
+	// so one malformed event does not reject the whole feed.
+	decodeErr error
+}
+
+// UnmarshalJSON decodes the event and keeps its raw payload. Fields of the wrong type
+// are reported by Map instead of failing the decoding.
+func (e *ExternalEventDTO) UnmarshalJSON(b []byte) error {
+	type plain ExternalEventDTO
+	var p plain
+	err := json.Unmarshal(b, &p)
+
+	var typeErr *json.UnmarshalTypeError
+	if err != nil && !errors.As(err, &typeErr) {
+		return err
+	}
+	*e = ExternalEventDTO(p)
+	e.Raw = append(json.RawMessage(nil), b...)
+	e.decodeErr = err
+	return nil
+}
+
+// Payload returns the raw payload, or the event encoded as JSON when it was not decoded from one.
+func (e ExternalEventDTO) Payload() json.RawMessage {
+	if len(e.Raw) > 0 {
+		return e.Raw
+	}
+	encoded, err := json.Marshal(e)
+	if err != nil {
+		return nil
+	}
+	return encoded
+}
+
+type MappingErrorCode string
+
+const (
+	MappingErrInvalidStartDate MappingErrorCode = "invalid_start_date"
+	MappingErrInvalidEndDate   MappingErrorCode = "invalid_end_date"
+	MappingErrUnknownResult    MappingErrorCode = "unknown_result"
+	MappingErrInvalidPayload   MappingErrorCode = "invalid_payload"
+)
+
+// MappingError describes why an external event could not be mapped to the internal model.
+type MappingError struct {
+	Code  MappingErrorCode
+	Field string
+	Value string
+	Err   error
+}
+
+func (e *MappingError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("%s: cannot map %s '%s': %v", e.Code, e.Field, e.Value, e.Err)
+	}
+	return fmt.Sprintf("%s: cannot map %s '%s'", e.Code, e.Field, e.Value)
+}
+
+func (e *MappingError) Unwrap() error {
+	return e.Err
+}
+
+// MappingErrorCodeOf returns the code of a MappingError wrapped in err, or an empty code.
+func MappingErrorCodeOf(err error) MappingErrorCode {
+	var mappingErr *MappingError
+	if errors.As(err, &mappingErr) {
+		return mappingErr.Code
+	}
+	return ""
+}
+
+// DefaultDateLayouts are tried in order when parsing external dates. Layouts without
+// an offset are interpreted in the source timezone.
+var DefaultDateLayouts = []string{
+	time.RFC3339Nano,
+	time.RFC3339,
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04",
 }
 
+type EventMapper struct {
+	location *time.Location
+	layouts  []string
+}
+
+func NewEventMapper(location *time.Location, layouts []string) *EventMapper {
+	if location == nil {
+		location = time.UTC
+	}
+	if len(layouts) == 0 {
+		layouts = DefaultDateLayouts
+	}
+	return &EventMapper{location: location, layouts: layouts}
+}
+
+// NewEventMapperForTimezone builds a mapper for an IANA timezone name such as "Asia/Almaty".
+func NewEventMapperForTimezone(timezone string, layouts []string) (*EventMapper, error) {
+	if timezone == "" {
+		timezone = "UTC"
+	}
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, fmt.Errorf("unknown source timezone '%s': %w", timezone, err)
+	}
+	return NewEventMapper(location, layouts), nil
+}
+
+var defaultEventMapper = NewEventMapper(time.UTC, nil)
+
+// MapExternalToInternalEvent maps an external event assuming its dates are in UTC.
 func MapExternalToInternalEvent(ext ExternalEventDTO) (Event, error) {
+	return defaultEventMapper.Map(ext)
+}
+
+func (m *EventMapper) parseTime(value string) (time.Time, error) {
+	var firstErr error
+	for _, layout := range m.layouts {
+		parsed, err := time.ParseInLocation(layout, value, m.location)
+		if err == nil {
+			return parsed.UTC(), nil
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	return time.Time{}, firstErr
+}
+
+func (m *EventMapper) Map(ext ExternalEventDTO) (Event, error) {
+	if ext.decodeErr != nil {
+		mappingErr := &MappingError{Code: MappingErrInvalidPayload, Field: "payload", Err: ext.decodeErr}
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(ext.decodeErr, &typeErr) {
+			mappingErr.Field = typeErr.Field
+			mappingErr.Value = typeErr.Value
+		}
+		return Event{}, mappingErr
+	}
+
 	internalEvent := Event{
 		ID:            ext.APIEventID,
 		EventName:     ext.Name,
@@ -32,6 +177,10 @@ func MapExternalToInternalEvent(ext ExternalEventDTO) (Event, error) {
 		IsActive:      true,
 	}
 
+	if ext.Competition != nil {
+		internalEvent.Competition = strings.TrimSpace(*ext.Competition)
+	}
+
 	if ext.CoefficientHome != nil {
 		internalEvent.HomeWinChance = *ext.CoefficientHome
 	}
@@ -42,18 +191,17 @@ func MapExternalToInternalEvent(ext ExternalEventDTO) (Event, error) {
 		internalEvent.DrawChance = *ext.CoefficientDraw
 	}
 
-	const timeLayout = "2006-01-02T15:04:05"
-	startTime, err := time.Parse(timeLayout, ext.StartsAt)
+	startTime, err := m.parseTime(ext.StartsAt)
 	if err != nil {
-		return Event{}, fmt.Errorf("не удалось распарсить eventStartDate '%s': %w", ext.StartsAt, err)
+		return Event{}, &MappingError{Code: MappingErrInvalidStartDate, Field: "eventStartDate", Value: ext.StartsAt, Err: err}
 	}
-	internalEvent.EventStartDate = startTime.UTC()
+	internalEvent.EventStartDate = startTime
 
-	endTime, err := time.Parse(timeLayout, ext.EndsAt)
+	endTime, err := m.parseTime(ext.EndsAt)
 	if err != nil {
-		return Event{}, fmt.Errorf("не удалось распарсить eventEndDate '%s': %w", ext.EndsAt, err)
+		return Event{}, &MappingError{Code: MappingErrInvalidEndDate, Field: "eventEndDate", Value: ext.EndsAt, Err: err}
 	}
-	internalEvent.EventEndDate = endTime.UTC()
+	internalEvent.EventEndDate = endTime
 
 	makeInactive := false
 
@@ -78,7 +226,7 @@ func MapExternalToInternalEvent(ext ExternalEventDTO) (Event, error) {
 		case "Pending":
 			break
 		default:
-			return Event{}, fmt.Errorf("unknown eventResult format '%s'", *ext.Result)
+			return Event{}, &MappingError{Code: MappingErrUnknownResult, Field: "eventResult", Value: *ext.Result}
 		}
 
 		if outcome != nil {
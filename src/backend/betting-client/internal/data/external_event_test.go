@@ -0,0 +1,119 @@
+package data_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Arlan-Z/def-betting-api/internal/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newExternalEvent(start, end string) data.ExternalEventDTO {
+	return data.ExternalEventDTO{
+		APIEventID: "3f2b8c1e-9a57-4b8e-8d7c-6a4f1e2d3c4b",
+		Name:       "Kairat vs Astana",
+		TeamHome:   "Kairat",
+		TeamAway:   "Astana",
+		StartsAt:   start,
+		EndsAt:     end,
+		SportType:  "Football",
+	}
+}
+
+func TestEventMapper_NaiveDatesUseSourceTimezone(t *testing.T) {
+	mapper, err := data.NewEventMapperForTimezone("Asia/Tokyo", nil)
+	require.NoError(t, err)
+
+	event, err := mapper.Map(newExternalEvent("2030-06-01T18:00:00", "2030-06-01T20:00:00"))
+
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2030, 6, 1, 9, 0, 0, 0, time.UTC), event.EventStartDate)
+	assert.Equal(t, time.Date(2030, 6, 1, 11, 0, 0, 0, time.UTC), event.EventEndDate)
+	assert.Equal(t, time.UTC, event.EventStartDate.Location())
+}
+
+func TestEventMapper_ExplicitOffsetWinsOverSourceTimezone(t *testing.T) {
+	mapper, err := data.NewEventMapperForTimezone("Asia/Tokyo", nil)
+	require.NoError(t, err)
+
+	event, err := mapper.Map(newExternalEvent("2030-06-01T18:00:00+02:00", "2030-06-01T20:00:00Z"))
+
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2030, 6, 1, 16, 0, 0, 0, time.UTC), event.EventStartDate)
+	assert.Equal(t, time.Date(2030, 6, 1, 20, 0, 0, 0, time.UTC), event.EventEndDate)
+}
+
+func TestEventMapper_CustomLayouts(t *testing.T) {
+	mapper := data.NewEventMapper(time.UTC, []string{"02.01.2006 15:04"})
+
+	event, err := mapper.Map(newExternalEvent("01.06.2030 18:00", "01.06.2030 20:00"))
+
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2030, 6, 1, 18, 0, 0, 0, time.UTC), event.EventStartDate)
+}
+
+func TestEventMapper_UnknownTimezone(t *testing.T) {
+	_, err := data.NewEventMapperForTimezone("Mars/Olympus_Mons", nil)
+
+	require.Error(t, err)
+}
+
+func TestMapExternalToInternalEvent_InvalidStartDate(t *testing.T) {
+	_, err := data.MapExternalToInternalEvent(newExternalEvent("tomorrow", "2030-06-01T20:00:00"))
+
+	require.Error(t, err)
+	var mappingErr *data.MappingError
+	require.True(t, errors.As(err, &mappingErr))
+	assert.Equal(t, data.MappingErrInvalidStartDate, mappingErr.Code)
+	assert.Equal(t, "eventStartDate", mappingErr.Field)
+	assert.Equal(t, "tomorrow", mappingErr.Value)
+	assert.Contains(t, err.Error(), "cannot map eventStartDate 'tomorrow'")
+}
+
+func TestMapExternalToInternalEvent_UnknownResult(t *testing.T) {
+	ext := newExternalEvent("2030-06-01T18:00:00", "2030-06-01T20:00:00")
+	result := "Abandoned"
+	ext.Result = &result
+
+	_, err := data.MapExternalToInternalEvent(ext)
+
+	require.Error(t, err)
+	assert.Equal(t, data.MappingErrUnknownResult, data.MappingErrorCodeOf(err))
+}
+
+func TestExternalEventDTO_WrongFieldTypeIsReportedByMap(t *testing.T) {
+	var events []data.ExternalEventDTO
+	payload := `[{"id":"e1","eventName":"Kairat vs Astana","eventStartDate":20300601},{"id":"e2","eventName":"Ordabasy vs Tobol"}]`
+
+	require.NoError(t, json.Unmarshal([]byte(payload), &events), "one malformed event must not reject the feed")
+	require.Len(t, events, 2)
+	assert.Equal(t, "e1", events[0].APIEventID)
+	assert.JSONEq(t, `{"id":"e1","eventName":"Kairat vs Astana","eventStartDate":20300601}`, string(events[0].Payload()))
+
+	_, err := data.MapExternalToInternalEvent(events[0])
+
+	var mappingErr *data.MappingError
+	require.True(t, errors.As(err, &mappingErr))
+	assert.Equal(t, data.MappingErrInvalidPayload, mappingErr.Code)
+	assert.Equal(t, "eventStartDate", mappingErr.Field)
+	assert.Equal(t, "number", mappingErr.Value)
+}
+
+func TestNewQuarantinedEvent(t *testing.T) {
+	ext := newExternalEvent("tomorrow", "2030-06-01T20:00:00")
+	_, mapErr := data.MapExternalToInternalEvent(ext)
+	now := time.Date(2030, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	entry := data.NewQuarantinedEvent("primary", ext, mapErr, now)
+
+	assert.NotEmpty(t, entry.ID)
+	assert.Equal(t, "primary", entry.Provider)
+	assert.Equal(t, ext.APIEventID, entry.ExternalID)
+	assert.Equal(t, data.MappingErrInvalidStartDate, entry.ErrorCode)
+	assert.Equal(t, data.QuarantinePending, entry.Status)
+	assert.Contains(t, entry.Payload, `"eventStartDate":"tomorrow"`, "events built in code are stored encoded as JSON")
+	assert.Equal(t, now, entry.FirstSeenAt)
+}
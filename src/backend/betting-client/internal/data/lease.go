@@ -0,0 +1,27 @@
+package data
+
+import "time"
+
+// Lease grants its holder an exclusive role until it expires. Token is a fencing token:
+// it grows whenever the lease passes to another holder, so work done under an older
+// token can be told apart.
+type Lease struct {
+	Name       string    `db:"name"`
+	Holder     string    `db:"holder"`
+	Token      int64     `db:"token"`
+	AcquiredAt time.Time `db:"acquired_at"`
+	RenewedAt  time.Time `db:"renewed_at"`
+	ExpiresAt  time.Time `db:"expires_at"`
+}
+
+// LeaderStatus tells whether this replica leads and who does otherwise.
+type LeaderStatus struct {
+	LeaseName string `json:"leaseName"`
+	// Holder identifies this replica.
+	Holder string `json:"holder"`
+	Leader bool   `json:"leader"`
+	// Token is the fencing token of the lease while this replica leads.
+	Token         int64      `json:"token,omitempty"`
+	CurrentLeader string     `json:"currentLeader,omitempty"`
+	ExpiresAt     *time.Time `json:"expiresAt,omitempty"`
+}
@@ -0,0 +1,87 @@
+package data
+
+import "time"
+
+// MissingEventSighting is when one provider last reported an event that is missing from
+// its full feed, and for how many consecutive full fetches it was missing.
+type MissingEventSighting struct {
+	EventID      string      `db:"event_id"`
+	EventName    string      `db:"event_name"`
+	Status       EventStatus `db:"status"`
+	SuspendedAt  *time.Time  `db:"suspended_at"`
+	Provider     string      `db:"provider"`
+	LastSeenAt   time.Time   `db:"last_seen_at"`
+	MissedCycles int         `db:"missed_cycles"`
+}
+
+// MissingEvent is an unsettled event that none of its providers reports anymore.
+type MissingEvent struct {
+	EventID     string
+	EventName   string
+	Status      EventStatus
+	SuspendedAt *time.Time
+	// LastSeenAt is the latest sighting by any provider.
+	LastSeenAt time.Time
+	// MissedCycles is the lowest count of any provider, so the event counts as missing
+	// only as long as every provider has missed it.
+	MissedCycles int
+	Providers    []string
+}
+
+// GroupMissingEventSightings merges the sightings of each event, keeping the order in
+// which events first appear.
+func GroupMissingEventSightings(sightings []MissingEventSighting) []MissingEvent {
+	events := make([]MissingEvent, 0)
+	index := make(map[string]int)
+	for _, s := range sightings {
+		i, ok := index[s.EventID]
+		if !ok {
+			index[s.EventID] = len(events)
+			events = append(events, MissingEvent{
+				EventID:      s.EventID,
+				EventName:    s.EventName,
+				Status:       s.Status,
+				SuspendedAt:  s.SuspendedAt,
+				LastSeenAt:   s.LastSeenAt,
+				MissedCycles: s.MissedCycles,
+				Providers:    []string{s.Provider},
+			})
+			continue
+		}
+		event := &events[i]
+		if s.LastSeenAt.After(event.LastSeenAt) {
+			event.LastSeenAt = s.LastSeenAt
+		}
+		if s.MissedCycles < event.MissedCycles {
+			event.MissedCycles = s.MissedCycles
+		}
+		event.Providers = append(event.Providers, s.Provider)
+	}
+	return events
+}
+
+type MissingEventDTO struct {
+	EventID      string      `json:"eventId"`
+	EventName    string      `json:"eventName"`
+	Status       EventStatus `json:"status"`
+	SuspendedAt  *time.Time  `json:"suspendedAt,omitempty"`
+	LastSeenAt   time.Time   `json:"lastSeenAt"`
+	MissedCycles int         `json:"missedCycles"`
+	Providers    []string    `json:"providers"`
+}
+
+func MapMissingEventsToDTOs(events []MissingEvent) []MissingEventDTO {
+	dtos := make([]MissingEventDTO, len(events))
+	for i, e := range events {
+		dtos[i] = MissingEventDTO{
+			EventID:      e.EventID,
+			EventName:    e.EventName,
+			Status:       e.Status,
+			SuspendedAt:  e.SuspendedAt,
+			LastSeenAt:   e.LastSeenAt,
+			MissedCycles: e.MissedCycles,
+			Providers:    e.Providers,
+		}
+	}
+	return dtos
+}
@@ -0,0 +1,29 @@
+package data_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Arlan-Z/def-betting-api/internal/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroupMissingEventSightings(t *testing.T) {
+	early := time.Date(2030, 6, 1, 10, 0, 0, 0, time.UTC)
+	late := early.Add(time.Hour)
+
+	events := data.GroupMissingEventSightings([]data.MissingEventSighting{
+		{EventID: "e1", EventName: "Kairat vs Astana", Status: data.EventStatusScheduled, Provider: "backup", LastSeenAt: early, MissedCycles: 5},
+		{EventID: "e1", EventName: "Kairat vs Astana", Status: data.EventStatusScheduled, Provider: "primary", LastSeenAt: late, MissedCycles: 2},
+		{EventID: "e2", EventName: "Ordabasy vs Tobol", Status: data.EventStatusSuspended, Provider: "primary", LastSeenAt: early, MissedCycles: 4},
+	})
+
+	require.Len(t, events, 2)
+	assert.Equal(t, "e1", events[0].EventID)
+	assert.Equal(t, late, events[0].LastSeenAt, "the latest sighting of any provider wins")
+	assert.Equal(t, 2, events[0].MissedCycles, "the event is missing only as long as every provider missed it")
+	assert.Equal(t, []string{"backup", "primary"}, events[0].Providers)
+	assert.Equal(t, "e2", events[1].EventID)
+	assert.Equal(t, data.EventStatusSuspended, events[1].Status)
+}
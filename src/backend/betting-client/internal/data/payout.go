@@ -0,0 +1,214 @@
+package data
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PayoutKind string
+
+const (
+	// PayoutWin pays the winnings of a bet.
+	PayoutWin PayoutKind = "win"
+	// PayoutRefund returns the stake of a voided bet.
+	PayoutRefund PayoutKind = "refund"
+	// PayoutCashout pays out a bet the user settled before the event ended. Bets cannot
+	// be cashed out yet, so no payout of this kind is queued, but the payout service
+	// accepts it as of schema version 2.
+	PayoutCashout PayoutKind = "cashout"
+	// PayoutAdjustment corrects an earlier payout without changing the bet status,
+	// e.g. a clawback after a resettlement. Its amount may be negative.
+	PayoutAdjustment PayoutKind = "adjustment"
+)
+
+type OutboxStatus string
+
+const (
+	OutboxPending   OutboxStatus = "Pending"
+	OutboxDelivered OutboxStatus = "Delivered"
+	OutboxFailed    OutboxStatus = "Failed"
+	// OutboxCanceled intents were replaced by a later settlement of the bet before delivery.
+	OutboxCanceled OutboxStatus = "Canceled"
+	// OutboxResolved intents were settled by hand outside the service.
+	OutboxResolved OutboxStatus = "Resolved"
+)
+
+// PayoutIntent is a payout notification waiting in the outbox. It is written together
+// with the bet status change and delivered to the payout service afterwards.
+type PayoutIntent struct {
+	ID             string       `db:"id"`
+	BetID          string       `db:"bet_id"`
+	UserID         string       `db:"user_id"`
+	Amount         float64      `db:"amount"`
+	Kind           PayoutKind   `db:"kind"`
+	Status         OutboxStatus `db:"status"`
+	Attempts       int          `db:"attempts"`
+	NextAttemptAt  time.Time    `db:"next_attempt_at"`
+	LastError      *string      `db:"last_error"`
+	CreatedAt      time.Time    `db:"created_at"`
+	FinishedAt     *time.Time   `db:"finished_at"`
+	ResolutionNote *string      `db:"resolution_note"`
+	// The bet details below are taken when the intent is queued and sent along with it.
+	EventID          string  `db:"event_id"`
+	PredictedOutcome Outcome `db:"predicted_outcome"`
+	Odds             float64 `db:"odds"`
+	Stake            float64 `db:"stake"`
+	// Sequence numbers the intents of the bet per kind, starting at 1. It is assigned
+	// when the intent is stored; intents queued before it existed have 0.
+	Sequence int `db:"sequence"`
+}
+
+// PayoutAttempt is one delivery attempt of a payout intent. Error is nil if it was delivered.
+type PayoutAttempt struct {
+	ID          int64     `db:"id"`
+	PayoutID    string    `db:"payout_id"`
+	BetID       string    `db:"bet_id"`
+	Attempt     int       `db:"attempt"`
+	AttemptedAt time.Time `db:"attempted_at"`
+	Error       *string   `db:"error"`
+}
+
+// NewPayoutIntent creates a pending intent for the bet, due immediately.
+func NewPayoutIntent(bet Bet, kind PayoutKind, amount float64, now time.Time) *PayoutIntent {
+	return &PayoutIntent{
+		ID:               uuid.NewString(),
+		BetID:            bet.ID,
+		UserID:           bet.UserID,
+		Amount:           amount,
+		Kind:             kind,
+		Status:           OutboxPending,
+		NextAttemptAt:    now,
+		CreatedAt:        now,
+		EventID:          bet.EventID,
+		PredictedOutcome: bet.PredictedOutcome,
+		Odds:             bet.Odds(),
+		Stake:            bet.Amount,
+	}
+}
+
+// Reversal creates a pending adjustment that takes back the intent's amount. It offsets
+// an intent that was delivered after a later settlement had replaced it.
+func (p PayoutIntent) Reversal(now time.Time) *PayoutIntent {
+	return &PayoutIntent{
+		ID:               uuid.NewString(),
+		BetID:            p.BetID,
+		UserID:           p.UserID,
+		Amount:           -p.Amount,
+		Kind:             PayoutAdjustment,
+		Status:           OutboxPending,
+		NextAttemptAt:    now,
+		CreatedAt:        now,
+		EventID:          p.EventID,
+		PredictedOutcome: p.PredictedOutcome,
+		Odds:             p.Odds,
+		Stake:            p.Stake,
+	}
+}
+
+// Stuck reports whether the intent failed for good or keeps failing, so it is listed for
+// an admin. Only failed intents may be requeued or resolved.
+func (p PayoutIntent) Stuck() bool {
+	return p.Status == OutboxFailed || (p.Status == OutboxPending && p.Attempts > 0)
+}
+
+// IdempotencyKey identifies the intent towards the payout service. It names the bet, the
+// kind of payout and the sequence, which tells apart payouts of the same bet, e.g. a win
+// that is paid again after a resettlement clawed it back. Intents without a sequence keep
+// the key they were first sent with, which ends in the intent ID.
+func (p PayoutIntent) IdempotencyKey() string {
+	if p.Sequence == 0 {
+		return "bet:" + p.BetID + ":" + string(p.Kind) + ":" + p.ID
+	}
+	return "bet:" + p.BetID + ":" + string(p.Kind) + ":" + strconv.Itoa(p.Sequence)
+}
+
+// Notification is what the payout service receives for the intent, in the given currency.
+func (p PayoutIntent) Notification(currency string) PayoutNotification {
+	return PayoutNotification{
+		SchemaVersion:  PayoutNotificationSchemaVersion,
+		IdempotencyKey: p.IdempotencyKey(),
+		UserID:         p.UserID,
+		Amount:         p.Amount,
+		Currency:       currency,
+		Reason:         p.Kind,
+		BetID:          p.BetID,
+		EventID:        p.EventID,
+		Outcome:        p.PredictedOutcome,
+		Odds:           p.Odds,
+		Stake:          p.Stake,
+	}
+}
+
+// BetStatuses returns the status a bet has while the payout is on its way and the one
+// it moves to once delivered. Cashouts and adjustments leave the bet status alone, so ok
+// is false.
+func (k PayoutKind) BetStatuses() (awaiting BetStatus, delivered BetStatus, ok bool) {
+	switch k {
+	case PayoutWin:
+		return StatusWon, StatusPaid, true
+	case PayoutRefund:
+		return StatusVoided, StatusRefunded, true
+	}
+	return "", "", false
+}
+
+type ResolvePayoutRequest struct {
+	Note string `json:"note" validate:"required"`
+}
+
+type PayoutAttemptDTO struct {
+	Attempt     int       `json:"attempt"`
+	AttemptedAt time.Time `json:"attemptedAt"`
+	Error       *string   `json:"error,omitempty"`
+}
+
+type PayoutDTO struct {
+	ID             string             `json:"id"`
+	BetID          string             `json:"betId"`
+	UserID         string             `json:"userId"`
+	Amount         float64            `json:"amount"`
+	Kind           PayoutKind         `json:"kind"`
+	Status         OutboxStatus       `json:"status"`
+	Attempts       int                `json:"attempts"`
+	NextAttemptAt  time.Time          `json:"nextAttemptAt"`
+	LastError      *string            `json:"lastError,omitempty"`
+	CreatedAt      time.Time          `json:"createdAt"`
+	FinishedAt     *time.Time         `json:"finishedAt,omitempty"`
+	ResolutionNote *string            `json:"resolutionNote,omitempty"`
+	History        []PayoutAttemptDTO `json:"history,omitempty"`
+}
+
+func MapPayoutToDTO(p PayoutIntent, history []PayoutAttempt) PayoutDTO {
+	dto := PayoutDTO{
+		ID:             p.ID,
+		BetID:          p.BetID,
+		UserID:         p.UserID,
+		Amount:         p.Amount,
+		Kind:           p.Kind,
+		Status:         p.Status,
+		Attempts:       p.Attempts,
+		NextAttemptAt:  p.NextAttemptAt,
+		LastError:      p.LastError,
+		CreatedAt:      p.CreatedAt,
+		FinishedAt:     p.FinishedAt,
+		ResolutionNote: p.ResolutionNote,
+	}
+	for _, a := range history {
+		dto.History = append(dto.History, PayoutAttemptDTO{
+			Attempt:     a.Attempt,
+			AttemptedAt: a.AttemptedAt,
+			Error:       a.Error,
+		})
+	}
+	return dto
+}
+
+func MapPayoutsToDTOs(payouts []PayoutIntent) []PayoutDTO {
+	dtos := make([]PayoutDTO, len(payouts))
+	for i, p := range payouts {
+		dtos[i] = MapPayoutToDTO(p, nil)
+	}
+	return dtos
+}
@@ -0,0 +1,90 @@
+package data_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Arlan-Z/def-betting-api/internal/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPayoutIntent(t *testing.T) {
+	now := time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+	bet := data.Bet{
+		ID:                    "bet-1",
+		UserID:                "user-1",
+		EventID:               "event-1",
+		Amount:                10,
+		PredictedOutcome:      data.AwayWin,
+		RecordedHomeWinChance: 1.8,
+		RecordedAwayWinChance: 2.55,
+		RecordedDrawChance:    3.1,
+	}
+
+	intent := data.NewPayoutIntent(bet, data.PayoutWin, 25.5, now)
+	intent.Sequence = 1
+
+	assert.NotEmpty(t, intent.ID)
+	assert.Equal(t, "bet-1", intent.BetID)
+	assert.Equal(t, data.OutboxPending, intent.Status)
+	assert.Equal(t, now, intent.NextAttemptAt)
+	assert.Equal(t, data.PayoutNotification{
+		SchemaVersion:  data.PayoutNotificationSchemaVersion,
+		IdempotencyKey: "bet:bet-1:win:1",
+		UserID:         "user-1",
+		Amount:         25.5,
+		Currency:       "KZT",
+		Reason:         data.PayoutWin,
+		BetID:          "bet-1",
+		EventID:        "event-1",
+		Outcome:        data.AwayWin,
+		Odds:           2.55,
+		Stake:          10,
+	}, intent.Notification("KZT"))
+}
+
+func TestPayoutIntent_IdempotencyKey(t *testing.T) {
+	now := time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+	bet := data.Bet{ID: "bet-1", UserID: "user-1", Amount: 10}
+
+	win := data.NewPayoutIntent(bet, data.PayoutWin, 20, now)
+	win.Sequence = 1
+	// A redelivery of the same intent, e.g. after a restart, keeps its key.
+	assert.Equal(t, "bet:bet-1:win:1", win.IdempotencyKey())
+	assert.Equal(t, win.IdempotencyKey(), win.Notification("USD").IdempotencyKey)
+
+	// The same bet paid again after a resettlement gets a key of its own.
+	rewin := data.NewPayoutIntent(bet, data.PayoutWin, 20, now)
+	rewin.Sequence = 2
+	assert.Equal(t, "bet:bet-1:win:2", rewin.IdempotencyKey())
+
+	// Intents queued before payouts were numbered keep the key they were sent with.
+	legacy := data.NewPayoutIntent(bet, data.PayoutWin, 20, now)
+	assert.Equal(t, "bet:bet-1:win:"+legacy.ID, legacy.IdempotencyKey())
+}
+
+func TestPayoutKind_BetStatuses(t *testing.T) {
+	awaiting, delivered, ok := data.PayoutWin.BetStatuses()
+	assert.True(t, ok)
+	assert.Equal(t, data.StatusWon, awaiting)
+	assert.Equal(t, data.StatusPaid, delivered)
+
+	awaiting, delivered, ok = data.PayoutRefund.BetStatuses()
+	assert.True(t, ok)
+	assert.Equal(t, data.StatusVoided, awaiting)
+	assert.Equal(t, data.StatusRefunded, delivered)
+
+	_, _, ok = data.PayoutCashout.BetStatuses()
+	assert.False(t, ok)
+
+	_, _, ok = data.PayoutAdjustment.BetStatuses()
+	assert.False(t, ok)
+}
+
+func TestPayoutIntent_Stuck(t *testing.T) {
+	assert.False(t, data.PayoutIntent{Status: data.OutboxPending}.Stuck())
+	assert.True(t, data.PayoutIntent{Status: data.OutboxPending, Attempts: 2}.Stuck())
+	assert.True(t, data.PayoutIntent{Status: data.OutboxFailed, Attempts: 8}.Stuck())
+	assert.False(t, data.PayoutIntent{Status: data.OutboxDelivered, Attempts: 1}.Stuck())
+	assert.False(t, data.PayoutIntent{Status: data.OutboxResolved, Attempts: 8}.Stuck())
+}
@@ -0,0 +1,214 @@
+package data
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// ResultCanceled is how a provider snapshot reports a canceled event in result comparisons.
+const ResultCanceled = "Canceled"
+
+// ProviderEventSnapshot is the latest state of an event as reported by one provider,
+// together with the internal event the provider's ID is mapped to.
+type ProviderEventSnapshot struct {
+	Provider        string    `db:"provider"`
+	ProviderEventID string    `db:"provider_event_id"`
+	EventID         string    `db:"event_id"`
+	EventName       string    `db:"event_name"`
+	HomeTeam        string    `db:"home_team"`
+	AwayTeam        string    `db:"away_team"`
+	HomeWinChance   float64   `db:"home_win_chance"`
+	AwayWinChance   float64   `db:"away_win_chance"`
+	DrawChance      float64   `db:"draw_chance"`
+	EventStartDate  time.Time `db:"event_start_date"`
+	EventEndDate    time.Time `db:"event_end_date"`
+	EventResult     *Outcome  `db:"event_result"`
+	Canceled        bool      `db:"canceled"`
+	Type            string    `db:"type"`
+	Competition     string    `db:"competition"`
+	ReceivedAt      time.Time `db:"received_at"`
+}
+
+// NewProviderEventSnapshot captures a mapped provider event.
+func NewProviderEventSnapshot(provider string, providerEventID string, event Event, canceled bool, receivedAt time.Time) ProviderEventSnapshot {
+	return ProviderEventSnapshot{
+		Provider:        provider,
+		ProviderEventID: providerEventID,
+		EventID:         event.ID,
+		EventName:       event.EventName,
+		HomeTeam:        event.HomeTeam,
+		AwayTeam:        event.AwayTeam,
+		HomeWinChance:   event.HomeWinChance,
+		AwayWinChance:   event.AwayWinChance,
+		DrawChance:      event.DrawChance,
+		EventStartDate:  event.EventStartDate,
+		EventEndDate:    event.EventEndDate,
+		EventResult:     event.EventResult,
+		Canceled:        canceled,
+		Type:            event.Type,
+		Competition:     event.Competition,
+		ReceivedAt:      receivedAt,
+	}
+}
+
+// FinalResult returns the outcome, ResultCanceled, or an empty string while the event is undecided.
+func (s ProviderEventSnapshot) FinalResult() string {
+	if s.EventResult != nil {
+		return string(*s.EventResult)
+	}
+	if s.Canceled {
+		return ResultCanceled
+	}
+	return ""
+}
+
+func (s ProviderEventSnapshot) hasOdds() bool {
+	return s.HomeWinChance != 0 || s.AwayWinChance != 0 || s.DrawChance != 0
+}
+
+// MergeRules list provider names by priority for each group of fields. Providers that
+// are not listed rank below the listed ones, in alphabetical order.
+type MergeRules struct {
+	Odds     []string
+	Schedule []string
+	Results  []string
+}
+
+// MergedEvent is the event built from all provider snapshots.
+type MergedEvent struct {
+	Event    Event
+	Canceled bool
+	// ResultConflict is set when providers report different final results.
+	ResultConflict bool
+	// Results holds the final result reported by each provider that has one.
+	Results map[string]string
+}
+
+// ResultsSummary formats the reported results as "provider=result" pairs sorted by provider.
+func (m MergedEvent) ResultsSummary() string {
+	pairs := make([]string, 0, len(m.Results))
+	for provider, result := range m.Results {
+		pairs = append(pairs, provider+"="+result)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+// Merge builds the event from the snapshots: schedule and descriptive fields come from
+// the highest schedule priority, odds from the highest odds priority that has odds, and
+// the result from the highest results priority that has one.
+func (r MergeRules) Merge(eventID string, snapshots []ProviderEventSnapshot, now time.Time) MergedEvent {
+	merged := MergedEvent{Results: make(map[string]string)}
+	if len(snapshots) == 0 {
+		return merged
+	}
+
+	schedule := pickSnapshot(snapshots, r.Schedule, func(ProviderEventSnapshot) bool { return true })
+	event := Event{
+		ID:             eventID,
+		EventName:      schedule.EventName,
+		HomeTeam:       schedule.HomeTeam,
+		AwayTeam:       schedule.AwayTeam,
+		EventStartDate: schedule.EventStartDate,
+		EventEndDate:   schedule.EventEndDate,
+		Type:           schedule.Type,
+		Competition:    schedule.Competition,
+		IsActive:       true,
+	}
+
+	if odds := pickSnapshot(snapshots, r.Odds, ProviderEventSnapshot.hasOdds); odds != nil {
+		event.HomeWinChance = odds.HomeWinChance
+		event.AwayWinChance = odds.AwayWinChance
+		event.DrawChance = odds.DrawChance
+	}
+
+	distinct := make(map[string]bool)
+	for _, s := range snapshots {
+		if result := s.FinalResult(); result != "" {
+			merged.Results[s.Provider] = result
+			distinct[result] = true
+		}
+	}
+	merged.ResultConflict = len(distinct) > 1
+
+	if result := pickSnapshot(snapshots, r.Results, func(s ProviderEventSnapshot) bool { return s.FinalResult() != "" }); result != nil {
+		event.EventResult = result.EventResult
+		merged.Canceled = result.EventResult == nil && result.Canceled
+		event.IsActive = false
+	} else if now.After(event.EventEndDate) {
+		event.IsActive = false
+	}
+
+	merged.Event = event
+	return merged
+}
+
+// pickSnapshot returns the highest priority snapshot accepted by the filter, or nil.
+func pickSnapshot(snapshots []ProviderEventSnapshot, priority []string, accept func(ProviderEventSnapshot) bool) *ProviderEventSnapshot {
+	rank := func(provider string) int {
+		for i, name := range priority {
+			if name == provider {
+				return i
+			}
+		}
+		return len(priority)
+	}
+
+	var best *ProviderEventSnapshot
+	for i := range snapshots {
+		s := &snapshots[i]
+		if !accept(*s) {
+			continue
+		}
+		if best == nil || rank(s.Provider) < rank(best.Provider) ||
+			(rank(s.Provider) == rank(best.Provider) && s.Provider < best.Provider) {
+			best = s
+		}
+	}
+	return best
+}
+
+type ResultConflict struct {
+	EventID        string     `db:"event_id"`
+	Results        string     `db:"results"`
+	DetectedAt     time.Time  `db:"detected_at"`
+	ResolvedAt     *time.Time `db:"resolved_at"`
+	ResolvedResult *string    `db:"resolved_result"`
+}
+
+type ConfirmResultRequest struct {
+	Result string `json:"result" validate:"required,oneof=HomeWin AwayWin Draw Canceled"`
+}
+
+type ResultConflictDTO struct {
+	EventID        string            `json:"eventId"`
+	Results        map[string]string `json:"results"`
+	DetectedAt     time.Time         `json:"detectedAt"`
+	ResolvedAt     *time.Time        `json:"resolvedAt,omitempty"`
+	ResolvedResult *string           `json:"resolvedResult,omitempty"`
+}
+
+func MapResultConflictToDTO(c ResultConflict) ResultConflictDTO {
+	results := make(map[string]string)
+	for _, pair := range strings.Split(c.Results, ",") {
+		if provider, result, ok := strings.Cut(pair, "="); ok {
+			results[provider] = result
+		}
+	}
+	return ResultConflictDTO{
+		EventID:        c.EventID,
+		Results:        results,
+		DetectedAt:     c.DetectedAt,
+		ResolvedAt:     c.ResolvedAt,
+		ResolvedResult: c.ResolvedResult,
+	}
+}
+
+func MapResultConflictsToDTOs(conflicts []ResultConflict) []ResultConflictDTO {
+	dtos := make([]ResultConflictDTO, len(conflicts))
+	for i, c := range conflicts {
+		dtos[i] = MapResultConflictToDTO(c)
+	}
+	return dtos
+}
@@ -0,0 +1,114 @@
+package data_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Arlan-Z/def-betting-api/internal/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func newSnapshot(provider string, start time.Time, homeOdds float64, result *data.Outcome) data.ProviderEventSnapshot {
+	return data.ProviderEventSnapshot{
+		Provider:        provider,
+		ProviderEventID: provider + "-1",
+		EventName:       "Kairat vs Astana (" + provider + ")",
+		HomeTeam:        "Kairat",
+		AwayTeam:        "Astana",
+		HomeWinChance:   homeOdds,
+		AwayWinChance:   0.3,
+		DrawChance:      0.2,
+		EventStartDate:  start,
+		EventEndDate:    start.Add(2 * time.Hour),
+		EventResult:     result,
+		Type:            "Football",
+	}
+}
+
+func TestMergeRules_FieldGroupsFollowTheirOwnPriority(t *testing.T) {
+	now := time.Date(2030, 6, 1, 12, 0, 0, 0, time.UTC)
+	startA := now.Add(24 * time.Hour)
+	startB := now.Add(25 * time.Hour)
+	snapshots := []data.ProviderEventSnapshot{
+		newSnapshot("a", startA, 0.5, nil),
+		newSnapshot("b", startB, 0.6, nil),
+	}
+	rules := data.MergeRules{Odds: []string{"b", "a"}, Schedule: []string{"a", "b"}}
+
+	merged := rules.Merge("event-1", snapshots, now)
+
+	assert.Equal(t, "event-1", merged.Event.ID)
+	assert.Equal(t, startA, merged.Event.EventStartDate)
+	assert.Equal(t, "Kairat vs Astana (a)", merged.Event.EventName)
+	assert.Equal(t, 0.6, merged.Event.HomeWinChance)
+	assert.True(t, merged.Event.IsActive)
+	assert.Nil(t, merged.Event.EventResult)
+	assert.False(t, merged.ResultConflict)
+}
+
+func TestMergeRules_UnlistedProvidersRankAlphabeticallyAfterListed(t *testing.T) {
+	now := time.Date(2030, 6, 1, 12, 0, 0, 0, time.UTC)
+	snapshots := []data.ProviderEventSnapshot{
+		newSnapshot("zeta", now.Add(time.Hour), 0.1, nil),
+		newSnapshot("beta", now.Add(2*time.Hour), 0.2, nil),
+		newSnapshot("alpha", now.Add(3*time.Hour), 0.3, nil),
+	}
+
+	merged := data.MergeRules{Odds: []string{"zeta"}}.Merge("event-1", snapshots, now)
+
+	assert.Equal(t, 0.1, merged.Event.HomeWinChance)
+	assert.Equal(t, now.Add(3*time.Hour), merged.Event.EventStartDate, "schedule falls back to alphabetical order")
+}
+
+func TestMergeRules_OddsSkipProvidersWithoutOdds(t *testing.T) {
+	now := time.Date(2030, 6, 1, 12, 0, 0, 0, time.UTC)
+	noOdds := newSnapshot("a", now.Add(time.Hour), 0, nil)
+	noOdds.AwayWinChance, noOdds.DrawChance = 0, 0
+	snapshots := []data.ProviderEventSnapshot{noOdds, newSnapshot("b", now.Add(time.Hour), 0.45, nil)}
+
+	merged := data.MergeRules{Odds: []string{"a", "b"}}.Merge("event-1", snapshots, now)
+
+	assert.Equal(t, 0.45, merged.Event.HomeWinChance)
+}
+
+func TestMergeRules_ResultFromPriorityProvider(t *testing.T) {
+	now := time.Date(2030, 6, 1, 12, 0, 0, 0, time.UTC)
+	homeWin := data.HomeWin
+	snapshots := []data.ProviderEventSnapshot{
+		newSnapshot("a", now.Add(-3*time.Hour), 0.5, nil),
+		newSnapshot("b", now.Add(-3*time.Hour), 0.5, &homeWin),
+	}
+
+	merged := data.MergeRules{Results: []string{"a", "b"}}.Merge("event-1", snapshots, now)
+
+	if assert.NotNil(t, merged.Event.EventResult) {
+		assert.Equal(t, data.HomeWin, *merged.Event.EventResult)
+	}
+	assert.False(t, merged.Event.IsActive)
+	assert.False(t, merged.ResultConflict)
+	assert.Equal(t, "b=HomeWin", merged.ResultsSummary())
+}
+
+func TestMergeRules_DifferentResultsAreAConflict(t *testing.T) {
+	now := time.Date(2030, 6, 1, 12, 0, 0, 0, time.UTC)
+	homeWin, draw := data.HomeWin, data.Draw
+	canceled := newSnapshot("c", now.Add(-3*time.Hour), 0.5, nil)
+	canceled.Canceled = true
+	snapshots := []data.ProviderEventSnapshot{
+		newSnapshot("a", now.Add(-3*time.Hour), 0.5, &homeWin),
+		newSnapshot("b", now.Add(-3*time.Hour), 0.5, &draw),
+		canceled,
+	}
+
+	merged := data.MergeRules{Results: []string{"c"}}.Merge("event-1", snapshots, now)
+
+	assert.True(t, merged.ResultConflict)
+	assert.True(t, merged.Canceled)
+	assert.Equal(t, "a=HomeWin,b=Draw,c=Canceled", merged.ResultsSummary())
+}
+
+func TestMapResultConflictToDTO_ParsesResults(t *testing.T) {
+	dto := data.MapResultConflictToDTO(data.ResultConflict{EventID: "event-1", Results: "a=HomeWin,b=Draw"})
+
+	assert.Equal(t, map[string]string{"a": "HomeWin", "b": "Draw"}, dto.Results)
+}
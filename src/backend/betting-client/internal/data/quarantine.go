@@ -0,0 +1,115 @@
+package data
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type QuarantineStatus string
+
+const (
+	QuarantinePending   QuarantineStatus = "Pending"
+	QuarantineReplayed  QuarantineStatus = "Replayed"
+	QuarantineDismissed QuarantineStatus = "Dismissed"
+)
+
+// QuarantinedEvent is an external event that could not be mapped, stored with its raw
+// payload until an admin replays or dismisses it. A provider event that keeps failing
+// stays one pending entry.
+type QuarantinedEvent struct {
+	ID           string           `db:"id"`
+	Provider     string           `db:"provider"`
+	ExternalID   string           `db:"external_id"`
+	Payload      string           `db:"payload"`
+	ErrorCode    MappingErrorCode `db:"error_code"`
+	ErrorMessage string           `db:"error_message"`
+	Status       QuarantineStatus `db:"status"`
+	Occurrences  int              `db:"occurrences"`
+	FirstSeenAt  time.Time        `db:"first_seen_at"`
+	LastSeenAt   time.Time        `db:"last_seen_at"`
+	ResolvedAt   *time.Time       `db:"resolved_at"`
+	Note         string           `db:"note"`
+}
+
+func NewQuarantinedEvent(provider string, ext ExternalEventDTO, err error, now time.Time) QuarantinedEvent {
+	code := MappingErrorCodeOf(err)
+	if code == "" {
+		code = MappingErrInvalidPayload
+	}
+	return QuarantinedEvent{
+		ID:           uuid.NewString(),
+		Provider:     provider,
+		ExternalID:   ext.APIEventID,
+		Payload:      string(ext.Payload()),
+		ErrorCode:    code,
+		ErrorMessage: err.Error(),
+		Status:       QuarantinePending,
+		Occurrences:  1,
+		FirstSeenAt:  now,
+		LastSeenAt:   now,
+	}
+}
+
+// QuarantineStat counts quarantined events of one error code and status.
+type QuarantineStat struct {
+	ErrorCode MappingErrorCode `db:"error_code" json:"errorCode"`
+	Status    QuarantineStatus `db:"status" json:"status"`
+	Count     int              `db:"count" json:"count"`
+}
+
+// ReplayQuarantinedRequest replays the stored payload, or the edited one when given.
+type ReplayQuarantinedRequest struct {
+	Payload json.RawMessage `json:"payload"`
+	Note    string          `json:"note"`
+}
+
+type DismissQuarantinedRequest struct {
+	Note string `json:"note"`
+}
+
+type QuarantinedEventDTO struct {
+	ID           string           `json:"id"`
+	Provider     string           `json:"provider"`
+	ExternalID   string           `json:"externalId"`
+	Payload      json.RawMessage  `json:"payload"`
+	ErrorCode    MappingErrorCode `json:"errorCode"`
+	ErrorMessage string           `json:"errorMessage"`
+	Status       QuarantineStatus `json:"status"`
+	Occurrences  int              `json:"occurrences"`
+	FirstSeenAt  time.Time        `json:"firstSeenAt"`
+	LastSeenAt   time.Time        `json:"lastSeenAt"`
+	ResolvedAt   *time.Time       `json:"resolvedAt,omitempty"`
+	Note         string           `json:"note,omitempty"`
+}
+
+func MapQuarantinedEventToDTO(q QuarantinedEvent) QuarantinedEventDTO {
+	payload := json.RawMessage(q.Payload)
+	if !json.Valid(payload) {
+		// Keep the response valid JSON even if a payload was stored truncated.
+		payload, _ = json.Marshal(q.Payload)
+	}
+	return QuarantinedEventDTO{
+		ID:           q.ID,
+		Provider:     q.Provider,
+		ExternalID:   q.ExternalID,
+		Payload:      payload,
+		ErrorCode:    q.ErrorCode,
+		ErrorMessage: q.ErrorMessage,
+		Status:       q.Status,
+		Occurrences:  q.Occurrences,
+		FirstSeenAt:  q.FirstSeenAt,
+		LastSeenAt:   q.LastSeenAt,
+		ResolvedAt:   q.ResolvedAt,
+		Note:         q.Note,
+	}
+}
+
+func MapQuarantinedEventsToDTOs(entries []QuarantinedEvent) []QuarantinedEventDTO {
+	dtos := make([]QuarantinedEventDTO, len(entries))
+	for i, q := range entries {
+		dtos[i] = MapQuarantinedEventToDTO(q)
+	}
+	return dtos
+}
@@ -0,0 +1,175 @@
+package data
+
+import (
+	"math"
+	"time"
+)
+
+type ResettlementStatus string
+
+const (
+	ResettlementRunning             ResettlementStatus = "Running"
+	ResettlementCompleted           ResettlementStatus = "Completed"
+	ResettlementCompletedWithErrors ResettlementStatus = "CompletedWithErrors"
+)
+
+const (
+	ResettledByAdmin = "admin"
+	ResettledBySync  = "sync"
+)
+
+// Resettlement is the audit record of settling a finalized event again with a corrected
+// result. Bets holds one entry per bet whose status or payout changed.
+type Resettlement struct {
+	ID             string             `db:"id"`
+	EventID        string             `db:"event_id"`
+	PreviousResult Outcome            `db:"previous_result"`
+	NewResult      Outcome            `db:"new_result"`
+	TriggeredBy    string             `db:"triggered_by"`
+	Reason         string             `db:"reason"`
+	Status         ResettlementStatus `db:"status"`
+	StartedAt      time.Time          `db:"started_at"`
+	FinishedAt     *time.Time         `db:"finished_at"`
+	BetsChanged    int                `db:"bets_changed"`
+	BetsUnchanged  int                `db:"bets_unchanged"`
+	// PaidOut and ClawedBack sum the positive and negative adjustments queued for the payout service.
+	PaidOut    float64 `db:"paid_out"`
+	ClawedBack float64 `db:"clawed_back"`
+	Errors     int     `db:"errors"`
+
+	Bets []ResettlementBet `db:"-"`
+}
+
+// ResettlementBet is the change of one bet. Adjustment is the amount sent to the payout
+// service: positive amounts pay the user, negative ones claw back an earlier payout.
+type ResettlementBet struct {
+	ResettlementID   string    `db:"resettlement_id"`
+	BetID            string    `db:"bet_id"`
+	UserID           string    `db:"user_id"`
+	PredictedOutcome Outcome   `db:"predicted_outcome"`
+	PreviousStatus   BetStatus `db:"previous_status"`
+	NewStatus        BetStatus `db:"new_status"`
+	PreviousPayout   float64   `db:"previous_payout"`
+	NewPayout        float64   `db:"new_payout"`
+	Adjustment       float64   `db:"adjustment"`
+	Error            string    `db:"error"`
+}
+
+// PlanBetResettlement returns how a bet settled with another result changes under the new
+// one. Only a Paid bet has received its payout, so only its payout is clawed back.
+func PlanBetResettlement(bet Bet, newResult Outcome) ResettlementBet {
+	change := ResettlementBet{
+		BetID:            bet.ID,
+		UserID:           bet.UserID,
+		PredictedOutcome: bet.PredictedOutcome,
+		PreviousStatus:   bet.Status,
+		PreviousPayout:   bet.PayoutAmount,
+		NewStatus:        StatusLost,
+	}
+	if bet.PredictedOutcome == newResult {
+		change.NewStatus = StatusWon
+		change.NewPayout = bet.PayoutFor(newResult)
+	}
+
+	delivered := 0.0
+	if bet.Status == StatusPaid {
+		delivered = bet.PayoutAmount
+	}
+	change.Adjustment = math.Round((change.NewPayout-delivered)*100) / 100
+	if change.NewStatus == StatusWon && change.Adjustment == 0 {
+		change.NewStatus = StatusPaid
+	}
+	return change
+}
+
+// Changed reports whether the bet ends up in another state than before.
+func (c ResettlementBet) Changed() bool {
+	return c.NewStatus != c.PreviousStatus || c.NewPayout != c.PreviousPayout || c.Adjustment != 0
+}
+
+// Finish sets the final status from the recorded errors.
+func (r *Resettlement) Finish(finishedAt time.Time) {
+	r.FinishedAt = &finishedAt
+	if r.Errors > 0 {
+		r.Status = ResettlementCompletedWithErrors
+	} else {
+		r.Status = ResettlementCompleted
+	}
+}
+
+type ResettleEventRequest struct {
+	Result string `json:"result" validate:"required,oneof=HomeWin AwayWin Draw"`
+	Reason string `json:"reason" validate:"required"`
+}
+
+type ResettlementBetDTO struct {
+	BetID            string    `json:"betId"`
+	UserID           string    `json:"userId"`
+	PredictedOutcome Outcome   `json:"predictedOutcome"`
+	PreviousStatus   BetStatus `json:"previousStatus"`
+	NewStatus        BetStatus `json:"newStatus"`
+	PreviousPayout   float64   `json:"previousPayout"`
+	NewPayout        float64   `json:"newPayout"`
+	Adjustment       float64   `json:"adjustment"`
+	Error            string    `json:"error,omitempty"`
+}
+
+type ResettlementDTO struct {
+	ID             string               `json:"id"`
+	EventID        string               `json:"eventId"`
+	PreviousResult Outcome              `json:"previousResult"`
+	NewResult      Outcome              `json:"newResult"`
+	TriggeredBy    string               `json:"triggeredBy"`
+	Reason         string               `json:"reason"`
+	Status         ResettlementStatus   `json:"status"`
+	StartedAt      time.Time            `json:"startedAt"`
+	FinishedAt     *time.Time           `json:"finishedAt,omitempty"`
+	BetsChanged    int                  `json:"betsChanged"`
+	BetsUnchanged  int                  `json:"betsUnchanged"`
+	PaidOut        float64              `json:"paidOut"`
+	ClawedBack     float64              `json:"clawedBack"`
+	Errors         int                  `json:"errors"`
+	Bets           []ResettlementBetDTO `json:"bets,omitempty"`
+}
+
+func MapResettlementToDTO(r Resettlement) ResettlementDTO {
+	var bets []ResettlementBetDTO
+	for _, b := range r.Bets {
+		bets = append(bets, ResettlementBetDTO{
+			BetID:            b.BetID,
+			UserID:           b.UserID,
+			PredictedOutcome: b.PredictedOutcome,
+			PreviousStatus:   b.PreviousStatus,
+			NewStatus:        b.NewStatus,
+			PreviousPayout:   b.PreviousPayout,
+			NewPayout:        b.NewPayout,
+			Adjustment:       b.Adjustment,
+			Error:            b.Error,
+		})
+	}
+	return ResettlementDTO{
+		ID:             r.ID,
+		EventID:        r.EventID,
+		PreviousResult: r.PreviousResult,
+		NewResult:      r.NewResult,
+		TriggeredBy:    r.TriggeredBy,
+		Reason:         r.Reason,
+		Status:         r.Status,
+		StartedAt:      r.StartedAt,
+		FinishedAt:     r.FinishedAt,
+		BetsChanged:    r.BetsChanged,
+		BetsUnchanged:  r.BetsUnchanged,
+		PaidOut:        r.PaidOut,
+		ClawedBack:     r.ClawedBack,
+		Errors:         r.Errors,
+		Bets:           bets,
+	}
+}
+
+func MapResettlementsToDTOs(resettlements []Resettlement) []ResettlementDTO {
+	dtos := make([]ResettlementDTO, len(resettlements))
+	for i, r := range resettlements {
+		dtos[i] = MapResettlementToDTO(r)
+	}
+	return dtos
+}
@@ -0,0 +1,48 @@
+package data_test
+
+import (
+	"testing"
+
+	"github.com/Arlan-Z/def-betting-api/internal/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlanBetResettlement(t *testing.T) {
+	bet := data.Bet{
+		ID:                    "bet-1",
+		UserID:                "user-1",
+		Amount:                10,
+		PredictedOutcome:      data.HomeWin,
+		RecordedHomeWinChance: 1.85,
+		RecordedAwayWinChance: 3.2,
+		RecordedDrawChance:    3.5,
+	}
+
+	paid := bet
+	paid.Status = data.StatusPaid
+	paid.PayoutAmount = 18.5
+	change := data.PlanBetResettlement(paid, data.Draw)
+	assert.Equal(t, data.StatusLost, change.NewStatus)
+	assert.Equal(t, 0.0, change.NewPayout)
+	assert.Equal(t, -18.5, change.Adjustment, "a delivered payout is clawed back")
+	assert.True(t, change.Changed())
+
+	failed := bet
+	failed.Status = data.StatusFailed
+	failed.PayoutAmount = 18.5
+	change = data.PlanBetResettlement(failed, data.AwayWin)
+	assert.Equal(t, data.StatusLost, change.NewStatus)
+	assert.Equal(t, 0.0, change.Adjustment, "a payout that never arrived is not clawed back")
+	assert.True(t, change.Changed())
+
+	lost := bet
+	lost.Status = data.StatusLost
+	change = data.PlanBetResettlement(lost, data.HomeWin)
+	assert.Equal(t, data.StatusWon, change.NewStatus)
+	assert.Equal(t, 18.5, change.NewPayout)
+	assert.Equal(t, 18.5, change.Adjustment)
+
+	change = data.PlanBetResettlement(lost, data.Draw)
+	assert.Equal(t, data.StatusLost, change.NewStatus)
+	assert.False(t, change.Changed())
+}
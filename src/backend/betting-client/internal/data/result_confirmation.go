@@ -0,0 +1,74 @@
+package data
+
+import "time"
+
+const (
+	// ConfirmedBySync marks results finalized once the confirmation policy was met.
+	ConfirmedBySync = "sync"
+	// ConfirmedByAdmin marks results an admin confirmed before the policy was met.
+	ConfirmedByAdmin = "admin"
+)
+
+// ResultConfirmation tracks a result reported by the source that was not finalized yet.
+// Sightings counts the sync runs that reported the same result in a row; a different
+// result starts over.
+type ResultConfirmation struct {
+	EventID     string     `db:"event_id"`
+	Result      Outcome    `db:"result"`
+	Sightings   int        `db:"sightings"`
+	FirstSeenAt time.Time  `db:"first_seen_at"`
+	LastSeenAt  time.Time  `db:"last_seen_at"`
+	LastRunID   string     `db:"last_run_id"`
+	ConfirmedAt *time.Time `db:"confirmed_at"`
+	ConfirmedBy *string    `db:"confirmed_by"`
+}
+
+// ResultConfirmationPolicy decides when a reported result is trusted enough to settle
+// the event. A result is confirmed as soon as either enabled condition holds; with both
+// disabled it is confirmed the first time it is seen.
+type ResultConfirmationPolicy struct {
+	// Cycles is how many sync runs in a row must report the same result. Zero disables the condition.
+	Cycles int
+	// StableFor is how long the result must stay unchanged. Zero disables the condition.
+	StableFor time.Duration
+}
+
+func (p ResultConfirmationPolicy) Confirmed(c ResultConfirmation, now time.Time) bool {
+	if p.Cycles <= 0 && p.StableFor <= 0 {
+		return true
+	}
+	if p.Cycles > 0 && c.Sightings >= p.Cycles {
+		return true
+	}
+	return p.StableFor > 0 && now.Sub(c.FirstSeenAt) >= p.StableFor
+}
+
+type ResultConfirmationDTO struct {
+	EventID     string     `json:"eventId"`
+	Result      Outcome    `json:"result"`
+	Sightings   int        `json:"sightings"`
+	FirstSeenAt time.Time  `json:"firstSeenAt"`
+	LastSeenAt  time.Time  `json:"lastSeenAt"`
+	ConfirmedAt *time.Time `json:"confirmedAt,omitempty"`
+	ConfirmedBy *string    `json:"confirmedBy,omitempty"`
+}
+
+func MapResultConfirmationToDTO(c ResultConfirmation) ResultConfirmationDTO {
+	return ResultConfirmationDTO{
+		EventID:     c.EventID,
+		Result:      c.Result,
+		Sightings:   c.Sightings,
+		FirstSeenAt: c.FirstSeenAt,
+		LastSeenAt:  c.LastSeenAt,
+		ConfirmedAt: c.ConfirmedAt,
+		ConfirmedBy: c.ConfirmedBy,
+	}
+}
+
+func MapResultConfirmationsToDTOs(confirmations []ResultConfirmation) []ResultConfirmationDTO {
+	dtos := make([]ResultConfirmationDTO, len(confirmations))
+	for i, c := range confirmations {
+		dtos[i] = MapResultConfirmationToDTO(c)
+	}
+	return dtos
+}
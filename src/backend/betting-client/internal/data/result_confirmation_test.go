@@ -0,0 +1,36 @@
+package data_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Arlan-Z/def-betting-api/internal/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResultConfirmationPolicy_Confirmed(t *testing.T) {
+	now := time.Date(2030, 6, 1, 20, 0, 0, 0, time.UTC)
+	seenOnce := data.ResultConfirmation{Result: data.HomeWin, Sightings: 1, FirstSeenAt: now.Add(-time.Minute)}
+	seenTwice := data.ResultConfirmation{Result: data.HomeWin, Sightings: 2, FirstSeenAt: now.Add(-time.Minute)}
+	stable := data.ResultConfirmation{Result: data.HomeWin, Sightings: 1, FirstSeenAt: now.Add(-15 * time.Minute)}
+
+	tests := []struct {
+		name         string
+		policy       data.ResultConfirmationPolicy
+		confirmation data.ResultConfirmation
+		want         bool
+	}{
+		{"disabled policy confirms the first sighting", data.ResultConfirmationPolicy{}, seenOnce, true},
+		{"too few cycles", data.ResultConfirmationPolicy{Cycles: 2}, seenOnce, false},
+		{"enough cycles", data.ResultConfirmationPolicy{Cycles: 2}, seenTwice, true},
+		{"not stable long enough", data.ResultConfirmationPolicy{StableFor: 10 * time.Minute}, seenTwice, false},
+		{"stable long enough", data.ResultConfirmationPolicy{StableFor: 10 * time.Minute}, stable, true},
+		{"either condition confirms", data.ResultConfirmationPolicy{Cycles: 3, StableFor: 10 * time.Minute}, stable, true},
+		{"neither condition holds", data.ResultConfirmationPolicy{Cycles: 3, StableFor: 10 * time.Minute}, seenTwice, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.policy.Confirmed(tt.confirmation, now))
+		})
+	}
+}
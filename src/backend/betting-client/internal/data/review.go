@@ -0,0 +1,62 @@
+package data
+
+import "time"
+
+type ReviewStatus string
+
+const (
+	ReviewPending ReviewStatus = "Pending"
+	ReviewVoided  ReviewStatus = "Voided"
+	ReviewKept    ReviewStatus = "Kept"
+	// ReviewDismissed reviews were closed without an effect, since the bet was settled
+	// or voided otherwise before the review was resolved.
+	ReviewDismissed ReviewStatus = "Dismissed"
+)
+
+type BetReview struct {
+	ID             string       `db:"id"`
+	BetID          string       `db:"bet_id"`
+	EventID        string       `db:"event_id"`
+	Reason         string       `db:"reason"`
+	Status         ReviewStatus `db:"status"`
+	CreatedAt      time.Time    `db:"created_at"`
+	ResolvedAt     *time.Time   `db:"resolved_at"`
+	ResolutionNote *string      `db:"resolution_note"`
+}
+
+type ResolveReviewRequest struct {
+	Action string `json:"action" validate:"required,oneof=void keep"`
+	Note   string `json:"note"`
+}
+
+type BetReviewDTO struct {
+	ID             string       `json:"id"`
+	BetID          string       `json:"betId"`
+	EventID        string       `json:"eventId"`
+	Reason         string       `json:"reason"`
+	Status         ReviewStatus `json:"status"`
+	CreatedAt      time.Time    `json:"createdAt"`
+	ResolvedAt     *time.Time   `json:"resolvedAt,omitempty"`
+	ResolutionNote *string      `json:"resolutionNote,omitempty"`
+}
+
+func MapBetReviewToDTO(r BetReview) BetReviewDTO {
+	return BetReviewDTO{
+		ID:             r.ID,
+		BetID:          r.BetID,
+		EventID:        r.EventID,
+		Reason:         r.Reason,
+		Status:         r.Status,
+		CreatedAt:      r.CreatedAt,
+		ResolvedAt:     r.ResolvedAt,
+		ResolutionNote: r.ResolutionNote,
+	}
+}
+
+func MapBetReviewsToDTOs(reviews []BetReview) []BetReviewDTO {
+	dtos := make([]BetReviewDTO, len(reviews))
+	for i, r := range reviews {
+		dtos[i] = MapBetReviewToDTO(r)
+	}
+	return dtos
+}
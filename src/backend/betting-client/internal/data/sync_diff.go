@@ -0,0 +1,100 @@
+package data
+
+import (
+	"fmt"
+	"time"
+)
+
+type SyncDiffKind string
+
+const (
+	SyncDiffNew        SyncDiffKind = "new"
+	SyncDiffOdds       SyncDiffKind = "odds"
+	SyncDiffSchedule   SyncDiffKind = "schedule"
+	SyncDiffFinalize   SyncDiffKind = "finalize"
+	SyncDiffResettle   SyncDiffKind = "resettle"
+	SyncDiffCancel     SyncDiffKind = "cancel"
+	SyncDiffUnmappable SyncDiffKind = "unmappable"
+)
+
+// SyncDiffChange is one change a sync cycle would apply to an event. Changes that settle
+// bets carry how many bets they settle and their total stake.
+type SyncDiffChange struct {
+	Kind       SyncDiffKind `json:"kind"`
+	ExternalID string       `json:"externalId"`
+	EventID    string       `json:"eventId,omitempty"`
+	EventName  string       `json:"eventName,omitempty"`
+	From       string       `json:"from,omitempty"`
+	To         string       `json:"to,omitempty"`
+	Bets       int          `json:"bets,omitempty"`
+	Stake      float64      `json:"stake,omitempty"`
+}
+
+// SyncDiff is what syncing a provider's current feed would change locally.
+type SyncDiff struct {
+	Provider  string           `json:"provider"`
+	FetchedAt time.Time        `json:"fetchedAt"`
+	Received  int              `json:"received"`
+	Unchanged int              `json:"unchanged"`
+	Changes   []SyncDiffChange `json:"changes"`
+}
+
+// Count returns how many changes of the kind the diff has.
+func (d SyncDiff) Count(kind SyncDiffKind) int {
+	count := 0
+	for _, c := range d.Changes {
+		if c.Kind == kind {
+			count++
+		}
+	}
+	return count
+}
+
+// DiffEvent lists what applying a provider's view of an event to the local event would
+// change. local is nil for events not stored yet, canceled is set when the provider
+// reports the event as canceled.
+func DiffEvent(externalID string, local *Event, incoming Event, canceled bool) []SyncDiffChange {
+	change := func(kind SyncDiffKind, from, to string) SyncDiffChange {
+		c := SyncDiffChange{Kind: kind, ExternalID: externalID, EventName: incoming.EventName, From: from, To: to}
+		if local != nil {
+			c.EventID = local.ID
+		}
+		return c
+	}
+
+	changes := make([]SyncDiffChange, 0)
+	if local == nil {
+		changes = append(changes, change(SyncDiffNew, "", formatOdds(incoming)))
+		if incoming.EventResult != nil && !incoming.IsActive {
+			changes = append(changes, change(SyncDiffFinalize, "", string(*incoming.EventResult)))
+		}
+		return changes
+	}
+
+	if local.HomeWinChance != incoming.HomeWinChance || local.DrawChance != incoming.DrawChance || local.AwayWinChance != incoming.AwayWinChance {
+		changes = append(changes, change(SyncDiffOdds, formatOdds(*local), formatOdds(incoming)))
+	}
+	if !local.EventStartDate.Equal(incoming.EventStartDate) || !local.EventEndDate.Equal(incoming.EventEndDate) {
+		changes = append(changes, change(SyncDiffSchedule, formatSchedule(*local), formatSchedule(incoming)))
+	}
+
+	settled := local.EventResult != nil || local.Status == EventStatusCanceled
+	switch {
+	case !settled && incoming.EventResult != nil && !incoming.IsActive:
+		changes = append(changes, change(SyncDiffFinalize, "", string(*incoming.EventResult)))
+	case local.EventResult != nil && incoming.EventResult != nil && *local.EventResult != *incoming.EventResult:
+		changes = append(changes, change(SyncDiffResettle, string(*local.EventResult), string(*incoming.EventResult)))
+	case canceled && !settled:
+		changes = append(changes, change(SyncDiffCancel, string(local.Status), string(EventStatusCanceled)))
+	}
+	return changes
+}
+
+// formatOdds lists the odds in home/draw/away order.
+func formatOdds(e Event) string {
+	return fmt.Sprintf("%g/%g/%g", e.HomeWinChance, e.DrawChance, e.AwayWinChance)
+}
+
+func formatSchedule(e Event) string {
+	return e.EventStartDate.UTC().Format(time.RFC3339) + " - " + e.EventEndDate.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,88 @@
+package data_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Arlan-Z/def-betting-api/internal/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffEvent(t *testing.T) {
+	start := time.Date(2030, 6, 1, 18, 0, 0, 0, time.UTC)
+	homeWin, draw := data.HomeWin, data.Draw
+	local := data.Event{
+		ID: "e1", EventName: "Kairat vs Astana", HomeWinChance: 1.8, DrawChance: 3.4, AwayWinChance: 4.2,
+		EventStartDate: start, EventEndDate: start.Add(2 * time.Hour), IsActive: true, Status: data.EventStatusScheduled,
+	}
+
+	t.Run("new event", func(t *testing.T) {
+		incoming := local
+		changes := data.DiffEvent("x1", nil, incoming, false)
+
+		require.Len(t, changes, 1)
+		assert.Equal(t, data.SyncDiffNew, changes[0].Kind)
+		assert.Equal(t, "1.8/3.4/4.2", changes[0].To)
+	})
+
+	t.Run("unchanged event", func(t *testing.T) {
+		assert.Empty(t, data.DiffEvent("x1", &local, local, false))
+	})
+
+	t.Run("odds and schedule", func(t *testing.T) {
+		incoming := local
+		incoming.HomeWinChance = 1.7
+		incoming.EventStartDate = start.Add(time.Hour)
+
+		changes := data.DiffEvent("x1", &local, incoming, false)
+
+		require.Len(t, changes, 2)
+		assert.Equal(t, data.SyncDiffOdds, changes[0].Kind)
+		assert.Equal(t, "1.8/3.4/4.2", changes[0].From)
+		assert.Equal(t, "1.7/3.4/4.2", changes[0].To)
+		assert.Equal(t, data.SyncDiffSchedule, changes[1].Kind)
+		assert.Equal(t, "2030-06-01T19:00:00Z - 2030-06-01T20:00:00Z", changes[1].To)
+	})
+
+	t.Run("result", func(t *testing.T) {
+		incoming := local
+		incoming.EventResult = &homeWin
+		incoming.IsActive = false
+
+		changes := data.DiffEvent("x1", &local, incoming, false)
+
+		require.Len(t, changes, 1)
+		assert.Equal(t, data.SyncDiffFinalize, changes[0].Kind)
+		assert.Equal(t, "HomeWin", changes[0].To)
+		assert.Equal(t, "e1", changes[0].EventID)
+	})
+
+	t.Run("corrected result", func(t *testing.T) {
+		finalized := local
+		finalized.EventResult = &homeWin
+		finalized.IsActive = false
+		incoming := finalized
+		incoming.EventResult = &draw
+
+		changes := data.DiffEvent("x1", &finalized, incoming, false)
+
+		require.Len(t, changes, 1)
+		assert.Equal(t, data.SyncDiffResettle, changes[0].Kind)
+		assert.Equal(t, "HomeWin", changes[0].From)
+		assert.Equal(t, "Draw", changes[0].To)
+	})
+
+	t.Run("cancellation", func(t *testing.T) {
+		incoming := local
+		incoming.IsActive = false
+
+		changes := data.DiffEvent("x1", &local, incoming, true)
+		require.Len(t, changes, 1)
+		assert.Equal(t, data.SyncDiffCancel, changes[0].Kind)
+
+		canceled := local
+		canceled.Status = data.EventStatusCanceled
+		assert.Empty(t, data.DiffEvent("x1", &canceled, incoming, true), "an event canceled locally has nothing left to cancel")
+	})
+}
@@ -0,0 +1,241 @@
+package data
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+type SyncRunKind string
+
+const (
+	SyncRunPoll    SyncRunKind = "poll"
+	SyncRunPush    SyncRunKind = "push"
+	SyncRunManual  SyncRunKind = "manual"
+	SyncRunRefresh SyncRunKind = "refresh"
+	SyncRunReplay  SyncRunKind = "replay"
+	SyncRunBroker  SyncRunKind = "broker"
+)
+
+// SyncTriggerResult tells whether a triggered cycle was queued or merged into one
+// that was already queued or running.
+type SyncTriggerResult struct {
+	Provider  string `json:"provider"`
+	Coalesced bool   `json:"coalesced"`
+}
+
+type SyncRunStatus string
+
+const (
+	SyncRunRunning SyncRunStatus = "Running"
+	// SyncRunSucceeded and SyncRunCompletedWithErrors both mean the feed was fetched
+	// and processed; the latter had per-event errors.
+	SyncRunSucceeded           SyncRunStatus = "Succeeded"
+	SyncRunCompletedWithErrors SyncRunStatus = "CompletedWithErrors"
+	SyncRunFailed              SyncRunStatus = "Failed"
+)
+
+// Stages of event processing recorded with sync run errors.
+const (
+	SyncStageMap      = "map"
+	SyncStageResolve  = "resolve"
+	SyncStageSnapshot = "snapshot"
+	SyncStageLoad     = "load"
+	SyncStageUpsert   = "upsert"
+	SyncStageConflict = "conflict"
+	SyncStageFinalize = "finalize"
+	SyncStageCancel   = "cancel"
+	SyncStageResettle = "resettle"
+	SyncStageDeadline = "deadline"
+)
+
+// SyncRun is one sync cycle of a provider or one batch of pushed events.
+type SyncRun struct {
+	ID                  string        `db:"id"`
+	Provider            string        `db:"provider"`
+	Kind                SyncRunKind   `db:"kind"`
+	Status              SyncRunStatus `db:"status"`
+	StartedAt           time.Time     `db:"started_at"`
+	FinishedAt          *time.Time    `db:"finished_at"`
+	FeedChecksum        string        `db:"feed_checksum"`
+	NotModified         bool          `db:"not_modified"`
+	Incremental         bool          `db:"incremental"`
+	Received            int           `db:"received"`
+	Upserted            int           `db:"upserted"`
+	Unchanged           int           `db:"unchanged"`
+	Failed              int           `db:"failed"`
+	FinalizeAttempts    int           `db:"finalize_attempts"`
+	FinalizeErrors      int           `db:"finalize_errors"`
+	CancelAttempts      int           `db:"cancel_attempts"`
+	CancelErrors        int           `db:"cancel_errors"`
+	ResultConflicts     int           `db:"result_conflicts"`
+	PostponedVoided     int           `db:"postponed_voided"`
+	PostponedVoidErrors int           `db:"postponed_void_errors"`
+	MissingSuspended    int           `db:"missing_suspended"`
+	MissingVoided       int           `db:"missing_voided"`
+	MissingVoidErrors   int           `db:"missing_void_errors"`
+	// Error is set when the whole run failed, e.g. the feed could not be fetched.
+	Error  string         `db:"error"`
+	Errors []SyncRunError `db:"-"`
+}
+
+type SyncRunError struct {
+	ID         int64     `db:"id"`
+	RunID      string    `db:"run_id"`
+	ExternalID string    `db:"external_id"`
+	EventID    string    `db:"event_id"`
+	Stage      string    `db:"stage"`
+	Message    string    `db:"message"`
+	OccurredAt time.Time `db:"occurred_at"`
+}
+
+// RecordError adds a per-event error. Errors of the finalize and cancel stages are
+// counted separately from the events that could not be stored.
+func (r *SyncRun) RecordError(externalID string, eventID string, stage string, err error) {
+	switch stage {
+	case SyncStageFinalize, SyncStageResettle:
+		r.FinalizeErrors++
+	case SyncStageCancel:
+		r.CancelErrors++
+	case SyncStageConflict:
+	default:
+		r.Failed++
+	}
+	r.Errors = append(r.Errors, SyncRunError{
+		RunID:      r.ID,
+		ExternalID: externalID,
+		EventID:    eventID,
+		Stage:      stage,
+		Message:    err.Error(),
+		OccurredAt: time.Now().UTC(),
+	})
+}
+
+// RetryableErrors counts the per-event errors that processing the events again may
+// fix. Mapping errors are quarantined and conflicts wait for an admin, so neither counts.
+func (r *SyncRun) RetryableErrors() int {
+	retryable := 0
+	for _, e := range r.Errors {
+		if e.Stage != SyncStageMap && e.Stage != SyncStageConflict {
+			retryable++
+		}
+	}
+	return retryable
+}
+
+// Merge adds the per-event counters and errors of other, which tallied part of the
+// same run, to the run.
+func (r *SyncRun) Merge(other *SyncRun) {
+	r.Upserted += other.Upserted
+	r.Unchanged += other.Unchanged
+	r.Failed += other.Failed
+	r.FinalizeAttempts += other.FinalizeAttempts
+	r.FinalizeErrors += other.FinalizeErrors
+	r.CancelAttempts += other.CancelAttempts
+	r.CancelErrors += other.CancelErrors
+	r.ResultConflicts += other.ResultConflicts
+	r.Errors = append(r.Errors, other.Errors...)
+}
+
+// Finish sets the final status. A run with Error set is Failed.
+func (r *SyncRun) Finish(finishedAt time.Time) {
+	r.FinishedAt = &finishedAt
+	switch {
+	case r.Error != "":
+		r.Status = SyncRunFailed
+	case len(r.Errors) > 0 || r.PostponedVoidErrors > 0 || r.MissingVoidErrors > 0:
+		r.Status = SyncRunCompletedWithErrors
+	default:
+		r.Status = SyncRunSucceeded
+	}
+}
+
+// FeedChecksum identifies a raw feed payload.
+func FeedChecksum(body []byte) string {
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:])
+}
+
+type SyncRunErrorDTO struct {
+	ExternalID string    `json:"externalId"`
+	EventID    string    `json:"eventId,omitempty"`
+	Stage      string    `json:"stage"`
+	Message    string    `json:"message"`
+	OccurredAt time.Time `json:"occurredAt"`
+}
+
+type SyncRunDTO struct {
+	ID                  string            `json:"id"`
+	Provider            string            `json:"provider"`
+	Kind                SyncRunKind       `json:"kind"`
+	Status              SyncRunStatus     `json:"status"`
+	StartedAt           time.Time         `json:"startedAt"`
+	FinishedAt          *time.Time        `json:"finishedAt,omitempty"`
+	FeedChecksum        string            `json:"feedChecksum,omitempty"`
+	NotModified         bool              `json:"notModified"`
+	Incremental         bool              `json:"incremental"`
+	Received            int               `json:"received"`
+	Upserted            int               `json:"upserted"`
+	Unchanged           int               `json:"unchanged"`
+	Failed              int               `json:"failed"`
+	FinalizeAttempts    int               `json:"finalizeAttempts"`
+	FinalizeErrors      int               `json:"finalizeErrors"`
+	CancelAttempts      int               `json:"cancelAttempts"`
+	CancelErrors        int               `json:"cancelErrors"`
+	ResultConflicts     int               `json:"resultConflicts"`
+	PostponedVoided     int               `json:"postponedVoided"`
+	PostponedVoidErrors int               `json:"postponedVoidErrors"`
+	MissingSuspended    int               `json:"missingSuspended"`
+	MissingVoided       int               `json:"missingVoided"`
+	MissingVoidErrors   int               `json:"missingVoidErrors"`
+	Error               string            `json:"error,omitempty"`
+	Errors              []SyncRunErrorDTO `json:"errors,omitempty"`
+}
+
+func MapSyncRunToDTO(r SyncRun) SyncRunDTO {
+	var errs []SyncRunErrorDTO
+	for _, e := range r.Errors {
+		errs = append(errs, SyncRunErrorDTO{
+			ExternalID: e.ExternalID,
+			EventID:    e.EventID,
+			Stage:      e.Stage,
+			Message:    e.Message,
+			OccurredAt: e.OccurredAt,
+		})
+	}
+	return SyncRunDTO{
+		ID:                  r.ID,
+		Provider:            r.Provider,
+		Kind:                r.Kind,
+		Status:              r.Status,
+		StartedAt:           r.StartedAt,
+		FinishedAt:          r.FinishedAt,
+		FeedChecksum:        r.FeedChecksum,
+		NotModified:         r.NotModified,
+		Incremental:         r.Incremental,
+		Received:            r.Received,
+		Upserted:            r.Upserted,
+		Unchanged:           r.Unchanged,
+		Failed:              r.Failed,
+		FinalizeAttempts:    r.FinalizeAttempts,
+		FinalizeErrors:      r.FinalizeErrors,
+		CancelAttempts:      r.CancelAttempts,
+		CancelErrors:        r.CancelErrors,
+		ResultConflicts:     r.ResultConflicts,
+		PostponedVoided:     r.PostponedVoided,
+		PostponedVoidErrors: r.PostponedVoidErrors,
+		MissingSuspended:    r.MissingSuspended,
+		MissingVoided:       r.MissingVoided,
+		MissingVoidErrors:   r.MissingVoidErrors,
+		Error:               r.Error,
+		Errors:              errs,
+	}
+}
+
+func MapSyncRunsToDTOs(runs []SyncRun) []SyncRunDTO {
+	dtos := make([]SyncRunDTO, len(runs))
+	for i, r := range runs {
+		dtos[i] = MapSyncRunToDTO(r)
+	}
+	return dtos
+}
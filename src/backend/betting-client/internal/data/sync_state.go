@@ -0,0 +1,47 @@
+package data
+
+import "time"
+
+// SourceSyncState holds the validators and cursor a provider returned on the last fully
+// processed fetch. They are sent back so the provider can answer with only what changed.
+type SourceSyncState struct {
+	Provider     string    `db:"provider"`
+	ETag         string    `db:"etag"`
+	LastModified string    `db:"last_modified"`
+	Cursor       string    `db:"cursor"`
+	UpdatedAt    time.Time `db:"updated_at"`
+}
+
+// EventBatch is the answer of an event source to a fetch.
+type EventBatch struct {
+	Events []ExternalEventDTO
+	// NotModified is set when the source confirmed that nothing changed since the last fetch.
+	NotModified bool
+	// Incremental is set when a cursor was sent, so Events hold only the changed events.
+	Incremental bool
+	// State is to be sent with the next fetch once Events are processed.
+	State SourceSyncState
+	// Checksum identifies the raw payload, see FeedChecksum.
+	Checksum string
+}
+
+// IngestResult summarizes the processing of events pushed by a provider.
+type IngestResult struct {
+	RunID     string `json:"runId"`
+	Received  int    `json:"received"`
+	Upserted  int    `json:"upserted"`
+	Unchanged int    `json:"unchanged"`
+	Failed    int    `json:"failed"`
+}
+
+// SourceHealth tells whether the syncer can currently reach a provider.
+type SourceHealth struct {
+	Provider            string     `json:"provider"`
+	Breaker             string     `json:"breaker"`
+	ConsecutiveFailures int        `json:"consecutiveFailures"`
+	OpenedAt            *time.Time `json:"openedAt,omitempty"`
+	LastSuccessAt       *time.Time `json:"lastSuccessAt,omitempty"`
+	// Stale is set once the provider failed for longer than the stale threshold and its
+	// events were suspended.
+	Stale bool `json:"stale"`
+}
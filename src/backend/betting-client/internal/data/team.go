@@ -0,0 +1,134 @@
+package data
+
+import (
+	"strings"
+	"time"
+	"unicode"
+)
+
+type AliasStatus string
+
+const (
+	AliasPending  AliasStatus = "Pending"
+	AliasResolved AliasStatus = "Resolved"
+)
+
+type Team struct {
+	ID        string    `db:"id"`
+	Name      string    `db:"name"`
+	Sport     string    `db:"sport"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+type Competition struct {
+	ID        string    `db:"id"`
+	Name      string    `db:"name"`
+	NameKey   string    `db:"name_key"`
+	Sport     string    `db:"sport"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// UnresolvedTeamAlias is a team name sent by the source that could not be matched
+// to a single known team and waits for an admin decision.
+type UnresolvedTeamAlias struct {
+	ID               string      `db:"id"`
+	Alias            string      `db:"alias"`
+	AliasKey         string      `db:"alias_key"`
+	Sport            string      `db:"sport"`
+	EventID          string      `db:"event_id"`
+	CandidateTeamIDs string      `db:"candidate_team_ids"`
+	Status           AliasStatus `db:"status"`
+	TeamID           *string     `db:"team_id"`
+	CreatedAt        time.Time   `db:"created_at"`
+	ResolvedAt       *time.Time  `db:"resolved_at"`
+}
+
+// Candidates returns the IDs of the teams the alias may refer to.
+func (a UnresolvedTeamAlias) Candidates() []string {
+	if a.CandidateTeamIDs == "" {
+		return []string{}
+	}
+	return strings.Split(a.CandidateTeamIDs, ",")
+}
+
+// NormalizeName turns a team or competition name into the key used for matching:
+// lower case, punctuation replaced by spaces and repeated spaces collapsed, so that
+// "Real  Madrid" and "real-madrid" share the key "real madrid".
+func NormalizeName(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		} else {
+			b.WriteRune(' ')
+		}
+	}
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+type ResolveAliasRequest struct {
+	TeamID     string `json:"teamId" validate:"required_without=CreateTeam"`
+	CreateTeam bool   `json:"createTeam"`
+}
+
+type TeamDTO struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Sport string `json:"sport"`
+}
+
+type CompetitionDTO struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Sport string `json:"sport"`
+}
+
+type UnresolvedTeamAliasDTO struct {
+	ID               string      `json:"id"`
+	Alias            string      `json:"alias"`
+	Sport            string      `json:"sport"`
+	EventID          string      `json:"eventId"`
+	CandidateTeamIDs []string    `json:"candidateTeamIds"`
+	Status           AliasStatus `json:"status"`
+	TeamID           *string     `json:"teamId,omitempty"`
+	CreatedAt        time.Time   `json:"createdAt"`
+	ResolvedAt       *time.Time  `json:"resolvedAt,omitempty"`
+}
+
+func MapTeamsToDTOs(teams []Team) []TeamDTO {
+	dtos := make([]TeamDTO, len(teams))
+	for i, t := range teams {
+		dtos[i] = TeamDTO{ID: t.ID, Name: t.Name, Sport: t.Sport}
+	}
+	return dtos
+}
+
+func MapCompetitionsToDTOs(competitions []Competition) []CompetitionDTO {
+	dtos := make([]CompetitionDTO, len(competitions))
+	for i, c := range competitions {
+		dtos[i] = CompetitionDTO{ID: c.ID, Name: c.Name, Sport: c.Sport}
+	}
+	return dtos
+}
+
+func MapUnresolvedTeamAliasToDTO(a UnresolvedTeamAlias) UnresolvedTeamAliasDTO {
+	return UnresolvedTeamAliasDTO{
+		ID:               a.ID,
+		Alias:            a.Alias,
+		Sport:            a.Sport,
+		EventID:          a.EventID,
+		CandidateTeamIDs: a.Candidates(),
+		Status:           a.Status,
+		TeamID:           a.TeamID,
+		CreatedAt:        a.CreatedAt,
+		ResolvedAt:       a.ResolvedAt,
+	}
+}
+
+func MapUnresolvedTeamAliasesToDTOs(aliases []UnresolvedTeamAlias) []UnresolvedTeamAliasDTO {
+	dtos := make([]UnresolvedTeamAliasDTO, len(aliases))
+	for i, a := range aliases {
+		dtos[i] = MapUnresolvedTeamAliasToDTO(a)
+	}
+	return dtos
+}
@@ -0,0 +1,84 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/Arlan-Z/def-betting-api/internal/data"
+	"github.com/Arlan-Z/def-betting-api/internal/usecases/confirmation"
+	"github.com/Arlan-Z/def-betting-api/internal/usecases/event"
+	"github.com/Arlan-Z/def-betting-api/internal/usecases/resettlement"
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+type ConfirmationUseCase interface {
+	GetPendingConfirmations(ctx context.Context) ([]data.ResultConfirmation, error)
+	Confirm(ctx context.Context, eventID string) (*data.ResultConfirmation, error)
+}
+
+type Handler struct {
+	useCase ConfirmationUseCase
+	logger  *zap.Logger
+}
+
+func NewHandler(uc ConfirmationUseCase, logger *zap.Logger) *Handler {
+	return &Handler{
+		useCase: uc,
+		logger:  logger.Named("ConfirmationHandler"),
+	}
+}
+
+func (h *Handler) RegisterRoutes(r chi.Router) {
+	r.Get("/admin/result-confirmations", h.GetPendingConfirmations)
+	r.Post("/admin/result-confirmations/{eventID}/confirm", h.Confirm)
+}
+
+func (h *Handler) GetPendingConfirmations(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := h.logger.With(zap.String("operation", "GetPendingConfirmations"))
+
+	confirmations, err := h.useCase.GetPendingConfirmations(ctx)
+	if err != nil {
+		log.Error("Error getting pending result confirmations from UseCase", zap.Error(err))
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	dtos := data.MapResultConfirmationsToDTOs(confirmations)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(dtos); err != nil {
+		log.Error("Error encoding JSON response", zap.Error(err))
+	}
+}
+
+func (h *Handler) Confirm(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	eventID := chi.URLParam(r, "eventID")
+	log := h.logger.With(zap.String("operation", "Confirm"), zap.String("eventId", eventID))
+	log.Info("Received request to confirm pending event result")
+
+	confirmed, err := h.useCase.Confirm(ctx, eventID)
+	if err != nil {
+		log.Error("Error confirming pending event result in UseCase", zap.Error(err))
+		switch {
+		case errors.Is(err, confirmation.ErrConfirmationNotFound), errors.Is(err, event.ErrEventNotFound):
+			http.Error(w, "Pending result confirmation not found", http.StatusNotFound)
+		case errors.Is(err, confirmation.ErrAlreadyConfirmed), errors.Is(err, resettlement.ErrSameResult), errors.Is(err, resettlement.ErrEventNotSettled):
+			http.Error(w, "Result already confirmed", http.StatusConflict)
+		default:
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(data.MapResultConfirmationToDTO(*confirmed)); err != nil {
+		log.Error("Error encoding JSON response", zap.Error(err))
+	}
+}
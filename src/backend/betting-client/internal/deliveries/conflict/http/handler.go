@@ -0,0 +1,100 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/Arlan-Z/def-betting-api/internal/data"
+	customvalidator "github.com/Arlan-Z/def-betting-api/internal/pkg/validator"
+	"github.com/Arlan-Z/def-betting-api/internal/usecases/conflict"
+	"github.com/Arlan-Z/def-betting-api/internal/usecases/event"
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+type ConflictUseCase interface {
+	GetOpenConflicts(ctx context.Context) ([]data.ResultConflict, error)
+	Confirm(ctx context.Context, eventID string, result string) (*data.ResultConflict, error)
+}
+
+type Handler struct {
+	useCase ConflictUseCase
+	logger  *zap.Logger
+}
+
+func NewHandler(uc ConflictUseCase, logger *zap.Logger) *Handler {
+	return &Handler{
+		useCase: uc,
+		logger:  logger.Named("ConflictHandler"),
+	}
+}
+
+func (h *Handler) RegisterRoutes(r chi.Router) {
+	r.Get("/admin/result-conflicts", h.GetOpenConflicts)
+	r.Post("/admin/result-conflicts/{eventID}/confirm", h.Confirm)
+}
+
+func (h *Handler) GetOpenConflicts(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := h.logger.With(zap.String("operation", "GetOpenConflicts"))
+
+	conflicts, err := h.useCase.GetOpenConflicts(ctx)
+	if err != nil {
+		log.Error("Error getting open result conflicts from UseCase", zap.Error(err))
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	dtos := data.MapResultConflictsToDTOs(conflicts)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(dtos); err != nil {
+		log.Error("Error encoding JSON response", zap.Error(err))
+	}
+}
+
+func (h *Handler) Confirm(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	eventID := chi.URLParam(r, "eventID")
+	log := h.logger.With(zap.String("operation", "Confirm"), zap.String("eventId", eventID))
+	log.Info("Received request to confirm event result")
+
+	var requestDTO data.ConfirmResultRequest
+	if err := json.NewDecoder(r.Body).Decode(&requestDTO); err != nil {
+		log.Warn("Error decoding request body", zap.Error(err))
+		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	defer r.Body.Close()
+
+	if err := customvalidator.ValidateStruct(requestDTO); err != nil {
+		log.Warn("Error validating request body", zap.Error(err))
+		http.Error(w, "Validation error: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	resolved, err := h.useCase.Confirm(ctx, eventID, requestDTO.Result)
+	if err != nil {
+		log.Error("Error confirming event result in UseCase", zap.Error(err))
+		switch {
+		case errors.Is(err, conflict.ErrConflictNotFound), errors.Is(err, event.ErrEventNotFound):
+			http.Error(w, "Result conflict not found", http.StatusNotFound)
+		case errors.Is(err, conflict.ErrConflictAlreadyResolved), errors.Is(err, event.ErrEventAlreadyFinalized):
+			http.Error(w, "Result conflict already resolved", http.StatusConflict)
+		case errors.Is(err, conflict.ErrInvalidConfirmedResult):
+			http.Error(w, "Invalid result", http.StatusBadRequest)
+		default:
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(data.MapResultConflictToDTO(*resolved)); err != nil {
+		log.Error("Error encoding JSON response", zap.Error(err))
+	}
+}
@@ -0,0 +1,273 @@
+package broker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/Arlan-Z/def-betting-api/internal/data"
+	"github.com/Arlan-Z/def-betting-api/internal/pkg/mq"
+	syncsvc "github.com/Arlan-Z/def-betting-api/internal/services/sync"
+	"go.uber.org/zap"
+)
+
+// Headers read from consumed messages and added to dead-lettered ones.
+const (
+	ProviderHeader       = "provider"
+	ErrorHeader          = "dlq-error"
+	AttemptsHeader       = "dlq-attempts"
+	OriginalTopicHeader  = "dlq-original-topic"
+	OriginalOffsetHeader = "dlq-original-offset"
+)
+
+// errPoison marks messages that can never be processed, so they are dead-lettered
+// without retries.
+var errPoison = errors.New("message cannot be processed")
+
+type EventProcessor interface {
+	ProcessBatch(ctx context.Context, providerName string, kind data.SyncRunKind, batch data.EventBatch) (*data.SyncRun, error)
+}
+
+type ProcessedStore interface {
+	IsProcessed(ctx context.Context, group string, messageID string) (bool, error)
+	MarkProcessed(ctx context.Context, group string, msg mq.Message, processedAt time.Time) error
+	DeleteProcessedBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+// Config describes the topic the consumer reads. Provider is the event provider the
+// messages belong to, unless a message names another one in its provider header.
+type Config struct {
+	Topic           string
+	DeadLetterTopic string
+	Group           string
+	Provider        string
+	MaxAttempts     int
+	RetryBackoff    time.Duration
+	BatchSize       int
+	PollWait        time.Duration
+	// Retention is how long processed message IDs are kept for recognizing redeliveries.
+	// Zero keeps them forever.
+	Retention time.Duration
+}
+
+// Consumer feeds event updates from a topic through the syncer pipeline. Messages are
+// processed at least once: the group's offset is committed only after a message was
+// processed or dead-lettered. Redelivered messages are recognized by their ID.
+type Consumer struct {
+	broker    mq.Broker
+	processor EventProcessor
+	processed ProcessedStore
+	cfg       Config
+	logger    *zap.Logger
+	prunedAt  time.Time
+}
+
+func NewConsumer(broker mq.Broker, processor EventProcessor, processed ProcessedStore, cfg Config, logger *zap.Logger) *Consumer {
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = 1
+	}
+	return &Consumer{
+		broker:    broker,
+		processor: processor,
+		processed: processed,
+		cfg:       cfg,
+		logger:    logger.Named("EventConsumer").With(zap.String("topic", cfg.Topic), zap.String("group", cfg.Group)),
+	}
+}
+
+func (c *Consumer) Start(ctx context.Context) {
+	c.logger.Info("Starting event consumer", zap.String("deadLetterTopic", c.cfg.DeadLetterTopic))
+
+	for {
+		if err := c.consume(ctx); err != nil {
+			if ctx.Err() != nil {
+				c.logger.Info("Stopping event consumer due to context cancellation")
+				return
+			}
+			c.logger.Error("Event consumer failed, resuming from the committed offset", zap.Error(err))
+			if !c.sleep(ctx, c.cfg.RetryBackoff) {
+				c.logger.Info("Stopping event consumer due to context cancellation")
+				return
+			}
+		}
+	}
+}
+
+// consume processes messages from the committed offset on until an error occurs.
+func (c *Consumer) consume(ctx context.Context) error {
+	offset, err := c.broker.CommittedOffset(ctx, c.cfg.Group, c.cfg.Topic)
+	if err != nil {
+		return err
+	}
+
+	for {
+		messages, err := c.broker.Poll(ctx, c.cfg.Topic, offset, c.cfg.BatchSize, c.cfg.PollWait)
+		if err != nil {
+			return err
+		}
+		c.pruneProcessed(ctx)
+		for _, msg := range messages {
+			if err := c.Handle(ctx, msg); err != nil {
+				return err
+			}
+			offset = msg.Offset + 1
+			if err := c.broker.CommitOffset(ctx, c.cfg.Group, c.cfg.Topic, offset); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// Handle processes one message, retrying it up to the configured attempts and then
+// sending it to the dead-letter topic. It only fails when the message was neither
+// processed nor dead-lettered, in which case it must be delivered again.
+func (c *Consumer) Handle(ctx context.Context, msg mq.Message) error {
+	log := c.logger.With(zap.String("messageId", msg.ID), zap.Int64("offset", msg.Offset))
+
+	done, err := c.processed.IsProcessed(ctx, c.cfg.Group, msg.ID)
+	if err != nil {
+		return err
+	}
+	if done {
+		log.Info("Skipping message that was already processed")
+		return nil
+	}
+
+	var processErr error
+	attempts := 0
+	for attempts < c.cfg.MaxAttempts {
+		attempts++
+		processErr = c.process(ctx, log, msg)
+		if processErr == nil || errors.Is(processErr, errPoison) {
+			break
+		}
+		if errors.Is(processErr, syncsvc.ErrNotRunning) {
+			// Leadership is being handed over, the next leader processes the message.
+			return processErr
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		log.Warn("Failed to process message", zap.Int("attempt", attempts), zap.Error(processErr))
+		if attempts < c.cfg.MaxAttempts && !c.sleep(ctx, c.cfg.RetryBackoff<<(attempts-1)) {
+			return ctx.Err()
+		}
+	}
+
+	if processErr != nil {
+		if err := c.deadLetter(ctx, msg, processErr, attempts); err != nil {
+			return err
+		}
+		log.Error("Message sent to the dead-letter topic", zap.Int("attempts", attempts), zap.Error(processErr))
+	}
+
+	if err := c.processed.MarkProcessed(ctx, c.cfg.Group, msg, time.Now().UTC()); err != nil {
+		// Redelivery is harmless, processing the same events again changes nothing.
+		log.Error("Failed to record processed message", zap.Error(err))
+	}
+	return nil
+}
+
+func (c *Consumer) process(ctx context.Context, log *zap.Logger, msg mq.Message) error {
+	events, err := decodeEvents(msg.Value)
+	if err != nil {
+		return fmt.Errorf("%w: %v", errPoison, err)
+	}
+	provider := c.cfg.Provider
+	if name := msg.Headers[ProviderHeader]; name != "" {
+		provider = name
+	}
+
+	run, err := c.processor.ProcessBatch(ctx, provider, data.SyncRunBroker, data.EventBatch{
+		Events:   events,
+		Checksum: data.FeedChecksum(msg.Value),
+	})
+	if err != nil {
+		if errors.Is(err, syncsvc.ErrUnknownProvider) {
+			// An unknown provider will not become known by retrying.
+			return fmt.Errorf("%w: %v", errPoison, err)
+		}
+		return err
+	}
+	if retryable := run.RetryableErrors(); retryable > 0 {
+		return fmt.Errorf("%d of %d events failed, see sync run %s", retryable, len(events), run.ID)
+	}
+	log.Debug("Processed message", zap.String("runId", run.ID), zap.Int("events", len(events)))
+	return nil
+}
+
+func (c *Consumer) deadLetter(ctx context.Context, msg mq.Message, cause error, attempts int) error {
+	headers := make(map[string]string, len(msg.Headers)+4)
+	for k, v := range msg.Headers {
+		headers[k] = v
+	}
+	headers[ErrorHeader] = cause.Error()
+	headers[AttemptsHeader] = strconv.Itoa(attempts)
+	headers[OriginalTopicHeader] = msg.Topic
+	headers[OriginalOffsetHeader] = strconv.FormatInt(msg.Offset, 10)
+
+	_, err := c.broker.Publish(ctx, mq.Message{
+		ID:      msg.ID,
+		Topic:   c.cfg.DeadLetterTopic,
+		Key:     msg.Key,
+		Value:   msg.Value,
+		Headers: headers,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to dead-letter message %s: %w", msg.ID, err)
+	}
+	return nil
+}
+
+// pruneProcessed forgets old processed message IDs, at most once an hour.
+func (c *Consumer) pruneProcessed(ctx context.Context) {
+	now := time.Now().UTC()
+	if c.cfg.Retention <= 0 || now.Sub(c.prunedAt) < time.Hour {
+		return
+	}
+	c.prunedAt = now
+	deleted, err := c.processed.DeleteProcessedBefore(ctx, now.Add(-c.cfg.Retention))
+	if err != nil {
+		c.logger.Error("Failed to delete old processed message IDs", zap.Error(err))
+		return
+	}
+	if deleted > 0 {
+		c.logger.Debug("Deleted old processed message IDs", zap.Int64("count", deleted))
+	}
+}
+
+func (c *Consumer) sleep(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// decodeEvents accepts a single event or an array of events.
+func decodeEvents(value []byte) ([]data.ExternalEventDTO, error) {
+	trimmed := bytes.TrimSpace(value)
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		var events []data.ExternalEventDTO
+		if err := json.Unmarshal(trimmed, &events); err != nil {
+			return nil, fmt.Errorf("invalid event array: %w", err)
+		}
+		return events, nil
+	}
+
+	var event data.ExternalEventDTO
+	if err := json.Unmarshal(trimmed, &event); err != nil {
+		return nil, fmt.Errorf("invalid event: %w", err)
+	}
+	if event.APIEventID == "" {
+		return nil, errors.New("event has no id")
+	}
+	return []data.ExternalEventDTO{event}, nil
+}
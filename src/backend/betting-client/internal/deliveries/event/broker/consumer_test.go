@@ -0,0 +1,183 @@
+package broker_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Arlan-Z/def-betting-api/internal/data"
+	eventbroker "github.com/Arlan-Z/def-betting-api/internal/deliveries/event/broker"
+	"github.com/Arlan-Z/def-betting-api/internal/pkg/mq"
+	syncsvc "github.com/Arlan-Z/def-betting-api/internal/services/sync"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type fakeProcessor struct {
+	mu       sync.Mutex
+	batches  []data.EventBatch
+	failures int
+	err      error
+}
+
+func (p *fakeProcessor) ProcessBatch(ctx context.Context, providerName string, kind data.SyncRunKind, batch data.EventBatch) (*data.SyncRun, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.err != nil {
+		return nil, p.err
+	}
+	p.batches = append(p.batches, batch)
+	run := &data.SyncRun{ID: "run", Provider: providerName, Kind: kind}
+	if p.failures > 0 {
+		p.failures--
+		run.RecordError(batch.Events[0].APIEventID, "", data.SyncStageUpsert, errors.New("database is locked"))
+	}
+	return run, nil
+}
+
+func (p *fakeProcessor) count() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.batches)
+}
+
+type memoryProcessedStore struct {
+	mu  sync.Mutex
+	ids map[string]bool
+}
+
+func (s *memoryProcessedStore) IsProcessed(ctx context.Context, group string, messageID string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.ids[group+"/"+messageID], nil
+}
+
+func (s *memoryProcessedStore) MarkProcessed(ctx context.Context, group string, msg mq.Message, processedAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ids[group+"/"+msg.ID] = true
+	return nil
+}
+
+func (s *memoryProcessedStore) DeleteProcessedBefore(ctx context.Context, before time.Time) (int64, error) {
+	return 0, nil
+}
+
+func newConsumer(broker mq.Broker, processor *fakeProcessor) *eventbroker.Consumer {
+	return eventbroker.NewConsumer(broker, processor, &memoryProcessedStore{ids: map[string]bool{}}, eventbroker.Config{
+		Topic:           "events",
+		DeadLetterTopic: "events.dlq",
+		Group:           "betting",
+		Provider:        "default",
+		MaxAttempts:     3,
+		RetryBackoff:    time.Millisecond,
+		BatchSize:       10,
+		PollWait:        10 * time.Millisecond,
+	}, zap.NewNop())
+}
+
+func TestConsumer_ProcessesOnceAndCommitsOffsets(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	broker := mq.NewMemoryBroker()
+	processor := &fakeProcessor{}
+
+	for _, msg := range []mq.Message{
+		{ID: "m1", Topic: "events", Value: []byte(`{"id":"e1","eventResult":"HomeWin"}`)},
+		{ID: "m2", Topic: "events", Value: []byte(`[{"id":"e2"},{"id":"e3"}]`)},
+		{ID: "m1", Topic: "events", Value: []byte(`{"id":"e1","eventResult":"HomeWin"}`)},
+	} {
+		_, err := broker.Publish(ctx, msg)
+		require.NoError(t, err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		newConsumer(broker, processor).Start(ctx)
+	}()
+	require.Eventually(t, func() bool {
+		offset, _ := broker.CommittedOffset(ctx, "betting", "events")
+		return offset == 3
+	}, time.Second, 5*time.Millisecond)
+	cancel()
+	<-done
+
+	require.Equal(t, 2, processor.count())
+	assert.Len(t, processor.batches[1].Events, 2)
+	assert.Empty(t, broker.Messages("events.dlq"))
+}
+
+func TestConsumer_RetriesFailedEvents(t *testing.T) {
+	broker := mq.NewMemoryBroker()
+	processor := &fakeProcessor{failures: 2}
+
+	err := newConsumer(broker, processor).Handle(context.Background(), mq.Message{ID: "m1", Topic: "events", Value: []byte(`{"id":"e1"}`)})
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, processor.count())
+	assert.Empty(t, broker.Messages("events.dlq"))
+}
+
+func TestConsumer_DeadLettersAfterMaxAttempts(t *testing.T) {
+	broker := mq.NewMemoryBroker()
+	processor := &fakeProcessor{failures: 5}
+	consumer := newConsumer(broker, processor)
+
+	msg := mq.Message{ID: "m1", Topic: "events", Offset: 7, Value: []byte(`{"id":"e1"}`), Headers: map[string]string{"trace": "abc"}}
+	require.NoError(t, consumer.Handle(context.Background(), msg))
+
+	assert.Equal(t, 3, processor.count())
+	dead := broker.Messages("events.dlq")
+	require.Len(t, dead, 1)
+	assert.Equal(t, "m1", dead[0].ID)
+	assert.Equal(t, "3", dead[0].Headers[eventbroker.AttemptsHeader])
+	assert.Equal(t, "events", dead[0].Headers[eventbroker.OriginalTopicHeader])
+	assert.Equal(t, "7", dead[0].Headers[eventbroker.OriginalOffsetHeader])
+	assert.Equal(t, "abc", dead[0].Headers["trace"])
+	assert.Contains(t, dead[0].Headers[eventbroker.ErrorHeader], "1 of 1 events failed")
+
+	// The dead-lettered message counts as handled.
+	require.NoError(t, consumer.Handle(context.Background(), msg))
+	assert.Equal(t, 3, processor.count())
+}
+
+func TestConsumer_DeadLettersPoisonMessagesWithoutRetrying(t *testing.T) {
+	broker := mq.NewMemoryBroker()
+	processor := &fakeProcessor{}
+	consumer := newConsumer(broker, processor)
+
+	require.NoError(t, consumer.Handle(context.Background(), mq.Message{ID: "m1", Topic: "events", Value: []byte(`not json`)}))
+	require.NoError(t, consumer.Handle(context.Background(), mq.Message{ID: "m2", Topic: "events", Value: []byte(`{"eventName":"no id"}`)}))
+
+	assert.Zero(t, processor.count())
+	dead := broker.Messages("events.dlq")
+	require.Len(t, dead, 2)
+	assert.Equal(t, "1", dead[0].Headers[eventbroker.AttemptsHeader])
+}
+
+func TestConsumer_DeadLettersUnknownProvider(t *testing.T) {
+	broker := mq.NewMemoryBroker()
+	processor := &fakeProcessor{err: syncsvc.ErrUnknownProvider}
+	consumer := newConsumer(broker, processor)
+
+	require.NoError(t, consumer.Handle(context.Background(), mq.Message{ID: "m1", Topic: "events", Value: []byte(`{"id":"e1"}`)}))
+
+	dead := broker.Messages("events.dlq")
+	require.Len(t, dead, 1)
+	assert.Equal(t, "1", dead[0].Headers[eventbroker.AttemptsHeader])
+}
+
+func TestConsumer_LeavesMessageToNextLeaderWhenSyncerStopped(t *testing.T) {
+	broker := mq.NewMemoryBroker()
+	processor := &fakeProcessor{err: syncsvc.ErrNotRunning}
+	consumer := newConsumer(broker, processor)
+
+	err := consumer.Handle(context.Background(), mq.Message{ID: "m1", Topic: "events", Value: []byte(`{"id":"e1"}`)})
+
+	require.ErrorIs(t, err, syncsvc.ErrNotRunning)
+	assert.Empty(t, broker.Messages("events.dlq"))
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/Arlan-Z/def-betting-api/internal/data"
 	customvalidator "github.com/Arlan-Z/def-betting-api/internal/pkg/validator"
@@ -15,6 +16,7 @@ import (
 
 type EventUseCase interface {
 	GetActiveEvents(ctx context.Context) ([]data.Event, error)
+	SearchEvents(ctx context.Context, query string, limit int) ([]data.EventSearchResult, error)
 	FinalizeEvent(ctx context.Context, eventID string, actualResult data.Outcome) error
 }
 
@@ -32,6 +34,7 @@ func NewHandler(uc EventUseCase, logger *zap.Logger) *Handler {
 
 func (h *Handler) RegisterRoutes(r chi.Router) {
 	r.Get("/events", h.GetActiveEvents)
+	r.Get("/events/search", h.SearchEvents)
 	r.Post("/events/{eventID}/finalize", h.FinalizeEvent)
 }
 
@@ -57,6 +60,44 @@ func (h *Handler) GetActiveEvents(w http.ResponseWriter, r *http.Request) {
 	log.Debug("Successful response", zap.Int("eventCount", len(dtos)))
 }
 
+func (h *Handler) SearchEvents(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	query := r.URL.Query().Get("q")
+	log := h.logger.With(zap.String("operation", "SearchEvents"), zap.String("query", query))
+	log.Debug("Received request to search events")
+
+	limit := 0
+	if rawLimit := r.URL.Query().Get("limit"); rawLimit != "" {
+		parsed, err := strconv.Atoi(rawLimit)
+		if err != nil || parsed < 1 {
+			log.Warn("Invalid search limit", zap.String("limit", rawLimit))
+			http.Error(w, "Invalid limit", http.StatusBadRequest)
+			return
+		}
+		limit = parsed
+	}
+
+	results, err := h.useCase.SearchEvents(ctx, query, limit)
+	if err != nil {
+		log.Error("Error searching events in UseCase", zap.Error(err))
+		if errors.Is(err, event.ErrInvalidSearchQuery) {
+			http.Error(w, "Search query must contain at least one letter or digit", http.StatusBadRequest)
+		} else {
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	dtos := data.MapSearchResultsToDTOs(results)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(dtos); err != nil {
+		log.Error("Error encoding JSON response", zap.Error(err))
+	}
+	log.Debug("Successful response", zap.Int("resultCount", len(dtos)))
+}
+
 func (h *Handler) FinalizeEvent(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	eventID := chi.URLParam(r, "eventID")
@@ -97,8 +138,8 @@ func (h *Handler) FinalizeEvent(w http.ResponseWriter, r *http.Request) {
 			http.Error(w, "Event already finalized", http.StatusConflict)
 		case errors.Is(err, event.ErrInvalidFinalizationResult):
 			http.Error(w, "Invalid result for finalization", http.StatusBadRequest)
-		case errors.Is(err, event.ErrBetUpdateFailed), errors.Is(err, event.ErrPayoutNotificationFailed):
-			http.Error(w, "Internal server error during bet or payout processing", http.StatusInternalServerError)
+		case errors.Is(err, event.ErrBetUpdateFailed):
+			http.Error(w, "Internal server error during bet processing", http.StatusInternalServerError)
 		default:
 			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
 		}
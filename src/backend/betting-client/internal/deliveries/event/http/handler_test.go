@@ -162,3 +162,57 @@ func TestEventHandler_FinalizeEvent_NotFound(t *testing.T) {
 
 	mockService.AssertExpectations(t)
 }
+
+func TestEventHandler_SearchEvents_Success(t *testing.T) {
+	mockService := svcmocks.NewEventService(t)
+	handler := eventhandler.NewHandler(mockService, zap.NewNop())
+	router := chi.NewRouter()
+	handler.RegisterRoutes(router)
+
+	results := []data.EventSearchResult{
+		{
+			Event:      data.Event{ID: uuid.NewString(), EventName: "Barcelona vs Girona", HomeTeam: "Barcelona"},
+			Score:      1.5,
+			Highlights: data.EventHighlights{EventName: "<mark>Barcelona</mark> vs Girona", HomeTeam: "<mark>Barcelona</mark>"},
+		},
+	}
+	mockService.On("SearchEvents", mock.Anything, "barc", 5).Return(results, nil).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/events/search?q=barc&limit=5", nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	var response []data.EventSearchResultDTO
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	require.Equal(t, data.MapSearchResultsToDTOs(results), response)
+}
+
+func TestEventHandler_SearchEvents_InvalidQuery(t *testing.T) {
+	mockService := svcmocks.NewEventService(t)
+	handler := eventhandler.NewHandler(mockService, zap.NewNop())
+	router := chi.NewRouter()
+	handler.RegisterRoutes(router)
+
+	mockService.On("SearchEvents", mock.Anything, "!!", 0).Return(nil, eventuc.ErrInvalidSearchQuery).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/events/search?q=!!", nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestEventHandler_SearchEvents_InvalidLimit(t *testing.T) {
+	mockService := svcmocks.NewEventService(t)
+	handler := eventhandler.NewHandler(mockService, zap.NewNop())
+	router := chi.NewRouter()
+	handler.RegisterRoutes(router)
+
+	req := httptest.NewRequest(http.MethodGet, "/events/search?q=barc&limit=abc", nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+	mockService.AssertNotCalled(t, "SearchEvents", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,190 @@
+package file
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/Arlan-Z/def-betting-api/internal/data"
+	eventsource "github.com/Arlan-Z/def-betting-api/internal/deliveries/eventsource/http"
+	"go.uber.org/zap"
+)
+
+// Snapshot file suffixes. A snapshot holds a JSON array of events (.json) or one event
+// per line (.ndjson). Snapshots written by the Recorder for incremental and unchanged
+// fetches carry the IncrementalSuffix and NotModifiedSuffix before the extension.
+const (
+	IncrementalSuffix = ".incremental"
+	NotModifiedSuffix = ".not-modified"
+)
+
+// Client reads events from local files instead of a source API.
+//
+// With a file path, every fetch returns the current content of the file, and a fetch
+// after which the file did not change is answered as not modified. With a directory,
+// the snapshots in it are returned one per fetch in name order, so a recorded sequence
+// of fetches is replayed cycle by cycle. After the last snapshot the feed stays unchanged.
+type Client struct {
+	path   string
+	logger *zap.Logger
+
+	mu sync.Mutex
+	// next is the index of the snapshot the next fetch of a directory returns.
+	next int
+	// current is the snapshot returned last, searched by FetchEvent.
+	current []data.ExternalEventDTO
+}
+
+func NewClient(path string, logger *zap.Logger) *Client {
+	return &Client{
+		path:   path,
+		logger: logger.Named("FileEventSourceClient").With(zap.String("path", path)),
+	}
+}
+
+func (c *Client) FetchActiveEvents(ctx context.Context, state data.SourceSyncState) (*data.EventBatch, error) {
+	info, err := os.Stat(c.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read event source file: %w", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !info.IsDir() {
+		return c.fetchFile(state)
+	}
+	return c.replayNext(state)
+}
+
+func (c *Client) fetchFile(state data.SourceSyncState) (*data.EventBatch, error) {
+	body, events, err := readSnapshot(c.path)
+	if err != nil {
+		return nil, err
+	}
+	c.current = events
+
+	checksum := data.FeedChecksum(body)
+	if state.ETag == checksum {
+		c.logger.Debug("Event source file did not change since last fetch")
+		return &data.EventBatch{NotModified: true, State: state}, nil
+	}
+	c.logger.Debug("Read events from file", zap.Int("count", len(events)))
+	return &data.EventBatch{
+		Events:   events,
+		State:    data.SourceSyncState{Provider: state.Provider, ETag: checksum},
+		Checksum: checksum,
+	}, nil
+}
+
+func (c *Client) replayNext(state data.SourceSyncState) (*data.EventBatch, error) {
+	snapshots, err := listSnapshots(c.path)
+	if err != nil {
+		return nil, err
+	}
+	if c.next >= len(snapshots) {
+		c.logger.Debug("All snapshots replayed, reporting no changes", zap.Int("snapshots", len(snapshots)))
+		return &data.EventBatch{NotModified: true, State: state}, nil
+	}
+
+	name := snapshots[c.next]
+	c.next++
+	log := c.logger.With(zap.String("snapshot", name), zap.Int("position", c.next), zap.Int("snapshots", len(snapshots)))
+
+	base := strings.TrimSuffix(name, filepath.Ext(name))
+	if strings.HasSuffix(base, NotModifiedSuffix) {
+		log.Info("Replaying unchanged fetch")
+		return &data.EventBatch{NotModified: true, State: state}, nil
+	}
+
+	body, events, err := readSnapshot(filepath.Join(c.path, name))
+	if err != nil {
+		return nil, err
+	}
+	c.current = events
+	log.Info("Replaying snapshot", zap.Int("count", len(events)))
+	return &data.EventBatch{
+		Events:      events,
+		Incremental: strings.HasSuffix(base, IncrementalSuffix),
+		State:       data.SourceSyncState{Provider: state.Provider},
+		Checksum:    data.FeedChecksum(body),
+	}, nil
+}
+
+// FetchEvent looks the event up in the snapshot returned last, or in the file.
+func (c *Client) FetchEvent(ctx context.Context, sourceEventID string) (*data.ExternalEventDTO, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	events := c.current
+	if info, err := os.Stat(c.path); err == nil && !info.IsDir() {
+		if _, events, err = readSnapshot(c.path); err != nil {
+			return nil, err
+		}
+	}
+	for _, event := range events {
+		if event.APIEventID == sourceEventID {
+			found := event
+			return &found, nil
+		}
+	}
+	return nil, eventsource.ErrEventNotFound
+}
+
+// listSnapshots returns the names of the snapshot files in dir, sorted.
+func listSnapshots(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list event snapshots: %w", err)
+	}
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		ext := filepath.Ext(entry.Name())
+		if entry.IsDir() || (ext != ".json" && ext != ".ndjson") {
+			continue
+		}
+		names = append(names, entry.Name())
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func readSnapshot(path string) ([]byte, []data.ExternalEventDTO, error) {
+	body, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read event snapshot: %w", err)
+	}
+
+	events := make([]data.ExternalEventDTO, 0)
+	if filepath.Ext(path) != ".ndjson" {
+		if err := json.Unmarshal(body, &events); err != nil {
+			return nil, nil, fmt.Errorf("failed to decode event snapshot %s: %w", filepath.Base(path), err)
+		}
+		return body, events, nil
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+	scanner.Buffer(make([]byte, 0, 64*1024), len(body)+1)
+	for line := 1; scanner.Scan(); line++ {
+		raw := bytes.TrimSpace(scanner.Bytes())
+		if len(raw) == 0 {
+			continue
+		}
+		var event data.ExternalEventDTO
+		if err := json.Unmarshal(raw, &event); err != nil {
+			return nil, nil, fmt.Errorf("failed to decode line %d of event snapshot %s: %w", line, filepath.Base(path), err)
+		}
+		events = append(events, event)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to read event snapshot %s: %w", filepath.Base(path), err)
+	}
+	return body, events, nil
+}
@@ -0,0 +1,130 @@
+package file_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Arlan-Z/def-betting-api/internal/data"
+	"github.com/Arlan-Z/def-betting-api/internal/deliveries/eventsource/file"
+	eventsource "github.com/Arlan-Z/def-betting-api/internal/deliveries/eventsource/http"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func writeFile(t *testing.T, path string, content string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+}
+
+func TestClient_FileReportsUnchangedContent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.json")
+	writeFile(t, path, `[{"id":"e1","eventName":"Kairat vs Astana","homeTeam":"Kairat","awayTeam":"Astana"}]`)
+	client := file.NewClient(path, zap.NewNop())
+	ctx := context.Background()
+
+	batch, err := client.FetchActiveEvents(ctx, data.SourceSyncState{Provider: "local"})
+	require.NoError(t, err)
+	require.Len(t, batch.Events, 1)
+	assert.Equal(t, "Kairat", batch.Events[0].TeamHome)
+	assert.NotEmpty(t, batch.State.ETag)
+
+	unchanged, err := client.FetchActiveEvents(ctx, batch.State)
+	require.NoError(t, err)
+	assert.True(t, unchanged.NotModified)
+
+	writeFile(t, path, `[]`)
+	changed, err := client.FetchActiveEvents(ctx, batch.State)
+	require.NoError(t, err)
+	assert.False(t, changed.NotModified)
+	assert.Empty(t, changed.Events)
+
+	_, err = client.FetchEvent(ctx, "e1")
+	assert.ErrorIs(t, err, eventsource.ErrEventNotFound)
+}
+
+func TestClient_ReadsNDJSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.ndjson")
+	writeFile(t, path, "{\"id\":\"e1\",\"homeTeam\":\"Kairat\"}\n\n{\"id\":\"e2\",\"homeTeam\":\"Astana\"}\n")
+	client := file.NewClient(path, zap.NewNop())
+
+	batch, err := client.FetchActiveEvents(context.Background(), data.SourceSyncState{})
+	require.NoError(t, err)
+	require.Len(t, batch.Events, 2)
+
+	event, err := client.FetchEvent(context.Background(), "e2")
+	require.NoError(t, err)
+	assert.Equal(t, "Astana", event.TeamHome)
+}
+
+func TestClient_ReplaysDirectory(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "000001.json"), `[{"id":"e1"},{"id":"e2"}]`)
+	writeFile(t, filepath.Join(dir, "000002.not-modified.json"), `[]`)
+	writeFile(t, filepath.Join(dir, "000003.incremental.ndjson"), `{"id":"e2","eventResult":"HomeWin"}`)
+	writeFile(t, filepath.Join(dir, "notes.txt"), `ignored`)
+	client := file.NewClient(dir, zap.NewNop())
+	ctx := context.Background()
+
+	first, err := client.FetchActiveEvents(ctx, data.SourceSyncState{})
+	require.NoError(t, err)
+	assert.Len(t, first.Events, 2)
+	assert.False(t, first.Incremental)
+
+	second, err := client.FetchActiveEvents(ctx, data.SourceSyncState{})
+	require.NoError(t, err)
+	assert.True(t, second.NotModified)
+
+	third, err := client.FetchActiveEvents(ctx, data.SourceSyncState{})
+	require.NoError(t, err)
+	assert.True(t, third.Incremental)
+	require.Len(t, third.Events, 1)
+	assert.Equal(t, "HomeWin", *third.Events[0].Result)
+
+	done, err := client.FetchActiveEvents(ctx, data.SourceSyncState{})
+	require.NoError(t, err)
+	assert.True(t, done.NotModified)
+}
+
+func TestRecorder_RecordingReplaysInOrder(t *testing.T) {
+	source := filepath.Join(t.TempDir(), "events.json")
+	dir := filepath.Join(t.TempDir(), "recorded")
+	ctx := context.Background()
+
+	recorder, err := file.NewRecorder(file.NewClient(source, zap.NewNop()), dir, zap.NewNop())
+	require.NoError(t, err)
+
+	writeFile(t, source, `[{"id":"e1","homeWinChance":"oops"}]`)
+	batch, err := recorder.FetchActiveEvents(ctx, data.SourceSyncState{})
+	require.NoError(t, err)
+	_, err = recorder.FetchActiveEvents(ctx, batch.State)
+	require.NoError(t, err)
+	writeFile(t, source, `[{"id":"e1","eventResult":"Draw"}]`)
+	_, err = recorder.FetchActiveEvents(ctx, batch.State)
+	require.NoError(t, err)
+
+	// A new recorder continues the numbering.
+	recorder, err = file.NewRecorder(file.NewClient(source, zap.NewNop()), dir, zap.NewNop())
+	require.NoError(t, err)
+	_, err = recorder.FetchActiveEvents(ctx, data.SourceSyncState{})
+	require.NoError(t, err)
+
+	replay := file.NewClient(dir, zap.NewNop())
+	first, err := replay.FetchActiveEvents(ctx, data.SourceSyncState{})
+	require.NoError(t, err)
+	require.Len(t, first.Events, 1)
+	assert.JSONEq(t, `{"id":"e1","homeWinChance":"oops"}`, string(first.Events[0].Payload()))
+
+	second, err := replay.FetchActiveEvents(ctx, data.SourceSyncState{})
+	require.NoError(t, err)
+	assert.True(t, second.NotModified)
+
+	for i := 0; i < 2; i++ {
+		next, err := replay.FetchActiveEvents(ctx, data.SourceSyncState{})
+		require.NoError(t, err)
+		require.Len(t, next.Events, 1)
+		assert.Equal(t, "Draw", *next.Events[0].Result)
+	}
+}
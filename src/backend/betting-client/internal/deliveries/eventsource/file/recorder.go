@@ -0,0 +1,106 @@
+package file
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Arlan-Z/def-betting-api/internal/data"
+	eventsource "github.com/Arlan-Z/def-betting-api/internal/deliveries/eventsource/http"
+	"go.uber.org/zap"
+)
+
+// Recorder saves every successful fetch of another client as a snapshot in a directory,
+// which a Client pointed at that directory replays in the same order. Failing to save a
+// snapshot is logged and does not fail the fetch.
+type Recorder struct {
+	next   eventsource.EventSourceClient
+	dir    string
+	logger *zap.Logger
+
+	mu  sync.Mutex
+	seq int
+}
+
+// NewRecorder creates the directory if needed and continues the numbering of the
+// snapshots already in it.
+func NewRecorder(next eventsource.EventSourceClient, dir string, logger *zap.Logger) (*Recorder, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+	names, err := listSnapshots(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	seq := 0
+	for _, name := range names {
+		prefix, _, _ := strings.Cut(name, "-")
+		if n, err := strconv.Atoi(prefix); err == nil && n > seq {
+			seq = n
+		}
+	}
+	return &Recorder{
+		next:   next,
+		dir:    dir,
+		logger: logger.Named("EventSourceRecorder").With(zap.String("dir", dir)),
+		seq:    seq,
+	}, nil
+}
+
+func (r *Recorder) FetchActiveEvents(ctx context.Context, state data.SourceSyncState) (*data.EventBatch, error) {
+	batch, err := r.next.FetchActiveEvents(ctx, state)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.record(batch, time.Now().UTC()); err != nil {
+		r.logger.Error("Failed to record event snapshot", zap.Error(err))
+	}
+	return batch, nil
+}
+
+// FetchEvent is not recorded, a replay only reproduces the fetches of sync cycles.
+func (r *Recorder) FetchEvent(ctx context.Context, sourceEventID string) (*data.ExternalEventDTO, error) {
+	return r.next.FetchEvent(ctx, sourceEventID)
+}
+
+func (r *Recorder) record(batch *data.EventBatch, fetchedAt time.Time) error {
+	payloads := make([]json.RawMessage, len(batch.Events))
+	for i, event := range batch.Events {
+		payloads[i] = event.Payload()
+	}
+	body, err := json.MarshalIndent(payloads, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode events: %w", err)
+	}
+
+	suffix := ""
+	switch {
+	case batch.NotModified:
+		suffix = NotModifiedSuffix
+	case batch.Incremental:
+		suffix = IncrementalSuffix
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.seq++
+	name := fmt.Sprintf("%06d-%s%s.json", r.seq, fetchedAt.Format("20060102T150405Z"), suffix)
+	// Write to a temporary name first, so a replay never reads a partial snapshot.
+	tmp := filepath.Join(r.dir, "."+name+".tmp")
+	if err := os.WriteFile(tmp, body, 0o644); err != nil {
+		return fmt.Errorf("failed to write snapshot %s: %w", name, err)
+	}
+	if err := os.Rename(tmp, filepath.Join(r.dir, name)); err != nil {
+		return fmt.Errorf("failed to save snapshot %s: %w", name, err)
+	}
+	r.logger.Debug("Recorded event snapshot", zap.String("snapshot", name), zap.Int("count", len(batch.Events)))
+	return nil
+}
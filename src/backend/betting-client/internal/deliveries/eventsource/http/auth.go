@@ -0,0 +1,155 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"go.uber.org/zap"
+)
+
+// Authenticator adds the source's credentials to a request.
+type Authenticator interface {
+	Authenticate(ctx context.Context, req *resty.Request) error
+}
+
+// tokenInvalidator is implemented by authenticators that cache tokens. The client drops
+// the cached token when the source rejects it and retries the request once.
+type tokenInvalidator interface {
+	Invalidate()
+}
+
+// APIKeyAuth sends a static key in a header.
+type APIKeyAuth struct {
+	header string
+	key    string
+}
+
+func NewAPIKeyAuth(header string, key string) *APIKeyAuth {
+	return &APIKeyAuth{header: header, key: key}
+}
+
+func (a *APIKeyAuth) Authenticate(ctx context.Context, req *resty.Request) error {
+	req.SetHeader(a.header, a.key)
+	return nil
+}
+
+// BasicAuth sends HTTP basic credentials.
+type BasicAuth struct {
+	username string
+	password string
+}
+
+func NewBasicAuth(username string, password string) *BasicAuth {
+	return &BasicAuth{username: username, password: password}
+}
+
+func (a *BasicAuth) Authenticate(ctx context.Context, req *resty.Request) error {
+	req.SetBasicAuth(a.username, a.password)
+	return nil
+}
+
+// ClientCredentialsAuth sends a bearer token obtained with the OAuth2 client credentials
+// grant. The token is cached and fetched again refreshBefore it expires, but not before
+// half of its lifetime passed, or once the source rejected it. Tokens without expires_in
+// are kept until they are rejected.
+type ClientCredentialsAuth struct {
+	client        *resty.Client
+	tokenURL      string
+	clientID      string
+	clientSecret  string
+	scopes        []string
+	refreshBefore time.Duration
+	logger        *zap.Logger
+
+	mu        sync.Mutex
+	token     string
+	refreshAt time.Time
+}
+
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int    `json:"expires_in"`
+	Error       string `json:"error"`
+}
+
+func NewClientCredentialsAuth(tokenURL string, clientID string, clientSecret string, scopes []string, refreshBefore time.Duration, timeout time.Duration, logger *zap.Logger) *ClientCredentialsAuth {
+	return &ClientCredentialsAuth{
+		client:        resty.New().SetTimeout(timeout),
+		tokenURL:      tokenURL,
+		clientID:      clientID,
+		clientSecret:  clientSecret,
+		scopes:        scopes,
+		refreshBefore: refreshBefore,
+		logger:        logger.Named("EventSourceAuth").With(zap.String("tokenUrl", tokenURL)),
+	}
+}
+
+func (a *ClientCredentialsAuth) Authenticate(ctx context.Context, req *resty.Request) error {
+	token, err := a.Token(ctx)
+	if err != nil {
+		return err
+	}
+	req.SetAuthToken(token)
+	return nil
+}
+
+// Token returns the cached token, fetching a new one when there is none or it is about
+// to expire. Concurrent callers share a single token request.
+func (a *ClientCredentialsAuth) Token(ctx context.Context) (string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	now := time.Now()
+	if a.token != "" && (a.refreshAt.IsZero() || now.Before(a.refreshAt)) {
+		return a.token, nil
+	}
+
+	form := map[string]string{"grant_type": "client_credentials"}
+	if len(a.scopes) > 0 {
+		form["scope"] = strings.Join(a.scopes, " ")
+	}
+	resp, err := a.client.R().
+		SetContext(ctx).
+		SetBasicAuth(a.clientID, a.clientSecret).
+		SetFormData(form).
+		Post(a.tokenURL)
+	if err != nil {
+		return "", fmt.Errorf("failed to request access token: %w", err)
+	}
+
+	var body tokenResponse
+	decodeErr := json.Unmarshal(resp.Body(), &body)
+	if resp.IsError() {
+		a.logger.Error("Token endpoint rejected the client credentials", zap.Int("status_code", resp.StatusCode()), zap.String("error", body.Error))
+		return "", fmt.Errorf("token endpoint returned status %d: %s", resp.StatusCode(), body.Error)
+	}
+	if decodeErr != nil {
+		return "", fmt.Errorf("failed to decode access token response: %w", decodeErr)
+	}
+	if body.AccessToken == "" {
+		return "", fmt.Errorf("token endpoint returned no access token")
+	}
+
+	a.token = body.AccessToken
+	a.refreshAt = time.Time{}
+	if body.ExpiresIn > 0 {
+		// A token shorter lived than refreshBefore would otherwise be fetched for every request.
+		lifetime := time.Duration(body.ExpiresIn) * time.Second
+		a.refreshAt = now.Add(lifetime - min(a.refreshBefore, lifetime/2))
+	}
+	a.logger.Debug("Fetched access token", zap.Time("refreshAt", a.refreshAt))
+	return a.token, nil
+}
+
+// Invalidate drops the cached token, so the next request fetches a new one.
+func (a *ClientCredentialsAuth) Invalidate() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.token = ""
+}
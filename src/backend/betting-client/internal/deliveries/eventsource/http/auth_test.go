@@ -0,0 +1,144 @@
+package http_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Arlan-Z/def-betting-api/internal/data"
+	eventsource "github.com/Arlan-Z/def-betting-api/internal/deliveries/eventsource/http"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// tokenServer issues tokens token-1, token-2, ... valid for expiresIn seconds.
+func tokenServer(t *testing.T, expiresIn int) (*httptest.Server, *atomic.Int32) {
+	var issued atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, secret, ok := r.BasicAuth()
+		if !ok || id != "betting" || secret != "s3cret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"error":"invalid_client"}`))
+			return
+		}
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "client_credentials", r.PostForm.Get("grant_type"))
+		assert.Equal(t, "events:read", r.PostForm.Get("scope"))
+
+		n := issued.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"access_token":"token-%d","token_type":"Bearer","expires_in":%d}`, n, expiresIn)
+	}))
+	t.Cleanup(server.Close)
+	return server, &issued
+}
+
+func TestAPIKeyAndBasicAuth(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, password, ok := r.BasicAuth()
+		if r.Header.Get("X-Api-Key") != "key-1" && !(ok && user == "betting" && password == "pw") {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	for name, auth := range map[string]eventsource.Authenticator{
+		"api key": eventsource.NewAPIKeyAuth("X-Api-Key", "key-1"),
+		"basic":   eventsource.NewBasicAuth("betting", "pw"),
+	} {
+		t.Run(name, func(t *testing.T) {
+			client := eventsource.NewRestyEventSourceClient(server.URL, time.Second, auth, zap.NewNop())
+			_, err := client.FetchActiveEvents(context.Background(), data.SourceSyncState{})
+			assert.NoError(t, err)
+		})
+	}
+
+	client := eventsource.NewRestyEventSourceClient(server.URL, time.Second, nil, zap.NewNop())
+	_, err := client.FetchActiveEvents(context.Background(), data.SourceSyncState{})
+	var statusErr *eventsource.StatusError
+	require.ErrorAs(t, err, &statusErr)
+	assert.Equal(t, http.StatusUnauthorized, statusErr.StatusCode)
+}
+
+func TestClientCredentialsAuth_CachesAndRefreshesTokens(t *testing.T) {
+	tokens, issued := tokenServer(t, 2)
+	auth := eventsource.NewClientCredentialsAuth(tokens.URL, "betting", "s3cret", []string{"events:read"}, time.Second, time.Second, zap.NewNop())
+
+	token, err := auth.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "token-1", token)
+
+	token, err = auth.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "token-1", token, "the cached token is reused")
+
+	// One second before the two second token expires, a new one is fetched.
+	time.Sleep(1100 * time.Millisecond)
+	token, err = auth.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "token-2", token)
+	assert.Equal(t, int32(2), issued.Load())
+}
+
+func TestClientCredentialsAuth_ReusesTokensShorterLivedThanRefreshMargin(t *testing.T) {
+	tokens, issued := tokenServer(t, 2)
+	auth := eventsource.NewClientCredentialsAuth(tokens.URL, "betting", "s3cret", []string{"events:read"}, time.Minute, time.Second, zap.NewNop())
+
+	token, err := auth.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "token-1", token)
+
+	// The two second token is kept for half of its lifetime.
+	token, err = auth.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "token-1", token)
+
+	time.Sleep(1100 * time.Millisecond)
+	token, err = auth.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "token-2", token)
+	assert.Equal(t, int32(2), issued.Load())
+}
+
+func TestClientCredentialsAuth_RetriesWithNewTokenWhenRejected(t *testing.T) {
+	tokens, issued := tokenServer(t, 3600)
+	var revoked atomic.Bool
+	source := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") == "Bearer token-1" && revoked.Load() {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		assert.Contains(t, r.Header.Get("Authorization"), "Bearer token-")
+		w.Write([]byte(`{"id":"e1"}`))
+	}))
+	defer source.Close()
+
+	auth := eventsource.NewClientCredentialsAuth(tokens.URL, "betting", "s3cret", []string{"events:read"}, time.Minute, time.Second, zap.NewNop())
+	client := eventsource.NewRestyEventSourceClient(source.URL, time.Second, auth, zap.NewNop())
+
+	_, err := client.FetchEvent(context.Background(), "e1")
+	require.NoError(t, err)
+	revoked.Store(true)
+	event, err := client.FetchEvent(context.Background(), "e1")
+
+	require.NoError(t, err)
+	assert.Equal(t, "e1", event.APIEventID)
+	assert.Equal(t, int32(2), issued.Load())
+}
+
+func TestClientCredentialsAuth_FailsOnRejectedCredentials(t *testing.T) {
+	tokens, _ := tokenServer(t, 3600)
+	auth := eventsource.NewClientCredentialsAuth(tokens.URL, "betting", "wrong", nil, time.Minute, time.Second, zap.NewNop())
+
+	_, err := auth.Token(context.Background())
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid_client")
+}
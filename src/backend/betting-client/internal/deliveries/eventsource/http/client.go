@@ -3,7 +3,9 @@ package http
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
 	"time"
 
 	"github.com/Arlan-Z/def-betting-api/internal/data"
@@ -11,16 +13,39 @@ import (
 	"go.uber.org/zap"
 )
 
+// SinceParam is the query parameter carrying the cursor of the last fetch, and
+// CursorHeader the response header in which the source returns the next one.
+const (
+	SinceParam   = "since"
+	CursorHeader = "X-Sync-Cursor"
+)
+
+// ErrEventNotFound is returned by FetchEvent when the source does not know the event.
+var ErrEventNotFound = errors.New("event not found at source")
+
+// StatusError is returned when the source answers with an error status.
+type StatusError struct {
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("event source returned status %d", e.StatusCode)
+}
+
 type EventSourceClient interface {
-	FetchActiveEvents(ctx context.Context) ([]data.ExternalEventDTO, error)
+	FetchActiveEvents(ctx context.Context, state data.SourceSyncState) (*data.EventBatch, error)
+	FetchEvent(ctx context.Context, sourceEventID string) (*data.ExternalEventDTO, error)
 }
 
 type RestyEventSourceClient struct {
 	client *resty.Client
+	auth   Authenticator
 	logger *zap.Logger
 }
 
-func NewRestyEventSourceClient(baseURL string, timeout time.Duration, logger *zap.Logger) *RestyEventSourceClient {
+// NewRestyEventSourceClient creates a client for the source at baseURL. auth may be nil
+// for sources that need no credentials.
+func NewRestyEventSourceClient(baseURL string, timeout time.Duration, auth Authenticator, logger *zap.Logger) *RestyEventSourceClient {
 	client := resty.New().
 		SetBaseURL(baseURL).
 		SetTimeout(timeout).
@@ -43,18 +68,55 @@ func NewRestyEventSourceClient(baseURL string, timeout time.Duration, logger *za
 				zap.String("body", body))
 		})
 
+	if auth != nil {
+		client.OnBeforeRequest(func(_ *resty.Client, req *resty.Request) error {
+			return auth.Authenticate(req.Context(), req)
+		})
+	}
+
 	return &RestyEventSourceClient{
 		client: client,
+		auth:   auth,
 		logger: logger.Named("EventSourceClient"),
 	}
 }
 
-func (c *RestyEventSourceClient) FetchActiveEvents(ctx context.Context) ([]data.ExternalEventDTO, error) {
+// send runs the request built by newRequest. When the source rejects a cached token, the
+// token is dropped and the request is sent once more with a fresh one.
+func (c *RestyEventSourceClient) send(newRequest func() *resty.Request, url string) (*resty.Response, error) {
+	resp, err := newRequest().Get(url)
+	if err != nil || resp.StatusCode() != http.StatusUnauthorized {
+		return resp, err
+	}
+	invalidator, ok := c.auth.(tokenInvalidator)
+	if !ok {
+		return resp, err
+	}
+	c.logger.Warn("Event source rejected the access token, fetching a new one")
+	invalidator.Invalidate()
+	return newRequest().Get(url)
+}
+
+// FetchActiveEvents fetches the events, sending the validators and cursor of the previous
+// fetch. Sources that support them may answer 304 Not Modified or only the changed events.
+func (c *RestyEventSourceClient) FetchActiveEvents(ctx context.Context, state data.SourceSyncState) (*data.EventBatch, error) {
 	endpoint := "/api/events/all"
 
-	req := c.client.R().SetContext(ctx)
+	newRequest := func() *resty.Request {
+		req := c.client.R().SetContext(ctx)
+		if state.ETag != "" {
+			req.SetHeader("If-None-Match", state.ETag)
+		}
+		if state.LastModified != "" {
+			req.SetHeader("If-Modified-Since", state.LastModified)
+		}
+		if state.Cursor != "" {
+			req.SetQueryParam(SinceParam, state.Cursor)
+		}
+		return req
+	}
 
-	resp, err := req.Get(endpoint)
+	resp, err := c.send(newRequest, endpoint)
 
 	if err != nil {
 		c.logger.Error("Error requesting events from source API", zap.Error(err))
@@ -66,7 +128,19 @@ func (c *RestyEventSourceClient) FetchActiveEvents(ctx context.Context) ([]data.
 			zap.Int("status_code", resp.StatusCode()),
 			zap.String("body", string(resp.Body())),
 		)
-		return nil, fmt.Errorf("event source returned status %d", resp.StatusCode())
+		return nil, &StatusError{StatusCode: resp.StatusCode()}
+	}
+
+	if resp.StatusCode() == http.StatusNotModified {
+		c.logger.Debug("Event source reported no changes since last fetch")
+		return &data.EventBatch{NotModified: true, State: state}, nil
+	}
+
+	next := data.SourceSyncState{
+		Provider:     state.Provider,
+		ETag:         resp.Header().Get("ETag"),
+		LastModified: resp.Header().Get("Last-Modified"),
+		Cursor:       resp.Header().Get(CursorHeader),
 	}
 
 	var events []data.ExternalEventDTO
@@ -76,6 +150,44 @@ func (c *RestyEventSourceClient) FetchActiveEvents(ctx context.Context) ([]data.
 		return nil, fmt.Errorf("failed to decode response from event source: %w", err)
 	}
 
-	c.logger.Debug("Successfully fetched events from external API", zap.Int("count", len(events)))
-	return events, nil
+	c.logger.Debug("Successfully fetched events from external API", zap.Int("count", len(events)), zap.Bool("incremental", state.Cursor != ""))
+	return &data.EventBatch{
+		Events:      events,
+		Incremental: state.Cursor != "",
+		State:       next,
+		Checksum:    data.FeedChecksum(resp.Body()),
+	}, nil
+}
+
+// FetchEvent fetches a single event by the source's own ID.
+func (c *RestyEventSourceClient) FetchEvent(ctx context.Context, sourceEventID string) (*data.ExternalEventDTO, error) {
+	resp, err := c.send(func() *resty.Request {
+		return c.client.R().
+			SetContext(ctx).
+			SetPathParam("id", sourceEventID)
+	}, "/api/events/{id}")
+
+	if err != nil {
+		c.logger.Error("Error requesting event from source API", zap.String("sourceEventId", sourceEventID), zap.Error(err))
+		return nil, fmt.Errorf("failed to execute request to event source: %w", err)
+	}
+
+	if resp.StatusCode() == http.StatusNotFound {
+		return nil, ErrEventNotFound
+	}
+	if resp.IsError() {
+		c.logger.Error("Event source API returned an error",
+			zap.String("sourceEventId", sourceEventID),
+			zap.Int("status_code", resp.StatusCode()),
+			zap.String("body", string(resp.Body())),
+		)
+		return nil, &StatusError{StatusCode: resp.StatusCode()}
+	}
+
+	var event data.ExternalEventDTO
+	if err := json.Unmarshal(resp.Body(), &event); err != nil {
+		c.logger.Error("Error decoding response from event source API", zap.Error(err))
+		return nil, fmt.Errorf("failed to decode response from event source: %w", err)
+	}
+	return &event, nil
 }
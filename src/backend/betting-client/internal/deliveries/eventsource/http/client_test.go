@@ -0,0 +1,117 @@
+package http_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Arlan-Z/def-betting-api/internal/data"
+	eventsource "github.com/Arlan-Z/def-betting-api/internal/deliveries/eventsource/http"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestFetchActiveEvents_ReturnsNextState(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/events/all", r.URL.Path)
+		assert.Empty(t, r.Header.Get("If-None-Match"))
+		assert.Empty(t, r.URL.Query().Get(eventsource.SinceParam))
+
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Last-Modified", "Sat, 01 Jun 2030 12:00:00 GMT")
+		w.Header().Set(eventsource.CursorHeader, "cursor-1")
+		w.Write([]byte(`[{"id":"e1","eventName":"Kairat vs Astana","homeTeam":"Kairat","awayTeam":"Astana"}]`))
+	}))
+	defer server.Close()
+
+	client := eventsource.NewRestyEventSourceClient(server.URL, time.Second, nil, zap.NewNop())
+	batch, err := client.FetchActiveEvents(context.Background(), data.SourceSyncState{Provider: "primary"})
+
+	require.NoError(t, err)
+	assert.False(t, batch.NotModified)
+	assert.False(t, batch.Incremental)
+	assert.Len(t, batch.Events, 1)
+	assert.Equal(t, data.SourceSyncState{
+		Provider:     "primary",
+		ETag:         `"v1"`,
+		LastModified: "Sat, 01 Jun 2030 12:00:00 GMT",
+		Cursor:       "cursor-1",
+	}, batch.State)
+}
+
+func TestFetchActiveEvents_SendsStateAndHandlesNotModified(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, `"v1"`, r.Header.Get("If-None-Match"))
+		assert.Equal(t, "Sat, 01 Jun 2030 12:00:00 GMT", r.Header.Get("If-Modified-Since"))
+		assert.Equal(t, "cursor-1", r.URL.Query().Get(eventsource.SinceParam))
+		w.WriteHeader(http.StatusNotModified)
+	}))
+	defer server.Close()
+
+	state := data.SourceSyncState{Provider: "primary", ETag: `"v1"`, LastModified: "Sat, 01 Jun 2030 12:00:00 GMT", Cursor: "cursor-1"}
+	client := eventsource.NewRestyEventSourceClient(server.URL, time.Second, nil, zap.NewNop())
+	batch, err := client.FetchActiveEvents(context.Background(), state)
+
+	require.NoError(t, err)
+	assert.True(t, batch.NotModified)
+	assert.Empty(t, batch.Events)
+	assert.Equal(t, state, batch.State)
+}
+
+func TestFetchActiveEvents_IncrementalWhenCursorSent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(eventsource.CursorHeader, "cursor-2")
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	client := eventsource.NewRestyEventSourceClient(server.URL, time.Second, nil, zap.NewNop())
+	batch, err := client.FetchActiveEvents(context.Background(), data.SourceSyncState{Provider: "primary", Cursor: "cursor-1"})
+
+	require.NoError(t, err)
+	assert.True(t, batch.Incremental)
+	assert.Equal(t, "cursor-2", batch.State.Cursor)
+}
+
+func TestFetchActiveEvents_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	client := eventsource.NewRestyEventSourceClient(server.URL, time.Second, nil, zap.NewNop())
+	_, err := client.FetchActiveEvents(context.Background(), data.SourceSyncState{})
+
+	assert.Error(t, err)
+}
+
+func TestFetchEvent_ReturnsEvent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/events/e1", r.URL.Path)
+		w.Write([]byte(`{"id":"e1","eventName":"Kairat vs Astana","homeTeam":"Kairat","awayTeam":"Astana"}`))
+	}))
+	defer server.Close()
+
+	client := eventsource.NewRestyEventSourceClient(server.URL, time.Second, nil, zap.NewNop())
+	event, err := client.FetchEvent(context.Background(), "e1")
+
+	require.NoError(t, err)
+	assert.Equal(t, "e1", event.APIEventID)
+	assert.Equal(t, "Kairat", event.TeamHome)
+}
+
+func TestFetchEvent_NotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := eventsource.NewRestyEventSourceClient(server.URL, time.Second, nil, zap.NewNop())
+	event, err := client.FetchEvent(context.Background(), "missing")
+
+	assert.ErrorIs(t, err, eventsource.ErrEventNotFound)
+	assert.Nil(t, event)
+}
@@ -0,0 +1,128 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"time"
+
+	"github.com/Arlan-Z/def-betting-api/internal/data"
+	"github.com/Arlan-Z/def-betting-api/internal/pkg/breaker"
+	"go.uber.org/zap"
+)
+
+// RetryPolicy controls how often a failed request is repeated. The wait doubles after
+// every attempt up to MaxWait, and a random jitter of up to half the wait is taken off.
+type RetryPolicy struct {
+	Retries int
+	Wait    time.Duration
+	MaxWait time.Duration
+}
+
+// ResilientClient retries failed requests of another client and stops calling the
+// source while its circuit breaker is open.
+type ResilientClient struct {
+	next    EventSourceClient
+	retry   RetryPolicy
+	breaker *breaker.Breaker
+	logger  *zap.Logger
+}
+
+func NewResilientClient(next EventSourceClient, retry RetryPolicy, b *breaker.Breaker, logger *zap.Logger) *ResilientClient {
+	return &ResilientClient{
+		next:    next,
+		retry:   retry,
+		breaker: b,
+		logger:  logger.Named("ResilientEventSourceClient"),
+	}
+}
+
+func (c *ResilientClient) FetchActiveEvents(ctx context.Context, state data.SourceSyncState) (*data.EventBatch, error) {
+	var batch *data.EventBatch
+	err := c.call(ctx, "FetchActiveEvents", func() error {
+		var err error
+		batch, err = c.next.FetchActiveEvents(ctx, state)
+		return err
+	})
+	return batch, err
+}
+
+func (c *ResilientClient) FetchEvent(ctx context.Context, sourceEventID string) (*data.ExternalEventDTO, error) {
+	var event *data.ExternalEventDTO
+	err := c.call(ctx, "FetchEvent", func() error {
+		var err error
+		event, err = c.next.FetchEvent(ctx, sourceEventID)
+		return err
+	})
+	return event, err
+}
+
+func (c *ResilientClient) call(ctx context.Context, operation string, fn func() error) error {
+	log := c.logger.With(zap.String("operation", operation))
+	if err := c.breaker.Allow(time.Now()); err != nil {
+		log.Warn("Event source circuit breaker is open, skipping request")
+		return err
+	}
+
+	var err error
+	for attempt := 0; ; attempt++ {
+		err = fn()
+		if err == nil || !retryable(err) || attempt >= c.retry.Retries || ctx.Err() != nil {
+			break
+		}
+
+		wait := c.backoff(attempt)
+		log.Warn("Event source request failed, retrying", zap.Int("attempt", attempt+1), zap.Duration("wait", wait), zap.Error(err))
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			c.breaker.Cancel()
+			return err
+		}
+	}
+
+	// A call the caller gave up on, e.g. on step-down or a cycle timeout, says nothing
+	// about the source.
+	if ctx.Err() != nil {
+		c.breaker.Cancel()
+		return err
+	}
+
+	// A missing event is an answer of a working source.
+	if err == nil || errors.Is(err, ErrEventNotFound) {
+		c.breaker.Success()
+		return err
+	}
+	c.breaker.Failure(time.Now())
+	if snapshot := c.breaker.Snapshot(time.Now()); snapshot.State == breaker.StateOpen {
+		log.Error("Event source circuit breaker opened", zap.Int("consecutiveFailures", snapshot.ConsecutiveFailures))
+	}
+	return err
+}
+
+func (c *ResilientClient) backoff(attempt int) time.Duration {
+	wait := c.retry.Wait << attempt
+	if wait <= 0 || (c.retry.MaxWait > 0 && wait > c.retry.MaxWait) {
+		wait = c.retry.MaxWait
+	}
+	if wait <= 0 {
+		return 0
+	}
+	return wait - rand.N(wait/2+1)
+}
+
+// retryable reports whether repeating the request may help. Client errors other than
+// timeouts and rate limiting will not change on their own.
+func retryable(err error) bool {
+	if errors.Is(err, ErrEventNotFound) || errors.Is(err, context.Canceled) {
+		return false
+	}
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) && statusErr.StatusCode < http.StatusInternalServerError {
+		return statusErr.StatusCode == http.StatusRequestTimeout || statusErr.StatusCode == http.StatusTooManyRequests
+	}
+	return true
+}
@@ -0,0 +1,133 @@
+package http_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Arlan-Z/def-betting-api/internal/data"
+	eventsource "github.com/Arlan-Z/def-betting-api/internal/deliveries/eventsource/http"
+	"github.com/Arlan-Z/def-betting-api/internal/pkg/breaker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestResilientClient_RetriesServerErrors(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	b := breaker.New(3, time.Minute)
+	client := eventsource.NewResilientClient(
+		eventsource.NewRestyEventSourceClient(server.URL, time.Second, nil, zap.NewNop()),
+		eventsource.RetryPolicy{Retries: 2, Wait: time.Millisecond, MaxWait: 5 * time.Millisecond},
+		b,
+		zap.NewNop(),
+	)
+	batch, err := client.FetchActiveEvents(context.Background(), data.SourceSyncState{})
+
+	require.NoError(t, err)
+	assert.Empty(t, batch.Events)
+	assert.Equal(t, 3, calls)
+	assert.Equal(t, breaker.StateClosed, b.Snapshot(time.Now()).State)
+}
+
+func TestResilientClient_DoesNotRetryClientErrors(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	client := eventsource.NewResilientClient(
+		eventsource.NewRestyEventSourceClient(server.URL, time.Second, nil, zap.NewNop()),
+		eventsource.RetryPolicy{Retries: 2, Wait: time.Millisecond},
+		breaker.New(3, time.Minute),
+		zap.NewNop(),
+	)
+	_, err := client.FetchActiveEvents(context.Background(), data.SourceSyncState{})
+
+	var statusErr *eventsource.StatusError
+	require.ErrorAs(t, err, &statusErr)
+	assert.Equal(t, http.StatusUnauthorized, statusErr.StatusCode)
+	assert.Equal(t, 1, calls)
+}
+
+func TestResilientClient_OpensBreaker(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	b := breaker.New(2, time.Minute)
+	client := eventsource.NewResilientClient(
+		eventsource.NewRestyEventSourceClient(server.URL, time.Second, nil, zap.NewNop()),
+		eventsource.RetryPolicy{},
+		b,
+		zap.NewNop(),
+	)
+	for i := 0; i < 2; i++ {
+		_, err := client.FetchActiveEvents(context.Background(), data.SourceSyncState{})
+		assert.Error(t, err)
+	}
+	_, err := client.FetchEvent(context.Background(), "e1")
+
+	assert.ErrorIs(t, err, breaker.ErrOpen)
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, breaker.StateOpen, b.Snapshot(time.Now()).State)
+}
+
+func TestResilientClient_CanceledCallDoesNotCountAsFailure(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cancel()
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	b := breaker.New(1, time.Minute)
+	client := eventsource.NewResilientClient(
+		eventsource.NewRestyEventSourceClient(server.URL, time.Second, nil, zap.NewNop()),
+		eventsource.RetryPolicy{Retries: 2, Wait: time.Millisecond},
+		b,
+		zap.NewNop(),
+	)
+	_, err := client.FetchActiveEvents(ctx, data.SourceSyncState{})
+
+	assert.Error(t, err)
+	snapshot := b.Snapshot(time.Now())
+	assert.Equal(t, breaker.StateClosed, snapshot.State)
+	assert.Zero(t, snapshot.ConsecutiveFailures)
+}
+
+func TestResilientClient_NotFoundKeepsBreakerClosed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	b := breaker.New(1, time.Minute)
+	client := eventsource.NewResilientClient(
+		eventsource.NewRestyEventSourceClient(server.URL, time.Second, nil, zap.NewNop()),
+		eventsource.RetryPolicy{Retries: 2, Wait: time.Millisecond},
+		b,
+		zap.NewNop(),
+	)
+	_, err := client.FetchEvent(context.Background(), "missing")
+
+	assert.ErrorIs(t, err, eventsource.ErrEventNotFound)
+	assert.Equal(t, breaker.StateClosed, b.Snapshot(time.Now()).State)
+}
@@ -3,23 +3,71 @@ package http
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
+	"github.com/Arlan-Z/def-betting-api/internal/data"
+	"github.com/Arlan-Z/def-betting-api/internal/pkg/breaker"
 	"github.com/go-chi/chi/v5"
 	"github.com/jmoiron/sqlx"
 	"go.uber.org/zap"
 )
 
+// SyncFreshness reports when event data was last synced successfully.
+type SyncFreshness interface {
+	LastSuccessfulSync(ctx context.Context) (*time.Time, error)
+}
+
+// SourceHealthReporter reports the circuit breaker state of every event source.
+type SourceHealthReporter interface {
+	SourceHealth() []data.SourceHealth
+}
+
+// LeadershipReporter tells whether this replica runs the background workers.
+type LeadershipReporter interface {
+	Status() data.LeaderStatus
+}
+
+// PayoutBacklog counts the payouts that failed for good or are being retried after a failure.
+type PayoutBacklog interface {
+	CountStuckPayouts(ctx context.Context) (int, error)
+}
+
 type Handler struct {
-	db     *sqlx.DB
-	logger *zap.Logger
+	db         *sqlx.DB
+	freshness  SyncFreshness
+	maxSyncAge time.Duration
+	sources    SourceHealthReporter
+	leadership LeadershipReporter
+	payouts    PayoutBacklog
+	logger     *zap.Logger
 }
 
-func NewHandler(db *sqlx.DB, logger *zap.Logger) *Handler {
+// ReadyResponse is the body of a successful readiness probe. Sources are only reported
+// by the leader, which is the replica that fetches them. Stuck payouts are reported, but
+// do not fail readiness: they need an operator, not a restart.
+type ReadyResponse struct {
+	Status       string              `json:"status"`
+	Leadership   *data.LeaderStatus  `json:"leadership,omitempty"`
+	Sources      []data.SourceHealth `json:"sources"`
+	StuckPayouts int                 `json:"stuckPayouts"`
+}
+
+// NewHandler creates the health handler. With a positive maxSyncAge, readiness fails
+// when the last successful sync is older than that. Readiness also fails while the
+// circuit breaker of every event source is open.
+func NewHandler(db *sqlx.DB, freshness SyncFreshness, maxSyncAge time.Duration, sources SourceHealthReporter, leadership LeadershipReporter, payouts PayoutBacklog, logger *zap.Logger) *Handler {
 	return &Handler{
-		db:     db,
-		logger: logger.Named("HealthHandler"),
+		db:         db,
+		freshness:  freshness,
+		maxSyncAge: maxSyncAge,
+		sources:    sources,
+		leadership: leadership,
+		payouts:    payouts,
+		logger:     logger.Named("HealthHandler"),
 	}
 }
 
@@ -48,7 +96,60 @@ func (h *Handler) Readyz(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if h.freshness != nil && h.maxSyncAge > 0 {
+		lastSync, err := h.freshness.LastSuccessfulSync(ctx)
+		if err != nil {
+			log.Error("Readiness probe failed: could not check last sync", zap.Error(err))
+			http.Error(w, "Service Unavailable: Could not check event sync", http.StatusServiceUnavailable)
+			return
+		}
+		if lastSync == nil {
+			log.Warn("Readiness probe failed: no successful event sync yet")
+			http.Error(w, "Service Unavailable: No successful event sync yet", http.StatusServiceUnavailable)
+			return
+		}
+		if age := time.Since(*lastSync); age > h.maxSyncAge {
+			log.Warn("Readiness probe failed: event data is stale", zap.Duration("age", age), zap.Duration("maxAge", h.maxSyncAge))
+			http.Error(w, fmt.Sprintf("Service Unavailable: Last successful event sync was %s ago", age.Truncate(time.Second)), http.StatusServiceUnavailable)
+			return
+		}
+	}
+
+	var leadership *data.LeaderStatus
+	if h.leadership != nil {
+		status := h.leadership.Status()
+		leadership = &status
+	}
+	sources := make([]data.SourceHealth, 0)
+	if h.sources != nil && (leadership == nil || leadership.Leader) {
+		sources = h.sources.SourceHealth()
+	}
+	open := make([]string, 0, len(sources))
+	for _, source := range sources {
+		if source.Breaker == string(breaker.StateOpen) {
+			open = append(open, source.Provider)
+		}
+	}
+	if len(sources) > 0 && len(open) == len(sources) {
+		log.Warn("Readiness probe failed: circuit breakers of all event sources are open", zap.Strings("providers", open))
+		http.Error(w, fmt.Sprintf("Service Unavailable: Event source circuit open for %s", strings.Join(open, ", ")), http.StatusServiceUnavailable)
+		return
+	}
+
+	stuckPayouts := 0
+	if h.payouts != nil {
+		stuckPayouts, err = h.payouts.CountStuckPayouts(ctx)
+		if err != nil {
+			log.Error("Readiness probe failed: could not count stuck payouts", zap.Error(err))
+			http.Error(w, "Service Unavailable: Could not check payouts", http.StatusServiceUnavailable)
+			return
+		}
+	}
+
 	log.Debug("Readyz probe successful")
+	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
-	// fmt.Fprintln(w, "OK")
+	if err := json.NewEncoder(w).Encode(ReadyResponse{Status: "ready", Leadership: leadership, Sources: sources, StuckPayouts: stuckPayouts}); err != nil {
+		log.Error("Failed to encode readiness response", zap.Error(err))
+	}
 }
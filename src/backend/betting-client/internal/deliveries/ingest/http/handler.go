@@ -0,0 +1,122 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/Arlan-Z/def-betting-api/internal/data"
+	"github.com/Arlan-Z/def-betting-api/internal/pkg/webhook"
+	syncsvc "github.com/Arlan-Z/def-betting-api/internal/services/sync"
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+// ProviderHeader names the provider that sent the events. It may be omitted when a
+// single provider has a webhook secret.
+const ProviderHeader = "X-Event-Provider"
+
+// MaxBodyBytes limits the size of a pushed payload.
+const MaxBodyBytes = 1 << 20
+
+type EventIngester interface {
+	Ingest(ctx context.Context, providerName string, batch data.EventBatch) (data.IngestResult, error)
+}
+
+type Handler struct {
+	ingester EventIngester
+	verifier *webhook.Verifier
+	logger   *zap.Logger
+}
+
+func NewHandler(ingester EventIngester, verifier *webhook.Verifier, logger *zap.Logger) *Handler {
+	return &Handler{
+		ingester: ingester,
+		verifier: verifier,
+		logger:   logger.Named("IngestHandler"),
+	}
+}
+
+func (h *Handler) RegisterRoutes(r chi.Router) {
+	r.Post("/ingest/events", h.IngestEvents)
+}
+
+func (h *Handler) IngestEvents(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := h.logger.With(zap.String("operation", "IngestEvents"))
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, MaxBodyBytes))
+	if err != nil {
+		log.Warn("Error reading request body", zap.Error(err))
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			http.Error(w, "Request body too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	defer r.Body.Close()
+
+	provider := r.Header.Get(ProviderHeader)
+	if senders := h.verifier.Senders(); provider == "" && len(senders) == 1 {
+		provider = senders[0]
+	}
+	log = log.With(zap.String("provider", provider))
+
+	signature := r.Header.Get(webhook.SignatureHeader)
+	err = h.verifier.Verify(provider, r.Header.Get(webhook.TimestampHeader), signature, body, time.Now())
+	if err != nil {
+		log.Warn("Rejected webhook request", zap.Error(err))
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	events, err := decodeEvents(body)
+	if err != nil {
+		log.Warn("Error decoding pushed events", zap.Error(err))
+		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.ingester.Ingest(ctx, provider, data.EventBatch{Events: events, Checksum: data.FeedChecksum(body)})
+	if err != nil {
+		// The events were not processed, so the sender's retry must not count as a replay.
+		h.verifier.Forget(provider, signature)
+		if errors.Is(err, syncsvc.ErrNotRunning) {
+			http.Error(w, "Events are processed by the leader replica", http.StatusServiceUnavailable)
+			return
+		}
+		log.Error("Error ingesting pushed events", zap.Error(err))
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		log.Error("Error encoding JSON response", zap.Error(err))
+	}
+}
+
+// decodeEvents accepts a single event object or an array of them.
+func decodeEvents(body []byte) ([]data.ExternalEventDTO, error) {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		var events []data.ExternalEventDTO
+		if err := json.Unmarshal(trimmed, &events); err != nil {
+			return nil, err
+		}
+		return events, nil
+	}
+
+	var event data.ExternalEventDTO
+	if err := json.Unmarshal(trimmed, &event); err != nil {
+		return nil, err
+	}
+	return []data.ExternalEventDTO{event}, nil
+}
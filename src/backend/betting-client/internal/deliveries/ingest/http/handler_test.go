@@ -0,0 +1,165 @@
+package http_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/Arlan-Z/def-betting-api/internal/data"
+	ingesthandler "github.com/Arlan-Z/def-betting-api/internal/deliveries/ingest/http"
+	"github.com/Arlan-Z/def-betting-api/internal/pkg/webhook"
+	syncsvc "github.com/Arlan-Z/def-betting-api/internal/services/sync"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type mockIngester struct {
+	mock.Mock
+}
+
+func (m *mockIngester) Ingest(ctx context.Context, providerName string, batch data.EventBatch) (data.IngestResult, error) {
+	args := m.Called(ctx, providerName, batch)
+	return args.Get(0).(data.IngestResult), args.Error(1)
+}
+
+func newRouter(ingester *mockIngester, secrets map[string]string) *chi.Mux {
+	handler := ingesthandler.NewHandler(ingester, webhook.NewVerifier(secrets, 5*time.Minute), zap.NewNop())
+	r := chi.NewRouter()
+	handler.RegisterRoutes(r)
+	return r
+}
+
+func signedRequest(secret string, provider string, body []byte) *http.Request {
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req := httptest.NewRequest(http.MethodPost, "/ingest/events", bytes.NewReader(body))
+	req.Header.Set(webhook.TimestampHeader, timestamp)
+	req.Header.Set(webhook.SignatureHeader, webhook.Sign(secret, timestamp, body))
+	if provider != "" {
+		req.Header.Set(ingesthandler.ProviderHeader, provider)
+	}
+	return req
+}
+
+func TestIngestHandler_IngestEvents_Batch(t *testing.T) {
+	ingester := &mockIngester{}
+	router := newRouter(ingester, map[string]string{"primary": "s3cret", "backup": "other"})
+
+	body := []byte(`[{"id":"e1","eventName":"Kairat vs Astana"},{"id":"e2","eventName":"Ordabasy vs Tobol"}]`)
+	ingester.On("Ingest", mock.Anything, "primary", mock.MatchedBy(func(batch data.EventBatch) bool {
+		return len(batch.Events) == 2 && batch.Events[0].APIEventID == "e1" && batch.Events[1].APIEventID == "e2" &&
+			batch.Checksum == data.FeedChecksum(body)
+	})).Return(data.IngestResult{Received: 2, Upserted: 2}, nil).Once()
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, signedRequest("s3cret", "primary", body))
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	var result data.IngestResult
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &result))
+	assert.Equal(t, data.IngestResult{Received: 2, Upserted: 2}, result)
+	ingester.AssertExpectations(t)
+}
+
+func TestIngestHandler_IngestEvents_SingleEventFromOnlyProvider(t *testing.T) {
+	ingester := &mockIngester{}
+	router := newRouter(ingester, map[string]string{"default": "s3cret"})
+
+	body := []byte(`{"id":"e1","eventName":"Kairat vs Astana"}`)
+	ingester.On("Ingest", mock.Anything, "default", mock.MatchedBy(func(batch data.EventBatch) bool {
+		return len(batch.Events) == 1 && batch.Events[0].APIEventID == "e1"
+	})).Return(data.IngestResult{Received: 1, Upserted: 1}, nil).Once()
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, signedRequest("s3cret", "", body))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	ingester.AssertExpectations(t)
+}
+
+func TestIngestHandler_IngestEvents_NotLeader(t *testing.T) {
+	ingester := &mockIngester{}
+	router := newRouter(ingester, map[string]string{"default": "s3cret"})
+
+	body := []byte(`{"id":"e1","eventName":"Kairat vs Astana"}`)
+	ingester.On("Ingest", mock.Anything, "default", mock.Anything).Return(data.IngestResult{}, syncsvc.ErrNotRunning).Once()
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, signedRequest("s3cret", "", body))
+
+	assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+	ingester.AssertExpectations(t)
+}
+
+func TestIngestHandler_IngestEvents_AcceptsRetryAfterFailedIngest(t *testing.T) {
+	ingester := &mockIngester{}
+	router := newRouter(ingester, map[string]string{"default": "s3cret"})
+	body := []byte(`{"id":"e1","eventName":"Kairat vs Astana"}`)
+
+	ingester.On("Ingest", mock.Anything, "default", mock.Anything).Return(data.IngestResult{}, errors.New("database is locked")).Once()
+	req := signedRequest("s3cret", "", body)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+
+	ingester.On("Ingest", mock.Anything, "default", mock.Anything).Return(data.IngestResult{}, syncsvc.ErrNotRunning).Once()
+	retry := httptest.NewRequest(http.MethodPost, "/ingest/events", bytes.NewReader(body))
+	retry.Header = req.Header.Clone()
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, retry)
+	assert.Equal(t, http.StatusServiceUnavailable, rr.Code, "a retry with the same signature is not a replay")
+
+	ingester.On("Ingest", mock.Anything, "default", mock.Anything).Return(data.IngestResult{Received: 1, Upserted: 1}, nil).Once()
+	retry = httptest.NewRequest(http.MethodPost, "/ingest/events", bytes.NewReader(body))
+	retry.Header = req.Header.Clone()
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, retry)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	ingester.AssertExpectations(t)
+}
+
+func TestIngestHandler_IngestEvents_RejectsInvalidSignatureAndReplay(t *testing.T) {
+	ingester := &mockIngester{}
+	router := newRouter(ingester, map[string]string{"primary": "s3cret", "backup": "other"})
+	body := []byte(`{"id":"e1"}`)
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, signedRequest("wrong", "primary", body))
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, signedRequest("s3cret", "", body))
+	assert.Equal(t, http.StatusUnauthorized, rr.Code, "provider is required when several have secrets")
+
+	ingester.On("Ingest", mock.Anything, "primary", mock.Anything).Return(data.IngestResult{Received: 1}, nil).Once()
+	req := signedRequest("s3cret", "primary", body)
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	replay := httptest.NewRequest(http.MethodPost, "/ingest/events", bytes.NewReader(body))
+	replay.Header = req.Header.Clone()
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, replay)
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	ingester.AssertExpectations(t)
+}
+
+func TestIngestHandler_IngestEvents_InvalidBody(t *testing.T) {
+	ingester := &mockIngester{}
+	router := newRouter(ingester, map[string]string{"primary": "s3cret"})
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, signedRequest("s3cret", "primary", []byte(`{"id":`)))
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	ingester.AssertNotCalled(t, "Ingest", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -1,7 +1,6 @@
 package http
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -13,6 +12,10 @@ import (
 	"go.uber.org/zap"
 )
 
+// IdempotencyKeyHeader carries the notification's idempotency key, so the payout service
+// can drop repeated deliveries of a payout.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
 type PayoutClient interface {
 	NotifyPayout(ctx context.Context, notification data.PayoutNotification) error
 }
@@ -77,8 +80,11 @@ func (c *RestyPayoutClient) NotifyPayout(ctx context.Context, notification data.
 		c.logger.Error("Failed to marshal payout notification", zap.Error(err))
 		return fmt.Errorf("failed to marshal request body: %w", err)
 	}
-	req.SetBody(bytes.NewReader(bodyBytes))
+	// A byte slice, unlike a reader, is sent again in full when resty retries.
+	req.SetBody(bodyBytes)
 	req.SetHeader("Content-Type", "application/json")
+	// Retries of this request and later deliveries of the same payout carry the same key.
+	req.SetHeader(IdempotencyKeyHeader, notification.IdempotencyKey)
 
 	resp, err := req.Post(endpoint)
 
@@ -92,7 +98,10 @@ func (c *RestyPayoutClient) NotifyPayout(ctx context.Context, notification data.
 
 	c.logger.Info("Successfully notified payout service",
 		zap.String("userId", notification.UserID),
-		zap.Float64("amount", notification.Amount))
+		zap.String("betId", notification.BetID),
+		zap.String("reason", string(notification.Reason)),
+		zap.Float64("amount", notification.Amount),
+		zap.String("idempotencyKey", notification.IdempotencyKey))
 
 	return nil
 }
@@ -0,0 +1,55 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Arlan-Z/def-betting-api/internal/data"
+	payoutclient "github.com/Arlan-Z/def-betting-api/internal/deliveries/payout/http"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestNotifyPayout_RetriesWithTheSameIdempotencyKey(t *testing.T) {
+	notification := data.PayoutNotification{
+		SchemaVersion:  data.PayoutNotificationSchemaVersion,
+		IdempotencyKey: "bet:bet-1:win:payout-1",
+		UserID:         "user-1",
+		Amount:         25.5,
+		Currency:       "USD",
+		Reason:         data.PayoutWin,
+		BetID:          "bet-1",
+		EventID:        "event-1",
+		Outcome:        data.HomeWin,
+		Odds:           2.55,
+		Stake:          10,
+	}
+
+	var keys []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/payouts", r.URL.Path)
+		keys = append(keys, r.Header.Get(payoutclient.IdempotencyKeyHeader))
+
+		var received data.PayoutNotification
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		assert.Equal(t, notification, received)
+
+		if len(keys) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	client := payoutclient.NewRestyPayoutClient(server.URL, time.Second, zap.NewNop())
+	err := client.NotifyPayout(context.Background(), notification)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"bet:bet-1:win:payout-1", "bet:bet-1:win:payout-1"}, keys)
+}
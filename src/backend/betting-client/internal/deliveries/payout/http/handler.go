@@ -0,0 +1,137 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/Arlan-Z/def-betting-api/internal/data"
+	customvalidator "github.com/Arlan-Z/def-betting-api/internal/pkg/validator"
+	"github.com/Arlan-Z/def-betting-api/internal/usecases/payout"
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+type PayoutUseCase interface {
+	GetStuckPayouts(ctx context.Context) ([]data.PayoutIntent, error)
+	GetPayout(ctx context.Context, payoutID string) (*data.PayoutIntent, []data.PayoutAttempt, error)
+	Requeue(ctx context.Context, payoutID string) (*data.PayoutIntent, error)
+	Resolve(ctx context.Context, payoutID string, note string) (*data.PayoutIntent, error)
+}
+
+type Handler struct {
+	useCase PayoutUseCase
+	logger  *zap.Logger
+}
+
+func NewHandler(uc PayoutUseCase, logger *zap.Logger) *Handler {
+	return &Handler{
+		useCase: uc,
+		logger:  logger.Named("PayoutHandler"),
+	}
+}
+
+func (h *Handler) RegisterRoutes(r chi.Router) {
+	r.Get("/admin/payouts/stuck", h.GetStuckPayouts)
+	r.Get("/admin/payouts/{payoutID}", h.GetPayout)
+	r.Post("/admin/payouts/{payoutID}/requeue", h.Requeue)
+	r.Post("/admin/payouts/{payoutID}/resolve", h.Resolve)
+}
+
+func (h *Handler) GetStuckPayouts(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := h.logger.With(zap.String("operation", "GetStuckPayouts"))
+
+	payouts, err := h.useCase.GetStuckPayouts(ctx)
+	if err != nil {
+		log.Error("Error getting stuck payouts from UseCase", zap.Error(err))
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	h.writeJSON(w, log, data.MapPayoutsToDTOs(payouts))
+}
+
+func (h *Handler) GetPayout(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	payoutID := chi.URLParam(r, "payoutID")
+	log := h.logger.With(zap.String("operation", "GetPayout"), zap.String("payoutId", payoutID))
+
+	p, attempts, err := h.useCase.GetPayout(ctx, payoutID)
+	if err != nil {
+		if errors.Is(err, payout.ErrPayoutNotFound) {
+			http.Error(w, "Payout not found", http.StatusNotFound)
+			return
+		}
+		log.Error("Error getting payout from UseCase", zap.Error(err))
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	h.writeJSON(w, log, data.MapPayoutToDTO(*p, attempts))
+}
+
+func (h *Handler) Requeue(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	payoutID := chi.URLParam(r, "payoutID")
+	log := h.logger.With(zap.String("operation", "Requeue"), zap.String("payoutId", payoutID))
+	log.Info("Received request to requeue payout")
+
+	p, err := h.useCase.Requeue(ctx, payoutID)
+	if err != nil {
+		h.writeResolveError(w, log, err)
+		return
+	}
+
+	h.writeJSON(w, log, data.MapPayoutToDTO(*p, nil))
+}
+
+func (h *Handler) Resolve(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	payoutID := chi.URLParam(r, "payoutID")
+	log := h.logger.With(zap.String("operation", "Resolve"), zap.String("payoutId", payoutID))
+	log.Info("Received request to resolve payout")
+
+	var requestDTO data.ResolvePayoutRequest
+	if err := json.NewDecoder(r.Body).Decode(&requestDTO); err != nil {
+		log.Warn("Error decoding request body", zap.Error(err))
+		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	defer r.Body.Close()
+
+	if err := customvalidator.ValidateStruct(requestDTO); err != nil {
+		log.Warn("Error validating request body", zap.Error(err))
+		http.Error(w, "Validation error: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	p, err := h.useCase.Resolve(ctx, payoutID, requestDTO.Note)
+	if err != nil {
+		h.writeResolveError(w, log, err)
+		return
+	}
+
+	h.writeJSON(w, log, data.MapPayoutToDTO(*p, nil))
+}
+
+func (h *Handler) writeResolveError(w http.ResponseWriter, log *zap.Logger, err error) {
+	switch {
+	case errors.Is(err, payout.ErrPayoutNotFound):
+		http.Error(w, "Payout not found", http.StatusNotFound)
+	case errors.Is(err, payout.ErrPayoutNotFailed):
+		http.Error(w, "Payout has not failed", http.StatusConflict)
+	default:
+		log.Error("Error resolving payout in UseCase", zap.Error(err))
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
+
+func (h *Handler) writeJSON(w http.ResponseWriter, log *zap.Logger, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		log.Error("Error encoding JSON response", zap.Error(err))
+	}
+}
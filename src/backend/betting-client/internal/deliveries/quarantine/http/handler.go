@@ -0,0 +1,168 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/Arlan-Z/def-betting-api/internal/data"
+	syncsvc "github.com/Arlan-Z/def-betting-api/internal/services/sync"
+	"github.com/Arlan-Z/def-betting-api/internal/usecases/quarantine"
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+type QuarantineUseCase interface {
+	GetEntries(ctx context.Context, status data.QuarantineStatus) ([]data.QuarantinedEvent, error)
+	GetEntry(ctx context.Context, entryID string) (*data.QuarantinedEvent, error)
+	GetStats(ctx context.Context) ([]data.QuarantineStat, error)
+	Replay(ctx context.Context, entryID string, payload json.RawMessage, note string) (*data.QuarantinedEvent, error)
+	Dismiss(ctx context.Context, entryID string, note string) (*data.QuarantinedEvent, error)
+}
+
+type Handler struct {
+	useCase QuarantineUseCase
+	logger  *zap.Logger
+}
+
+func NewHandler(uc QuarantineUseCase, logger *zap.Logger) *Handler {
+	return &Handler{
+		useCase: uc,
+		logger:  logger.Named("QuarantineHandler"),
+	}
+}
+
+func (h *Handler) RegisterRoutes(r chi.Router) {
+	r.Get("/admin/quarantine", h.GetEntries)
+	r.Get("/admin/quarantine/stats", h.GetStats)
+	r.Get("/admin/quarantine/{entryID}", h.GetEntry)
+	r.Post("/admin/quarantine/{entryID}/replay", h.Replay)
+	r.Post("/admin/quarantine/{entryID}/dismiss", h.Dismiss)
+}
+
+func (h *Handler) GetEntries(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	status := data.QuarantineStatus(r.URL.Query().Get("status"))
+	log := h.logger.With(zap.String("operation", "GetEntries"), zap.String("status", string(status)))
+
+	entries, err := h.useCase.GetEntries(ctx, status)
+	if err != nil {
+		if errors.Is(err, quarantine.ErrInvalidStatus) {
+			http.Error(w, "Invalid status", http.StatusBadRequest)
+			return
+		}
+		log.Error("Error getting quarantined events from UseCase", zap.Error(err))
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	h.writeJSON(w, log, data.MapQuarantinedEventsToDTOs(entries))
+}
+
+func (h *Handler) GetStats(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := h.logger.With(zap.String("operation", "GetStats"))
+
+	stats, err := h.useCase.GetStats(ctx)
+	if err != nil {
+		log.Error("Error counting quarantined events in UseCase", zap.Error(err))
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	h.writeJSON(w, log, stats)
+}
+
+func (h *Handler) GetEntry(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	entryID := chi.URLParam(r, "entryID")
+	log := h.logger.With(zap.String("operation", "GetEntry"), zap.String("entryId", entryID))
+
+	entry, err := h.useCase.GetEntry(ctx, entryID)
+	if err != nil {
+		if errors.Is(err, quarantine.ErrEntryNotFound) {
+			http.Error(w, "Quarantined event not found", http.StatusNotFound)
+			return
+		}
+		log.Error("Error getting quarantined event from UseCase", zap.Error(err))
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	h.writeJSON(w, log, data.MapQuarantinedEventToDTO(*entry))
+}
+
+func (h *Handler) Replay(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	entryID := chi.URLParam(r, "entryID")
+	log := h.logger.With(zap.String("operation", "Replay"), zap.String("entryId", entryID))
+	log.Info("Received request to replay quarantined event")
+
+	// The body is optional; without one the stored payload is replayed.
+	var requestDTO data.ReplayQuarantinedRequest
+	if err := json.NewDecoder(r.Body).Decode(&requestDTO); err != nil && !errors.Is(err, io.EOF) {
+		log.Warn("Error decoding request body", zap.Error(err))
+		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	defer r.Body.Close()
+
+	entry, err := h.useCase.Replay(ctx, entryID, requestDTO.Payload, requestDTO.Note)
+	if err != nil {
+		h.writeResolveError(w, log, err)
+		return
+	}
+
+	h.writeJSON(w, log, data.MapQuarantinedEventToDTO(*entry))
+}
+
+func (h *Handler) Dismiss(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	entryID := chi.URLParam(r, "entryID")
+	log := h.logger.With(zap.String("operation", "Dismiss"), zap.String("entryId", entryID))
+	log.Info("Received request to dismiss quarantined event")
+
+	var requestDTO data.DismissQuarantinedRequest
+	if err := json.NewDecoder(r.Body).Decode(&requestDTO); err != nil && !errors.Is(err, io.EOF) {
+		log.Warn("Error decoding request body", zap.Error(err))
+		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	defer r.Body.Close()
+
+	entry, err := h.useCase.Dismiss(ctx, entryID, requestDTO.Note)
+	if err != nil {
+		h.writeResolveError(w, log, err)
+		return
+	}
+
+	h.writeJSON(w, log, data.MapQuarantinedEventToDTO(*entry))
+}
+
+func (h *Handler) writeResolveError(w http.ResponseWriter, log *zap.Logger, err error) {
+	switch {
+	case errors.Is(err, quarantine.ErrEntryNotFound):
+		http.Error(w, "Quarantined event not found", http.StatusNotFound)
+	case errors.Is(err, quarantine.ErrEntryAlreadyResolved):
+		http.Error(w, "Quarantined event already resolved", http.StatusConflict)
+	case errors.Is(err, quarantine.ErrInvalidPayload):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, syncsvc.ErrNotRunning):
+		http.Error(w, "Events are replayed by the leader replica", http.StatusServiceUnavailable)
+	case errors.Is(err, quarantine.ErrReplayFailed):
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+	default:
+		log.Error("Error resolving quarantined event in UseCase", zap.Error(err))
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
+
+func (h *Handler) writeJSON(w http.ResponseWriter, log *zap.Logger, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		log.Error("Error encoding JSON response", zap.Error(err))
+	}
+}
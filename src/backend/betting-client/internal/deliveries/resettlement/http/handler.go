@@ -0,0 +1,125 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/Arlan-Z/def-betting-api/internal/data"
+	customvalidator "github.com/Arlan-Z/def-betting-api/internal/pkg/validator"
+	"github.com/Arlan-Z/def-betting-api/internal/usecases/resettlement"
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+type ResettlementUseCase interface {
+	Resettle(ctx context.Context, eventID string, result data.Outcome, triggeredBy string, reason string) (*data.Resettlement, error)
+	GetResettlements(ctx context.Context, eventID string) ([]data.Resettlement, error)
+	GetResettlement(ctx context.Context, resettlementID string) (*data.Resettlement, error)
+}
+
+type Handler struct {
+	useCase ResettlementUseCase
+	logger  *zap.Logger
+}
+
+func NewHandler(uc ResettlementUseCase, logger *zap.Logger) *Handler {
+	return &Handler{
+		useCase: uc,
+		logger:  logger.Named("ResettlementHandler"),
+	}
+}
+
+func (h *Handler) RegisterRoutes(r chi.Router) {
+	r.Post("/admin/events/{eventID}/resettle", h.Resettle)
+	r.Get("/admin/events/{eventID}/resettlements", h.GetResettlements)
+	r.Get("/admin/resettlements/{resettlementID}", h.GetResettlement)
+}
+
+func (h *Handler) Resettle(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	eventID := chi.URLParam(r, "eventID")
+	log := h.logger.With(zap.String("operation", "Resettle"), zap.String("eventId", eventID))
+	log.Info("Received request to resettle event")
+
+	var requestDTO data.ResettleEventRequest
+	if err := json.NewDecoder(r.Body).Decode(&requestDTO); err != nil {
+		log.Warn("Error decoding request body", zap.Error(err))
+		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	defer r.Body.Close()
+
+	if err := customvalidator.ValidateStruct(requestDTO); err != nil {
+		log.Warn("Error validating request body", zap.Error(err))
+		http.Error(w, "Validation error: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	resettled, err := h.useCase.Resettle(ctx, eventID, data.Outcome(requestDTO.Result), data.ResettledByAdmin, requestDTO.Reason)
+	if err != nil {
+		log.Error("Error resettling event in UseCase", zap.Error(err))
+		switch {
+		case errors.Is(err, resettlement.ErrEventNotFound):
+			http.Error(w, "Event not found", http.StatusNotFound)
+		case errors.Is(err, resettlement.ErrEventNotSettled):
+			http.Error(w, "Event has no result to correct", http.StatusConflict)
+		case errors.Is(err, resettlement.ErrSameResult):
+			http.Error(w, "Event already has this result", http.StatusConflict)
+		case errors.Is(err, resettlement.ErrInvalidResult):
+			http.Error(w, "Invalid result", http.StatusBadRequest)
+		default:
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(data.MapResettlementToDTO(*resettled)); err != nil {
+		log.Error("Error encoding JSON response", zap.Error(err))
+	}
+}
+
+func (h *Handler) GetResettlements(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	eventID := chi.URLParam(r, "eventID")
+	log := h.logger.With(zap.String("operation", "GetResettlements"), zap.String("eventId", eventID))
+
+	resettlements, err := h.useCase.GetResettlements(ctx, eventID)
+	if err != nil {
+		log.Error("Error getting resettlements from UseCase", zap.Error(err))
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(data.MapResettlementsToDTOs(resettlements)); err != nil {
+		log.Error("Error encoding JSON response", zap.Error(err))
+	}
+}
+
+func (h *Handler) GetResettlement(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	resettlementID := chi.URLParam(r, "resettlementID")
+	log := h.logger.With(zap.String("operation", "GetResettlement"), zap.String("resettlementId", resettlementID))
+
+	found, err := h.useCase.GetResettlement(ctx, resettlementID)
+	if err != nil {
+		if errors.Is(err, resettlement.ErrResettlementNotFound) {
+			http.Error(w, "Resettlement not found", http.StatusNotFound)
+			return
+		}
+		log.Error("Error getting resettlement from UseCase", zap.Error(err))
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(data.MapResettlementToDTO(*found)); err != nil {
+		log.Error("Error encoding JSON response", zap.Error(err))
+	}
+}
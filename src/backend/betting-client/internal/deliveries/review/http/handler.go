@@ -0,0 +1,99 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/Arlan-Z/def-betting-api/internal/data"
+	customvalidator "github.com/Arlan-Z/def-betting-api/internal/pkg/validator"
+	"github.com/Arlan-Z/def-betting-api/internal/usecases/review"
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+type ReviewUseCase interface {
+	GetPendingReviews(ctx context.Context) ([]data.BetReview, error)
+	Resolve(ctx context.Context, reviewID string, action string, note string) (*data.BetReview, error)
+}
+
+type Handler struct {
+	useCase ReviewUseCase
+	logger  *zap.Logger
+}
+
+func NewHandler(uc ReviewUseCase, logger *zap.Logger) *Handler {
+	return &Handler{
+		useCase: uc,
+		logger:  logger.Named("ReviewHandler"),
+	}
+}
+
+func (h *Handler) RegisterRoutes(r chi.Router) {
+	r.Get("/admin/bet-reviews", h.GetPendingReviews)
+	r.Post("/admin/bet-reviews/{reviewID}/resolve", h.Resolve)
+}
+
+func (h *Handler) GetPendingReviews(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := h.logger.With(zap.String("operation", "GetPendingReviews"))
+
+	reviews, err := h.useCase.GetPendingReviews(ctx)
+	if err != nil {
+		log.Error("Error getting pending reviews from UseCase", zap.Error(err))
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	dtos := data.MapBetReviewsToDTOs(reviews)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(dtos); err != nil {
+		log.Error("Error encoding JSON response", zap.Error(err))
+	}
+}
+
+func (h *Handler) Resolve(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	reviewID := chi.URLParam(r, "reviewID")
+	log := h.logger.With(zap.String("operation", "Resolve"), zap.String("reviewId", reviewID))
+	log.Info("Received request to resolve bet review")
+
+	var requestDTO data.ResolveReviewRequest
+	if err := json.NewDecoder(r.Body).Decode(&requestDTO); err != nil {
+		log.Warn("Error decoding request body", zap.Error(err))
+		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	defer r.Body.Close()
+
+	if err := customvalidator.ValidateStruct(requestDTO); err != nil {
+		log.Warn("Error validating request body", zap.Error(err))
+		http.Error(w, "Validation error: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	resolved, err := h.useCase.Resolve(ctx, reviewID, requestDTO.Action, requestDTO.Note)
+	if err != nil {
+		log.Error("Error resolving bet review in UseCase", zap.Error(err))
+		switch {
+		case errors.Is(err, review.ErrReviewNotFound):
+			http.Error(w, "Bet review not found", http.StatusNotFound)
+		case errors.Is(err, review.ErrReviewAlreadyResolved):
+			http.Error(w, "Bet review already resolved", http.StatusConflict)
+		case errors.Is(err, review.ErrInvalidReviewAction):
+			http.Error(w, "Invalid review action", http.StatusBadRequest)
+		default:
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(data.MapBetReviewToDTO(*resolved)); err != nil {
+		log.Error("Error encoding JSON response", zap.Error(err))
+	}
+}
@@ -0,0 +1,174 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/Arlan-Z/def-betting-api/internal/data"
+	syncsvc "github.com/Arlan-Z/def-betting-api/internal/services/sync"
+	"github.com/Arlan-Z/def-betting-api/internal/usecases/syncrun"
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+type SyncRunUseCase interface {
+	GetRuns(ctx context.Context, provider string, limit int) ([]data.SyncRun, error)
+	GetRun(ctx context.Context, runID string) (*data.SyncRun, error)
+	GetMissingEvents(ctx context.Context) ([]data.MissingEvent, error)
+}
+
+type SyncTrigger interface {
+	Trigger(providerName string) ([]data.SyncTriggerResult, error)
+	RefreshEvent(ctx context.Context, eventID string) ([]data.SyncRun, error)
+}
+
+type Handler struct {
+	useCase SyncRunUseCase
+	trigger SyncTrigger
+	logger  *zap.Logger
+}
+
+func NewHandler(uc SyncRunUseCase, trigger SyncTrigger, logger *zap.Logger) *Handler {
+	return &Handler{
+		useCase: uc,
+		trigger: trigger,
+		logger:  logger.Named("SyncRunHandler"),
+	}
+}
+
+func (h *Handler) RegisterRoutes(r chi.Router) {
+	r.Post("/admin/sync", h.TriggerSync)
+	r.Post("/admin/sync/events/{eventID}", h.RefreshEvent)
+	r.Get("/admin/sync/runs", h.GetRuns)
+	r.Get("/admin/sync/runs/{runID}", h.GetRun)
+	r.Get("/admin/sync/missing-events", h.GetMissingEvents)
+}
+
+func (h *Handler) TriggerSync(w http.ResponseWriter, r *http.Request) {
+	provider := r.URL.Query().Get("provider")
+	log := h.logger.With(zap.String("operation", "TriggerSync"), zap.String("provider", provider))
+
+	results, err := h.trigger.Trigger(provider)
+	if err != nil {
+		if errors.Is(err, syncsvc.ErrUnknownProvider) {
+			http.Error(w, "Provider not found", http.StatusNotFound)
+			return
+		}
+		if errors.Is(err, syncsvc.ErrNotRunning) {
+			http.Error(w, "Event sync runs on the leader replica", http.StatusServiceUnavailable)
+			return
+		}
+		log.Error("Error triggering event sync", zap.Error(err))
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	if err := json.NewEncoder(w).Encode(results); err != nil {
+		log.Error("Error encoding JSON response", zap.Error(err))
+	}
+}
+
+func (h *Handler) RefreshEvent(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	eventID := chi.URLParam(r, "eventID")
+	log := h.logger.With(zap.String("operation", "RefreshEvent"), zap.String("eventId", eventID))
+
+	runs, err := h.trigger.RefreshEvent(ctx, eventID)
+	if err != nil {
+		if errors.Is(err, syncsvc.ErrEventNotMapped) {
+			http.Error(w, "Event not found at any provider", http.StatusNotFound)
+			return
+		}
+		if errors.Is(err, syncsvc.ErrNotRunning) {
+			http.Error(w, "Event sync runs on the leader replica", http.StatusServiceUnavailable)
+			return
+		}
+		log.Error("Error refreshing event", zap.Error(err))
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(data.MapSyncRunsToDTOs(runs)); err != nil {
+		log.Error("Error encoding JSON response", zap.Error(err))
+	}
+}
+
+func (h *Handler) GetRuns(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	provider := r.URL.Query().Get("provider")
+	log := h.logger.With(zap.String("operation", "GetRuns"), zap.String("provider", provider))
+
+	limit := 0
+	if rawLimit := r.URL.Query().Get("limit"); rawLimit != "" {
+		parsed, err := strconv.Atoi(rawLimit)
+		if err != nil || parsed < 1 {
+			log.Warn("Invalid sync runs limit", zap.String("limit", rawLimit))
+			http.Error(w, "Invalid limit", http.StatusBadRequest)
+			return
+		}
+		limit = parsed
+	}
+
+	runs, err := h.useCase.GetRuns(ctx, provider, limit)
+	if err != nil {
+		log.Error("Error getting sync runs from UseCase", zap.Error(err))
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	dtos := data.MapSyncRunsToDTOs(runs)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(dtos); err != nil {
+		log.Error("Error encoding JSON response", zap.Error(err))
+	}
+}
+
+func (h *Handler) GetRun(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	runID := chi.URLParam(r, "runID")
+	log := h.logger.With(zap.String("operation", "GetRun"), zap.String("runId", runID))
+
+	run, err := h.useCase.GetRun(ctx, runID)
+	if err != nil {
+		if errors.Is(err, syncrun.ErrRunNotFound) {
+			http.Error(w, "Sync run not found", http.StatusNotFound)
+			return
+		}
+		log.Error("Error getting sync run from UseCase", zap.Error(err))
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(data.MapSyncRunToDTO(*run)); err != nil {
+		log.Error("Error encoding JSON response", zap.Error(err))
+	}
+}
+
+func (h *Handler) GetMissingEvents(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := h.logger.With(zap.String("operation", "GetMissingEvents"))
+
+	events, err := h.useCase.GetMissingEvents(ctx)
+	if err != nil {
+		log.Error("Error getting missing events from UseCase", zap.Error(err))
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(data.MapMissingEventsToDTOs(events)); err != nil {
+		log.Error("Error encoding JSON response", zap.Error(err))
+	}
+}
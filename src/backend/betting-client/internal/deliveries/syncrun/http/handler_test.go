@@ -0,0 +1,153 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Arlan-Z/def-betting-api/internal/data"
+	syncrunhandler "github.com/Arlan-Z/def-betting-api/internal/deliveries/syncrun/http"
+	syncsvc "github.com/Arlan-Z/def-betting-api/internal/services/sync"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type mockSyncRunUseCase struct {
+	mock.Mock
+}
+
+func (m *mockSyncRunUseCase) GetRuns(ctx context.Context, provider string, limit int) ([]data.SyncRun, error) {
+	args := m.Called(ctx, provider, limit)
+	return args.Get(0).([]data.SyncRun), args.Error(1)
+}
+
+func (m *mockSyncRunUseCase) GetMissingEvents(ctx context.Context) ([]data.MissingEvent, error) {
+	args := m.Called(ctx)
+	events, _ := args.Get(0).([]data.MissingEvent)
+	return events, args.Error(1)
+}
+
+func (m *mockSyncRunUseCase) GetRun(ctx context.Context, runID string) (*data.SyncRun, error) {
+	args := m.Called(ctx, runID)
+	run, _ := args.Get(0).(*data.SyncRun)
+	return run, args.Error(1)
+}
+
+type mockSyncTrigger struct {
+	mock.Mock
+}
+
+func (m *mockSyncTrigger) Trigger(providerName string) ([]data.SyncTriggerResult, error) {
+	args := m.Called(providerName)
+	results, _ := args.Get(0).([]data.SyncTriggerResult)
+	return results, args.Error(1)
+}
+
+func (m *mockSyncTrigger) RefreshEvent(ctx context.Context, eventID string) ([]data.SyncRun, error) {
+	args := m.Called(ctx, eventID)
+	runs, _ := args.Get(0).([]data.SyncRun)
+	return runs, args.Error(1)
+}
+
+func newRouter(useCase *mockSyncRunUseCase, trigger *mockSyncTrigger) *chi.Mux {
+	handler := syncrunhandler.NewHandler(useCase, trigger, zap.NewNop())
+	r := chi.NewRouter()
+	handler.RegisterRoutes(r)
+	return r
+}
+
+func TestSyncRunHandler_TriggerSync(t *testing.T) {
+	trigger := &mockSyncTrigger{}
+	router := newRouter(&mockSyncRunUseCase{}, trigger)
+
+	expected := []data.SyncTriggerResult{{Provider: "primary"}, {Provider: "backup", Coalesced: true}}
+	trigger.On("Trigger", "").Return(expected, nil).Once()
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/admin/sync", nil))
+
+	require.Equal(t, http.StatusAccepted, rr.Code)
+	var results []data.SyncTriggerResult
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &results))
+	assert.Equal(t, expected, results)
+	trigger.AssertExpectations(t)
+}
+
+func TestSyncRunHandler_TriggerSync_UnknownProvider(t *testing.T) {
+	trigger := &mockSyncTrigger{}
+	router := newRouter(&mockSyncRunUseCase{}, trigger)
+
+	trigger.On("Trigger", "nope").Return(nil, syncsvc.ErrUnknownProvider).Once()
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/admin/sync?provider=nope", nil))
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	trigger.AssertExpectations(t)
+}
+
+func TestSyncRunHandler_RefreshEvent(t *testing.T) {
+	trigger := &mockSyncTrigger{}
+	router := newRouter(&mockSyncRunUseCase{}, trigger)
+
+	startedAt := time.Date(2030, 6, 1, 12, 0, 0, 0, time.UTC)
+	runs := []data.SyncRun{{ID: "run-1", Provider: "primary", Kind: data.SyncRunRefresh, Status: data.SyncRunSucceeded, StartedAt: startedAt, Received: 1, Upserted: 1}}
+	trigger.On("RefreshEvent", mock.Anything, "event-1").Return(runs, nil).Once()
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/admin/sync/events/event-1", nil))
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	var dtos []data.SyncRunDTO
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &dtos))
+	require.Len(t, dtos, 1)
+	assert.Equal(t, "run-1", dtos[0].ID)
+	trigger.AssertExpectations(t)
+}
+
+func TestSyncRunHandler_RefreshEvent_NotMapped(t *testing.T) {
+	trigger := &mockSyncTrigger{}
+	router := newRouter(&mockSyncRunUseCase{}, trigger)
+
+	trigger.On("RefreshEvent", mock.Anything, "unknown").Return(nil, syncsvc.ErrEventNotMapped).Once()
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/admin/sync/events/unknown", nil))
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	trigger.AssertExpectations(t)
+}
+
+func TestSyncRunHandler_GetMissingEvents(t *testing.T) {
+	useCase := &mockSyncRunUseCase{}
+	router := newRouter(useCase, &mockSyncTrigger{})
+
+	lastSeen := time.Date(2030, 6, 1, 12, 0, 0, 0, time.UTC)
+	useCase.On("GetMissingEvents", mock.Anything).Return([]data.MissingEvent{{
+		EventID:      "event-1",
+		EventName:    "Kairat vs Astana",
+		Status:       data.EventStatusSuspended,
+		SuspendedAt:  &lastSeen,
+		LastSeenAt:   lastSeen,
+		MissedCycles: 3,
+		Providers:    []string{"primary"},
+	}}, nil).Once()
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/admin/sync/missing-events", nil))
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	var dtos []data.MissingEventDTO
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &dtos))
+	require.Len(t, dtos, 1)
+	assert.Equal(t, "event-1", dtos[0].EventID)
+	assert.Equal(t, data.EventStatusSuspended, dtos[0].Status)
+	assert.Equal(t, 3, dtos[0].MissedCycles)
+	useCase.AssertExpectations(t)
+}
@@ -0,0 +1,152 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/Arlan-Z/def-betting-api/internal/data"
+	customvalidator "github.com/Arlan-Z/def-betting-api/internal/pkg/validator"
+	"github.com/Arlan-Z/def-betting-api/internal/usecases/team"
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+type TeamUseCase interface {
+	GetCompetitions(ctx context.Context, sport string) ([]data.Competition, error)
+	GetTeams(ctx context.Context, sport string) ([]data.Team, error)
+	GetTeamFixtures(ctx context.Context, teamID string) ([]data.Event, error)
+	GetPendingAliases(ctx context.Context) ([]data.UnresolvedTeamAlias, error)
+	ResolveAlias(ctx context.Context, aliasID string, teamID string, createTeam bool) (*data.UnresolvedTeamAlias, error)
+}
+
+type Handler struct {
+	useCase TeamUseCase
+	logger  *zap.Logger
+}
+
+func NewHandler(uc TeamUseCase, logger *zap.Logger) *Handler {
+	return &Handler{
+		useCase: uc,
+		logger:  logger.Named("TeamHandler"),
+	}
+}
+
+func (h *Handler) RegisterRoutes(r chi.Router) {
+	r.Get("/competitions", h.GetCompetitions)
+	r.Get("/teams", h.GetTeams)
+	r.Get("/teams/{teamID}/fixtures", h.GetTeamFixtures)
+	r.Get("/admin/team-aliases", h.GetPendingAliases)
+	r.Post("/admin/team-aliases/{aliasID}/resolve", h.ResolveAlias)
+}
+
+func (h *Handler) GetCompetitions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := h.logger.With(zap.String("operation", "GetCompetitions"))
+
+	competitions, err := h.useCase.GetCompetitions(ctx, r.URL.Query().Get("sport"))
+	if err != nil {
+		log.Error("Error getting competitions from UseCase", zap.Error(err))
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	h.writeJSON(w, log, data.MapCompetitionsToDTOs(competitions))
+}
+
+func (h *Handler) GetTeams(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := h.logger.With(zap.String("operation", "GetTeams"))
+
+	teams, err := h.useCase.GetTeams(ctx, r.URL.Query().Get("sport"))
+	if err != nil {
+		log.Error("Error getting teams from UseCase", zap.Error(err))
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	h.writeJSON(w, log, data.MapTeamsToDTOs(teams))
+}
+
+func (h *Handler) GetTeamFixtures(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	teamID := chi.URLParam(r, "teamID")
+	log := h.logger.With(zap.String("operation", "GetTeamFixtures"), zap.String("teamId", teamID))
+
+	events, err := h.useCase.GetTeamFixtures(ctx, teamID)
+	if err != nil {
+		log.Error("Error getting team fixtures from UseCase", zap.Error(err))
+		if errors.Is(err, team.ErrTeamNotFound) {
+			http.Error(w, "Team not found", http.StatusNotFound)
+		} else {
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	h.writeJSON(w, log, data.MapEventsToDTOs(events))
+}
+
+func (h *Handler) GetPendingAliases(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := h.logger.With(zap.String("operation", "GetPendingAliases"))
+
+	aliases, err := h.useCase.GetPendingAliases(ctx)
+	if err != nil {
+		log.Error("Error getting unresolved team aliases from UseCase", zap.Error(err))
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	h.writeJSON(w, log, data.MapUnresolvedTeamAliasesToDTOs(aliases))
+}
+
+func (h *Handler) ResolveAlias(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	aliasID := chi.URLParam(r, "aliasID")
+	log := h.logger.With(zap.String("operation", "ResolveAlias"), zap.String("aliasId", aliasID))
+	log.Info("Received request to resolve team alias")
+
+	var requestDTO data.ResolveAliasRequest
+	if err := json.NewDecoder(r.Body).Decode(&requestDTO); err != nil {
+		log.Warn("Error decoding request body", zap.Error(err))
+		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	defer r.Body.Close()
+
+	if err := customvalidator.ValidateStruct(requestDTO); err != nil {
+		log.Warn("Error validating request body", zap.Error(err))
+		http.Error(w, "Validation error: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	resolved, err := h.useCase.ResolveAlias(ctx, aliasID, requestDTO.TeamID, requestDTO.CreateTeam)
+	if err != nil {
+		log.Error("Error resolving team alias in UseCase", zap.Error(err))
+		switch {
+		case errors.Is(err, team.ErrAliasNotFound):
+			http.Error(w, "Team alias not found", http.StatusNotFound)
+		case errors.Is(err, team.ErrAliasAlreadyResolved):
+			http.Error(w, "Team alias already resolved", http.StatusConflict)
+		case errors.Is(err, team.ErrTeamNotFound):
+			http.Error(w, "Team not found", http.StatusNotFound)
+		case errors.Is(err, team.ErrInvalidAliasResolution):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	h.writeJSON(w, log, data.MapUnresolvedTeamAliasToDTO(*resolved))
+}
+
+func (h *Handler) writeJSON(w http.ResponseWriter, log *zap.Logger, payload interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(payload); err != nil {
+		log.Error("Error encoding JSON response", zap.Error(err))
+	}
+}
@@ -0,0 +1,122 @@
+package breaker
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrOpen is returned by Allow while the breaker rejects calls.
+var ErrOpen = errors.New("circuit breaker is open")
+
+type State string
+
+const (
+	StateClosed   State = "closed"
+	StateOpen     State = "open"
+	StateHalfOpen State = "half-open"
+)
+
+// Breaker opens after a number of consecutive failures and rejects calls for a while.
+// Once that time has passed it lets a single probe through: a successful probe closes
+// the breaker, a failed one opens it again.
+type Breaker struct {
+	failureThreshold int
+	openFor          time.Duration
+
+	mu                  sync.Mutex
+	state               State
+	consecutiveFailures int
+	openedAt            time.Time
+	probing             bool
+}
+
+// Snapshot is the state of a breaker at one point in time.
+type Snapshot struct {
+	State               State
+	ConsecutiveFailures int
+	OpenedAt            *time.Time
+}
+
+// New creates a closed breaker. A failureThreshold of zero or less never opens it.
+func New(failureThreshold int, openFor time.Duration) *Breaker {
+	return &Breaker{
+		failureThreshold: failureThreshold,
+		openFor:          openFor,
+		state:            StateClosed,
+	}
+}
+
+// Allow reports whether a call may go through. Every allowed call must be followed by
+// Success, Failure or Cancel.
+func (b *Breaker) Allow(now time.Time) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case StateOpen:
+		if now.Sub(b.openedAt) < b.openFor {
+			return ErrOpen
+		}
+		b.state = StateHalfOpen
+		b.probing = true
+		return nil
+	case StateHalfOpen:
+		if b.probing {
+			return ErrOpen
+		}
+		b.probing = true
+		return nil
+	}
+	return nil
+}
+
+// Success closes the breaker.
+func (b *Breaker) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.state = StateClosed
+	b.consecutiveFailures = 0
+	b.probing = false
+}
+
+// Failure counts a failed call. It opens the breaker when the threshold is reached or
+// the call was the half-open probe.
+func (b *Breaker) Failure(now time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.consecutiveFailures++
+	b.probing = false
+	if b.state == StateHalfOpen || (b.failureThreshold > 0 && b.consecutiveFailures >= b.failureThreshold) {
+		b.state = StateOpen
+		b.openedAt = now
+	}
+}
+
+// Cancel ends a call that was abandoned by the caller without telling anything about the
+// callee. It counts neither way and frees the probe slot of a half-open breaker.
+func (b *Breaker) Cancel() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+}
+
+// Snapshot returns the current state. An open breaker whose open period has passed is
+// reported as half-open, since the next call will probe.
+func (b *Breaker) Snapshot(now time.Time) Snapshot {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	snapshot := Snapshot{State: b.state, ConsecutiveFailures: b.consecutiveFailures}
+	if b.state != StateClosed {
+		openedAt := b.openedAt
+		snapshot.OpenedAt = &openedAt
+	}
+	if b.state == StateOpen && now.Sub(b.openedAt) >= b.openFor {
+		snapshot.State = StateHalfOpen
+	}
+	return snapshot
+}
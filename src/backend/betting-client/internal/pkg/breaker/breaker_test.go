@@ -0,0 +1,67 @@
+package breaker_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Arlan-Z/def-betting-api/internal/pkg/breaker"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBreaker_OpensAndProbes(t *testing.T) {
+	now := time.Date(2030, 6, 1, 12, 0, 0, 0, time.UTC)
+	b := breaker.New(2, time.Minute)
+
+	assert.NoError(t, b.Allow(now))
+	b.Failure(now)
+	assert.Equal(t, breaker.StateClosed, b.Snapshot(now).State)
+	assert.NoError(t, b.Allow(now))
+	b.Failure(now)
+
+	assert.Equal(t, breaker.StateOpen, b.Snapshot(now).State)
+	assert.ErrorIs(t, b.Allow(now.Add(30*time.Second)), breaker.ErrOpen)
+
+	// One probe after the open period, a failed probe opens the breaker again.
+	probeAt := now.Add(time.Minute)
+	assert.Equal(t, breaker.StateHalfOpen, b.Snapshot(probeAt).State)
+	assert.NoError(t, b.Allow(probeAt))
+	assert.ErrorIs(t, b.Allow(probeAt), breaker.ErrOpen)
+	b.Failure(probeAt)
+	assert.ErrorIs(t, b.Allow(probeAt.Add(30*time.Second)), breaker.ErrOpen)
+
+	// A successful probe closes it.
+	probeAt = probeAt.Add(time.Minute)
+	assert.NoError(t, b.Allow(probeAt))
+	b.Success()
+	snapshot := b.Snapshot(probeAt)
+	assert.Equal(t, breaker.StateClosed, snapshot.State)
+	assert.Zero(t, snapshot.ConsecutiveFailures)
+	assert.Nil(t, snapshot.OpenedAt)
+}
+
+func TestBreaker_CanceledProbeKeepsBreakerHalfOpen(t *testing.T) {
+	now := time.Date(2030, 6, 1, 12, 0, 0, 0, time.UTC)
+	b := breaker.New(1, time.Minute)
+	assert.NoError(t, b.Allow(now))
+	b.Failure(now)
+
+	probeAt := now.Add(time.Minute)
+	assert.NoError(t, b.Allow(probeAt))
+	b.Cancel()
+
+	assert.Equal(t, breaker.StateHalfOpen, b.Snapshot(probeAt).State)
+	assert.Equal(t, 1, b.Snapshot(probeAt).ConsecutiveFailures)
+	assert.NoError(t, b.Allow(probeAt), "the next call may probe")
+}
+
+func TestBreaker_ZeroThresholdNeverOpens(t *testing.T) {
+	now := time.Date(2030, 6, 1, 12, 0, 0, 0, time.UTC)
+	b := breaker.New(0, time.Minute)
+
+	for i := 0; i < 10; i++ {
+		assert.NoError(t, b.Allow(now))
+		b.Failure(now)
+	}
+	assert.Equal(t, breaker.StateClosed, b.Snapshot(now).State)
+	assert.Equal(t, 10, b.Snapshot(now).ConsecutiveFailures)
+}
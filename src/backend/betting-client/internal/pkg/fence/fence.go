@@ -0,0 +1,59 @@
+package fence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrLeaseLost is returned for writes made on behalf of a leader whose lease expired or
+// passed to another replica.
+var ErrLeaseLost = errors.New("leadership lease lost, write rejected")
+
+// Token is the fencing token of a leadership lease: the lease, its holder and the token
+// the holder acquired it with.
+type Token struct {
+	Lease  string
+	Holder string
+	Value  int64
+}
+
+type contextKey struct{}
+
+// WithToken returns a context whose writes are only made while the lease is still held
+// with the token.
+func WithToken(ctx context.Context, token Token) context.Context {
+	return context.WithValue(ctx, contextKey{}, token)
+}
+
+// FromContext returns the token of the context, if it has one.
+func FromContext(ctx context.Context) (Token, bool) {
+	token, ok := ctx.Value(contextKey{}).(Token)
+	return token, ok
+}
+
+type getter interface {
+	GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+}
+
+// Check returns ErrLeaseLost unless the lease of the context's token is still held with
+// it and has not expired. A context without a token, e.g. of an admin request or of a
+// single replica without leader election, passes. Run it inside the transaction of the
+// write it guards, so the lease cannot move on in between.
+func Check(ctx context.Context, q getter, now time.Time) error {
+	token, ok := FromContext(ctx)
+	if !ok {
+		return nil
+	}
+
+	var held int
+	query := `SELECT COUNT(*) FROM leases WHERE name = ? AND holder = ? AND token = ? AND expires_at > ?`
+	if err := q.GetContext(ctx, &held, query, token.Lease, token.Holder, token.Value, now); err != nil {
+		return fmt.Errorf("error checking lease %s: %w", token.Lease, err)
+	}
+	if held == 0 {
+		return fmt.Errorf("%w: lease %s, token %d", ErrLeaseLost, token.Lease, token.Value)
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package mq
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Message is one record of a topic. Offsets start at zero and grow by one per message
+// of the topic. ID is chosen by the producer and stays the same when a producer sends
+// the message again, so consumers can recognize redeliveries.
+type Message struct {
+	ID          string
+	Topic       string
+	Offset      int64
+	Key         string
+	Value       []byte
+	Headers     map[string]string
+	PublishedAt time.Time
+}
+
+// Broker is an append-only log of topics with offsets committed per consumer group.
+type Broker interface {
+	// Publish appends the message to its topic and returns it with its offset. A
+	// message without ID gets a random one.
+	Publish(ctx context.Context, msg Message) (Message, error)
+	// Poll returns up to max messages of the topic from offset on. When there are none
+	// yet, it waits up to wait for new ones.
+	Poll(ctx context.Context, topic string, offset int64, max int, wait time.Duration) ([]Message, error)
+	// CommitOffset stores the offset of the next message the group consumes.
+	CommitOffset(ctx context.Context, group string, topic string, offset int64) error
+	// CommittedOffset returns the committed offset of the group, zero if it has none.
+	CommittedOffset(ctx context.Context, group string, topic string) (int64, error)
+}
+
+// MemoryBroker keeps topics and offsets in memory. It serves tests and runs where the
+// producer lives in the same process.
+type MemoryBroker struct {
+	mu      sync.Mutex
+	topics  map[string][]Message
+	offsets map[string]int64
+	// published is closed and replaced on every publish, waking up waiting polls.
+	published chan struct{}
+}
+
+func NewMemoryBroker() *MemoryBroker {
+	return &MemoryBroker{
+		topics:    make(map[string][]Message),
+		offsets:   make(map[string]int64),
+		published: make(chan struct{}),
+	}
+}
+
+func (b *MemoryBroker) Publish(ctx context.Context, msg Message) (Message, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if msg.ID == "" {
+		msg.ID = uuid.NewString()
+	}
+	msg.Offset = int64(len(b.topics[msg.Topic]))
+	msg.PublishedAt = time.Now().UTC()
+	b.topics[msg.Topic] = append(b.topics[msg.Topic], msg)
+
+	close(b.published)
+	b.published = make(chan struct{})
+	return msg, nil
+}
+
+func (b *MemoryBroker) Poll(ctx context.Context, topic string, offset int64, max int, wait time.Duration) ([]Message, error) {
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	for {
+		b.mu.Lock()
+		messages := b.topics[topic]
+		published := b.published
+		if offset < int64(len(messages)) {
+			end := int64(len(messages))
+			if max > 0 && offset+int64(max) < end {
+				end = offset + int64(max)
+			}
+			batch := append([]Message(nil), messages[offset:end]...)
+			b.mu.Unlock()
+			return batch, nil
+		}
+		b.mu.Unlock()
+
+		select {
+		case <-published:
+		case <-timer.C:
+			return nil, nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+func (b *MemoryBroker) CommitOffset(ctx context.Context, group string, topic string, offset int64) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.offsets[group+"/"+topic] = offset
+	return nil
+}
+
+func (b *MemoryBroker) CommittedOffset(ctx context.Context, group string, topic string) (int64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.offsets[group+"/"+topic], nil
+}
+
+// Messages returns every message of the topic.
+func (b *MemoryBroker) Messages(topic string) []Message {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]Message(nil), b.topics[topic]...)
+}
@@ -0,0 +1,132 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	SignatureHeader = "X-Signature"
+	TimestampHeader = "X-Signature-Timestamp"
+	signaturePrefix = "sha256="
+)
+
+var (
+	ErrUnknownSender    = errors.New("unknown webhook sender")
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+	ErrStaleTimestamp   = errors.New("webhook timestamp outside tolerance")
+	ErrReplayed         = errors.New("webhook request already received")
+)
+
+// Verifier checks HMAC-SHA256 signatures of "{timestamp}.{body}". Timestamps must be
+// within the tolerance of the local clock, and each signature is accepted only once
+// while its timestamp is within tolerance, unless the request it came with is forgotten
+// because it could not be processed. Seen signatures are kept in memory, so a
+// replay is only caught by the process that received the original, e.g. not after a
+// restart or by another replica.
+type Verifier struct {
+	secrets   map[string][]byte
+	tolerance time.Duration
+
+	mu        sync.Mutex
+	seen      map[string]time.Time
+	nextSweep time.Time
+}
+
+func NewVerifier(secrets map[string]string, tolerance time.Duration) *Verifier {
+	keys := make(map[string][]byte, len(secrets))
+	for sender, secret := range secrets {
+		keys[sender] = []byte(secret)
+	}
+	return &Verifier{
+		secrets:   keys,
+		tolerance: tolerance,
+		seen:      make(map[string]time.Time),
+	}
+}
+
+// Senders returns the names of the senders that have a secret.
+func (v *Verifier) Senders() []string {
+	senders := make([]string, 0, len(v.secrets))
+	for sender := range v.secrets {
+		senders = append(senders, sender)
+	}
+	return senders
+}
+
+// Sign returns the signature header value for the body sent at the given unix timestamp.
+func Sign(secret string, timestamp string, body []byte) string {
+	return signaturePrefix + hex.EncodeToString(mac([]byte(secret), timestamp, body))
+}
+
+func (v *Verifier) Verify(sender string, timestamp string, signature string, body []byte, now time.Time) error {
+	secret, ok := v.secrets[sender]
+	if !ok {
+		return ErrUnknownSender
+	}
+
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrStaleTimestamp
+	}
+	sentAt := time.Unix(unix, 0)
+	if sentAt.Before(now.Add(-v.tolerance)) || sentAt.After(now.Add(v.tolerance)) {
+		return ErrStaleTimestamp
+	}
+
+	given, err := hex.DecodeString(strings.TrimPrefix(signature, signaturePrefix))
+	if err != nil || !strings.HasPrefix(signature, signaturePrefix) || !hmac.Equal(given, mac(secret, timestamp, body)) {
+		return ErrInvalidSignature
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	// Expired signatures are dropped once per tolerance rather than on every request.
+	if !now.Before(v.nextSweep) {
+		for key, expiresAt := range v.seen {
+			if now.After(expiresAt) {
+				delete(v.seen, key)
+			}
+		}
+		v.nextSweep = now.Add(v.tolerance)
+	}
+	key := seenKey(sender, given)
+	if _, replayed := v.seen[key]; replayed {
+		return ErrReplayed
+	}
+	v.seen[key] = sentAt.Add(v.tolerance)
+	return nil
+}
+
+// Forget lets a verified signature be accepted again, so the sender may retry a request
+// that failed after it was verified.
+func (v *Verifier) Forget(sender string, signature string) {
+	given, err := hex.DecodeString(strings.TrimPrefix(signature, signaturePrefix))
+	if err != nil {
+		return
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	delete(v.seen, seenKey(sender, given))
+}
+
+// seenKey is built from the decoded MAC, so the same signature in other letter case is a
+// replay too.
+func seenKey(sender string, mac []byte) string {
+	return sender + ":" + hex.EncodeToString(mac)
+}
+
+func mac(secret []byte, timestamp string, body []byte) []byte {
+	h := hmac.New(sha256.New, secret)
+	h.Write([]byte(timestamp))
+	h.Write([]byte("."))
+	h.Write(body)
+	return h.Sum(nil)
+}
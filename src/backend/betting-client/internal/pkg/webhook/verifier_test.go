@@ -0,0 +1,43 @@
+package webhook_test
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Arlan-Z/def-betting-api/internal/pkg/webhook"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifier_Verify(t *testing.T) {
+	now := time.Date(2030, 6, 1, 12, 0, 0, 0, time.UTC)
+	verifier := webhook.NewVerifier(map[string]string{"primary": "s3cret"}, 5*time.Minute)
+	body := []byte(`{"id":"e1"}`)
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	signature := webhook.Sign("s3cret", timestamp, body)
+
+	assert.ErrorIs(t, verifier.Verify("backup", timestamp, signature, body, now), webhook.ErrUnknownSender)
+	assert.ErrorIs(t, verifier.Verify("primary", timestamp, webhook.Sign("other", timestamp, body), body, now), webhook.ErrInvalidSignature)
+	assert.ErrorIs(t, verifier.Verify("primary", timestamp, signature, []byte(`{"id":"e2"}`), now), webhook.ErrInvalidSignature)
+	assert.ErrorIs(t, verifier.Verify("primary", timestamp, signature, body, now.Add(6*time.Minute)), webhook.ErrStaleTimestamp)
+	assert.ErrorIs(t, verifier.Verify("primary", "yesterday", signature, body, now), webhook.ErrStaleTimestamp)
+
+	assert.NoError(t, verifier.Verify("primary", timestamp, signature, body, now))
+	assert.ErrorIs(t, verifier.Verify("primary", timestamp, signature, body, now.Add(time.Minute)), webhook.ErrReplayed)
+	upper := "sha256=" + strings.ToUpper(strings.TrimPrefix(signature, "sha256="))
+	assert.ErrorIs(t, verifier.Verify("primary", timestamp, upper, body, now.Add(time.Minute)), webhook.ErrReplayed)
+}
+
+func TestVerifier_ForgetAcceptsRetry(t *testing.T) {
+	now := time.Date(2030, 6, 1, 12, 0, 0, 0, time.UTC)
+	verifier := webhook.NewVerifier(map[string]string{"primary": "s3cret"}, 5*time.Minute)
+	body := []byte(`{"id":"e1"}`)
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	signature := webhook.Sign("s3cret", timestamp, body)
+
+	assert.NoError(t, verifier.Verify("primary", timestamp, signature, body, now))
+	verifier.Forget("primary", signature)
+	assert.NoError(t, verifier.Verify("primary", timestamp, signature, body, now.Add(time.Minute)))
+	assert.ErrorIs(t, verifier.Verify("primary", timestamp, signature, body, now.Add(2*time.Minute)), webhook.ErrReplayed)
+}
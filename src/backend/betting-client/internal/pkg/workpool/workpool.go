@@ -0,0 +1,51 @@
+package workpool
+
+import (
+	"context"
+	"hash/fnv"
+	"sync"
+)
+
+// Run calls fn for every item on up to workers goroutines and returns once all items are
+// handled. Items with the same key go to the same worker and are handled in their order
+// in items, items with different keys run in parallel. Once ctx is done, the items not
+// started yet are passed to skip instead of fn.
+func Run[T any](ctx context.Context, workers int, items []T, key func(T) string, fn func(context.Context, T), skip func(T)) {
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > len(items) {
+		workers = len(items)
+	}
+
+	queues := make([]chan T, workers)
+	for i := range queues {
+		queues[i] = make(chan T, len(items))
+	}
+	for _, item := range items {
+		queues[partition(key(item), workers)] <- item
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for _, queue := range queues {
+		close(queue)
+		go func() {
+			defer wg.Done()
+			for item := range queue {
+				if ctx.Err() != nil {
+					skip(item)
+					continue
+				}
+				fn(ctx, item)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func partition(key string, workers int) int {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return int(h.Sum32() % uint32(workers))
+}
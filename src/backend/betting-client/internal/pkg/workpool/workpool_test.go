@@ -0,0 +1,82 @@
+package workpool_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Arlan-Z/def-betting-api/internal/pkg/workpool"
+	"github.com/stretchr/testify/assert"
+)
+
+type job struct {
+	key string
+	seq int
+}
+
+func TestRun_KeepsOrderPerKey(t *testing.T) {
+	var items []job
+	for seq := 0; seq < 50; seq++ {
+		for _, key := range []string{"a", "b", "c", "d"} {
+			items = append(items, job{key: key, seq: seq})
+		}
+	}
+
+	var mu sync.Mutex
+	seen := map[string][]int{}
+	workpool.Run(context.Background(), 3, items, func(j job) string { return j.key }, func(ctx context.Context, j job) {
+		mu.Lock()
+		defer mu.Unlock()
+		seen[j.key] = append(seen[j.key], j.seq)
+	}, func(j job) { t.Errorf("job %v skipped", j) })
+
+	for _, key := range []string{"a", "b", "c", "d"} {
+		assert.Len(t, seen[key], 50)
+		assert.IsIncreasing(t, seen[key])
+	}
+}
+
+func TestRun_RunsKeysInParallel(t *testing.T) {
+	items := []job{{key: "slow"}}
+	for _, key := range []string{"a", "b", "c", "d", "e", "f", "g", "h"} {
+		items = append(items, job{key: key})
+	}
+	release := make(chan struct{})
+	progressed := make(chan struct{}, len(items))
+
+	finished := make(chan struct{})
+	go func() {
+		defer close(finished)
+		workpool.Run(context.Background(), 2, items, func(j job) string { return j.key }, func(ctx context.Context, j job) {
+			if j.key == "slow" {
+				<-release
+				return
+			}
+			progressed <- struct{}{}
+		}, func(job) {})
+	}()
+
+	// Keys on the other worker are handled while the slow job blocks its own.
+	select {
+	case <-progressed:
+	case <-time.After(time.Second):
+		t.Error("no job was handled while the slow one blocked")
+	}
+	close(release)
+	<-finished
+}
+
+func TestRun_SkipsAfterCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	items := []job{{key: "a", seq: 0}, {key: "a", seq: 1}, {key: "a", seq: 2}}
+
+	var handled, skipped []int
+	workpool.Run(ctx, 2, items, func(j job) string { return j.key }, func(ctx context.Context, j job) {
+		handled = append(handled, j.seq)
+		cancel()
+	}, func(j job) { skipped = append(skipped, j.seq) })
+
+	assert.Equal(t, []int{0}, handled)
+	assert.Equal(t, []int{1, 2}, skipped)
+}
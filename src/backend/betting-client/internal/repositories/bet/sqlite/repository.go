@@ -3,12 +3,17 @@ package sqlite
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/Arlan-Z/def-betting-api/internal/data" // Change path
+	"github.com/Arlan-Z/def-betting-api/internal/pkg/fence"
 	"github.com/jmoiron/sqlx"
 )
 
+const betColumns = `id, user_id, event_id, amount, predicted_outcome, recorded_home_win_chance, recorded_away_win_chance, recorded_draw_chance, placed_at, status, payout_amount, void_flagged_at, void_flag_reason`
+
 type BetRepository struct {
 	db *sqlx.DB
 }
@@ -34,7 +39,7 @@ func (r *BetRepository) Save(ctx context.Context, bet *data.Bet) error {
 
 func (r *BetRepository) FindPendingByEventID(ctx context.Context, eventID string) ([]data.Bet, error) {
 	bets := make([]data.Bet, 0)
-	query := `SELECT id, user_id, event_id, amount, predicted_outcome, recorded_home_win_chance, recorded_away_win_chance, recorded_draw_chance, placed_at, status, payout_amount
+	query := `SELECT ` + betColumns + `
               FROM bets
               WHERE event_id = ? AND status = ?`
 
@@ -48,6 +53,21 @@ func (r *BetRepository) FindPendingByEventID(ctx context.Context, eventID string
 	return bets, nil
 }
 
+// FindSettledByEventID returns the bets of the event that were settled against its result.
+func (r *BetRepository) FindSettledByEventID(ctx context.Context, eventID string) ([]data.Bet, error) {
+	bets := make([]data.Bet, 0)
+	query := `SELECT ` + betColumns + `
+              FROM bets
+              WHERE event_id = ? AND status IN (?, ?, ?, ?)
+              ORDER BY placed_at ASC`
+
+	err := r.db.SelectContext(ctx, &bets, query, eventID, data.StatusWon, data.StatusLost, data.StatusPaid, data.StatusFailed)
+	if err != nil {
+		return nil, fmt.Errorf("error querying settled bets for event %s: %w", eventID, err)
+	}
+	return bets, nil
+}
+
 func (r *BetRepository) UpdateStatusAndPayout(ctx context.Context, betID string, status data.BetStatus, payout float64) error {
 	query := `UPDATE bets SET status = ?, payout_amount = ? WHERE id = ?`
 	_, err := r.db.ExecContext(ctx, query, status, payout, betID)
@@ -57,6 +77,63 @@ func (r *BetRepository) UpdateStatusAndPayout(ctx context.Context, betID string,
 	return nil
 }
 
+// SettleWithPayout moves the bet from the given status to the new one with its payout
+// and, in the same transaction, puts the payout intent into the outbox. Intents of the
+// bet still waiting for delivery are canceled, since the new settlement replaces them. A
+// nil intent only settles the bet; otherwise its Sequence is set. It reports false,
+// writing nothing, when the bet no longer has the from status because another settlement
+// got to it first. Settlements on behalf of a leader that lost its lease are rejected
+// with fence.ErrLeaseLost.
+func (r *BetRepository) SettleWithPayout(ctx context.Context, betID string, from data.BetStatus, status data.BetStatus, payout float64, intent *data.PayoutIntent) (bool, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("error starting transaction to settle bet %s: %w", betID, err)
+	}
+	defer tx.Rollback()
+
+	if err := fence.Check(ctx, tx, time.Now().UTC()); err != nil {
+		return false, fmt.Errorf("error settling bet %s: %w", betID, err)
+	}
+
+	updateQuery := `UPDATE bets SET status = ?, payout_amount = ? WHERE id = ? AND status = ?`
+	result, err := tx.ExecContext(ctx, updateQuery, status, payout, betID, from)
+	if err != nil {
+		return false, fmt.Errorf("error updating bet status for %s: %w", betID, err)
+	}
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("error checking settlement of bet %s: %w", betID, err)
+	}
+	if updated == 0 {
+		return false, nil
+	}
+
+	now := time.Now().UTC()
+	cancelQuery := `UPDATE outbox SET status = ?, finished_at = ? WHERE bet_id = ? AND status = ?`
+	if _, err := tx.ExecContext(ctx, cancelQuery, data.OutboxCanceled, now, betID, data.OutboxPending); err != nil {
+		return false, fmt.Errorf("error canceling earlier payouts of bet %s: %w", betID, err)
+	}
+
+	if intent != nil {
+		sequenceQuery := `SELECT COALESCE(MAX(sequence), 0) + 1 FROM outbox WHERE bet_id = ? AND kind = ?`
+		if err := tx.GetContext(ctx, &intent.Sequence, sequenceQuery, betID, intent.Kind); err != nil {
+			return false, fmt.Errorf("error numbering payout for bet %s: %w", betID, err)
+		}
+		insertQuery := `INSERT INTO outbox (id, bet_id, user_id, amount, kind, status, attempts, next_attempt_at, last_error, created_at, finished_at,
+                                  event_id, predicted_outcome, odds, stake, sequence)
+              VALUES (:id, :bet_id, :user_id, :amount, :kind, :status, :attempts, :next_attempt_at, :last_error, :created_at, :finished_at,
+                      :event_id, :predicted_outcome, :odds, :stake, :sequence)`
+		if _, err := tx.NamedExecContext(ctx, insertQuery, intent); err != nil {
+			return false, fmt.Errorf("error queueing payout for bet %s: %w", betID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("error committing settlement of bet %s: %w", betID, err)
+	}
+	return true, nil
+}
+
 func (r *BetRepository) UpdateStatus(ctx context.Context, betID string, status data.BetStatus) error {
 	query := `UPDATE bets SET status = ? WHERE id = ?`
 	_, err := r.db.ExecContext(ctx, query, status, betID)
@@ -65,3 +142,66 @@ func (r *BetRepository) UpdateStatus(ctx context.Context, betID string, status d
 	}
 	return nil
 }
+
+func (r *BetRepository) FindByID(ctx context.Context, betID string) (*data.Bet, error) {
+	var bet data.Bet
+	query := `SELECT ` + betColumns + `
+              FROM bets
+              WHERE id = ?`
+
+	err := r.db.GetContext(ctx, &bet, query, betID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error querying bet by ID %s: %w", betID, err)
+	}
+	return &bet, nil
+}
+
+// FlagPlacedAfter flags pending bets of the event placed after the given time for
+// voiding and returns the bets flagged by this call.
+func (r *BetRepository) FlagPlacedAfter(ctx context.Context, eventID string, after time.Time, reason string) ([]data.Bet, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error starting transaction to flag late bets for event %s: %w", eventID, err)
+	}
+	defer tx.Rollback()
+
+	bets := make([]data.Bet, 0)
+	selectQuery := `SELECT ` + betColumns + `
+              FROM bets
+              WHERE event_id = ? AND status = ? AND placed_at > ? AND void_flagged_at IS NULL`
+	if err := tx.SelectContext(ctx, &bets, selectQuery, eventID, data.StatusPending, after); err != nil {
+		return nil, fmt.Errorf("error querying late bets for event %s: %w", eventID, err)
+	}
+	if len(bets) == 0 {
+		return bets, nil
+	}
+
+	flaggedAt := time.Now().UTC()
+	updateQuery := `UPDATE bets SET void_flagged_at = ?, void_flag_reason = ?
+              WHERE event_id = ? AND status = ? AND placed_at > ? AND void_flagged_at IS NULL`
+	if _, err := tx.ExecContext(ctx, updateQuery, flaggedAt, reason, eventID, data.StatusPending, after); err != nil {
+		return nil, fmt.Errorf("error flagging late bets for event %s: %w", eventID, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("error committing late bet flags for event %s: %w", eventID, err)
+	}
+
+	for i := range bets {
+		bets[i].VoidFlaggedAt = &flaggedAt
+		bets[i].VoidFlagReason = &reason
+	}
+	return bets, nil
+}
+
+func (r *BetRepository) ClearVoidFlag(ctx context.Context, betID string) error {
+	query := `UPDATE bets SET void_flagged_at = NULL, void_flag_reason = NULL WHERE id = ?`
+	_, err := r.db.ExecContext(ctx, query, betID)
+	if err != nil {
+		return fmt.Errorf("error clearing void flag for bet %s: %w", betID, err)
+	}
+	return nil
+}
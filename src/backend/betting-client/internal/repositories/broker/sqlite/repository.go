@@ -0,0 +1,181 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Arlan-Z/def-betting-api/internal/pkg/mq"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+const messageColumns = `topic, msg_offset, id, msg_key, value, headers, published_at`
+
+// pollInterval is how often Poll looks for new messages while waiting.
+const pollInterval = 200 * time.Millisecond
+
+// BrokerRepository is an embedded broker: topics, consumer group offsets and processed
+// message IDs live in the service's database. Producers append to broker_messages.
+type BrokerRepository struct {
+	db *sqlx.DB
+}
+
+func NewBrokerRepository(db *sqlx.DB) *BrokerRepository {
+	return &BrokerRepository{db: db}
+}
+
+type messageRow struct {
+	Topic       string    `db:"topic"`
+	Offset      int64     `db:"msg_offset"`
+	ID          string    `db:"id"`
+	Key         string    `db:"msg_key"`
+	Value       []byte    `db:"value"`
+	Headers     string    `db:"headers"`
+	PublishedAt time.Time `db:"published_at"`
+}
+
+func (r *BrokerRepository) Publish(ctx context.Context, msg mq.Message) (mq.Message, error) {
+	if msg.ID == "" {
+		msg.ID = uuid.NewString()
+	}
+	headers := msg.Headers
+	if headers == nil {
+		headers = map[string]string{}
+	}
+	encoded, err := json.Marshal(headers)
+	if err != nil {
+		return mq.Message{}, fmt.Errorf("error encoding headers of message %s: %w", msg.ID, err)
+	}
+	msg.PublishedAt = time.Now().UTC()
+
+	query := `INSERT INTO broker_messages (` + messageColumns + `)
+              SELECT ?, COALESCE(MAX(msg_offset) + 1, 0), ?, ?, ?, ?, ? FROM broker_messages WHERE topic = ?
+              RETURNING msg_offset`
+
+	err = r.db.QueryRowxContext(ctx, query, msg.Topic, msg.ID, msg.Key, msg.Value, string(encoded), msg.PublishedAt, msg.Topic).Scan(&msg.Offset)
+	if err != nil {
+		return mq.Message{}, fmt.Errorf("error publishing message %s to %s: %w", msg.ID, msg.Topic, err)
+	}
+	return msg, nil
+}
+
+func (r *BrokerRepository) Poll(ctx context.Context, topic string, offset int64, max int, wait time.Duration) ([]mq.Message, error) {
+	deadline := time.Now().Add(wait)
+	for {
+		messages, err := r.findFrom(ctx, topic, offset, max)
+		if err != nil || len(messages) > 0 {
+			return messages, err
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return nil, nil
+		}
+		timer := time.NewTimer(min(remaining, pollInterval))
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		}
+	}
+}
+
+func (r *BrokerRepository) findFrom(ctx context.Context, topic string, offset int64, max int) ([]mq.Message, error) {
+	if max <= 0 {
+		max = -1
+	}
+	rows := make([]messageRow, 0)
+	query := `SELECT ` + messageColumns + ` FROM broker_messages
+              WHERE topic = ? AND msg_offset >= ?
+              ORDER BY msg_offset ASC LIMIT ?`
+
+	err := r.db.SelectContext(ctx, &rows, query, topic, offset, max)
+	if err != nil {
+		return nil, fmt.Errorf("error polling topic %s: %w", topic, err)
+	}
+
+	messages := make([]mq.Message, len(rows))
+	for i, row := range rows {
+		headers := map[string]string{}
+		if err := json.Unmarshal([]byte(row.Headers), &headers); err != nil {
+			return nil, fmt.Errorf("error decoding headers of message %s at %s/%d: %w", row.ID, topic, row.Offset, err)
+		}
+		messages[i] = mq.Message{
+			ID:          row.ID,
+			Topic:       row.Topic,
+			Offset:      row.Offset,
+			Key:         row.Key,
+			Value:       row.Value,
+			Headers:     headers,
+			PublishedAt: row.PublishedAt,
+		}
+	}
+	return messages, nil
+}
+
+func (r *BrokerRepository) CommitOffset(ctx context.Context, group string, topic string, offset int64) error {
+	query := `INSERT INTO broker_offsets (consumer_group, topic, next_offset, updated_at) VALUES (?, ?, ?, ?)
+              ON CONFLICT(consumer_group, topic) DO UPDATE SET next_offset = excluded.next_offset, updated_at = excluded.updated_at`
+
+	_, err := r.db.ExecContext(ctx, query, group, topic, offset, time.Now().UTC())
+	if err != nil {
+		return fmt.Errorf("error committing offset %d of group %s on %s: %w", offset, group, topic, err)
+	}
+	return nil
+}
+
+func (r *BrokerRepository) CommittedOffset(ctx context.Context, group string, topic string) (int64, error) {
+	var offset int64
+	query := `SELECT next_offset FROM broker_offsets WHERE consumer_group = ? AND topic = ?`
+
+	err := r.db.GetContext(ctx, &offset, query, group, topic)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("error finding offset of group %s on %s: %w", group, topic, err)
+	}
+	return offset, nil
+}
+
+// IsProcessed reports whether the group already processed a message with this ID.
+func (r *BrokerRepository) IsProcessed(ctx context.Context, group string, messageID string) (bool, error) {
+	var count int
+	query := `SELECT COUNT(*) FROM consumed_messages WHERE consumer_group = ? AND message_id = ?`
+
+	err := r.db.GetContext(ctx, &count, query, group, messageID)
+	if err != nil {
+		return false, fmt.Errorf("error checking message %s of group %s: %w", messageID, group, err)
+	}
+	return count > 0, nil
+}
+
+// MarkProcessed records that the group processed the message. Marking it again is a no-op.
+func (r *BrokerRepository) MarkProcessed(ctx context.Context, group string, msg mq.Message, processedAt time.Time) error {
+	query := `INSERT INTO consumed_messages (consumer_group, message_id, topic, msg_offset, processed_at) VALUES (?, ?, ?, ?, ?)
+              ON CONFLICT(consumer_group, message_id) DO NOTHING`
+
+	_, err := r.db.ExecContext(ctx, query, group, msg.ID, msg.Topic, msg.Offset, processedAt)
+	if err != nil {
+		return fmt.Errorf("error marking message %s of group %s as processed: %w", msg.ID, group, err)
+	}
+	return nil
+}
+
+// DeleteProcessedBefore forgets processed message IDs older than before.
+func (r *BrokerRepository) DeleteProcessedBefore(ctx context.Context, before time.Time) (int64, error) {
+	res, err := r.db.ExecContext(ctx, `DELETE FROM consumed_messages WHERE processed_at < ?`, before)
+	if err != nil {
+		return 0, fmt.Errorf("error deleting processed messages: %w", err)
+	}
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("error checking deleted processed messages: %w", err)
+	}
+	return deleted, nil
+}
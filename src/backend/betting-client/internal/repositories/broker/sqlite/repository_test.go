@@ -0,0 +1,135 @@
+package sqlite_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/Arlan-Z/def-betting-api/internal/pkg/mq"
+	brokerrepo "github.com/Arlan-Z/def-betting-api/internal/repositories/broker/sqlite"
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/sqlite3"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type BrokerRepositorySuite struct {
+	suite.Suite
+	db      *sqlx.DB
+	repo    *brokerrepo.BrokerRepository
+	dbPath  string
+	migrate *migrate.Migrate
+}
+
+func (s *BrokerRepositorySuite) SetupSuite() {
+	tempFile, err := os.CreateTemp("", "test_broker_*.db")
+	require.NoError(s.T(), err)
+	s.dbPath = tempFile.Name()
+	tempFile.Close()
+
+	db, err := sqlx.Open("sqlite3", s.dbPath+"?_foreign_keys=on")
+	require.NoError(s.T(), err)
+	s.db = db
+
+	driver, err := sqlite3.WithInstance(db.DB, &sqlite3.Config{})
+	require.NoError(s.T(), err)
+
+	m, err := migrate.NewWithDatabaseInstance(fmt.Sprintf("file://%s", "../../../../migrations"), "sqlite3", driver)
+	require.NoError(s.T(), err)
+	s.migrate = m
+	require.NoError(s.T(), s.migrate.Up(), "Failed to run migrations UP")
+
+	s.repo = brokerrepo.NewBrokerRepository(s.db)
+}
+
+func (s *BrokerRepositorySuite) TearDownSuite() {
+	if s.migrate != nil {
+		if err := s.migrate.Down(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+			s.T().Logf("Warning: failed to run migrations DOWN: %v", err)
+		}
+		s.migrate.Close()
+	}
+	if s.db != nil {
+		require.NoError(s.T(), s.db.Close())
+	}
+	require.NoError(s.T(), os.Remove(s.dbPath))
+}
+
+func (s *BrokerRepositorySuite) BeforeTest(suiteName, testName string) {
+	for _, table := range []string{"broker_messages", "broker_offsets", "consumed_messages"} {
+		_, err := s.db.Exec("DELETE FROM " + table + ";")
+		require.NoError(s.T(), err)
+	}
+}
+
+func TestBrokerRepositorySuite(t *testing.T) {
+	suite.Run(t, new(BrokerRepositorySuite))
+}
+
+func (s *BrokerRepositorySuite) TestPublishPollAndCommit() {
+	ctx := context.Background()
+
+	first, err := s.repo.Publish(ctx, mq.Message{Topic: "events", Key: "e1", Value: []byte(`{"id":"e1"}`), Headers: map[string]string{"provider": "primary"}})
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), int64(0), first.Offset)
+	require.NotEmpty(s.T(), first.ID)
+	second, err := s.repo.Publish(ctx, mq.Message{ID: "m2", Topic: "events", Value: []byte(`{"id":"e2"}`)})
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), int64(1), second.Offset)
+	other, err := s.repo.Publish(ctx, mq.Message{Topic: "events.dlq", Value: []byte(`{}`)})
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), int64(0), other.Offset)
+
+	messages, err := s.repo.Poll(ctx, "events", 0, 10, 0)
+	require.NoError(s.T(), err)
+	require.Len(s.T(), messages, 2)
+	require.Equal(s.T(), "primary", messages[0].Headers["provider"])
+	require.Equal(s.T(), `{"id":"e2"}`, string(messages[1].Value))
+
+	messages, err = s.repo.Poll(ctx, "events", 1, 10, 0)
+	require.NoError(s.T(), err)
+	require.Len(s.T(), messages, 1)
+	require.Equal(s.T(), "m2", messages[0].ID)
+
+	messages, err = s.repo.Poll(ctx, "events", 2, 10, 10*time.Millisecond)
+	require.NoError(s.T(), err)
+	require.Empty(s.T(), messages)
+
+	offset, err := s.repo.CommittedOffset(ctx, "betting", "events")
+	require.NoError(s.T(), err)
+	require.Zero(s.T(), offset)
+	require.NoError(s.T(), s.repo.CommitOffset(ctx, "betting", "events", 1))
+	require.NoError(s.T(), s.repo.CommitOffset(ctx, "betting", "events", 2))
+	offset, err = s.repo.CommittedOffset(ctx, "betting", "events")
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), int64(2), offset)
+}
+
+func (s *BrokerRepositorySuite) TestProcessedMessages() {
+	ctx := context.Background()
+	now := time.Date(2030, 6, 1, 12, 0, 0, 0, time.UTC)
+	msg := mq.Message{ID: "m1", Topic: "events", Offset: 3}
+
+	processed, err := s.repo.IsProcessed(ctx, "betting", "m1")
+	require.NoError(s.T(), err)
+	require.False(s.T(), processed)
+
+	require.NoError(s.T(), s.repo.MarkProcessed(ctx, "betting", msg, now))
+	require.NoError(s.T(), s.repo.MarkProcessed(ctx, "betting", msg, now))
+	processed, err = s.repo.IsProcessed(ctx, "betting", "m1")
+	require.NoError(s.T(), err)
+	require.True(s.T(), processed)
+	processed, err = s.repo.IsProcessed(ctx, "other", "m1")
+	require.NoError(s.T(), err)
+	require.False(s.T(), processed)
+
+	deleted, err := s.repo.DeleteProcessedBefore(ctx, now.Add(time.Hour))
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), int64(1), deleted)
+}
@@ -0,0 +1,59 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/Arlan-Z/def-betting-api/internal/data"
+	"github.com/jmoiron/sqlx"
+)
+
+const competitionColumns = `id, name, name_key, sport, created_at`
+
+type CompetitionRepository struct {
+	db *sqlx.DB
+}
+
+func NewCompetitionRepository(db *sqlx.DB) *CompetitionRepository {
+	return &CompetitionRepository{db: db}
+}
+
+func (r *CompetitionRepository) Create(ctx context.Context, competition *data.Competition) error {
+	query := `INSERT INTO competitions (` + competitionColumns + `)
+              VALUES (:id, :name, :name_key, :sport, :created_at)`
+
+	_, err := r.db.NamedExecContext(ctx, query, competition)
+	if err != nil {
+		return fmt.Errorf("error inserting competition %s: %w", competition.Name, err)
+	}
+	return nil
+}
+
+// FindByKey returns the competition with the normalized name in the sport, or nil.
+func (r *CompetitionRepository) FindByKey(ctx context.Context, sport string, nameKey string) (*data.Competition, error) {
+	var competition data.Competition
+	query := `SELECT ` + competitionColumns + ` FROM competitions WHERE sport = ? AND name_key = ?`
+
+	err := r.db.GetContext(ctx, &competition, query, sport, nameKey)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error querying competition '%s': %w", nameKey, err)
+	}
+	return &competition, nil
+}
+
+// FindBySport lists competitions of a sport, or all competitions when sport is empty.
+func (r *CompetitionRepository) FindBySport(ctx context.Context, sport string) ([]data.Competition, error) {
+	competitions := make([]data.Competition, 0)
+	query := `SELECT ` + competitionColumns + ` FROM competitions WHERE ? = '' OR sport = ? ORDER BY sport, name`
+
+	err := r.db.SelectContext(ctx, &competitions, query, sport, sport)
+	if err != nil {
+		return nil, fmt.Errorf("error querying competitions: %w", err)
+	}
+	return competitions, nil
+}
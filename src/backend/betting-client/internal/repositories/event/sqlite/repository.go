@@ -11,6 +11,8 @@ import (
 	"github.com/jmoiron/sqlx"
 )
 
+const eventColumns = `id, event_name, home_team, away_team, home_win_chance, away_win_chance, draw_chance, event_start_date, event_end_date, event_result, type, is_active, status, postponed_at, betting_closed_at, suspended_at, competition, competition_id, home_team_id, away_team_id, content_hash`
+
 type EventRepository struct {
 	db *sqlx.DB
 }
@@ -21,9 +23,9 @@ func NewEventRepository(db *sqlx.DB) *EventRepository {
 
 func (r *EventRepository) FindActiveEvents(ctx context.Context) ([]data.Event, error) {
 	events := make([]data.Event, 0)
-	query := `SELECT id, event_name, home_team, away_team, home_win_chance, away_win_chance, draw_chance, event_start_date, event_end_date, event_result, type, is_active
+	query := `SELECT ` + eventColumns + `
               FROM events
-              WHERE is_active = 1 AND event_end_date > ?
+              WHERE is_active = 1 AND status = 'Scheduled' AND event_end_date > ?
               ORDER BY event_start_date ASC`
 
 	now := time.Now().UTC()
@@ -39,7 +41,7 @@ func (r *EventRepository) FindActiveEvents(ctx context.Context) ([]data.Event, e
 
 func (r *EventRepository) FindByID(ctx context.Context, eventID string) (*data.Event, error) {
 	var event data.Event
-	query := `SELECT id, event_name, home_team, away_team, home_win_chance, away_win_chance, draw_chance, event_start_date, event_end_date, event_result, type, is_active
+	query := `SELECT ` + eventColumns + `
               FROM events
               WHERE id = ?`
 
@@ -74,10 +76,27 @@ func (r *EventRepository) UpdateResultAndStatus(ctx context.Context, eventID str
 	return nil
 }
 
+// ReplaceResult changes the result of an event that is already finalized.
+func (r *EventRepository) ReplaceResult(ctx context.Context, eventID string, result data.Outcome) error {
+	query := `UPDATE events SET event_result = ? WHERE id = ? AND event_result IS NOT NULL`
+	res, err := r.db.ExecContext(ctx, query, result, eventID)
+	if err != nil {
+		return fmt.Errorf("error replacing result of event %s: %w", eventID, err)
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error checking replaced result of event %s: %w", eventID, err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("event %s has no result to replace", eventID)
+	}
+	return nil
+}
+
 func (r *EventRepository) Upsert(ctx context.Context, event *data.Event) error {
 	query := `
-        INSERT INTO events (id, event_name, home_team, away_team, home_win_chance, away_win_chance, draw_chance, event_start_date, event_end_date, event_result, type, is_active)
-        VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+        INSERT INTO events (id, event_name, home_team, away_team, home_win_chance, away_win_chance, draw_chance, event_start_date, event_end_date, event_result, type, is_active, status, competition, competition_id, home_team_id, away_team_id, content_hash)
+        VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, COALESCE(NULLIF(?, ''), 'Scheduled'), ?, ?, ?, ?, ?)
         ON CONFLICT(id) DO UPDATE SET
             event_name = excluded.event_name,
             home_team = excluded.home_team,
@@ -89,9 +108,20 @@ func (r *EventRepository) Upsert(ctx context.Context, event *data.Event) error {
             event_end_date = excluded.event_end_date,
             event_result = excluded.event_result,
             type = excluded.type,
-            is_active = excluded.is_active
+            is_active = excluded.is_active,
+            competition = excluded.competition,
+            competition_id = excluded.competition_id,
+            home_team_id = excluded.home_team_id,
+            away_team_id = excluded.away_team_id,
+            content_hash = excluded.content_hash
     `
-	_, err := r.db.ExecContext(ctx, query,
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, query,
 		event.ID,
 		event.EventName,
 		event.HomeTeam,
@@ -104,9 +134,210 @@ func (r *EventRepository) Upsert(ctx context.Context, event *data.Event) error {
 		event.EventResult,
 		event.Type,
 		event.IsActive,
+		event.Status,
+		event.Competition,
+		event.CompetitionID,
+		event.HomeTeamID,
+		event.AwayTeamID,
+		event.ContentHash,
 	)
 	if err != nil {
 		return fmt.Errorf("error upserting event %s: %w", event.ID, err)
 	}
+
+	if err := reindexEvent(ctx, tx, event.ID); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error committing event %s: %w", event.ID, err)
+	}
+	return nil
+}
+
+// reindexEvent replaces the full-text search entry of the event with its stored values.
+func reindexEvent(ctx context.Context, tx *sqlx.Tx, eventID string) error {
+	deleteQuery := `DELETE FROM events_fts WHERE docid = (SELECT rowid FROM events WHERE id = ?)`
+	if _, err := tx.ExecContext(ctx, deleteQuery, eventID); err != nil {
+		return fmt.Errorf("error removing search entry of event %s: %w", eventID, err)
+	}
+
+	insertQuery := `INSERT INTO events_fts (docid, event_name, home_team, away_team, type, competition)
+                    SELECT rowid, event_name, home_team, away_team, type, competition FROM events WHERE id = ?`
+	if _, err := tx.ExecContext(ctx, insertQuery, eventID); err != nil {
+		return fmt.Errorf("error indexing event %s for search: %w", eventID, err)
+	}
+	return nil
+}
+
+func (r *EventRepository) MarkPostponed(ctx context.Context, eventID string, postponedAt time.Time) error {
+	query := `UPDATE events SET status = ?, postponed_at = ? WHERE id = ? AND status != ?`
+	_, err := r.db.ExecContext(ctx, query, data.EventStatusPostponed, postponedAt, eventID, data.EventStatusPostponed)
+	if err != nil {
+		return fmt.Errorf("error marking event %s as postponed: %w", eventID, err)
+	}
+	return nil
+}
+
+func (r *EventRepository) MarkCanceled(ctx context.Context, eventID string) error {
+	query := `UPDATE events SET status = ?, is_active = 0 WHERE id = ?`
+	_, err := r.db.ExecContext(ctx, query, data.EventStatusCanceled, eventID)
+	if err != nil {
+		return fmt.Errorf("error marking event %s as canceled: %w", eventID, err)
+	}
+	return nil
+}
+
+// FindPostponedBefore returns unsettled postponed events that were both postponed and
+// due to start before the given time, so an event moved to a later date is not returned
+// before its new start.
+func (r *EventRepository) FindPostponedBefore(ctx context.Context, before time.Time) ([]data.Event, error) {
+	events := make([]data.Event, 0)
+	query := `SELECT ` + eventColumns + `
+              FROM events
+              WHERE status = ? AND event_result IS NULL AND postponed_at <= ? AND event_start_date <= ?
+              ORDER BY postponed_at ASC`
+
+	err := r.db.SelectContext(ctx, &events, query, data.EventStatusPostponed, before, before)
+	if err != nil {
+		return nil, fmt.Errorf("error querying postponed events: %w", err)
+	}
+	return events, nil
+}
+
+func (r *EventRepository) RecordScheduleChange(ctx context.Context, change *data.EventScheduleChange) error {
+	query := `INSERT INTO event_schedule_changes (event_id, old_start_date, new_start_date, old_end_date, new_end_date, detected_at)
+              VALUES (:event_id, :old_start_date, :new_start_date, :old_end_date, :new_end_date, :detected_at)`
+
+	res, err := r.db.NamedExecContext(ctx, query, change)
+	if err != nil {
+		return fmt.Errorf("error recording schedule change for event %s: %w", change.EventID, err)
+	}
+	if id, err := res.LastInsertId(); err == nil {
+		change.ID = id
+	}
+	return nil
+}
+
+func (r *EventRepository) MarkBettingClosed(ctx context.Context, eventID string, closedAt time.Time) error {
+	query := `UPDATE events SET status = ?, betting_closed_at = ? WHERE id = ? AND status = ?`
+	_, err := r.db.ExecContext(ctx, query, data.EventStatusClosed, closedAt, eventID, data.EventStatusScheduled)
+	if err != nil {
+		return fmt.Errorf("error closing betting for event %s: %w", eventID, err)
+	}
 	return nil
 }
+
+// ReopenBetting opens betting again on a closed event without a result, e.g. after its
+// start was moved later. It reports whether the event was closed.
+func (r *EventRepository) ReopenBetting(ctx context.Context, eventID string) (bool, error) {
+	query := `UPDATE events SET status = ?, betting_closed_at = NULL
+              WHERE id = ? AND status = ? AND event_result IS NULL`
+	res, err := r.db.ExecContext(ctx, query, data.EventStatusScheduled, eventID, data.EventStatusClosed)
+	if err != nil {
+		return false, fmt.Errorf("error reopening betting for event %s: %w", eventID, err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("error checking reopened event %s: %w", eventID, err)
+	}
+	return affected > 0, nil
+}
+
+func (r *EventRepository) FindUpcomingByTeam(ctx context.Context, teamID string, from time.Time) ([]data.Event, error) {
+	events := make([]data.Event, 0)
+	query := `SELECT ` + eventColumns + `
+              FROM events
+              WHERE (home_team_id = ? OR away_team_id = ?) AND is_active = 1 AND event_start_date > ?
+              ORDER BY event_start_date ASC`
+
+	err := r.db.SelectContext(ctx, &events, query, teamID, teamID, from)
+	if err != nil {
+		return nil, fmt.Errorf("error querying fixtures of team %s: %w", teamID, err)
+	}
+	return events, nil
+}
+
+// AssignTeamByName links events that still carry the given team name without a team reference.
+func (r *EventRepository) AssignTeamByName(ctx context.Context, sport string, name string, teamID string) (int64, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var assigned int64
+	for _, side := range []string{"home", "away"} {
+		query := `UPDATE events SET ` + side + `_team_id = ?
+                  WHERE ` + side + `_team_id IS NULL AND ` + side + `_team = ? AND type = ? COLLATE NOCASE`
+		res, err := tx.ExecContext(ctx, query, teamID, name, sport)
+		if err != nil {
+			return 0, fmt.Errorf("error assigning team %s to %s events: %w", teamID, side, err)
+		}
+		if n, err := res.RowsAffected(); err == nil {
+			assigned += n
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("error committing team assignment: %w", err)
+	}
+	return assigned, nil
+}
+
+// FindStartingBetween returns events of the sport starting within [from, to], used to
+// match events reported by several providers under different IDs.
+func (r *EventRepository) FindStartingBetween(ctx context.Context, sport string, from time.Time, to time.Time) ([]data.Event, error) {
+	events := make([]data.Event, 0)
+	query := `SELECT ` + eventColumns + `
+              FROM events
+              WHERE type = ? COLLATE NOCASE AND event_start_date BETWEEN ? AND ?
+              ORDER BY event_start_date ASC`
+
+	err := r.db.SelectContext(ctx, &events, query, sport, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("error querying events starting between %s and %s: %w", from, to, err)
+	}
+	return events, nil
+}
+
+// MarkSuspended stops betting on a scheduled or closed event.
+func (r *EventRepository) MarkSuspended(ctx context.Context, eventID string, suspendedAt time.Time) error {
+	query := `UPDATE events SET status = ?, suspended_at = ? WHERE id = ? AND status IN (?, ?)`
+	_, err := r.db.ExecContext(ctx, query, data.EventStatusSuspended, suspendedAt, eventID, data.EventStatusScheduled, data.EventStatusClosed)
+	if err != nil {
+		return fmt.Errorf("error marking event %s as suspended: %w", eventID, err)
+	}
+	return nil
+}
+
+// ResumeSuspended restores the status a suspended event had: Closed if betting was
+// closed before, Scheduled otherwise. It reports whether the event was suspended.
+func (r *EventRepository) ResumeSuspended(ctx context.Context, eventID string) (bool, error) {
+	query := `UPDATE events
+              SET status = CASE WHEN betting_closed_at IS NULL THEN ? ELSE ? END, suspended_at = NULL
+              WHERE id = ? AND status = ?`
+	res, err := r.db.ExecContext(ctx, query, data.EventStatusScheduled, data.EventStatusClosed, eventID, data.EventStatusSuspended)
+	if err != nil {
+		return false, fmt.Errorf("error resuming suspended event %s: %w", eventID, err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("error checking resumed event %s: %w", eventID, err)
+	}
+	return affected > 0, nil
+}
+
+func (r *EventRepository) FindSuspendedBefore(ctx context.Context, before time.Time) ([]data.Event, error) {
+	events := make([]data.Event, 0)
+	query := `SELECT ` + eventColumns + `
+              FROM events
+              WHERE status = ? AND event_result IS NULL AND suspended_at <= ?
+              ORDER BY suspended_at ASC`
+
+	err := r.db.SelectContext(ctx, &events, query, data.EventStatusSuspended, before)
+	if err != nil {
+		return nil, fmt.Errorf("error querying suspended events: %w", err)
+	}
+	return events, nil
+}
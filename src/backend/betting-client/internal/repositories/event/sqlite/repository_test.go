@@ -80,8 +80,14 @@ func (s *EventRepositorySuite) TearDownSuite() {
 func (s *EventRepositorySuite) BeforeTest(suiteName, testName string) {
 	_, err := s.db.Exec("DELETE FROM bets;")
 	require.NoError(s.T(), err)
+	_, err = s.db.Exec("DELETE FROM event_schedule_changes;")
+	require.NoError(s.T(), err)
 	_, err = s.db.Exec("DELETE FROM events;")
 	require.NoError(s.T(), err)
+	_, err = s.db.Exec("DELETE FROM team_aliases;")
+	require.NoError(s.T(), err)
+	_, err = s.db.Exec("DELETE FROM teams;")
+	require.NoError(s.T(), err)
 }
 
 func TestEventRepositorySuite(t *testing.T) {
@@ -113,6 +119,7 @@ func (s *EventRepositorySuite) TestUpsertAndFindByID_Found() {
 		Type:           "Test",
 		IsActive:       true,
 	}
+	expectedEvent.ContentHash = expectedEvent.ComputeContentHash()
 
 	err := s.repo.Upsert(ctx, expectedEvent)
 	require.NoError(s.T(), err, "Upsert (insert) should not return error")
@@ -133,6 +140,7 @@ func (s *EventRepositorySuite) TestUpsertAndFindByID_Found() {
 	require.Nil(s.T(), foundEvent.EventResult)
 	require.Equal(s.T(), expectedEvent.Type, foundEvent.Type)
 	require.Equal(s.T(), expectedEvent.IsActive, foundEvent.IsActive)
+	require.Equal(s.T(), expectedEvent.ContentHash, foundEvent.ContentHash)
 
 	expectedEvent.EventName = "Updated Test Event"
 	expectedEvent.HomeWinChance = 1.8
@@ -219,3 +227,229 @@ func (s *EventRepositorySuite) TestUpdateResultAndStatus() {
 	require.NotNil(s.T(), finalEvent)
 	require.Equal(s.T(), result, *finalEvent.EventResult)
 }
+
+func (s *EventRepositorySuite) TestMarkPostponedAndFindPostponedBefore() {
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+	event := &data.Event{ID: uuid.NewString(), EventName: "To Postpone", EventStartDate: now.Add(time.Hour), EventEndDate: now.Add(3 * time.Hour), IsActive: true}
+	err := s.repo.Upsert(ctx, event)
+	require.NoError(s.T(), err)
+
+	stored, err := s.repo.FindByID(ctx, event.ID)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), data.EventStatusScheduled, stored.Status)
+
+	err = s.repo.MarkPostponed(ctx, event.ID, now.Add(-2*time.Hour))
+	require.NoError(s.T(), err)
+
+	activeEvents, err := s.repo.FindActiveEvents(ctx)
+	require.NoError(s.T(), err)
+	require.Empty(s.T(), activeEvents, "Postponed events should not be listed as active")
+
+	event.EventName = "Renamed By Source"
+	err = s.repo.Upsert(ctx, event)
+	require.NoError(s.T(), err)
+
+	postponed, err := s.repo.FindByID(ctx, event.ID)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), data.EventStatusPostponed, postponed.Status, "Upsert must not reset status")
+	require.NotNil(s.T(), postponed.PostponedAt)
+
+	expired, err := s.repo.FindPostponedBefore(ctx, now.Add(2*time.Hour))
+	require.NoError(s.T(), err)
+	require.Len(s.T(), expired, 1)
+	require.Equal(s.T(), event.ID, expired[0].ID)
+
+	notStarted, err := s.repo.FindPostponedBefore(ctx, now.Add(-time.Hour))
+	require.NoError(s.T(), err)
+	require.Empty(s.T(), notStarted, "An event postponed long ago is kept until its new start date passed")
+
+	notYet, err := s.repo.FindPostponedBefore(ctx, now.Add(-3*time.Hour))
+	require.NoError(s.T(), err)
+	require.Empty(s.T(), notYet)
+
+	err = s.repo.MarkCanceled(ctx, event.ID)
+	require.NoError(s.T(), err)
+
+	canceled, err := s.repo.FindByID(ctx, event.ID)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), data.EventStatusCanceled, canceled.Status)
+	require.False(s.T(), canceled.IsActive)
+}
+
+func (s *EventRepositorySuite) TestRecordScheduleChange() {
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+	event := &data.Event{ID: uuid.NewString(), EventName: "Rescheduled", EventStartDate: now, EventEndDate: now.Add(2 * time.Hour), IsActive: true}
+	err := s.repo.Upsert(ctx, event)
+	require.NoError(s.T(), err)
+
+	change := &data.EventScheduleChange{
+		EventID:      event.ID,
+		OldStartDate: event.EventStartDate,
+		NewStartDate: event.EventStartDate.Add(24 * time.Hour),
+		OldEndDate:   event.EventEndDate,
+		NewEndDate:   event.EventEndDate.Add(24 * time.Hour),
+		DetectedAt:   now,
+	}
+	err = s.repo.RecordScheduleChange(ctx, change)
+	require.NoError(s.T(), err)
+	require.NotZero(s.T(), change.ID)
+
+	var count int
+	err = s.db.Get(&count, "SELECT COUNT(*) FROM event_schedule_changes WHERE event_id = ?", event.ID)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), 1, count)
+}
+
+func (s *EventRepositorySuite) TestMarkBettingClosed() {
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+	event := &data.Event{ID: uuid.NewString(), EventName: "Kick-off Soon", EventStartDate: now.Add(2 * time.Minute), EventEndDate: now.Add(2 * time.Hour), IsActive: true}
+	err := s.repo.Upsert(ctx, event)
+	require.NoError(s.T(), err)
+
+	err = s.repo.MarkBettingClosed(ctx, event.ID, now)
+	require.NoError(s.T(), err)
+
+	activeEvents, err := s.repo.FindActiveEvents(ctx)
+	require.NoError(s.T(), err)
+	require.Empty(s.T(), activeEvents, "Closed events should not be listed as active")
+
+	closed, err := s.repo.FindByID(ctx, event.ID)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), data.EventStatusClosed, closed.Status)
+	require.NotNil(s.T(), closed.BettingClosedAt)
+	require.WithinDuration(s.T(), now, *closed.BettingClosedAt, time.Second)
+
+	reopened, err := s.repo.ReopenBetting(ctx, event.ID)
+	require.NoError(s.T(), err)
+	require.True(s.T(), reopened)
+	reopened, err = s.repo.ReopenBetting(ctx, event.ID)
+	require.NoError(s.T(), err)
+	require.False(s.T(), reopened, "An open event is left alone")
+
+	open, err := s.repo.FindByID(ctx, event.ID)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), data.EventStatusScheduled, open.Status)
+	require.Nil(s.T(), open.BettingClosedAt)
+}
+
+func (s *EventRepositorySuite) TestMarkSuspendedAndResumeSuspended() {
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+	open := &data.Event{ID: uuid.NewString(), EventName: "Vanished", EventStartDate: now.Add(time.Hour), EventEndDate: now.Add(3 * time.Hour), IsActive: true}
+	closed := &data.Event{ID: uuid.NewString(), EventName: "Vanished After Cutoff", EventStartDate: now.Add(time.Minute), EventEndDate: now.Add(2 * time.Hour), IsActive: true}
+	require.NoError(s.T(), s.repo.Upsert(ctx, open))
+	require.NoError(s.T(), s.repo.Upsert(ctx, closed))
+	require.NoError(s.T(), s.repo.MarkBettingClosed(ctx, closed.ID, now))
+
+	require.NoError(s.T(), s.repo.MarkSuspended(ctx, open.ID, now.Add(-2*time.Hour)))
+	require.NoError(s.T(), s.repo.MarkSuspended(ctx, closed.ID, now))
+
+	activeEvents, err := s.repo.FindActiveEvents(ctx)
+	require.NoError(s.T(), err)
+	require.Empty(s.T(), activeEvents, "Suspended events should not be listed as active")
+
+	expired, err := s.repo.FindSuspendedBefore(ctx, now.Add(-time.Hour))
+	require.NoError(s.T(), err)
+	require.Len(s.T(), expired, 1)
+	require.Equal(s.T(), open.ID, expired[0].ID)
+	require.NotNil(s.T(), expired[0].SuspendedAt)
+
+	resumed, err := s.repo.ResumeSuspended(ctx, open.ID)
+	require.NoError(s.T(), err)
+	require.True(s.T(), resumed)
+	resumed, err = s.repo.ResumeSuspended(ctx, open.ID)
+	require.NoError(s.T(), err)
+	require.False(s.T(), resumed, "An event that is not suspended is left alone")
+
+	_, err = s.repo.ResumeSuspended(ctx, closed.ID)
+	require.NoError(s.T(), err)
+
+	reopened, err := s.repo.FindByID(ctx, open.ID)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), data.EventStatusScheduled, reopened.Status)
+	require.Nil(s.T(), reopened.SuspendedAt)
+
+	stillClosed, err := s.repo.FindByID(ctx, closed.ID)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), data.EventStatusClosed, stillClosed.Status, "Betting stays closed after the event reappears")
+}
+
+func (s *EventRepositorySuite) TestAssignTeamByNameAndFindUpcomingByTeam() {
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+	teamID := uuid.NewString()
+	_, err := s.db.Exec("INSERT INTO teams (id, name, sport, created_at) VALUES (?, ?, ?, ?)", teamID, "Kairat", "Football", now)
+	require.NoError(s.T(), err)
+
+	upcoming := &data.Event{ID: uuid.NewString(), EventName: "Kairat vs Astana", HomeTeam: "Kairat", AwayTeam: "Astana", Type: "Football", EventStartDate: now.Add(time.Hour), EventEndDate: now.Add(3 * time.Hour), IsActive: true}
+	away := &data.Event{ID: uuid.NewString(), EventName: "Tobol vs Kairat", HomeTeam: "Tobol", AwayTeam: "Kairat", Type: "football", EventStartDate: now.Add(24 * time.Hour), EventEndDate: now.Add(26 * time.Hour), IsActive: true}
+	past := &data.Event{ID: uuid.NewString(), EventName: "Kairat vs Ordabasy", HomeTeam: "Kairat", AwayTeam: "Ordabasy", Type: "Football", EventStartDate: now.Add(-3 * time.Hour), EventEndDate: now.Add(-time.Hour), IsActive: true}
+	otherSport := &data.Event{ID: uuid.NewString(), EventName: "Kairat vs Barys", HomeTeam: "Kairat", AwayTeam: "Barys", Type: "Hockey", EventStartDate: now.Add(time.Hour), EventEndDate: now.Add(3 * time.Hour), IsActive: true}
+	for _, e := range []*data.Event{upcoming, away, past, otherSport} {
+		require.NoError(s.T(), s.repo.Upsert(ctx, e))
+	}
+
+	assigned, err := s.repo.AssignTeamByName(ctx, "Football", "Kairat", teamID)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), int64(3), assigned, "Football events with the name on either side should be linked")
+
+	fixtures, err := s.repo.FindUpcomingByTeam(ctx, teamID, now)
+	require.NoError(s.T(), err)
+	require.Len(s.T(), fixtures, 2)
+	require.Equal(s.T(), upcoming.ID, fixtures[0].ID)
+	require.Equal(s.T(), teamID, *fixtures[0].HomeTeamID)
+	require.Equal(s.T(), away.ID, fixtures[1].ID)
+	require.Equal(s.T(), teamID, *fixtures[1].AwayTeamID)
+
+	hockey, err := s.repo.FindByID(ctx, otherSport.ID)
+	require.NoError(s.T(), err)
+	require.Nil(s.T(), hockey.HomeTeamID)
+}
+
+func (s *EventRepositorySuite) TestSearch() {
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+	newEvent := func(name, home, away, sport string) *data.Event {
+		return &data.Event{ID: uuid.NewString(), EventName: name, HomeTeam: home, AwayTeam: away, Type: sport, EventStartDate: now.Add(time.Hour), EventEndDate: now.Add(3 * time.Hour), IsActive: true}
+	}
+	barcelona := newEvent("Barcelona vs Atlético Madrid", "Barcelona", "Atlético Madrid", "Football")
+	espanyol := newEvent("Derbi barceloní", "Espanyol", "Girona", "Football")
+	kairat := newEvent("Кайрат vs Астана", "Кайрат", "Астана", "Football")
+	finished := newEvent("Barcelona vs Sevilla", "Barcelona", "Sevilla", "Football")
+	finished.EventStartDate, finished.EventEndDate = now.Add(-3*time.Hour), now.Add(-time.Hour)
+	for _, e := range []*data.Event{barcelona, espanyol, kairat, finished} {
+		require.NoError(s.T(), s.repo.Upsert(ctx, e))
+	}
+
+	results, err := s.repo.Search(ctx, []string{"barc"}, 10)
+	require.NoError(s.T(), err)
+	require.Len(s.T(), results, 2, "Prefix should match active events only")
+	require.Equal(s.T(), barcelona.ID, results[0].ID, "Team name matches should rank first")
+	require.Equal(s.T(), espanyol.ID, results[1].ID)
+	require.Greater(s.T(), results[0].Score, results[1].Score)
+	require.Equal(s.T(), "<mark>Barcelona</mark>", results[0].Highlights.HomeTeam)
+	require.Equal(s.T(), "Derbi <mark>barceloní</mark>", results[1].Highlights.EventName)
+
+	results, err = s.repo.Search(ctx, []string{"atletico", "madr"}, 10)
+	require.NoError(s.T(), err)
+	require.Len(s.T(), results, 1, "Diacritics should be ignored")
+	require.Equal(s.T(), "<mark>Atlético</mark> <mark>Madrid</mark>", results[0].Highlights.AwayTeam)
+
+	results, err = s.repo.Search(ctx, []string{"кайр"}, 10)
+	require.NoError(s.T(), err)
+	require.Len(s.T(), results, 1)
+	require.Equal(s.T(), kairat.ID, results[0].ID)
+
+	kairat.HomeTeam = "Тобол"
+	require.NoError(s.T(), s.repo.Upsert(ctx, kairat))
+	results, err = s.repo.Search(ctx, []string{"тобол"}, 10)
+	require.NoError(s.T(), err)
+	require.Len(s.T(), results, 1, "Upsert should reindex changed events")
+
+	results, err = s.repo.Search(ctx, []string{"barcelona"}, 1)
+	require.NoError(s.T(), err)
+	require.Len(s.T(), results, 1)
+}
@@ -0,0 +1,137 @@
+package sqlite
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Arlan-Z/def-betting-api/internal/data"
+)
+
+const (
+	highlightStart = "<mark>"
+	highlightEnd   = "</mark>"
+	// snippetTokens is the largest fragment FTS4 returns, enough for any event name.
+	snippetTokens = 64
+
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+// searchColumnWeights follow the column order of events_fts: event_name, home_team,
+// away_team, type, competition. Team names matter most when looking for fixtures.
+var searchColumnWeights = []float64{1.0, 2.0, 2.0, 0.5, 1.0}
+
+type searchRow struct {
+	data.Event
+	NameHighlight string `db:"name_highlight"`
+	HomeHighlight string `db:"home_highlight"`
+	AwayHighlight string `db:"away_highlight"`
+	MatchInfo     []byte `db:"match_info"`
+}
+
+// Search returns active events matching all terms, each term as a prefix, ordered by
+// BM25 relevance. Terms are expected to be normalized words without FTS operators.
+func (r *EventRepository) Search(ctx context.Context, terms []string, limit int) ([]data.EventSearchResult, error) {
+	if len(terms) == 0 {
+		return []data.EventSearchResult{}, nil
+	}
+
+	prefixes := make([]string, len(terms))
+	for i, term := range terms {
+		prefixes[i] = `"` + strings.ReplaceAll(term, `"`, "") + `*"`
+	}
+	match := strings.Join(prefixes, " ")
+
+	query := `SELECT e.` + strings.ReplaceAll(eventColumns, ", ", ", e.") + `,
+                     snippet(events_fts, ?, ?, '', 0, ?) AS name_highlight,
+                     snippet(events_fts, ?, ?, '', 1, ?) AS home_highlight,
+                     snippet(events_fts, ?, ?, '', 2, ?) AS away_highlight,
+                     matchinfo(events_fts, 'pcnalx') AS match_info
+              FROM events_fts
+              JOIN events e ON e.rowid = events_fts.docid
+              WHERE events_fts MATCH ? AND e.is_active = 1 AND e.event_end_date > ?`
+
+	var rows []searchRow
+	err := r.db.SelectContext(ctx, &rows, query,
+		highlightStart, highlightEnd, snippetTokens,
+		highlightStart, highlightEnd, snippetTokens,
+		highlightStart, highlightEnd, snippetTokens,
+		match, time.Now().UTC(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error searching events for '%s': %w", match, err)
+	}
+
+	results := make([]data.EventSearchResult, len(rows))
+	for i, row := range rows {
+		results[i] = data.EventSearchResult{
+			Event: row.Event,
+			Score: bm25(row.MatchInfo, searchColumnWeights),
+			Highlights: data.EventHighlights{
+				EventName: row.NameHighlight,
+				HomeTeam:  row.HomeHighlight,
+				AwayTeam:  row.AwayHighlight,
+			},
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].EventStartDate.Before(results[j].EventStartDate)
+	})
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+	return results, nil
+}
+
+// bm25 computes the Okapi BM25 score of a row from an FTS4 matchinfo 'pcnalx' blob,
+// which FTS4, unlike FTS5, does not provide on its own.
+func bm25(matchInfo []byte, weights []float64) float64 {
+	values := make([]uint32, len(matchInfo)/4)
+	for i := range values {
+		values[i] = binary.NativeEndian.Uint32(matchInfo[i*4:])
+	}
+	if len(values) < 3 {
+		return 0
+	}
+
+	phrases, columns, rowCount := int(values[0]), int(values[1]), float64(values[2])
+	avgLengths := values[3 : 3+columns]
+	lengths := values[3+columns : 3+2*columns]
+	hits := values[3+2*columns:]
+	if len(hits) < 3*phrases*columns {
+		return 0
+	}
+
+	score := 0.0
+	for p := 0; p < phrases; p++ {
+		for c := 0; c < columns; c++ {
+			base := 3 * (c + p*columns)
+			hitsInRow := float64(hits[base])
+			docsWithHits := float64(hits[base+2])
+			if hitsInRow == 0 || avgLengths[c] == 0 {
+				continue
+			}
+
+			idf := math.Log((rowCount - docsWithHits + 0.5) / (docsWithHits + 0.5))
+			if idf <= 0 {
+				idf = 1e-6
+			}
+			weight := 1.0
+			if c < len(weights) {
+				weight = weights[c]
+			}
+			norm := 1 - bm25B + bm25B*float64(lengths[c])/float64(avgLengths[c])
+			score += weight * idf * (hitsInRow * (bm25K1 + 1)) / (hitsInRow + bm25K1*norm)
+		}
+	}
+	return score
+}
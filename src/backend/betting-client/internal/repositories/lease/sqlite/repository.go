@@ -0,0 +1,93 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Arlan-Z/def-betting-api/internal/data"
+	"github.com/jmoiron/sqlx"
+)
+
+const leaseColumns = `name, holder, token, acquired_at, renewed_at, expires_at`
+
+type LeaseRepository struct {
+	db *sqlx.DB
+}
+
+func NewLeaseRepository(db *sqlx.DB) *LeaseRepository {
+	return &LeaseRepository{db: db}
+}
+
+// TryAcquire takes the lease for the holder if it is free, expired or already held by
+// the holder, and returns the lease as it is afterwards. The caller holds the lease only
+// if the returned holder is its own.
+func (r *LeaseRepository) TryAcquire(ctx context.Context, name string, holder string, ttl time.Duration, now time.Time) (*data.Lease, error) {
+	query := `INSERT INTO leases (` + leaseColumns + `) VALUES (?, ?, 1, ?, ?, ?)
+              ON CONFLICT(name) DO UPDATE SET
+                  token = CASE WHEN leases.holder = excluded.holder THEN leases.token ELSE leases.token + 1 END,
+                  acquired_at = CASE WHEN leases.holder = excluded.holder THEN leases.acquired_at ELSE excluded.acquired_at END,
+                  holder = excluded.holder,
+                  renewed_at = excluded.renewed_at,
+                  expires_at = excluded.expires_at
+              WHERE leases.holder = excluded.holder OR leases.expires_at <= excluded.renewed_at`
+
+	_, err := r.db.ExecContext(ctx, query, name, holder, now, now, now.Add(ttl))
+	if err != nil {
+		return nil, fmt.Errorf("error acquiring lease %s: %w", name, err)
+	}
+
+	lease, err := r.FindByName(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if lease == nil {
+		return nil, fmt.Errorf("lease %s vanished while being acquired", name)
+	}
+	return lease, nil
+}
+
+// Renew extends the lease if the holder still holds it with the given token. It
+// reports false once the lease passed to another holder.
+func (r *LeaseRepository) Renew(ctx context.Context, name string, holder string, token int64, ttl time.Duration, now time.Time) (bool, error) {
+	query := `UPDATE leases SET renewed_at = ?, expires_at = ?
+              WHERE name = ? AND holder = ? AND token = ? AND expires_at > ?`
+
+	res, err := r.db.ExecContext(ctx, query, now, now.Add(ttl), name, holder, token, now)
+	if err != nil {
+		return false, fmt.Errorf("error renewing lease %s: %w", name, err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("error checking renewal of lease %s: %w", name, err)
+	}
+	return affected > 0, nil
+}
+
+// Release lets the lease expire right away, so another holder can take it without
+// waiting for the TTL.
+func (r *LeaseRepository) Release(ctx context.Context, name string, holder string, token int64, now time.Time) error {
+	query := `UPDATE leases SET expires_at = ? WHERE name = ? AND holder = ? AND token = ?`
+	_, err := r.db.ExecContext(ctx, query, now, name, holder, token)
+	if err != nil {
+		return fmt.Errorf("error releasing lease %s: %w", name, err)
+	}
+	return nil
+}
+
+// FindByName returns the lease, or nil if it was never taken.
+func (r *LeaseRepository) FindByName(ctx context.Context, name string) (*data.Lease, error) {
+	var lease data.Lease
+	query := `SELECT ` + leaseColumns + ` FROM leases WHERE name = ?`
+
+	err := r.db.GetContext(ctx, &lease, query, name)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error finding lease %s: %w", name, err)
+	}
+	return &lease, nil
+}
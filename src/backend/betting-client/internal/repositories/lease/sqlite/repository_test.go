@@ -0,0 +1,141 @@
+package sqlite_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/Arlan-Z/def-betting-api/internal/pkg/fence"
+	leaserepo "github.com/Arlan-Z/def-betting-api/internal/repositories/lease/sqlite"
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/sqlite3"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type LeaseRepositorySuite struct {
+	suite.Suite
+	db      *sqlx.DB
+	repo    *leaserepo.LeaseRepository
+	dbPath  string
+	migrate *migrate.Migrate
+}
+
+func (s *LeaseRepositorySuite) SetupSuite() {
+	tempFile, err := os.CreateTemp("", "test_lease_*.db")
+	require.NoError(s.T(), err)
+	s.dbPath = tempFile.Name()
+	tempFile.Close()
+
+	db, err := sqlx.Open("sqlite3", s.dbPath+"?_foreign_keys=on")
+	require.NoError(s.T(), err)
+	s.db = db
+
+	driver, err := sqlite3.WithInstance(db.DB, &sqlite3.Config{})
+	require.NoError(s.T(), err)
+
+	m, err := migrate.NewWithDatabaseInstance(fmt.Sprintf("file://%s", "../../../../migrations"), "sqlite3", driver)
+	require.NoError(s.T(), err)
+	s.migrate = m
+	require.NoError(s.T(), s.migrate.Up(), "Failed to run migrations UP")
+
+	s.repo = leaserepo.NewLeaseRepository(s.db)
+}
+
+func (s *LeaseRepositorySuite) TearDownSuite() {
+	if s.migrate != nil {
+		if err := s.migrate.Down(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+			s.T().Logf("Warning: failed to run migrations DOWN: %v", err)
+		}
+		s.migrate.Close()
+	}
+	if s.db != nil {
+		require.NoError(s.T(), s.db.Close())
+	}
+	require.NoError(s.T(), os.Remove(s.dbPath))
+}
+
+func (s *LeaseRepositorySuite) BeforeTest(suiteName, testName string) {
+	_, err := s.db.Exec("DELETE FROM leases;")
+	require.NoError(s.T(), err)
+}
+
+func TestLeaseRepositorySuite(t *testing.T) {
+	suite.Run(t, new(LeaseRepositorySuite))
+}
+
+func (s *LeaseRepositorySuite) TestAcquireRenewAndTakeOver() {
+	ctx := context.Background()
+	now := time.Date(2030, 6, 1, 12, 0, 0, 0, time.UTC)
+	ttl := 15 * time.Second
+
+	lease, err := s.repo.TryAcquire(ctx, "workers", "replica-a", ttl, now)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), "replica-a", lease.Holder)
+	require.Equal(s.T(), int64(1), lease.Token)
+
+	// Another replica cannot take a lease that has not expired.
+	lease, err = s.repo.TryAcquire(ctx, "workers", "replica-b", ttl, now.Add(5*time.Second))
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), "replica-a", lease.Holder)
+
+	renewed, err := s.repo.Renew(ctx, "workers", "replica-a", 1, ttl, now.Add(10*time.Second))
+	require.NoError(s.T(), err)
+	require.True(s.T(), renewed)
+
+	// Once expired, it passes to the other replica with a new fencing token.
+	lease, err = s.repo.TryAcquire(ctx, "workers", "replica-b", ttl, now.Add(26*time.Second))
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), "replica-b", lease.Holder)
+	require.Equal(s.T(), int64(2), lease.Token)
+
+	renewed, err = s.repo.Renew(ctx, "workers", "replica-a", 1, ttl, now.Add(27*time.Second))
+	require.NoError(s.T(), err)
+	require.False(s.T(), renewed)
+
+	// Renewing through TryAcquire keeps the token.
+	lease, err = s.repo.TryAcquire(ctx, "workers", "replica-b", ttl, now.Add(30*time.Second))
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), int64(2), lease.Token)
+}
+
+func (s *LeaseRepositorySuite) TestRelease() {
+	ctx := context.Background()
+	now := time.Date(2030, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	_, err := s.repo.TryAcquire(ctx, "workers", "replica-a", time.Minute, now)
+	require.NoError(s.T(), err)
+	require.NoError(s.T(), s.repo.Release(ctx, "workers", "replica-a", 1, now.Add(time.Second)))
+
+	lease, err := s.repo.TryAcquire(ctx, "workers", "replica-b", time.Minute, now.Add(2*time.Second))
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), "replica-b", lease.Holder)
+
+	missing, err := s.repo.FindByName(ctx, "unknown")
+	require.NoError(s.T(), err)
+	require.Nil(s.T(), missing)
+}
+
+func (s *LeaseRepositorySuite) TestFenceRejectsDeposedHolder() {
+	ctx := context.Background()
+	now := time.Date(2030, 6, 1, 12, 0, 0, 0, time.UTC)
+	ttl := 15 * time.Second
+
+	lease, err := s.repo.TryAcquire(ctx, "workers", "replica-a", ttl, now)
+	require.NoError(s.T(), err)
+	leaderCtx := fence.WithToken(ctx, fence.Token{Lease: "workers", Holder: "replica-a", Value: lease.Token})
+
+	require.NoError(s.T(), fence.Check(leaderCtx, s.db, now.Add(time.Second)))
+	require.NoError(s.T(), fence.Check(ctx, s.db, now.Add(time.Hour)), "writes without a token are not fenced")
+	require.ErrorIs(s.T(), fence.Check(leaderCtx, s.db, now.Add(ttl)), fence.ErrLeaseLost, "expired lease")
+
+	_, err = s.repo.TryAcquire(ctx, "workers", "replica-b", ttl, now.Add(ttl+time.Second))
+	require.NoError(s.T(), err)
+	require.ErrorIs(s.T(), fence.Check(leaderCtx, s.db, now.Add(ttl+2*time.Second)), fence.ErrLeaseLost, "lease taken over")
+}
@@ -2,6 +2,7 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	"github.com/Arlan-Z/def-betting-api/internal/data"
 	"github.com/stretchr/testify/mock"
@@ -52,6 +53,26 @@ func (_m *BetRepository) UpdateStatusAndPayout(ctx context.Context, betID string
 	return r0
 }
 
+func (_m *BetRepository) SettleWithPayout(ctx context.Context, betID string, from data.BetStatus, status data.BetStatus, payout float64, intent *data.PayoutIntent) (bool, error) {
+	ret := _m.Called(ctx, betID, from, status, payout, intent)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string, data.BetStatus, data.BetStatus, float64, *data.PayoutIntent) bool); ok {
+		r0 = rf(ctx, betID, from, status, payout, intent)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, data.BetStatus, data.BetStatus, float64, *data.PayoutIntent) error); ok {
+		r1 = rf(ctx, betID, from, status, payout, intent)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 func (_m *BetRepository) UpdateStatus(ctx context.Context, betID string, status data.BetStatus) error {
 	ret := _m.Called(ctx, betID, status)
 	var r0 error
@@ -63,6 +84,74 @@ func (_m *BetRepository) UpdateStatus(ctx context.Context, betID string, status
 	return r0
 }
 
+func (_m *BetRepository) FindByID(ctx context.Context, betID string) (*data.Bet, error) {
+	ret := _m.Called(ctx, betID)
+	var r0 *data.Bet
+	if rf, ok := ret.Get(0).(func(context.Context, string) *data.Bet); ok {
+		r0 = rf(ctx, betID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*data.Bet)
+		}
+	}
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, betID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+func (_m *BetRepository) FlagPlacedAfter(ctx context.Context, eventID string, after time.Time, reason string) ([]data.Bet, error) {
+	ret := _m.Called(ctx, eventID, after, reason)
+	var r0 []data.Bet
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, string) []data.Bet); ok {
+		r0 = rf(ctx, eventID, after, reason)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]data.Bet)
+		}
+	}
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time, string) error); ok {
+		r1 = rf(ctx, eventID, after, reason)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+func (_m *BetRepository) ClearVoidFlag(ctx context.Context, betID string) error {
+	ret := _m.Called(ctx, betID)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, betID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+func (_m *BetRepository) FindSettledByEventID(ctx context.Context, eventID string) ([]data.Bet, error) {
+	ret := _m.Called(ctx, eventID)
+	var r0 []data.Bet
+	if rf, ok := ret.Get(0).(func(context.Context, string) []data.Bet); ok {
+		r0 = rf(ctx, eventID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]data.Bet)
+		}
+	}
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, eventID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
 func NewBetRepository(t interface {
 	mock.TestingT
 	Cleanup(func())
@@ -0,0 +1,71 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/Arlan-Z/def-betting-api/internal/data"
+	"github.com/stretchr/testify/mock"
+)
+
+type CompetitionRepository struct {
+	mock.Mock
+}
+
+func (_m *CompetitionRepository) Create(ctx context.Context, competition *data.Competition) error {
+	ret := _m.Called(ctx, competition)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *data.Competition) error); ok {
+		r0 = rf(ctx, competition)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+func (_m *CompetitionRepository) FindByKey(ctx context.Context, sport string, nameKey string) (*data.Competition, error) {
+	ret := _m.Called(ctx, sport, nameKey)
+	var r0 *data.Competition
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *data.Competition); ok {
+		r0 = rf(ctx, sport, nameKey)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*data.Competition)
+		}
+	}
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, sport, nameKey)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+func (_m *CompetitionRepository) FindBySport(ctx context.Context, sport string) ([]data.Competition, error) {
+	ret := _m.Called(ctx, sport)
+	var r0 []data.Competition
+	if rf, ok := ret.Get(0).(func(context.Context, string) []data.Competition); ok {
+		r0 = rf(ctx, sport)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]data.Competition)
+		}
+	}
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, sport)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+func NewCompetitionRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *CompetitionRepository {
+	mock := &CompetitionRepository{}
+	mock.Mock.Test(t)
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+	return mock
+}
@@ -2,6 +2,7 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	"github.com/Arlan-Z/def-betting-api/internal/data"
 	"github.com/stretchr/testify/mock"
@@ -81,6 +82,244 @@ func (_m *EventRepository) Upsert(ctx context.Context, event *data.Event) error
 	return r0
 }
 
+func (_m *EventRepository) MarkPostponed(ctx context.Context, eventID string, postponedAt time.Time) error {
+	ret := _m.Called(ctx, eventID, postponedAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) error); ok {
+		r0 = rf(ctx, eventID, postponedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+func (_m *EventRepository) MarkCanceled(ctx context.Context, eventID string) error {
+	ret := _m.Called(ctx, eventID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, eventID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+func (_m *EventRepository) FindPostponedBefore(ctx context.Context, before time.Time) ([]data.Event, error) {
+	ret := _m.Called(ctx, before)
+
+	var r0 []data.Event
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []data.Event); ok {
+		r0 = rf(ctx, before)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]data.Event)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (_m *EventRepository) RecordScheduleChange(ctx context.Context, change *data.EventScheduleChange) error {
+	ret := _m.Called(ctx, change)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *data.EventScheduleChange) error); ok {
+		r0 = rf(ctx, change)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+func (_m *EventRepository) ReopenBetting(ctx context.Context, eventID string) (bool, error) {
+	ret := _m.Called(ctx, eventID)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, eventID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, eventID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (_m *EventRepository) MarkBettingClosed(ctx context.Context, eventID string, closedAt time.Time) error {
+	ret := _m.Called(ctx, eventID, closedAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) error); ok {
+		r0 = rf(ctx, eventID, closedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+func (_m *EventRepository) FindUpcomingByTeam(ctx context.Context, teamID string, from time.Time) ([]data.Event, error) {
+	ret := _m.Called(ctx, teamID, from)
+
+	var r0 []data.Event
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) []data.Event); ok {
+		r0 = rf(ctx, teamID, from)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]data.Event)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = rf(ctx, teamID, from)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (_m *EventRepository) AssignTeamByName(ctx context.Context, sport string, name string, teamID string) (int64, error) {
+	ret := _m.Called(ctx, sport, name, teamID)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) int64); ok {
+		r0 = rf(ctx, sport, name, teamID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, sport, name, teamID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (_m *EventRepository) Search(ctx context.Context, terms []string, limit int) ([]data.EventSearchResult, error) {
+	ret := _m.Called(ctx, terms, limit)
+
+	var r0 []data.EventSearchResult
+	if rf, ok := ret.Get(0).(func(context.Context, []string, int) []data.EventSearchResult); ok {
+		r0 = rf(ctx, terms, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]data.EventSearchResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []string, int) error); ok {
+		r1 = rf(ctx, terms, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (_m *EventRepository) FindStartingBetween(ctx context.Context, sport string, from time.Time, to time.Time) ([]data.Event, error) {
+	ret := _m.Called(ctx, sport, from, to)
+
+	var r0 []data.Event
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) []data.Event); ok {
+		r0 = rf(ctx, sport, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]data.Event)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, sport, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (_m *EventRepository) MarkSuspended(ctx context.Context, eventID string, suspendedAt time.Time) error {
+	ret := _m.Called(ctx, eventID, suspendedAt)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) error); ok {
+		r0 = rf(ctx, eventID, suspendedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+func (_m *EventRepository) ResumeSuspended(ctx context.Context, eventID string) (bool, error) {
+	ret := _m.Called(ctx, eventID)
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, eventID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, eventID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+func (_m *EventRepository) FindSuspendedBefore(ctx context.Context, before time.Time) ([]data.Event, error) {
+	ret := _m.Called(ctx, before)
+	var r0 []data.Event
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []data.Event); ok {
+		r0 = rf(ctx, before)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]data.Event)
+		}
+	}
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+func (_m *EventRepository) ReplaceResult(ctx context.Context, eventID string, result data.Outcome) error {
+	ret := _m.Called(ctx, eventID, result)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, data.Outcome) error); ok {
+		r0 = rf(ctx, eventID, result)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
 func NewEventRepository(t interface {
 	mock.TestingT
 	Cleanup(func())
@@ -0,0 +1,224 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/Arlan-Z/def-betting-api/internal/data"
+	"github.com/stretchr/testify/mock"
+)
+
+type OutboxRepository struct {
+	mock.Mock
+}
+
+func (_m *OutboxRepository) FindDue(ctx context.Context, now time.Time, limit int) ([]data.PayoutIntent, error) {
+	ret := _m.Called(ctx, now, limit)
+
+	var r0 []data.PayoutIntent
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) []data.PayoutIntent); ok {
+		r0 = rf(ctx, now, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]data.PayoutIntent)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, int) error); ok {
+		r1 = rf(ctx, now, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (_m *OutboxRepository) FindByID(ctx context.Context, intentID string) (*data.PayoutIntent, error) {
+	ret := _m.Called(ctx, intentID)
+
+	var r0 *data.PayoutIntent
+	if rf, ok := ret.Get(0).(func(context.Context, string) *data.PayoutIntent); ok {
+		r0 = rf(ctx, intentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*data.PayoutIntent)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, intentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (_m *OutboxRepository) MarkDelivered(ctx context.Context, intentID string, deliveredAt time.Time) (bool, error) {
+	ret := _m.Called(ctx, intentID, deliveredAt)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) bool); ok {
+		r0 = rf(ctx, intentID, deliveredAt)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = rf(ctx, intentID, deliveredAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (_m *OutboxRepository) MarkFailed(ctx context.Context, intentID string, lastError string, failedAt time.Time) (bool, error) {
+	ret := _m.Called(ctx, intentID, lastError, failedAt)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) bool); ok {
+		r0 = rf(ctx, intentID, lastError, failedAt)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, time.Time) error); ok {
+		r1 = rf(ctx, intentID, lastError, failedAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (_m *OutboxRepository) RecordFailedAttempt(ctx context.Context, intentID string, lastError string, attemptedAt time.Time, nextAttemptAt time.Time) error {
+	ret := _m.Called(ctx, intentID, lastError, attemptedAt, nextAttemptAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time, time.Time) error); ok {
+		r0 = rf(ctx, intentID, lastError, attemptedAt, nextAttemptAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+func (_m *OutboxRepository) FindStuck(ctx context.Context) ([]data.PayoutIntent, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []data.PayoutIntent
+	if rf, ok := ret.Get(0).(func(context.Context) []data.PayoutIntent); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]data.PayoutIntent)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (_m *OutboxRepository) CountStuck(ctx context.Context) (int, error) {
+	ret := _m.Called(ctx)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (_m *OutboxRepository) FindAttempts(ctx context.Context, intentID string) ([]data.PayoutAttempt, error) {
+	ret := _m.Called(ctx, intentID)
+
+	var r0 []data.PayoutAttempt
+	if rf, ok := ret.Get(0).(func(context.Context, string) []data.PayoutAttempt); ok {
+		r0 = rf(ctx, intentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]data.PayoutAttempt)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, intentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (_m *OutboxRepository) Requeue(ctx context.Context, intentID string, now time.Time) (bool, error) {
+	ret := _m.Called(ctx, intentID, now)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) bool); ok {
+		r0 = rf(ctx, intentID, now)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = rf(ctx, intentID, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (_m *OutboxRepository) Resolve(ctx context.Context, intentID string, note string, resolvedAt time.Time) (bool, error) {
+	ret := _m.Called(ctx, intentID, note, resolvedAt)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) bool); ok {
+		r0 = rf(ctx, intentID, note, resolvedAt)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, time.Time) error); ok {
+		r1 = rf(ctx, intentID, note, resolvedAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func NewOutboxRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *OutboxRepository {
+	mock := &OutboxRepository{}
+	mock.Mock.Test(t)
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+	return mock
+}
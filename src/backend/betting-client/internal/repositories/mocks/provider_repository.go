@@ -0,0 +1,317 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/Arlan-Z/def-betting-api/internal/data"
+	"github.com/stretchr/testify/mock"
+)
+
+type ProviderRepository struct {
+	mock.Mock
+}
+
+func (_m *ProviderRepository) FindSnapshot(ctx context.Context, provider string, providerEventID string) (*data.ProviderEventSnapshot, error) {
+	ret := _m.Called(ctx, provider, providerEventID)
+	var r0 *data.ProviderEventSnapshot
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *data.ProviderEventSnapshot); ok {
+		r0 = rf(ctx, provider, providerEventID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*data.ProviderEventSnapshot)
+		}
+	}
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, provider, providerEventID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+func (_m *ProviderRepository) SaveSnapshot(ctx context.Context, snapshot *data.ProviderEventSnapshot) error {
+	ret := _m.Called(ctx, snapshot)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *data.ProviderEventSnapshot) error); ok {
+		r0 = rf(ctx, snapshot)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+func (_m *ProviderRepository) FindSnapshotsByEvent(ctx context.Context, eventID string) ([]data.ProviderEventSnapshot, error) {
+	ret := _m.Called(ctx, eventID)
+	var r0 []data.ProviderEventSnapshot
+	if rf, ok := ret.Get(0).(func(context.Context, string) []data.ProviderEventSnapshot); ok {
+		r0 = rf(ctx, eventID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]data.ProviderEventSnapshot)
+		}
+	}
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, eventID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+func (_m *ProviderRepository) OpenResultConflict(ctx context.Context, conflict *data.ResultConflict) error {
+	ret := _m.Called(ctx, conflict)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *data.ResultConflict) error); ok {
+		r0 = rf(ctx, conflict)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+func (_m *ProviderRepository) FindResultConflict(ctx context.Context, eventID string) (*data.ResultConflict, error) {
+	ret := _m.Called(ctx, eventID)
+	var r0 *data.ResultConflict
+	if rf, ok := ret.Get(0).(func(context.Context, string) *data.ResultConflict); ok {
+		r0 = rf(ctx, eventID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*data.ResultConflict)
+		}
+	}
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, eventID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+func (_m *ProviderRepository) FindOpenResultConflicts(ctx context.Context) ([]data.ResultConflict, error) {
+	ret := _m.Called(ctx)
+	var r0 []data.ResultConflict
+	if rf, ok := ret.Get(0).(func(context.Context) []data.ResultConflict); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]data.ResultConflict)
+		}
+	}
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+func (_m *ProviderRepository) ResolveResultConflict(ctx context.Context, eventID string, result string, resolvedAt time.Time) error {
+	ret := _m.Called(ctx, eventID, result, resolvedAt)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) error); ok {
+		r0 = rf(ctx, eventID, result, resolvedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+func (_m *ProviderRepository) FindSyncState(ctx context.Context, provider string) (*data.SourceSyncState, error) {
+	ret := _m.Called(ctx, provider)
+	var r0 *data.SourceSyncState
+	if rf, ok := ret.Get(0).(func(context.Context, string) *data.SourceSyncState); ok {
+		r0 = rf(ctx, provider)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*data.SourceSyncState)
+		}
+	}
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, provider)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+func (_m *ProviderRepository) SaveSyncState(ctx context.Context, state *data.SourceSyncState) error {
+	ret := _m.Called(ctx, state)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *data.SourceSyncState) error); ok {
+		r0 = rf(ctx, state)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+func (_m *ProviderRepository) MarkSeen(ctx context.Context, provider string, providerEventID string, seenAt time.Time) error {
+	ret := _m.Called(ctx, provider, providerEventID, seenAt)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) error); ok {
+		r0 = rf(ctx, provider, providerEventID, seenAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+func (_m *ProviderRepository) RecordMissedCycle(ctx context.Context, provider string, fetchStartedAt time.Time) (int64, error) {
+	ret := _m.Called(ctx, provider, fetchStartedAt)
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) int64); ok {
+		r0 = rf(ctx, provider, fetchStartedAt)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = rf(ctx, provider, fetchStartedAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+func (_m *ProviderRepository) FindMissingEvents(ctx context.Context, minMissedCycles int) ([]data.MissingEvent, error) {
+	ret := _m.Called(ctx, minMissedCycles)
+	var r0 []data.MissingEvent
+	if rf, ok := ret.Get(0).(func(context.Context, int) []data.MissingEvent); ok {
+		r0 = rf(ctx, minMissedCycles)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]data.MissingEvent)
+		}
+	}
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, minMissedCycles)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+func (_m *ProviderRepository) RecordResultSighting(ctx context.Context, eventID string, result data.Outcome, runID string, seenAt time.Time) (*data.ResultConfirmation, error) {
+	ret := _m.Called(ctx, eventID, result, runID, seenAt)
+	var r0 *data.ResultConfirmation
+	if rf, ok := ret.Get(0).(func(context.Context, string, data.Outcome, string, time.Time) *data.ResultConfirmation); ok {
+		r0 = rf(ctx, eventID, result, runID, seenAt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*data.ResultConfirmation)
+		}
+	}
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, data.Outcome, string, time.Time) error); ok {
+		r1 = rf(ctx, eventID, result, runID, seenAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+func (_m *ProviderRepository) RecordConfirmationCycle(ctx context.Context, provider string, runID string, seenAt time.Time) error {
+	ret := _m.Called(ctx, provider, runID, seenAt)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) error); ok {
+		r0 = rf(ctx, provider, runID, seenAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+func (_m *ProviderRepository) FindResultConfirmation(ctx context.Context, eventID string) (*data.ResultConfirmation, error) {
+	ret := _m.Called(ctx, eventID)
+	var r0 *data.ResultConfirmation
+	if rf, ok := ret.Get(0).(func(context.Context, string) *data.ResultConfirmation); ok {
+		r0 = rf(ctx, eventID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*data.ResultConfirmation)
+		}
+	}
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, eventID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+func (_m *ProviderRepository) FindPendingResultConfirmations(ctx context.Context) ([]data.ResultConfirmation, error) {
+	ret := _m.Called(ctx)
+	var r0 []data.ResultConfirmation
+	if rf, ok := ret.Get(0).(func(context.Context) []data.ResultConfirmation); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]data.ResultConfirmation)
+		}
+	}
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+func (_m *ProviderRepository) ConfirmResult(ctx context.Context, eventID string, confirmedBy string, confirmedAt time.Time) error {
+	ret := _m.Called(ctx, eventID, confirmedBy, confirmedAt)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) error); ok {
+		r0 = rf(ctx, eventID, confirmedBy, confirmedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+func (_m *ProviderRepository) DiscardResultConfirmation(ctx context.Context, eventID string) error {
+	ret := _m.Called(ctx, eventID)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, eventID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+func (_m *ProviderRepository) FindEventsReportedOnlyBy(ctx context.Context, providers []string) ([]string, error) {
+	ret := _m.Called(ctx, providers)
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []string); ok {
+		r0 = rf(ctx, providers)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, providers)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+func NewProviderRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ProviderRepository {
+	mock := &ProviderRepository{}
+	mock.Mock.Test(t)
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+	return mock
+}
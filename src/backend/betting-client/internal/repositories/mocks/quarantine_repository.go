@@ -0,0 +1,102 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/Arlan-Z/def-betting-api/internal/data"
+	"github.com/stretchr/testify/mock"
+)
+
+type QuarantineRepository struct {
+	mock.Mock
+}
+
+func (_m *QuarantineRepository) Save(ctx context.Context, entry *data.QuarantinedEvent) error {
+	ret := _m.Called(ctx, entry)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *data.QuarantinedEvent) error); ok {
+		r0 = rf(ctx, entry)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+func (_m *QuarantineRepository) FindByID(ctx context.Context, entryID string) (*data.QuarantinedEvent, error) {
+	ret := _m.Called(ctx, entryID)
+	var r0 *data.QuarantinedEvent
+	if rf, ok := ret.Get(0).(func(context.Context, string) *data.QuarantinedEvent); ok {
+		r0 = rf(ctx, entryID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*data.QuarantinedEvent)
+		}
+	}
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, entryID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+func (_m *QuarantineRepository) FindByStatus(ctx context.Context, status data.QuarantineStatus) ([]data.QuarantinedEvent, error) {
+	ret := _m.Called(ctx, status)
+	var r0 []data.QuarantinedEvent
+	if rf, ok := ret.Get(0).(func(context.Context, data.QuarantineStatus) []data.QuarantinedEvent); ok {
+		r0 = rf(ctx, status)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]data.QuarantinedEvent)
+		}
+	}
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, data.QuarantineStatus) error); ok {
+		r1 = rf(ctx, status)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+func (_m *QuarantineRepository) Resolve(ctx context.Context, entryID string, status data.QuarantineStatus, payload string, note string, resolvedAt time.Time) error {
+	ret := _m.Called(ctx, entryID, status, payload, note, resolvedAt)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, data.QuarantineStatus, string, string, time.Time) error); ok {
+		r0 = rf(ctx, entryID, status, payload, note, resolvedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+func (_m *QuarantineRepository) CountByErrorCode(ctx context.Context) ([]data.QuarantineStat, error) {
+	ret := _m.Called(ctx)
+	var r0 []data.QuarantineStat
+	if rf, ok := ret.Get(0).(func(context.Context) []data.QuarantineStat); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]data.QuarantineStat)
+		}
+	}
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+func NewQuarantineRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *QuarantineRepository {
+	mock := &QuarantineRepository{}
+	mock.Mock.Test(t)
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+	return mock
+}
@@ -0,0 +1,82 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/Arlan-Z/def-betting-api/internal/data"
+	"github.com/stretchr/testify/mock"
+)
+
+type ResettlementRepository struct {
+	mock.Mock
+}
+
+func (_m *ResettlementRepository) Create(ctx context.Context, resettlement *data.Resettlement) error {
+	ret := _m.Called(ctx, resettlement)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *data.Resettlement) error); ok {
+		r0 = rf(ctx, resettlement)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+func (_m *ResettlementRepository) Finish(ctx context.Context, resettlement *data.Resettlement) error {
+	ret := _m.Called(ctx, resettlement)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *data.Resettlement) error); ok {
+		r0 = rf(ctx, resettlement)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+func (_m *ResettlementRepository) FindByID(ctx context.Context, resettlementID string) (*data.Resettlement, error) {
+	ret := _m.Called(ctx, resettlementID)
+	var r0 *data.Resettlement
+	if rf, ok := ret.Get(0).(func(context.Context, string) *data.Resettlement); ok {
+		r0 = rf(ctx, resettlementID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*data.Resettlement)
+		}
+	}
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, resettlementID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+func (_m *ResettlementRepository) FindByEvent(ctx context.Context, eventID string) ([]data.Resettlement, error) {
+	ret := _m.Called(ctx, eventID)
+	var r0 []data.Resettlement
+	if rf, ok := ret.Get(0).(func(context.Context, string) []data.Resettlement); ok {
+		r0 = rf(ctx, eventID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]data.Resettlement)
+		}
+	}
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, eventID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+func NewResettlementRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ResettlementRepository {
+	mock := &ResettlementRepository{}
+	mock.Mock.Test(t)
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+	return mock
+}
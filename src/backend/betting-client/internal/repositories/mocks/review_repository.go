@@ -0,0 +1,83 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/Arlan-Z/def-betting-api/internal/data"
+	"github.com/stretchr/testify/mock"
+)
+
+type ReviewRepository struct {
+	mock.Mock
+}
+
+func (_m *ReviewRepository) Enqueue(ctx context.Context, review *data.BetReview) error {
+	ret := _m.Called(ctx, review)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *data.BetReview) error); ok {
+		r0 = rf(ctx, review)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+func (_m *ReviewRepository) FindByStatus(ctx context.Context, status data.ReviewStatus) ([]data.BetReview, error) {
+	ret := _m.Called(ctx, status)
+	var r0 []data.BetReview
+	if rf, ok := ret.Get(0).(func(context.Context, data.ReviewStatus) []data.BetReview); ok {
+		r0 = rf(ctx, status)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]data.BetReview)
+		}
+	}
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, data.ReviewStatus) error); ok {
+		r1 = rf(ctx, status)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+func (_m *ReviewRepository) FindByID(ctx context.Context, reviewID string) (*data.BetReview, error) {
+	ret := _m.Called(ctx, reviewID)
+	var r0 *data.BetReview
+	if rf, ok := ret.Get(0).(func(context.Context, string) *data.BetReview); ok {
+		r0 = rf(ctx, reviewID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*data.BetReview)
+		}
+	}
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, reviewID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+func (_m *ReviewRepository) Resolve(ctx context.Context, reviewID string, status data.ReviewStatus, note string, resolvedAt time.Time) error {
+	ret := _m.Called(ctx, reviewID, status, note, resolvedAt)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, data.ReviewStatus, string, time.Time) error); ok {
+		r0 = rf(ctx, reviewID, status, note, resolvedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+func NewReviewRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ReviewRepository {
+	mock := &ReviewRepository{}
+	mock.Mock.Test(t)
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+	return mock
+}
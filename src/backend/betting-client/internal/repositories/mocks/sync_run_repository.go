@@ -0,0 +1,119 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/Arlan-Z/def-betting-api/internal/data"
+	"github.com/stretchr/testify/mock"
+)
+
+type SyncRunRepository struct {
+	mock.Mock
+}
+
+func (_m *SyncRunRepository) Create(ctx context.Context, run *data.SyncRun) error {
+	ret := _m.Called(ctx, run)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *data.SyncRun) error); ok {
+		r0 = rf(ctx, run)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+func (_m *SyncRunRepository) Finish(ctx context.Context, run *data.SyncRun) error {
+	ret := _m.Called(ctx, run)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *data.SyncRun) error); ok {
+		r0 = rf(ctx, run)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+func (_m *SyncRunRepository) FindByID(ctx context.Context, runID string) (*data.SyncRun, error) {
+	ret := _m.Called(ctx, runID)
+	var r0 *data.SyncRun
+	if rf, ok := ret.Get(0).(func(context.Context, string) *data.SyncRun); ok {
+		r0 = rf(ctx, runID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*data.SyncRun)
+		}
+	}
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, runID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+func (_m *SyncRunRepository) FindRecent(ctx context.Context, provider string, limit int) ([]data.SyncRun, error) {
+	ret := _m.Called(ctx, provider, limit)
+	var r0 []data.SyncRun
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) []data.SyncRun); ok {
+		r0 = rf(ctx, provider, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]data.SyncRun)
+		}
+	}
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, provider, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+func (_m *SyncRunRepository) FindLastSuccessful(ctx context.Context) (*data.SyncRun, error) {
+	ret := _m.Called(ctx)
+	var r0 *data.SyncRun
+	if rf, ok := ret.Get(0).(func(context.Context) *data.SyncRun); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*data.SyncRun)
+		}
+	}
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+func (_m *SyncRunRepository) DeleteStartedBefore(ctx context.Context, before time.Time) (int64, error) {
+	ret := _m.Called(ctx, before)
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = rf(ctx, before)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+func NewSyncRunRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *SyncRunRepository {
+	mock := &SyncRunRepository{}
+	mock.Mock.Test(t)
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+	return mock
+}
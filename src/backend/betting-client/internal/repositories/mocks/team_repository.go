@@ -0,0 +1,181 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/Arlan-Z/def-betting-api/internal/data"
+	"github.com/stretchr/testify/mock"
+)
+
+type TeamRepository struct {
+	mock.Mock
+}
+
+func (_m *TeamRepository) Create(ctx context.Context, team *data.Team) error {
+	ret := _m.Called(ctx, team)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *data.Team) error); ok {
+		r0 = rf(ctx, team)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+func (_m *TeamRepository) FindByID(ctx context.Context, teamID string) (*data.Team, error) {
+	ret := _m.Called(ctx, teamID)
+	var r0 *data.Team
+	if rf, ok := ret.Get(0).(func(context.Context, string) *data.Team); ok {
+		r0 = rf(ctx, teamID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*data.Team)
+		}
+	}
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, teamID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+func (_m *TeamRepository) FindBySport(ctx context.Context, sport string) ([]data.Team, error) {
+	ret := _m.Called(ctx, sport)
+	var r0 []data.Team
+	if rf, ok := ret.Get(0).(func(context.Context, string) []data.Team); ok {
+		r0 = rf(ctx, sport)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]data.Team)
+		}
+	}
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, sport)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+func (_m *TeamRepository) FindByAlias(ctx context.Context, sport string, aliasKey string) (*data.Team, error) {
+	ret := _m.Called(ctx, sport, aliasKey)
+	var r0 *data.Team
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *data.Team); ok {
+		r0 = rf(ctx, sport, aliasKey)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*data.Team)
+		}
+	}
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, sport, aliasKey)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+func (_m *TeamRepository) FindAliasKeys(ctx context.Context, sport string) (map[string][]string, error) {
+	ret := _m.Called(ctx, sport)
+	var r0 map[string][]string
+	if rf, ok := ret.Get(0).(func(context.Context, string) map[string][]string); ok {
+		r0 = rf(ctx, sport)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string][]string)
+		}
+	}
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, sport)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+func (_m *TeamRepository) AddAlias(ctx context.Context, sport string, aliasKey string, teamID string) error {
+	ret := _m.Called(ctx, sport, aliasKey, teamID)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, sport, aliasKey, teamID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+func (_m *TeamRepository) EnqueueUnresolved(ctx context.Context, alias *data.UnresolvedTeamAlias) error {
+	ret := _m.Called(ctx, alias)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *data.UnresolvedTeamAlias) error); ok {
+		r0 = rf(ctx, alias)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+func (_m *TeamRepository) FindUnresolvedByStatus(ctx context.Context, status data.AliasStatus) ([]data.UnresolvedTeamAlias, error) {
+	ret := _m.Called(ctx, status)
+	var r0 []data.UnresolvedTeamAlias
+	if rf, ok := ret.Get(0).(func(context.Context, data.AliasStatus) []data.UnresolvedTeamAlias); ok {
+		r0 = rf(ctx, status)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]data.UnresolvedTeamAlias)
+		}
+	}
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, data.AliasStatus) error); ok {
+		r1 = rf(ctx, status)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+func (_m *TeamRepository) FindUnresolvedByID(ctx context.Context, aliasID string) (*data.UnresolvedTeamAlias, error) {
+	ret := _m.Called(ctx, aliasID)
+	var r0 *data.UnresolvedTeamAlias
+	if rf, ok := ret.Get(0).(func(context.Context, string) *data.UnresolvedTeamAlias); ok {
+		r0 = rf(ctx, aliasID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*data.UnresolvedTeamAlias)
+		}
+	}
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, aliasID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+func (_m *TeamRepository) MarkAliasResolved(ctx context.Context, aliasID string, teamID string, resolvedAt time.Time) error {
+	ret := _m.Called(ctx, aliasID, teamID, resolvedAt)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) error); ok {
+		r0 = rf(ctx, aliasID, teamID, resolvedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+func NewTeamRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *TeamRepository {
+	mock := &TeamRepository{}
+	mock.Mock.Test(t)
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+	return mock
+}
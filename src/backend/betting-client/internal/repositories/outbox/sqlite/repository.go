@@ -0,0 +1,301 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Arlan-Z/def-betting-api/internal/data"
+	"github.com/Arlan-Z/def-betting-api/internal/pkg/fence"
+	"github.com/jmoiron/sqlx"
+)
+
+const outboxColumns = `id, bet_id, user_id, amount, kind, status, attempts, next_attempt_at, last_error, created_at, finished_at, resolution_note, event_id, predicted_outcome, odds, stake, sequence`
+
+const attemptColumns = `id, payout_id, bet_id, attempt, attempted_at, error`
+
+// recordAttemptQuery numbers attempts per intent across requeues, which reset the attempt counter.
+const recordAttemptQuery = `INSERT INTO payout_attempts (payout_id, bet_id, attempt, attempted_at, error)
+              SELECT id, bet_id, (SELECT COUNT(*) FROM payout_attempts WHERE payout_id = outbox.id) + 1, ?, ?
+              FROM outbox WHERE id = ?`
+
+// nextSequenceQuery numbers an intent after the earlier ones of its bet and kind.
+const nextSequenceQuery = `SELECT COALESCE(MAX(sequence), 0) + 1 FROM outbox WHERE bet_id = ? AND kind = ?`
+
+const insertIntentQuery = `INSERT INTO outbox (id, bet_id, user_id, amount, kind, status, attempts, next_attempt_at, last_error, created_at, finished_at,
+                                  event_id, predicted_outcome, odds, stake, sequence)
+              VALUES (:id, :bet_id, :user_id, :amount, :kind, :status, :attempts, :next_attempt_at, :last_error, :created_at, :finished_at,
+                      :event_id, :predicted_outcome, :odds, :stake, :sequence)`
+
+// OutboxRepository reads and finishes the payout intents that settlements put into the
+// outbox. The dispatcher's writes are rejected with fence.ErrLeaseLost once its leader
+// lost the lease.
+type OutboxRepository struct {
+	db *sqlx.DB
+}
+
+func NewOutboxRepository(db *sqlx.DB) *OutboxRepository {
+	return &OutboxRepository{db: db}
+}
+
+// FindDue returns pending intents whose next attempt is due, oldest first.
+func (r *OutboxRepository) FindDue(ctx context.Context, now time.Time, limit int) ([]data.PayoutIntent, error) {
+	intents := make([]data.PayoutIntent, 0)
+	query := `SELECT ` + outboxColumns + `
+              FROM outbox
+              WHERE status = ? AND next_attempt_at <= ?
+              ORDER BY next_attempt_at ASC, created_at ASC
+              LIMIT ?`
+
+	err := r.db.SelectContext(ctx, &intents, query, data.OutboxPending, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error querying due payouts: %w", err)
+	}
+	return intents, nil
+}
+
+// FindStuck returns intents that failed for good or failed at least once and are still
+// being retried, oldest first.
+func (r *OutboxRepository) FindStuck(ctx context.Context) ([]data.PayoutIntent, error) {
+	intents := make([]data.PayoutIntent, 0)
+	query := `SELECT ` + outboxColumns + `
+              FROM outbox
+              WHERE status = ? OR (status = ? AND attempts > 0)
+              ORDER BY created_at ASC`
+
+	err := r.db.SelectContext(ctx, &intents, query, data.OutboxFailed, data.OutboxPending)
+	if err != nil {
+		return nil, fmt.Errorf("error querying stuck payouts: %w", err)
+	}
+	return intents, nil
+}
+
+// CountStuck returns how many intents FindStuck would list.
+func (r *OutboxRepository) CountStuck(ctx context.Context) (int, error) {
+	var count int
+	query := `SELECT COUNT(*) FROM outbox WHERE status = ? OR (status = ? AND attempts > 0)`
+
+	if err := r.db.GetContext(ctx, &count, query, data.OutboxFailed, data.OutboxPending); err != nil {
+		return 0, fmt.Errorf("error counting stuck payouts: %w", err)
+	}
+	return count, nil
+}
+
+// FindAttempts returns the delivery attempts of the intent in the order they were made.
+func (r *OutboxRepository) FindAttempts(ctx context.Context, intentID string) ([]data.PayoutAttempt, error) {
+	attempts := make([]data.PayoutAttempt, 0)
+	query := `SELECT ` + attemptColumns + `
+              FROM payout_attempts
+              WHERE payout_id = ?
+              ORDER BY attempt ASC`
+
+	err := r.db.SelectContext(ctx, &attempts, query, intentID)
+	if err != nil {
+		return nil, fmt.Errorf("error querying attempts of payout %s: %w", intentID, err)
+	}
+	return attempts, nil
+}
+
+func (r *OutboxRepository) FindByID(ctx context.Context, intentID string) (*data.PayoutIntent, error) {
+	var intent data.PayoutIntent
+	query := `SELECT ` + outboxColumns + ` FROM outbox WHERE id = ?`
+
+	err := r.db.GetContext(ctx, &intent, query, intentID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error querying payout %s: %w", intentID, err)
+	}
+	return &intent, nil
+}
+
+// MarkDelivered finishes a pending intent and moves its bet on, e.g. from Won to Paid.
+// An intent canceled by a later settlement while it was being sent is recorded as
+// delivered all the same, since the money went out, and a reversal of its amount is
+// queued: the later settlement was made as if it had never been paid. It reports false
+// if the intent was neither pending nor canceled.
+func (r *OutboxRepository) MarkDelivered(ctx context.Context, intentID string, deliveredAt time.Time) (bool, error) {
+	return r.finish(ctx, intentID, data.OutboxDelivered, nil, deliveredAt)
+}
+
+// MarkFailed gives up on a pending intent and marks its bet as Failed.
+func (r *OutboxRepository) MarkFailed(ctx context.Context, intentID string, lastError string, failedAt time.Time) (bool, error) {
+	return r.finish(ctx, intentID, data.OutboxFailed, &lastError, failedAt)
+}
+
+func (r *OutboxRepository) finish(ctx context.Context, intentID string, status data.OutboxStatus, lastError *string, finishedAt time.Time) (bool, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("error starting transaction to finish payout %s: %w", intentID, err)
+	}
+	defer tx.Rollback()
+
+	if err := fence.Check(ctx, tx, time.Now().UTC()); err != nil {
+		return false, fmt.Errorf("error finishing payout %s: %w", intentID, err)
+	}
+
+	var intent data.PayoutIntent
+	selectQuery := `SELECT ` + outboxColumns + ` FROM outbox WHERE id = ?`
+	if err := tx.GetContext(ctx, &intent, selectQuery, intentID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("error querying payout %s: %w", intentID, err)
+	}
+	late := intent.Status == data.OutboxCanceled && status == data.OutboxDelivered
+	if intent.Status != data.OutboxPending && !late {
+		return false, nil
+	}
+
+	updateQuery := `UPDATE outbox SET status = ?, attempts = attempts + 1, last_error = COALESCE(?, last_error), finished_at = ?
+              WHERE id = ?`
+	if _, err := tx.ExecContext(ctx, updateQuery, status, lastError, finishedAt, intentID); err != nil {
+		return false, fmt.Errorf("error finishing payout %s: %w", intentID, err)
+	}
+	if _, err := tx.ExecContext(ctx, recordAttemptQuery, finishedAt, lastError, intentID); err != nil {
+		return false, fmt.Errorf("error recording attempt of payout %s: %w", intentID, err)
+	}
+
+	if late {
+		reversal := intent.Reversal(finishedAt)
+		if err := tx.GetContext(ctx, &reversal.Sequence, nextSequenceQuery, reversal.BetID, reversal.Kind); err != nil {
+			return false, fmt.Errorf("error numbering reversal of payout %s: %w", intentID, err)
+		}
+		if _, err := tx.NamedExecContext(ctx, insertIntentQuery, reversal); err != nil {
+			return false, fmt.Errorf("error queueing reversal of payout %s: %w", intentID, err)
+		}
+	} else if awaiting, delivered, ok := intent.Kind.BetStatuses(); ok {
+		// The bet only moves on if it is still in the state the payout was queued for.
+		betStatus := delivered
+		if status == data.OutboxFailed {
+			betStatus = data.StatusFailed
+		}
+		betQuery := `UPDATE bets SET status = ? WHERE id = ? AND status = ?`
+		if _, err := tx.ExecContext(ctx, betQuery, betStatus, intent.BetID, awaiting); err != nil {
+			return false, fmt.Errorf("error updating status of bet %s after payout: %w", intent.BetID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("error committing payout %s: %w", intentID, err)
+	}
+	return true, nil
+}
+
+// RecordFailedAttempt counts a failed delivery and schedules the next one.
+func (r *OutboxRepository) RecordFailedAttempt(ctx context.Context, intentID string, lastError string, attemptedAt time.Time, nextAttemptAt time.Time) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error starting transaction to record attempt of payout %s: %w", intentID, err)
+	}
+	defer tx.Rollback()
+
+	if err := fence.Check(ctx, tx, time.Now().UTC()); err != nil {
+		return fmt.Errorf("error recording attempt of payout %s: %w", intentID, err)
+	}
+
+	query := `UPDATE outbox SET attempts = attempts + 1, last_error = ?, next_attempt_at = ?
+              WHERE id = ? AND status = ?`
+	res, err := tx.ExecContext(ctx, query, lastError, nextAttemptAt, intentID, data.OutboxPending)
+	if err != nil {
+		return fmt.Errorf("error recording failed attempt of payout %s: %w", intentID, err)
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error checking failed attempt of payout %s: %w", intentID, err)
+	}
+	if rows == 0 {
+		// The intent was canceled or resolved meanwhile, so the attempt is not recorded.
+		return nil
+	}
+	if _, err := tx.ExecContext(ctx, recordAttemptQuery, attemptedAt, lastError, intentID); err != nil {
+		return fmt.Errorf("error recording attempt of payout %s: %w", intentID, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error committing attempt of payout %s: %w", intentID, err)
+	}
+	return nil
+}
+
+// Requeue makes a failed intent due again with a fresh attempt budget and moves the
+// Failed bet back to the status it waits for the payout in. It reports false if the
+// intent has not failed, e.g. while the dispatcher still retries it.
+func (r *OutboxRepository) Requeue(ctx context.Context, intentID string, now time.Time) (bool, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("error starting transaction to requeue payout %s: %w", intentID, err)
+	}
+	defer tx.Rollback()
+
+	intent, err := r.findFailedTx(ctx, tx, intentID)
+	if intent == nil || err != nil {
+		return false, err
+	}
+
+	updateQuery := `UPDATE outbox SET status = ?, attempts = 0, next_attempt_at = ?, finished_at = NULL WHERE id = ?`
+	if _, err := tx.ExecContext(ctx, updateQuery, data.OutboxPending, now, intentID); err != nil {
+		return false, fmt.Errorf("error requeueing payout %s: %w", intentID, err)
+	}
+	if awaiting, _, ok := intent.Kind.BetStatuses(); ok {
+		betQuery := `UPDATE bets SET status = ? WHERE id = ? AND status = ?`
+		if _, err := tx.ExecContext(ctx, betQuery, awaiting, intent.BetID, data.StatusFailed); err != nil {
+			return false, fmt.Errorf("error updating status of bet %s for requeued payout: %w", intent.BetID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("error committing requeue of payout %s: %w", intentID, err)
+	}
+	return true, nil
+}
+
+// Resolve records that a failed intent was settled by hand and moves its bet on as if the
+// payout had been delivered. It reports false if the intent has not failed: an intent
+// that is still retried may be delivered at any moment, and would be paid twice.
+func (r *OutboxRepository) Resolve(ctx context.Context, intentID string, note string, resolvedAt time.Time) (bool, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("error starting transaction to resolve payout %s: %w", intentID, err)
+	}
+	defer tx.Rollback()
+
+	intent, err := r.findFailedTx(ctx, tx, intentID)
+	if intent == nil || err != nil {
+		return false, err
+	}
+
+	updateQuery := `UPDATE outbox SET status = ?, resolution_note = ?, finished_at = ? WHERE id = ?`
+	if _, err := tx.ExecContext(ctx, updateQuery, data.OutboxResolved, note, resolvedAt, intentID); err != nil {
+		return false, fmt.Errorf("error resolving payout %s: %w", intentID, err)
+	}
+	if _, delivered, ok := intent.Kind.BetStatuses(); ok {
+		betQuery := `UPDATE bets SET status = ? WHERE id = ? AND status = ?`
+		if _, err := tx.ExecContext(ctx, betQuery, delivered, intent.BetID, data.StatusFailed); err != nil {
+			return false, fmt.Errorf("error updating status of bet %s for resolved payout: %w", intent.BetID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("error committing resolution of payout %s: %w", intentID, err)
+	}
+	return true, nil
+}
+
+func (r *OutboxRepository) findFailedTx(ctx context.Context, tx *sqlx.Tx, intentID string) (*data.PayoutIntent, error) {
+	var intent data.PayoutIntent
+	query := `SELECT ` + outboxColumns + ` FROM outbox WHERE id = ?`
+	if err := tx.GetContext(ctx, &intent, query, intentID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error querying payout %s: %w", intentID, err)
+	}
+	if intent.Status != data.OutboxFailed {
+		return nil, nil
+	}
+	return &intent, nil
+}
@@ -0,0 +1,399 @@
+package sqlite_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/Arlan-Z/def-betting-api/internal/data"
+	betrepo "github.com/Arlan-Z/def-betting-api/internal/repositories/bet/sqlite"
+	outboxrepo "github.com/Arlan-Z/def-betting-api/internal/repositories/outbox/sqlite"
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/sqlite3"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type OutboxRepositorySuite struct {
+	suite.Suite
+	db      *sqlx.DB
+	repo    *outboxrepo.OutboxRepository
+	betRepo *betrepo.BetRepository
+	dbPath  string
+	migrate *migrate.Migrate
+}
+
+func (s *OutboxRepositorySuite) SetupSuite() {
+	tempFile, err := os.CreateTemp("", "test_outbox_*.db")
+	require.NoError(s.T(), err)
+	s.dbPath = tempFile.Name()
+	tempFile.Close()
+
+	db, err := sqlx.Open("sqlite3", s.dbPath+"?_foreign_keys=on")
+	require.NoError(s.T(), err)
+	s.db = db
+
+	driver, err := sqlite3.WithInstance(db.DB, &sqlite3.Config{})
+	require.NoError(s.T(), err)
+
+	m, err := migrate.NewWithDatabaseInstance(fmt.Sprintf("file://%s", "../../../../migrations"), "sqlite3", driver)
+	require.NoError(s.T(), err)
+	s.migrate = m
+	require.NoError(s.T(), s.migrate.Up(), "Failed to run migrations UP")
+
+	s.repo = outboxrepo.NewOutboxRepository(s.db)
+	s.betRepo = betrepo.NewBetRepository(s.db)
+}
+
+func (s *OutboxRepositorySuite) TearDownSuite() {
+	if s.migrate != nil {
+		if err := s.migrate.Down(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+			s.T().Logf("Warning: failed to run migrations DOWN: %v", err)
+		}
+		s.migrate.Close()
+	}
+	if s.db != nil {
+		require.NoError(s.T(), s.db.Close())
+	}
+	require.NoError(s.T(), os.Remove(s.dbPath))
+}
+
+func (s *OutboxRepositorySuite) BeforeTest(suiteName, testName string) {
+	for _, table := range []string{"payout_attempts", "outbox", "bets", "events"} {
+		_, err := s.db.Exec("DELETE FROM " + table + ";")
+		require.NoError(s.T(), err)
+	}
+	_, err := s.db.Exec(`INSERT INTO events (id, event_name, home_team, away_team, home_win_chance, away_win_chance, draw_chance,
+                                           event_start_date, event_end_date, event_result, is_active, type)
+                         VALUES ('event-1', 'A vs B', 'A', 'B', 2, 3, 4, '2030-06-01 18:00:00', '2030-06-01 20:00:00', 'HomeWin', 0, 'Football')`)
+	require.NoError(s.T(), err)
+	_, err = s.db.Exec(`INSERT INTO bets (id, user_id, event_id, amount, predicted_outcome, recorded_home_win_chance,
+                                         recorded_away_win_chance, recorded_draw_chance, placed_at, status, payout_amount)
+                        VALUES ('bet-1', 'user-1', 'event-1', 10, 'HomeWin', 2, 3, 4, '2030-06-01 17:00:00', 'Pending', 0)`)
+	require.NoError(s.T(), err)
+}
+
+func TestOutboxRepositorySuite(t *testing.T) {
+	suite.Run(t, new(OutboxRepositorySuite))
+}
+
+func (s *OutboxRepositorySuite) settleWon(now time.Time) *data.PayoutIntent {
+	bet, err := s.betRepo.FindByID(context.Background(), "bet-1")
+	require.NoError(s.T(), err)
+	intent := data.NewPayoutIntent(*bet, data.PayoutWin, 20, now)
+	settled, err := s.betRepo.SettleWithPayout(context.Background(), "bet-1", data.StatusPending, data.StatusWon, 20, intent)
+	require.NoError(s.T(), err)
+	require.True(s.T(), settled)
+	return intent
+}
+
+func (s *OutboxRepositorySuite) betStatus() data.BetStatus {
+	bet, err := s.betRepo.FindByID(context.Background(), "bet-1")
+	require.NoError(s.T(), err)
+	return bet.Status
+}
+
+func (s *OutboxRepositorySuite) TestSettleQueuesPayoutAndDeliveryPaysBet() {
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+	intent := s.settleWon(now)
+	require.Equal(s.T(), data.StatusWon, s.betStatus())
+
+	due, err := s.repo.FindDue(ctx, now.Add(-time.Second), 10)
+	require.NoError(s.T(), err)
+	require.Empty(s.T(), due)
+
+	due, err = s.repo.FindDue(ctx, now, 10)
+	require.NoError(s.T(), err)
+	require.Len(s.T(), due, 1)
+	require.Equal(s.T(), intent.ID, due[0].ID)
+	require.Equal(s.T(), "user-1", due[0].UserID)
+	require.Equal(s.T(), 20.0, due[0].Amount)
+	require.Equal(s.T(), intent.Notification("USD"), due[0].Notification("USD"), "bet details are stored with the intent")
+
+	delivered, err := s.repo.MarkDelivered(ctx, intent.ID, now)
+	require.NoError(s.T(), err)
+	require.True(s.T(), delivered)
+	require.Equal(s.T(), data.StatusPaid, s.betStatus())
+
+	found, err := s.repo.FindByID(ctx, intent.ID)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), data.OutboxDelivered, found.Status)
+	require.Equal(s.T(), 1, found.Attempts)
+	require.NotNil(s.T(), found.FinishedAt)
+
+	delivered, err = s.repo.MarkDelivered(ctx, intent.ID, now)
+	require.NoError(s.T(), err)
+	require.False(s.T(), delivered)
+}
+
+func (s *OutboxRepositorySuite) TestConcurrentSettlementQueuesNoSecondPayout() {
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+	intent := s.settleWon(now)
+
+	bet, err := s.betRepo.FindByID(ctx, "bet-1")
+	require.NoError(s.T(), err)
+	again := data.NewPayoutIntent(*bet, data.PayoutWin, 20, now)
+	settled, err := s.betRepo.SettleWithPayout(ctx, "bet-1", data.StatusPending, data.StatusWon, 20, again)
+	require.NoError(s.T(), err)
+	require.False(s.T(), settled)
+
+	due, err := s.repo.FindDue(ctx, now, 10)
+	require.NoError(s.T(), err)
+	require.Len(s.T(), due, 1)
+	require.Equal(s.T(), intent.ID, due[0].ID)
+}
+
+func (s *OutboxRepositorySuite) TestPayoutsOfABetAreNumberedPerKind() {
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+	first := s.settleWon(now)
+	require.Equal(s.T(), 1, first.Sequence)
+	require.Equal(s.T(), "bet:bet-1:win:1", first.IdempotencyKey())
+
+	bet, err := s.betRepo.FindByID(ctx, "bet-1")
+	require.NoError(s.T(), err)
+	refund := data.NewPayoutIntent(*bet, data.PayoutRefund, 10, now)
+	settled, err := s.betRepo.SettleWithPayout(ctx, "bet-1", data.StatusWon, data.StatusVoided, 10, refund)
+	require.NoError(s.T(), err)
+	require.True(s.T(), settled)
+	require.Equal(s.T(), "bet:bet-1:refund:1", refund.IdempotencyKey())
+
+	rewin := data.NewPayoutIntent(*bet, data.PayoutWin, 20, now)
+	settled, err = s.betRepo.SettleWithPayout(ctx, "bet-1", data.StatusVoided, data.StatusWon, 20, rewin)
+	require.NoError(s.T(), err)
+	require.True(s.T(), settled)
+	require.Equal(s.T(), "bet:bet-1:win:2", rewin.IdempotencyKey())
+
+	found, err := s.repo.FindByID(ctx, rewin.ID)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), rewin.IdempotencyKey(), found.IdempotencyKey())
+}
+
+func (s *OutboxRepositorySuite) TestFailedAttemptsAndGivingUp() {
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+	intent := s.settleWon(now)
+
+	require.NoError(s.T(), s.repo.RecordFailedAttempt(ctx, intent.ID, "payout service down", now, now.Add(time.Minute)))
+	due, err := s.repo.FindDue(ctx, now, 10)
+	require.NoError(s.T(), err)
+	require.Empty(s.T(), due)
+
+	due, err = s.repo.FindDue(ctx, now.Add(time.Minute), 10)
+	require.NoError(s.T(), err)
+	require.Len(s.T(), due, 1)
+	require.Equal(s.T(), 1, due[0].Attempts)
+	require.Equal(s.T(), "payout service down", *due[0].LastError)
+
+	failed, err := s.repo.MarkFailed(ctx, intent.ID, "still down", now.Add(time.Minute))
+	require.NoError(s.T(), err)
+	require.True(s.T(), failed)
+	require.Equal(s.T(), data.StatusFailed, s.betStatus())
+
+	found, err := s.repo.FindByID(ctx, intent.ID)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), data.OutboxFailed, found.Status)
+	require.Equal(s.T(), 2, found.Attempts)
+	require.Equal(s.T(), "still down", *found.LastError)
+}
+
+func (s *OutboxRepositorySuite) TestResettlingCancelsUndeliveredPayout() {
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+	intent := s.settleWon(now)
+
+	settled, err := s.betRepo.SettleWithPayout(ctx, "bet-1", data.StatusWon, data.StatusLost, 0, nil)
+	require.NoError(s.T(), err)
+	require.True(s.T(), settled)
+	require.Equal(s.T(), data.StatusLost, s.betStatus())
+
+	found, err := s.repo.FindByID(ctx, intent.ID)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), data.OutboxCanceled, found.Status)
+
+	due, err := s.repo.FindDue(ctx, now, 10)
+	require.NoError(s.T(), err)
+	require.Empty(s.T(), due)
+
+}
+
+func (s *OutboxRepositorySuite) TestDeliveryAfterCancelIsRecordedAndReversed() {
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+	intent := s.settleWon(now)
+
+	// The bet is resettled while the payout is being sent.
+	settled, err := s.betRepo.SettleWithPayout(ctx, "bet-1", data.StatusWon, data.StatusLost, 0, nil)
+	require.NoError(s.T(), err)
+	require.True(s.T(), settled)
+
+	delivered, err := s.repo.MarkDelivered(ctx, intent.ID, now)
+	require.NoError(s.T(), err)
+	require.True(s.T(), delivered)
+	require.Equal(s.T(), data.StatusLost, s.betStatus())
+
+	found, err := s.repo.FindByID(ctx, intent.ID)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), data.OutboxDelivered, found.Status)
+
+	due, err := s.repo.FindDue(ctx, now, 10)
+	require.NoError(s.T(), err)
+	require.Len(s.T(), due, 1)
+	require.Equal(s.T(), data.PayoutAdjustment, due[0].Kind)
+	require.Equal(s.T(), -20.0, due[0].Amount)
+	require.Equal(s.T(), "bet-1", due[0].BetID)
+	require.Equal(s.T(), "bet:bet-1:adjustment:1", due[0].IdempotencyKey())
+
+	delivered, err = s.repo.MarkDelivered(ctx, intent.ID, now)
+	require.NoError(s.T(), err)
+	require.False(s.T(), delivered, "a delivered payout is not recorded twice")
+}
+
+func (s *OutboxRepositorySuite) TestAttemptsAreRecordedAcrossRequeues() {
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+	intent := s.settleWon(now)
+
+	require.NoError(s.T(), s.repo.RecordFailedAttempt(ctx, intent.ID, "timeout", now, now))
+	failed, err := s.repo.MarkFailed(ctx, intent.ID, "payout service down", now.Add(time.Second))
+	require.NoError(s.T(), err)
+	require.True(s.T(), failed)
+
+	stuck, err := s.repo.FindStuck(ctx)
+	require.NoError(s.T(), err)
+	require.Len(s.T(), stuck, 1)
+	require.Equal(s.T(), intent.ID, stuck[0].ID)
+	count, err := s.repo.CountStuck(ctx)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), 1, count)
+
+	requeued, err := s.repo.Requeue(ctx, intent.ID, now.Add(2*time.Second))
+	require.NoError(s.T(), err)
+	require.True(s.T(), requeued)
+	require.Equal(s.T(), data.StatusWon, s.betStatus())
+
+	found, err := s.repo.FindByID(ctx, intent.ID)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), data.OutboxPending, found.Status)
+	require.Equal(s.T(), 0, found.Attempts)
+	require.Nil(s.T(), found.FinishedAt)
+
+	stuck, err = s.repo.FindStuck(ctx)
+	require.NoError(s.T(), err)
+	require.Empty(s.T(), stuck)
+	count, err = s.repo.CountStuck(ctx)
+	require.NoError(s.T(), err)
+	require.Zero(s.T(), count)
+
+	delivered, err := s.repo.MarkDelivered(ctx, intent.ID, now.Add(3*time.Second))
+	require.NoError(s.T(), err)
+	require.True(s.T(), delivered)
+	require.Equal(s.T(), data.StatusPaid, s.betStatus())
+
+	attempts, err := s.repo.FindAttempts(ctx, intent.ID)
+	require.NoError(s.T(), err)
+	require.Len(s.T(), attempts, 3)
+	require.Equal(s.T(), 1, attempts[0].Attempt)
+	require.Equal(s.T(), "timeout", *attempts[0].Error)
+	require.Equal(s.T(), "payout service down", *attempts[1].Error)
+	require.Equal(s.T(), 3, attempts[2].Attempt)
+	require.Nil(s.T(), attempts[2].Error)
+	require.Equal(s.T(), "bet-1", attempts[2].BetID)
+
+	requeued, err = s.repo.Requeue(ctx, intent.ID, now)
+	require.NoError(s.T(), err)
+	require.False(s.T(), requeued)
+}
+
+func (s *OutboxRepositorySuite) TestRetriedPayoutCannotBeResolvedBeforeItIsDelivered() {
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+	intent := s.settleWon(now)
+	require.NoError(s.T(), s.repo.RecordFailedAttempt(ctx, intent.ID, "timeout", now, now))
+
+	stuck, err := s.repo.FindStuck(ctx)
+	require.NoError(s.T(), err)
+	require.Len(s.T(), stuck, 1, "a retried payout is listed")
+
+	// An admin tries to settle the payout by hand while the dispatcher is sending it.
+	resolved, err := s.repo.Resolve(ctx, intent.ID, "paid by bank transfer", now)
+	require.NoError(s.T(), err)
+	require.False(s.T(), resolved)
+	requeued, err := s.repo.Requeue(ctx, intent.ID, now)
+	require.NoError(s.T(), err)
+	require.False(s.T(), requeued)
+
+	delivered, err := s.repo.MarkDelivered(ctx, intent.ID, now)
+	require.NoError(s.T(), err)
+	require.True(s.T(), delivered)
+	require.Equal(s.T(), data.StatusPaid, s.betStatus())
+
+	found, err := s.repo.FindByID(ctx, intent.ID)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), data.OutboxDelivered, found.Status)
+	require.Nil(s.T(), found.ResolutionNote)
+}
+
+func (s *OutboxRepositorySuite) TestResolveStuckPayout() {
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+	intent := s.settleWon(now)
+
+	resolved, err := s.repo.Resolve(ctx, intent.ID, "paid by bank transfer", now)
+	require.NoError(s.T(), err)
+	require.False(s.T(), resolved, "an intent that was never attempted has not failed")
+
+	_, err = s.repo.MarkFailed(ctx, intent.ID, "payout service down", now)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), data.StatusFailed, s.betStatus())
+
+	resolved, err = s.repo.Resolve(ctx, intent.ID, "paid by bank transfer", now)
+	require.NoError(s.T(), err)
+	require.True(s.T(), resolved)
+	require.Equal(s.T(), data.StatusPaid, s.betStatus())
+
+	found, err := s.repo.FindByID(ctx, intent.ID)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), data.OutboxResolved, found.Status)
+	require.Equal(s.T(), "paid by bank transfer", *found.ResolutionNote)
+
+	resolved, err = s.repo.Resolve(ctx, intent.ID, "again", now)
+	require.NoError(s.T(), err)
+	require.False(s.T(), resolved)
+}
+
+func (s *OutboxRepositorySuite) TestMigrationQueuesPayoutsThatFailedBeforeTheOutbox() {
+	ctx := context.Background()
+	_, err := s.db.Exec(`UPDATE bets SET status = 'Failed', payout_amount = 20 WHERE id = 'bet-1'`)
+	require.NoError(s.T(), err)
+	// Back to before 0018, which queues the payout, 0019, which adds the bet details, and
+	// 0020, which numbers payouts.
+	require.NoError(s.T(), s.migrate.Steps(-3))
+	require.NoError(s.T(), s.migrate.Steps(3))
+
+	stuck, err := s.repo.FindStuck(ctx)
+	require.NoError(s.T(), err)
+	require.Len(s.T(), stuck, 1)
+	require.Equal(s.T(), "bet-1", stuck[0].BetID)
+	require.Equal(s.T(), data.PayoutWin, stuck[0].Kind)
+	require.Equal(s.T(), 20.0, stuck[0].Amount)
+	require.Len(s.T(), stuck[0].ID, 36)
+	require.Equal(s.T(), "event-1", stuck[0].EventID)
+	require.Equal(s.T(), data.HomeWin, stuck[0].PredictedOutcome)
+	require.Equal(s.T(), 2.0, stuck[0].Odds)
+	require.Equal(s.T(), 10.0, stuck[0].Stake)
+	require.Zero(s.T(), stuck[0].Sequence, "the payout keeps its key")
+
+	requeued, err := s.repo.Requeue(ctx, stuck[0].ID, time.Now().UTC())
+	require.NoError(s.T(), err)
+	require.True(s.T(), requeued)
+	require.Equal(s.T(), data.StatusWon, s.betStatus())
+}
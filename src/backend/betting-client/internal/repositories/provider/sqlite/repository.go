@@ -0,0 +1,336 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Arlan-Z/def-betting-api/internal/data"
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	snapshotColumns = `provider, provider_event_id, event_id, event_name, home_team, away_team, home_win_chance, away_win_chance, draw_chance, event_start_date, event_end_date, event_result, canceled, type, competition, received_at`
+	conflictColumns = `event_id, results, detected_at, resolved_at, resolved_result`
+	stateColumns    = `provider, etag, last_modified, cursor, updated_at`
+	confirmColumns  = `event_id, result, sightings, first_seen_at, last_seen_at, last_run_id, confirmed_at, confirmed_by`
+)
+
+type ProviderRepository struct {
+	db *sqlx.DB
+}
+
+func NewProviderRepository(db *sqlx.DB) *ProviderRepository {
+	return &ProviderRepository{db: db}
+}
+
+// FindSnapshot returns the latest snapshot of a provider event, or nil if the provider ID is not mapped yet.
+func (r *ProviderRepository) FindSnapshot(ctx context.Context, provider string, providerEventID string) (*data.ProviderEventSnapshot, error) {
+	var snapshot data.ProviderEventSnapshot
+	query := `SELECT ` + snapshotColumns + `
+              FROM provider_event_mappings
+              WHERE provider = ? AND provider_event_id = ?`
+
+	err := r.db.GetContext(ctx, &snapshot, query, provider, providerEventID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error querying mapping of %s event %s: %w", provider, providerEventID, err)
+	}
+	return &snapshot, nil
+}
+
+// SaveSnapshot stores the provider's latest values and marks the event as seen. The
+// internal event of an already mapped provider ID is never changed.
+func (r *ProviderRepository) SaveSnapshot(ctx context.Context, snapshot *data.ProviderEventSnapshot) error {
+	query := `INSERT INTO provider_event_mappings (` + snapshotColumns + `, last_seen_at, missed_cycles)
+              VALUES (:provider, :provider_event_id, :event_id, :event_name, :home_team, :away_team, :home_win_chance, :away_win_chance, :draw_chance,
+                      :event_start_date, :event_end_date, :event_result, :canceled, :type, :competition, :received_at, :received_at, 0)
+              ON CONFLICT(provider, provider_event_id) DO UPDATE SET
+                  event_name = excluded.event_name,
+                  home_team = excluded.home_team,
+                  away_team = excluded.away_team,
+                  home_win_chance = excluded.home_win_chance,
+                  away_win_chance = excluded.away_win_chance,
+                  draw_chance = excluded.draw_chance,
+                  event_start_date = excluded.event_start_date,
+                  event_end_date = excluded.event_end_date,
+                  event_result = excluded.event_result,
+                  canceled = excluded.canceled,
+                  type = excluded.type,
+                  competition = excluded.competition,
+                  received_at = excluded.received_at,
+                  last_seen_at = excluded.last_seen_at,
+                  missed_cycles = 0`
+
+	_, err := r.db.NamedExecContext(ctx, query, snapshot)
+	if err != nil {
+		return fmt.Errorf("error saving snapshot of %s event %s: %w", snapshot.Provider, snapshot.ProviderEventID, err)
+	}
+	return nil
+}
+
+func (r *ProviderRepository) FindSnapshotsByEvent(ctx context.Context, eventID string) ([]data.ProviderEventSnapshot, error) {
+	snapshots := make([]data.ProviderEventSnapshot, 0)
+	query := `SELECT ` + snapshotColumns + `
+              FROM provider_event_mappings
+              WHERE event_id = ?
+              ORDER BY provider ASC`
+
+	err := r.db.SelectContext(ctx, &snapshots, query, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("error querying provider snapshots of event %s: %w", eventID, err)
+	}
+	return snapshots, nil
+}
+
+// OpenResultConflict records a result disagreement, refreshing the reported results of
+// a conflict that is still open. Resolved conflicts are left untouched.
+func (r *ProviderRepository) OpenResultConflict(ctx context.Context, conflict *data.ResultConflict) error {
+	query := `INSERT INTO event_result_conflicts (event_id, results, detected_at)
+              VALUES (:event_id, :results, :detected_at)
+              ON CONFLICT(event_id) DO UPDATE SET results = excluded.results
+              WHERE event_result_conflicts.resolved_at IS NULL`
+
+	_, err := r.db.NamedExecContext(ctx, query, conflict)
+	if err != nil {
+		return fmt.Errorf("error recording result conflict of event %s: %w", conflict.EventID, err)
+	}
+	return nil
+}
+
+func (r *ProviderRepository) FindResultConflict(ctx context.Context, eventID string) (*data.ResultConflict, error) {
+	var conflict data.ResultConflict
+	query := `SELECT ` + conflictColumns + ` FROM event_result_conflicts WHERE event_id = ?`
+
+	err := r.db.GetContext(ctx, &conflict, query, eventID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error querying result conflict of event %s: %w", eventID, err)
+	}
+	return &conflict, nil
+}
+
+func (r *ProviderRepository) FindOpenResultConflicts(ctx context.Context) ([]data.ResultConflict, error) {
+	conflicts := make([]data.ResultConflict, 0)
+	query := `SELECT ` + conflictColumns + `
+              FROM event_result_conflicts
+              WHERE resolved_at IS NULL
+              ORDER BY detected_at ASC`
+
+	err := r.db.SelectContext(ctx, &conflicts, query)
+	if err != nil {
+		return nil, fmt.Errorf("error querying open result conflicts: %w", err)
+	}
+	return conflicts, nil
+}
+
+func (r *ProviderRepository) ResolveResultConflict(ctx context.Context, eventID string, result string, resolvedAt time.Time) error {
+	query := `UPDATE event_result_conflicts SET resolved_result = ?, resolved_at = ? WHERE event_id = ? AND resolved_at IS NULL`
+	_, err := r.db.ExecContext(ctx, query, result, resolvedAt, eventID)
+	if err != nil {
+		return fmt.Errorf("error resolving result conflict of event %s: %w", eventID, err)
+	}
+	return nil
+}
+
+// RecordResultSighting records that a run reported the result of an event and returns the
+// pending confirmation. The same result counts once per run; a different result starts
+// the confirmation over, even for a confirmed result, which then becomes a pending correction.
+func (r *ProviderRepository) RecordResultSighting(ctx context.Context, eventID string, result data.Outcome, runID string, seenAt time.Time) (*data.ResultConfirmation, error) {
+	query := `INSERT INTO event_result_confirmations (event_id, result, sightings, first_seen_at, last_seen_at, last_run_id)
+              VALUES (?, ?, 1, ?, ?, ?)
+              ON CONFLICT(event_id) DO UPDATE SET
+                  sightings = CASE
+                      WHEN result <> excluded.result THEN 1
+                      WHEN last_run_id = excluded.last_run_id THEN sightings
+                      ELSE sightings + 1
+                  END,
+                  first_seen_at = CASE WHEN result <> excluded.result THEN excluded.first_seen_at ELSE first_seen_at END,
+                  result = excluded.result,
+                  last_seen_at = excluded.last_seen_at,
+                  last_run_id = excluded.last_run_id,
+                  confirmed_at = NULL,
+                  confirmed_by = NULL
+              WHERE event_result_confirmations.confirmed_at IS NULL
+                 OR event_result_confirmations.result <> excluded.result`
+
+	if _, err := r.db.ExecContext(ctx, query, eventID, result, seenAt, seenAt, runID); err != nil {
+		return nil, fmt.Errorf("error recording result sighting of event %s: %w", eventID, err)
+	}
+	return r.FindResultConfirmation(ctx, eventID)
+}
+
+// RecordConfirmationCycle counts a run that did not resend the provider's events, because
+// the source reported no changes or only sent changed events, as a sighting of every
+// pending result of those events.
+func (r *ProviderRepository) RecordConfirmationCycle(ctx context.Context, provider string, runID string, seenAt time.Time) error {
+	query := `UPDATE event_result_confirmations
+              SET sightings = sightings + 1, last_seen_at = ?, last_run_id = ?
+              WHERE confirmed_at IS NULL
+                AND last_run_id <> ?
+                AND event_id IN (SELECT event_id FROM provider_event_mappings WHERE provider = ?)`
+
+	_, err := r.db.ExecContext(ctx, query, seenAt, runID, runID, provider)
+	if err != nil {
+		return fmt.Errorf("error recording confirmation cycle of provider %s: %w", provider, err)
+	}
+	return nil
+}
+
+func (r *ProviderRepository) FindResultConfirmation(ctx context.Context, eventID string) (*data.ResultConfirmation, error) {
+	var confirmation data.ResultConfirmation
+	query := `SELECT ` + confirmColumns + ` FROM event_result_confirmations WHERE event_id = ?`
+
+	err := r.db.GetContext(ctx, &confirmation, query, eventID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error querying result confirmation of event %s: %w", eventID, err)
+	}
+	return &confirmation, nil
+}
+
+func (r *ProviderRepository) FindPendingResultConfirmations(ctx context.Context) ([]data.ResultConfirmation, error) {
+	confirmations := make([]data.ResultConfirmation, 0)
+	query := `SELECT ` + confirmColumns + `
+              FROM event_result_confirmations
+              WHERE confirmed_at IS NULL
+              ORDER BY first_seen_at ASC`
+
+	err := r.db.SelectContext(ctx, &confirmations, query)
+	if err != nil {
+		return nil, fmt.Errorf("error querying pending result confirmations: %w", err)
+	}
+	return confirmations, nil
+}
+
+func (r *ProviderRepository) ConfirmResult(ctx context.Context, eventID string, confirmedBy string, confirmedAt time.Time) error {
+	query := `UPDATE event_result_confirmations SET confirmed_by = ?, confirmed_at = ? WHERE event_id = ? AND confirmed_at IS NULL`
+	_, err := r.db.ExecContext(ctx, query, confirmedBy, confirmedAt, eventID)
+	if err != nil {
+		return fmt.Errorf("error confirming result of event %s: %w", eventID, err)
+	}
+	return nil
+}
+
+// DiscardResultConfirmation drops the pending result of an event, e.g. when the source
+// withdrew it. Confirmed results are kept.
+func (r *ProviderRepository) DiscardResultConfirmation(ctx context.Context, eventID string) error {
+	query := `DELETE FROM event_result_confirmations WHERE event_id = ? AND confirmed_at IS NULL`
+	_, err := r.db.ExecContext(ctx, query, eventID)
+	if err != nil {
+		return fmt.Errorf("error discarding result confirmation of event %s: %w", eventID, err)
+	}
+	return nil
+}
+
+// FindSyncState returns what the provider returned on its last processed fetch, or nil before the first one.
+func (r *ProviderRepository) FindSyncState(ctx context.Context, provider string) (*data.SourceSyncState, error) {
+	var state data.SourceSyncState
+	query := `SELECT ` + stateColumns + ` FROM source_sync_state WHERE provider = ?`
+
+	err := r.db.GetContext(ctx, &state, query, provider)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error querying sync state of provider %s: %w", provider, err)
+	}
+	return &state, nil
+}
+
+func (r *ProviderRepository) SaveSyncState(ctx context.Context, state *data.SourceSyncState) error {
+	query := `INSERT INTO source_sync_state (` + stateColumns + `)
+              VALUES (:provider, :etag, :last_modified, :cursor, :updated_at)
+              ON CONFLICT(provider) DO UPDATE SET
+                  etag = excluded.etag,
+                  last_modified = excluded.last_modified,
+                  cursor = excluded.cursor,
+                  updated_at = excluded.updated_at`
+
+	_, err := r.db.NamedExecContext(ctx, query, state)
+	if err != nil {
+		return fmt.Errorf("error saving sync state of provider %s: %w", state.Provider, err)
+	}
+	return nil
+}
+
+// MarkSeen records that the provider still reports the event, for events whose data
+// could not be stored.
+func (r *ProviderRepository) MarkSeen(ctx context.Context, provider string, providerEventID string, seenAt time.Time) error {
+	query := `UPDATE provider_event_mappings SET last_seen_at = ?, missed_cycles = 0
+              WHERE provider = ? AND provider_event_id = ?`
+
+	_, err := r.db.ExecContext(ctx, query, seenAt, provider, providerEventID)
+	if err != nil {
+		return fmt.Errorf("error marking %s event %s as seen: %w", provider, providerEventID, err)
+	}
+	return nil
+}
+
+// RecordMissedCycle counts one more missed full fetch for the provider's unsettled
+// events that were not seen since the fetch started.
+func (r *ProviderRepository) RecordMissedCycle(ctx context.Context, provider string, fetchStartedAt time.Time) (int64, error) {
+	query := `UPDATE provider_event_mappings SET missed_cycles = missed_cycles + 1
+              WHERE provider = ? AND last_seen_at < ?
+                AND event_id IN (SELECT id FROM events WHERE is_active = 1 AND event_result IS NULL AND status != ?)`
+
+	res, err := r.db.ExecContext(ctx, query, provider, fetchStartedAt, data.EventStatusCanceled)
+	if err != nil {
+		return 0, fmt.Errorf("error recording missed cycle of provider %s: %w", provider, err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("error checking missed events of provider %s: %w", provider, err)
+	}
+	return affected, nil
+}
+
+// FindEventsReportedOnlyBy returns the IDs of open events that are mapped to none but the
+// given providers.
+func (r *ProviderRepository) FindEventsReportedOnlyBy(ctx context.Context, providers []string) ([]string, error) {
+	eventIDs := make([]string, 0)
+	if len(providers) == 0 {
+		return eventIDs, nil
+	}
+	query, args, err := sqlx.In(`SELECT e.id
+              FROM events e
+              WHERE e.is_active = 1 AND e.event_result IS NULL AND e.status IN (?, ?)
+                AND EXISTS (SELECT 1 FROM provider_event_mappings m WHERE m.event_id = e.id)
+                AND NOT EXISTS (SELECT 1 FROM provider_event_mappings m WHERE m.event_id = e.id AND m.provider NOT IN (?))
+              ORDER BY e.event_start_date ASC, e.id ASC`, data.EventStatusScheduled, data.EventStatusClosed, providers)
+	if err != nil {
+		return nil, fmt.Errorf("error building query for events of providers %v: %w", providers, err)
+	}
+
+	err = r.db.SelectContext(ctx, &eventIDs, r.db.Rebind(query), args...)
+	if err != nil {
+		return nil, fmt.Errorf("error querying events of providers %v: %w", providers, err)
+	}
+	return eventIDs, nil
+}
+
+// FindMissingEvents returns the unsettled events every mapped provider has missed for at
+// least minMissedCycles consecutive full fetches.
+func (r *ProviderRepository) FindMissingEvents(ctx context.Context, minMissedCycles int) ([]data.MissingEvent, error) {
+	sightings := make([]data.MissingEventSighting, 0)
+	query := `SELECT e.id AS event_id, e.event_name, e.status, e.suspended_at, m.provider, m.last_seen_at, m.missed_cycles
+              FROM events e
+              JOIN provider_event_mappings m ON m.event_id = e.id
+              WHERE e.is_active = 1 AND e.event_result IS NULL AND e.status != ?
+                AND NOT EXISTS (SELECT 1 FROM provider_event_mappings s WHERE s.event_id = e.id AND s.missed_cycles < ?)
+              ORDER BY e.event_start_date ASC, e.id ASC, m.provider ASC`
+
+	err := r.db.SelectContext(ctx, &sightings, query, data.EventStatusCanceled, minMissedCycles)
+	if err != nil {
+		return nil, fmt.Errorf("error querying missing events: %w", err)
+	}
+	return data.GroupMissingEventSightings(sightings), nil
+}
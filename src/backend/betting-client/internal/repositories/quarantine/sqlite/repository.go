@@ -0,0 +1,95 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Arlan-Z/def-betting-api/internal/data"
+	"github.com/jmoiron/sqlx"
+)
+
+const quarantineColumns = `id, provider, external_id, payload, error_code, error_message, status, occurrences, first_seen_at, last_seen_at, resolved_at, note`
+
+type QuarantineRepository struct {
+	db *sqlx.DB
+}
+
+func NewQuarantineRepository(db *sqlx.DB) *QuarantineRepository {
+	return &QuarantineRepository{db: db}
+}
+
+// Save adds the entry, or refreshes the payload and error of the provider event's
+// pending entry and counts one more occurrence.
+func (r *QuarantineRepository) Save(ctx context.Context, entry *data.QuarantinedEvent) error {
+	query := `INSERT INTO event_quarantine (` + quarantineColumns + `)
+              VALUES (:id, :provider, :external_id, :payload, :error_code, :error_message, :status, :occurrences, :first_seen_at, :last_seen_at, :resolved_at, :note)
+              ON CONFLICT(provider, external_id) WHERE status = 'Pending' DO UPDATE SET
+                  payload = excluded.payload,
+                  error_code = excluded.error_code,
+                  error_message = excluded.error_message,
+                  occurrences = event_quarantine.occurrences + 1,
+                  last_seen_at = excluded.last_seen_at`
+
+	_, err := r.db.NamedExecContext(ctx, query, entry)
+	if err != nil {
+		return fmt.Errorf("error quarantining %s event %s: %w", entry.Provider, entry.ExternalID, err)
+	}
+	return nil
+}
+
+func (r *QuarantineRepository) FindByID(ctx context.Context, entryID string) (*data.QuarantinedEvent, error) {
+	var entry data.QuarantinedEvent
+	query := `SELECT ` + quarantineColumns + `
+              FROM event_quarantine
+              WHERE id = ?`
+
+	err := r.db.GetContext(ctx, &entry, query, entryID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error querying quarantined event %s: %w", entryID, err)
+	}
+	return &entry, nil
+}
+
+func (r *QuarantineRepository) FindByStatus(ctx context.Context, status data.QuarantineStatus) ([]data.QuarantinedEvent, error) {
+	entries := make([]data.QuarantinedEvent, 0)
+	query := `SELECT ` + quarantineColumns + `
+              FROM event_quarantine
+              WHERE status = ?
+              ORDER BY last_seen_at DESC`
+
+	err := r.db.SelectContext(ctx, &entries, query, status)
+	if err != nil {
+		return nil, fmt.Errorf("error querying quarantined events: %w", err)
+	}
+	return entries, nil
+}
+
+// Resolve closes a pending entry, storing the payload that was replayed.
+func (r *QuarantineRepository) Resolve(ctx context.Context, entryID string, status data.QuarantineStatus, payload string, note string, resolvedAt time.Time) error {
+	query := `UPDATE event_quarantine SET status = ?, payload = ?, note = ?, resolved_at = ? WHERE id = ? AND status = ?`
+	_, err := r.db.ExecContext(ctx, query, status, payload, note, resolvedAt, entryID, data.QuarantinePending)
+	if err != nil {
+		return fmt.Errorf("error resolving quarantined event %s: %w", entryID, err)
+	}
+	return nil
+}
+
+func (r *QuarantineRepository) CountByErrorCode(ctx context.Context) ([]data.QuarantineStat, error) {
+	stats := make([]data.QuarantineStat, 0)
+	query := `SELECT error_code, status, COUNT(*) AS count
+              FROM event_quarantine
+              GROUP BY error_code, status
+              ORDER BY error_code ASC, status ASC`
+
+	err := r.db.SelectContext(ctx, &stats, query)
+	if err != nil {
+		return nil, fmt.Errorf("error counting quarantined events: %w", err)
+	}
+	return stats, nil
+}
@@ -0,0 +1,137 @@
+package sqlite_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/Arlan-Z/def-betting-api/internal/data"
+	quarantinerepo "github.com/Arlan-Z/def-betting-api/internal/repositories/quarantine/sqlite"
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/sqlite3"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type QuarantineRepositorySuite struct {
+	suite.Suite
+	db      *sqlx.DB
+	repo    *quarantinerepo.QuarantineRepository
+	dbPath  string
+	migrate *migrate.Migrate
+}
+
+func (s *QuarantineRepositorySuite) SetupSuite() {
+	tempFile, err := os.CreateTemp("", "test_quarantine_*.db")
+	require.NoError(s.T(), err)
+	s.dbPath = tempFile.Name()
+	tempFile.Close()
+
+	db, err := sqlx.Open("sqlite3", s.dbPath+"?_foreign_keys=on")
+	require.NoError(s.T(), err)
+	s.db = db
+
+	driver, err := sqlite3.WithInstance(db.DB, &sqlite3.Config{})
+	require.NoError(s.T(), err)
+
+	m, err := migrate.NewWithDatabaseInstance(fmt.Sprintf("file://%s", "../../../../migrations"), "sqlite3", driver)
+	require.NoError(s.T(), err)
+	s.migrate = m
+	require.NoError(s.T(), s.migrate.Up(), "Failed to run migrations UP")
+
+	s.repo = quarantinerepo.NewQuarantineRepository(s.db)
+}
+
+func (s *QuarantineRepositorySuite) TearDownSuite() {
+	if s.migrate != nil {
+		if err := s.migrate.Down(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+			s.T().Logf("Warning: failed to run migrations DOWN: %v", err)
+		}
+		s.migrate.Close()
+	}
+	if s.db != nil {
+		require.NoError(s.T(), s.db.Close())
+	}
+	require.NoError(s.T(), os.Remove(s.dbPath))
+}
+
+func (s *QuarantineRepositorySuite) BeforeTest(suiteName, testName string) {
+	_, err := s.db.Exec("DELETE FROM event_quarantine;")
+	require.NoError(s.T(), err)
+}
+
+func TestQuarantineRepositorySuite(t *testing.T) {
+	suite.Run(t, new(QuarantineRepositorySuite))
+}
+
+func (s *QuarantineRepositorySuite) newEntry(externalID string, code data.MappingErrorCode, seenAt time.Time) *data.QuarantinedEvent {
+	return &data.QuarantinedEvent{
+		ID:           externalID + seenAt.Format("150405"),
+		Provider:     "primary",
+		ExternalID:   externalID,
+		Payload:      `{"id":"` + externalID + `"}`,
+		ErrorCode:    code,
+		ErrorMessage: string(code),
+		Status:       data.QuarantinePending,
+		Occurrences:  1,
+		FirstSeenAt:  seenAt,
+		LastSeenAt:   seenAt,
+	}
+}
+
+func (s *QuarantineRepositorySuite) TestSaveKeepsOnePendingEntryPerEvent() {
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+	first := s.newEntry("ext-1", data.MappingErrInvalidStartDate, now)
+	require.NoError(s.T(), s.repo.Save(ctx, first))
+
+	again := s.newEntry("ext-1", data.MappingErrUnknownResult, now.Add(time.Minute))
+	require.NoError(s.T(), s.repo.Save(ctx, again))
+
+	pending, err := s.repo.FindByStatus(ctx, data.QuarantinePending)
+	require.NoError(s.T(), err)
+	require.Len(s.T(), pending, 1)
+	require.Equal(s.T(), first.ID, pending[0].ID)
+	require.Equal(s.T(), 2, pending[0].Occurrences)
+	require.Equal(s.T(), data.MappingErrUnknownResult, pending[0].ErrorCode, "the latest error is kept")
+	require.True(s.T(), pending[0].FirstSeenAt.Equal(now))
+	require.True(s.T(), pending[0].LastSeenAt.Equal(now.Add(time.Minute)))
+}
+
+func (s *QuarantineRepositorySuite) TestResolveAndQuarantineAgain() {
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+	entry := s.newEntry("ext-1", data.MappingErrInvalidStartDate, now)
+	require.NoError(s.T(), s.repo.Save(ctx, entry))
+
+	edited := `{"id":"ext-1","eventStartDate":"2030-06-01T18:00:00Z"}`
+	require.NoError(s.T(), s.repo.Resolve(ctx, entry.ID, data.QuarantineReplayed, edited, "fixed date", now.Add(time.Minute)))
+
+	resolved, err := s.repo.FindByID(ctx, entry.ID)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), data.QuarantineReplayed, resolved.Status)
+	require.Equal(s.T(), edited, resolved.Payload)
+	require.Equal(s.T(), "fixed date", resolved.Note)
+	require.NotNil(s.T(), resolved.ResolvedAt)
+
+	// The same provider event failing again opens a new entry.
+	require.NoError(s.T(), s.repo.Save(ctx, s.newEntry("ext-1", data.MappingErrInvalidStartDate, now.Add(time.Hour))))
+	require.NoError(s.T(), s.repo.Save(ctx, s.newEntry("ext-2", data.MappingErrInvalidStartDate, now.Add(time.Hour))))
+
+	stats, err := s.repo.CountByErrorCode(ctx)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), []data.QuarantineStat{
+		{ErrorCode: data.MappingErrInvalidStartDate, Status: data.QuarantinePending, Count: 2},
+		{ErrorCode: data.MappingErrInvalidStartDate, Status: data.QuarantineReplayed, Count: 1},
+	}, stats)
+
+	missing, err := s.repo.FindByID(ctx, "unknown")
+	require.NoError(s.T(), err)
+	require.Nil(s.T(), missing)
+}
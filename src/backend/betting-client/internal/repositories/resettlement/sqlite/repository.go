@@ -0,0 +1,110 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/Arlan-Z/def-betting-api/internal/data"
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	resettlementColumns = `id, event_id, previous_result, new_result, triggered_by, reason, status, started_at, finished_at, bets_changed, bets_unchanged, paid_out, clawed_back, errors`
+	betChangeColumns    = `resettlement_id, bet_id, user_id, predicted_outcome, previous_status, new_status, previous_payout, new_payout, adjustment, error`
+)
+
+type ResettlementRepository struct {
+	db *sqlx.DB
+}
+
+func NewResettlementRepository(db *sqlx.DB) *ResettlementRepository {
+	return &ResettlementRepository{db: db}
+}
+
+// Create stores a resettlement when it starts, so one that never finishes stays visible.
+func (r *ResettlementRepository) Create(ctx context.Context, resettlement *data.Resettlement) error {
+	query := `INSERT INTO resettlements (` + resettlementColumns + `)
+              VALUES (:id, :event_id, :previous_result, :new_result, :triggered_by, :reason, :status, :started_at, :finished_at,
+                      :bets_changed, :bets_unchanged, :paid_out, :clawed_back, :errors)`
+
+	_, err := r.db.NamedExecContext(ctx, query, resettlement)
+	if err != nil {
+		return fmt.Errorf("error creating resettlement of event %s: %w", resettlement.EventID, err)
+	}
+	return nil
+}
+
+// Finish stores the final status and totals of the resettlement together with its bet changes.
+func (r *ResettlementRepository) Finish(ctx context.Context, resettlement *data.Resettlement) error {
+	query := `UPDATE resettlements SET
+                  status = :status,
+                  finished_at = :finished_at,
+                  bets_changed = :bets_changed,
+                  bets_unchanged = :bets_unchanged,
+                  paid_out = :paid_out,
+                  clawed_back = :clawed_back,
+                  errors = :errors
+              WHERE id = :id`
+	betQuery := `INSERT INTO resettlement_bets (` + betChangeColumns + `)
+                 VALUES (:resettlement_id, :bet_id, :user_id, :predicted_outcome, :previous_status, :new_status,
+                         :previous_payout, :new_payout, :adjustment, :error)`
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.NamedExecContext(ctx, query, resettlement); err != nil {
+		return fmt.Errorf("error finishing resettlement %s: %w", resettlement.ID, err)
+	}
+	for _, change := range resettlement.Bets {
+		change.ResettlementID = resettlement.ID
+		if _, err := tx.NamedExecContext(ctx, betQuery, change); err != nil {
+			return fmt.Errorf("error storing bet changes of resettlement %s: %w", resettlement.ID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error committing resettlement %s: %w", resettlement.ID, err)
+	}
+	return nil
+}
+
+// FindByID returns the resettlement with its bet changes, or nil if it does not exist.
+func (r *ResettlementRepository) FindByID(ctx context.Context, resettlementID string) (*data.Resettlement, error) {
+	var resettlement data.Resettlement
+	query := `SELECT ` + resettlementColumns + ` FROM resettlements WHERE id = ?`
+
+	err := r.db.GetContext(ctx, &resettlement, query, resettlementID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error querying resettlement %s: %w", resettlementID, err)
+	}
+
+	resettlement.Bets = make([]data.ResettlementBet, 0)
+	betQuery := `SELECT ` + betChangeColumns + ` FROM resettlement_bets WHERE resettlement_id = ? ORDER BY id ASC`
+	if err := r.db.SelectContext(ctx, &resettlement.Bets, betQuery, resettlementID); err != nil {
+		return nil, fmt.Errorf("error querying bet changes of resettlement %s: %w", resettlementID, err)
+	}
+	return &resettlement, nil
+}
+
+// FindByEvent returns the resettlements of an event without their bet changes, newest first.
+func (r *ResettlementRepository) FindByEvent(ctx context.Context, eventID string) ([]data.Resettlement, error) {
+	resettlements := make([]data.Resettlement, 0)
+	query := `SELECT ` + resettlementColumns + `
+              FROM resettlements
+              WHERE event_id = ?
+              ORDER BY started_at DESC`
+
+	err := r.db.SelectContext(ctx, &resettlements, query, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("error querying resettlements of event %s: %w", eventID, err)
+	}
+	return resettlements, nil
+}
@@ -0,0 +1,134 @@
+package sqlite_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/Arlan-Z/def-betting-api/internal/data"
+	resettlementrepo "github.com/Arlan-Z/def-betting-api/internal/repositories/resettlement/sqlite"
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/sqlite3"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type ResettlementRepositorySuite struct {
+	suite.Suite
+	db      *sqlx.DB
+	repo    *resettlementrepo.ResettlementRepository
+	dbPath  string
+	migrate *migrate.Migrate
+}
+
+func (s *ResettlementRepositorySuite) SetupSuite() {
+	tempFile, err := os.CreateTemp("", "test_resettlement_*.db")
+	require.NoError(s.T(), err)
+	s.dbPath = tempFile.Name()
+	tempFile.Close()
+
+	db, err := sqlx.Open("sqlite3", s.dbPath+"?_foreign_keys=on")
+	require.NoError(s.T(), err)
+	s.db = db
+
+	driver, err := sqlite3.WithInstance(db.DB, &sqlite3.Config{})
+	require.NoError(s.T(), err)
+
+	m, err := migrate.NewWithDatabaseInstance(fmt.Sprintf("file://%s", "../../../../migrations"), "sqlite3", driver)
+	require.NoError(s.T(), err)
+	s.migrate = m
+	require.NoError(s.T(), s.migrate.Up(), "Failed to run migrations UP")
+
+	s.repo = resettlementrepo.NewResettlementRepository(s.db)
+}
+
+func (s *ResettlementRepositorySuite) TearDownSuite() {
+	if s.migrate != nil {
+		if err := s.migrate.Down(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+			s.T().Logf("Warning: failed to run migrations DOWN: %v", err)
+		}
+		s.migrate.Close()
+	}
+	if s.db != nil {
+		require.NoError(s.T(), s.db.Close())
+	}
+	require.NoError(s.T(), os.Remove(s.dbPath))
+}
+
+func (s *ResettlementRepositorySuite) BeforeTest(suiteName, testName string) {
+	for _, table := range []string{"resettlement_bets", "resettlements", "bets", "events"} {
+		_, err := s.db.Exec("DELETE FROM " + table + ";")
+		require.NoError(s.T(), err)
+	}
+	_, err := s.db.Exec(`INSERT INTO events (id, event_name, home_team, away_team, home_win_chance, away_win_chance, draw_chance,
+                                           event_start_date, event_end_date, event_result, is_active, type)
+                         VALUES ('event-1', 'A vs B', 'A', 'B', 2, 3, 4, '2030-06-01 18:00:00', '2030-06-01 20:00:00', 'AwayWin', 0, 'Football')`)
+	require.NoError(s.T(), err)
+	_, err = s.db.Exec(`INSERT INTO bets (id, user_id, event_id, amount, predicted_outcome, recorded_home_win_chance,
+                                         recorded_away_win_chance, recorded_draw_chance, placed_at, status, payout_amount)
+                        VALUES ('bet-1', 'user-1', 'event-1', 10, 'HomeWin', 2, 3, 4, '2030-06-01 17:00:00', 'Lost', 0)`)
+	require.NoError(s.T(), err)
+}
+
+func TestResettlementRepositorySuite(t *testing.T) {
+	suite.Run(t, new(ResettlementRepositorySuite))
+}
+
+func (s *ResettlementRepositorySuite) TestCreateAndFinish() {
+	ctx := context.Background()
+	startedAt := time.Now().UTC().Truncate(time.Second)
+	resettlement := &data.Resettlement{
+		ID:             "resettlement-1",
+		EventID:        "event-1",
+		PreviousResult: data.HomeWin,
+		NewResult:      data.AwayWin,
+		TriggeredBy:    data.ResettledByAdmin,
+		Reason:         "wrong result",
+		Status:         data.ResettlementRunning,
+		StartedAt:      startedAt,
+	}
+	require.NoError(s.T(), s.repo.Create(ctx, resettlement))
+
+	running, err := s.repo.FindByID(ctx, "resettlement-1")
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), data.ResettlementRunning, running.Status)
+	require.Empty(s.T(), running.Bets)
+
+	resettlement.Bets = []data.ResettlementBet{{
+		BetID:            "bet-1",
+		UserID:           "user-1",
+		PredictedOutcome: data.HomeWin,
+		PreviousStatus:   data.StatusPaid,
+		NewStatus:        data.StatusLost,
+		PreviousPayout:   20,
+		Adjustment:       -20,
+	}}
+	resettlement.BetsChanged = 1
+	resettlement.ClawedBack = 20
+	resettlement.Finish(startedAt.Add(time.Second))
+	require.NoError(s.T(), s.repo.Finish(ctx, resettlement))
+
+	finished, err := s.repo.FindByID(ctx, "resettlement-1")
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), data.ResettlementCompleted, finished.Status)
+	require.NotNil(s.T(), finished.FinishedAt)
+	require.Equal(s.T(), 20.0, finished.ClawedBack)
+	require.Len(s.T(), finished.Bets, 1)
+	require.Equal(s.T(), "resettlement-1", finished.Bets[0].ResettlementID)
+	require.Equal(s.T(), -20.0, finished.Bets[0].Adjustment)
+
+	byEvent, err := s.repo.FindByEvent(ctx, "event-1")
+	require.NoError(s.T(), err)
+	require.Len(s.T(), byEvent, 1)
+	require.Nil(s.T(), byEvent[0].Bets)
+
+	missing, err := s.repo.FindByID(ctx, "unknown")
+	require.NoError(s.T(), err)
+	require.Nil(s.T(), missing)
+}
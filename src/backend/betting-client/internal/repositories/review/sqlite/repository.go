@@ -0,0 +1,74 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Arlan-Z/def-betting-api/internal/data"
+	"github.com/jmoiron/sqlx"
+)
+
+const reviewColumns = `id, bet_id, event_id, reason, status, created_at, resolved_at, resolution_note`
+
+type ReviewRepository struct {
+	db *sqlx.DB
+}
+
+func NewReviewRepository(db *sqlx.DB) *ReviewRepository {
+	return &ReviewRepository{db: db}
+}
+
+// Enqueue adds the review unless the bet is already queued.
+func (r *ReviewRepository) Enqueue(ctx context.Context, review *data.BetReview) error {
+	query := `INSERT INTO bet_reviews (id, bet_id, event_id, reason, status, created_at)
+              VALUES (:id, :bet_id, :event_id, :reason, :status, :created_at)
+              ON CONFLICT(bet_id) DO NOTHING`
+
+	_, err := r.db.NamedExecContext(ctx, query, review)
+	if err != nil {
+		return fmt.Errorf("error enqueuing review for bet %s: %w", review.BetID, err)
+	}
+	return nil
+}
+
+func (r *ReviewRepository) FindByStatus(ctx context.Context, status data.ReviewStatus) ([]data.BetReview, error) {
+	reviews := make([]data.BetReview, 0)
+	query := `SELECT ` + reviewColumns + `
+              FROM bet_reviews
+              WHERE status = ?
+              ORDER BY created_at ASC`
+
+	err := r.db.SelectContext(ctx, &reviews, query, status)
+	if err != nil {
+		return nil, fmt.Errorf("error querying bet reviews: %w", err)
+	}
+	return reviews, nil
+}
+
+func (r *ReviewRepository) FindByID(ctx context.Context, reviewID string) (*data.BetReview, error) {
+	var review data.BetReview
+	query := `SELECT ` + reviewColumns + `
+              FROM bet_reviews
+              WHERE id = ?`
+
+	err := r.db.GetContext(ctx, &review, query, reviewID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error querying bet review %s: %w", reviewID, err)
+	}
+	return &review, nil
+}
+
+func (r *ReviewRepository) Resolve(ctx context.Context, reviewID string, status data.ReviewStatus, note string, resolvedAt time.Time) error {
+	query := `UPDATE bet_reviews SET status = ?, resolution_note = ?, resolved_at = ? WHERE id = ? AND status = ?`
+	_, err := r.db.ExecContext(ctx, query, status, note, resolvedAt, reviewID, data.ReviewPending)
+	if err != nil {
+		return fmt.Errorf("error resolving bet review %s: %w", reviewID, err)
+	}
+	return nil
+}
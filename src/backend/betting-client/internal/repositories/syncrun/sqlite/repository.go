@@ -0,0 +1,172 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Arlan-Z/def-betting-api/internal/data"
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	runColumns   = `id, provider, kind, status, started_at, finished_at, feed_checksum, not_modified, incremental, received, upserted, unchanged, failed, finalize_attempts, finalize_errors, cancel_attempts, cancel_errors, result_conflicts, postponed_voided, postponed_void_errors, missing_suspended, missing_voided, missing_void_errors, error`
+	errorColumns = `id, run_id, external_id, event_id, stage, message, occurred_at`
+)
+
+type SyncRunRepository struct {
+	db *sqlx.DB
+}
+
+func NewSyncRunRepository(db *sqlx.DB) *SyncRunRepository {
+	return &SyncRunRepository{db: db}
+}
+
+// Create stores a run when it starts, so runs that never finish stay visible.
+func (r *SyncRunRepository) Create(ctx context.Context, run *data.SyncRun) error {
+	query := `INSERT INTO sync_runs (` + runColumns + `)
+              VALUES (:id, :provider, :kind, :status, :started_at, :finished_at, :feed_checksum, :not_modified, :incremental, :received, :upserted, :unchanged, :failed,
+                      :finalize_attempts, :finalize_errors, :cancel_attempts, :cancel_errors, :result_conflicts, :postponed_voided, :postponed_void_errors,
+                      :missing_suspended, :missing_voided, :missing_void_errors, :error)`
+
+	_, err := r.db.NamedExecContext(ctx, query, run)
+	if err != nil {
+		return fmt.Errorf("error creating sync run %s: %w", run.ID, err)
+	}
+	return nil
+}
+
+// Finish stores the final status and counts of the run together with its errors.
+func (r *SyncRunRepository) Finish(ctx context.Context, run *data.SyncRun) error {
+	query := `UPDATE sync_runs SET
+                  status = :status,
+                  finished_at = :finished_at,
+                  feed_checksum = :feed_checksum,
+                  not_modified = :not_modified,
+                  incremental = :incremental,
+                  received = :received,
+                  upserted = :upserted,
+                  unchanged = :unchanged,
+                  failed = :failed,
+                  finalize_attempts = :finalize_attempts,
+                  finalize_errors = :finalize_errors,
+                  cancel_attempts = :cancel_attempts,
+                  cancel_errors = :cancel_errors,
+                  result_conflicts = :result_conflicts,
+                  postponed_voided = :postponed_voided,
+                  postponed_void_errors = :postponed_void_errors,
+                  missing_suspended = :missing_suspended,
+                  missing_voided = :missing_voided,
+                  missing_void_errors = :missing_void_errors,
+                  error = :error
+              WHERE id = :id`
+	errorQuery := `INSERT INTO sync_run_errors (run_id, external_id, event_id, stage, message, occurred_at)
+                   VALUES (:run_id, :external_id, :event_id, :stage, :message, :occurred_at)`
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.NamedExecContext(ctx, query, run); err != nil {
+		return fmt.Errorf("error finishing sync run %s: %w", run.ID, err)
+	}
+	for _, runErr := range run.Errors {
+		runErr.RunID = run.ID
+		if _, err := tx.NamedExecContext(ctx, errorQuery, runErr); err != nil {
+			return fmt.Errorf("error storing errors of sync run %s: %w", run.ID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error committing sync run %s: %w", run.ID, err)
+	}
+	return nil
+}
+
+// FindByID returns the run with its errors, or nil if it does not exist.
+func (r *SyncRunRepository) FindByID(ctx context.Context, runID string) (*data.SyncRun, error) {
+	var run data.SyncRun
+	query := `SELECT ` + runColumns + ` FROM sync_runs WHERE id = ?`
+
+	err := r.db.GetContext(ctx, &run, query, runID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error querying sync run %s: %w", runID, err)
+	}
+
+	run.Errors = make([]data.SyncRunError, 0)
+	errorQuery := `SELECT ` + errorColumns + ` FROM sync_run_errors WHERE run_id = ? ORDER BY id ASC`
+	if err := r.db.SelectContext(ctx, &run.Errors, errorQuery, runID); err != nil {
+		return nil, fmt.Errorf("error querying errors of sync run %s: %w", runID, err)
+	}
+	return &run, nil
+}
+
+// FindRecent returns the latest runs, newest first. An empty provider matches all providers.
+func (r *SyncRunRepository) FindRecent(ctx context.Context, provider string, limit int) ([]data.SyncRun, error) {
+	runs := make([]data.SyncRun, 0)
+	query := `SELECT ` + runColumns + `
+              FROM sync_runs
+              WHERE ? = '' OR provider = ?
+              ORDER BY started_at DESC
+              LIMIT ?`
+
+	err := r.db.SelectContext(ctx, &runs, query, provider, provider, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error querying sync runs: %w", err)
+	}
+	return runs, nil
+}
+
+// FindLastSuccessful returns the most recently finished run that fetched and processed
+// its feed, or nil if there is none.
+func (r *SyncRunRepository) FindLastSuccessful(ctx context.Context) (*data.SyncRun, error) {
+	var run data.SyncRun
+	query := `SELECT ` + runColumns + `
+              FROM sync_runs
+              WHERE status IN (?, ?)
+              ORDER BY finished_at DESC
+              LIMIT 1`
+
+	err := r.db.GetContext(ctx, &run, query, data.SyncRunSucceeded, data.SyncRunCompletedWithErrors)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error querying last successful sync run: %w", err)
+	}
+	return &run, nil
+}
+
+// DeleteStartedBefore removes old runs and their errors.
+func (r *SyncRunRepository) DeleteStartedBefore(ctx context.Context, before time.Time) (int64, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	errorQuery := `DELETE FROM sync_run_errors WHERE run_id IN (SELECT id FROM sync_runs WHERE started_at < ?)`
+	if _, err := tx.ExecContext(ctx, errorQuery, before); err != nil {
+		return 0, fmt.Errorf("error deleting old sync run errors: %w", err)
+	}
+	res, err := tx.ExecContext(ctx, `DELETE FROM sync_runs WHERE started_at < ?`, before)
+	if err != nil {
+		return 0, fmt.Errorf("error deleting old sync runs: %w", err)
+	}
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("error counting deleted sync runs: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("error committing deletion of sync runs: %w", err)
+	}
+	return deleted, nil
+}
@@ -0,0 +1,179 @@
+package sqlite_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/Arlan-Z/def-betting-api/internal/data"
+	syncrunrepo "github.com/Arlan-Z/def-betting-api/internal/repositories/syncrun/sqlite"
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/sqlite3"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type SyncRunRepositorySuite struct {
+	suite.Suite
+	db      *sqlx.DB
+	repo    *syncrunrepo.SyncRunRepository
+	dbPath  string
+	migrate *migrate.Migrate
+}
+
+func (s *SyncRunRepositorySuite) SetupSuite() {
+	tempFile, err := os.CreateTemp("", "test_sync_runs_*.db")
+	require.NoError(s.T(), err)
+	s.dbPath = tempFile.Name()
+	tempFile.Close()
+
+	db, err := sqlx.Open("sqlite3", s.dbPath+"?_foreign_keys=on")
+	require.NoError(s.T(), err)
+	s.db = db
+
+	driver, err := sqlite3.WithInstance(db.DB, &sqlite3.Config{})
+	require.NoError(s.T(), err)
+
+	m, err := migrate.NewWithDatabaseInstance(fmt.Sprintf("file://%s", "../../../../migrations"), "sqlite3", driver)
+	require.NoError(s.T(), err)
+	s.migrate = m
+	require.NoError(s.T(), s.migrate.Up(), "Failed to run migrations UP")
+
+	s.repo = syncrunrepo.NewSyncRunRepository(s.db)
+}
+
+func (s *SyncRunRepositorySuite) TearDownSuite() {
+	if s.migrate != nil {
+		if err := s.migrate.Down(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+			s.T().Logf("Warning: failed to run migrations DOWN: %v", err)
+		}
+		s.migrate.Close()
+	}
+	if s.db != nil {
+		require.NoError(s.T(), s.db.Close())
+	}
+	require.NoError(s.T(), os.Remove(s.dbPath))
+}
+
+func (s *SyncRunRepositorySuite) BeforeTest(suiteName, testName string) {
+	_, err := s.db.Exec("DELETE FROM sync_run_errors;")
+	require.NoError(s.T(), err)
+	_, err = s.db.Exec("DELETE FROM sync_runs;")
+	require.NoError(s.T(), err)
+}
+
+func TestSyncRunRepositorySuite(t *testing.T) {
+	suite.Run(t, new(SyncRunRepositorySuite))
+}
+
+func (s *SyncRunRepositorySuite) newRun(provider string, startedAt time.Time) *data.SyncRun {
+	run := &data.SyncRun{
+		ID:        uuid.NewString(),
+		Provider:  provider,
+		Kind:      data.SyncRunPoll,
+		Status:    data.SyncRunRunning,
+		StartedAt: startedAt,
+	}
+	require.NoError(s.T(), s.repo.Create(context.Background(), run))
+	return run
+}
+
+func (s *SyncRunRepositorySuite) TestCreateFinishAndFindByID() {
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+	run := s.newRun("primary", now)
+
+	found, err := s.repo.FindByID(ctx, run.ID)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), data.SyncRunRunning, found.Status)
+	require.Nil(s.T(), found.FinishedAt)
+	require.Empty(s.T(), found.Errors)
+
+	run.Received = 3
+	run.Upserted = 1
+	run.Unchanged = 1
+	run.FeedChecksum = data.FeedChecksum([]byte("[]"))
+	run.RecordError("ext-1", "", data.SyncStageMap, errors.New("missing start date"))
+	run.Finish(now.Add(time.Second))
+	require.NoError(s.T(), s.repo.Finish(ctx, run))
+
+	found, err = s.repo.FindByID(ctx, run.ID)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), data.SyncRunCompletedWithErrors, found.Status)
+	require.NotNil(s.T(), found.FinishedAt)
+	require.Equal(s.T(), 3, found.Received)
+	require.Equal(s.T(), 1, found.Failed)
+	require.Equal(s.T(), run.FeedChecksum, found.FeedChecksum)
+	require.Len(s.T(), found.Errors, 1)
+	require.Equal(s.T(), "ext-1", found.Errors[0].ExternalID)
+	require.Equal(s.T(), data.SyncStageMap, found.Errors[0].Stage)
+	require.Equal(s.T(), "missing start date", found.Errors[0].Message)
+
+	missing, err := s.repo.FindByID(ctx, uuid.NewString())
+	require.NoError(s.T(), err)
+	require.Nil(s.T(), missing)
+}
+
+func (s *SyncRunRepositorySuite) TestFindRecentAndLastSuccessful() {
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+
+	last, err := s.repo.FindLastSuccessful(ctx)
+	require.NoError(s.T(), err)
+	require.Nil(s.T(), last)
+
+	succeeded := s.newRun("primary", now.Add(-3*time.Minute))
+	succeeded.Finish(now.Add(-3 * time.Minute))
+	require.NoError(s.T(), s.repo.Finish(ctx, succeeded))
+
+	failed := s.newRun("primary", now.Add(-2*time.Minute))
+	failed.Error = "event source returned status 502"
+	failed.Finish(now.Add(-2 * time.Minute))
+	require.NoError(s.T(), s.repo.Finish(ctx, failed))
+
+	s.newRun("backup", now.Add(-time.Minute))
+
+	runs, err := s.repo.FindRecent(ctx, "", 10)
+	require.NoError(s.T(), err)
+	require.Len(s.T(), runs, 3)
+	require.Equal(s.T(), "backup", runs[0].Provider)
+
+	runs, err = s.repo.FindRecent(ctx, "primary", 1)
+	require.NoError(s.T(), err)
+	require.Len(s.T(), runs, 1)
+	require.Equal(s.T(), failed.ID, runs[0].ID)
+	require.Equal(s.T(), data.SyncRunFailed, runs[0].Status)
+
+	last, err = s.repo.FindLastSuccessful(ctx)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), succeeded.ID, last.ID)
+}
+
+func (s *SyncRunRepositorySuite) TestDeleteStartedBefore() {
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+
+	old := s.newRun("primary", now.Add(-48*time.Hour))
+	old.RecordError("ext-1", "", data.SyncStageMap, errors.New("bad"))
+	old.Finish(now.Add(-48 * time.Hour))
+	require.NoError(s.T(), s.repo.Finish(ctx, old))
+	recent := s.newRun("primary", now)
+
+	deleted, err := s.repo.DeleteStartedBefore(ctx, now.Add(-24*time.Hour))
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), int64(1), deleted)
+
+	found, err := s.repo.FindByID(ctx, old.ID)
+	require.NoError(s.T(), err)
+	require.Nil(s.T(), found)
+	found, err = s.repo.FindByID(ctx, recent.ID)
+	require.NoError(s.T(), err)
+	require.NotNil(s.T(), found)
+}
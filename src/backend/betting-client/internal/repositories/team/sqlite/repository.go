@@ -0,0 +1,172 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Arlan-Z/def-betting-api/internal/data"
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	teamColumns  = `id, name, sport, created_at`
+	aliasColumns = `id, alias, alias_key, sport, event_id, candidate_team_ids, status, team_id, created_at, resolved_at`
+)
+
+type TeamRepository struct {
+	db *sqlx.DB
+}
+
+func NewTeamRepository(db *sqlx.DB) *TeamRepository {
+	return &TeamRepository{db: db}
+}
+
+// Create stores the team and registers its own name as an alias.
+func (r *TeamRepository) Create(ctx context.Context, team *data.Team) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `INSERT INTO teams (` + teamColumns + `) VALUES (:id, :name, :sport, :created_at)`
+	if _, err := tx.NamedExecContext(ctx, query, team); err != nil {
+		return fmt.Errorf("error inserting team %s: %w", team.Name, err)
+	}
+
+	aliasQuery := `INSERT INTO team_aliases (alias_key, sport, team_id, created_at) VALUES (?, ?, ?, ?)
+                   ON CONFLICT(sport, alias_key) DO NOTHING`
+	if _, err := tx.ExecContext(ctx, aliasQuery, data.NormalizeName(team.Name), team.Sport, team.ID, team.CreatedAt); err != nil {
+		return fmt.Errorf("error registering name of team %s as alias: %w", team.Name, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error committing team %s: %w", team.Name, err)
+	}
+	return nil
+}
+
+func (r *TeamRepository) FindByID(ctx context.Context, teamID string) (*data.Team, error) {
+	var team data.Team
+	query := `SELECT ` + teamColumns + ` FROM teams WHERE id = ?`
+
+	err := r.db.GetContext(ctx, &team, query, teamID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error querying team %s: %w", teamID, err)
+	}
+	return &team, nil
+}
+
+// FindBySport lists teams of a sport, or all teams when sport is empty.
+func (r *TeamRepository) FindBySport(ctx context.Context, sport string) ([]data.Team, error) {
+	teams := make([]data.Team, 0)
+	query := `SELECT ` + teamColumns + ` FROM teams WHERE ? = '' OR sport = ? ORDER BY sport, name`
+
+	err := r.db.SelectContext(ctx, &teams, query, sport, sport)
+	if err != nil {
+		return nil, fmt.Errorf("error querying teams: %w", err)
+	}
+	return teams, nil
+}
+
+// FindByAlias returns the team registered for the normalized alias, or nil.
+func (r *TeamRepository) FindByAlias(ctx context.Context, sport string, aliasKey string) (*data.Team, error) {
+	var team data.Team
+	query := `SELECT t.id, t.name, t.sport, t.created_at
+              FROM team_aliases a JOIN teams t ON t.id = a.team_id
+              WHERE a.sport = ? AND a.alias_key = ?`
+
+	err := r.db.GetContext(ctx, &team, query, sport, aliasKey)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error querying team by alias '%s': %w", aliasKey, err)
+	}
+	return &team, nil
+}
+
+// FindAliasKeys returns every known alias key of the sport grouped by team ID.
+func (r *TeamRepository) FindAliasKeys(ctx context.Context, sport string) (map[string][]string, error) {
+	var rows []struct {
+		TeamID   string `db:"team_id"`
+		AliasKey string `db:"alias_key"`
+	}
+	query := `SELECT team_id, alias_key FROM team_aliases WHERE sport = ? ORDER BY team_id`
+
+	if err := r.db.SelectContext(ctx, &rows, query, sport); err != nil {
+		return nil, fmt.Errorf("error querying team aliases: %w", err)
+	}
+
+	keys := make(map[string][]string)
+	for _, row := range rows {
+		keys[row.TeamID] = append(keys[row.TeamID], row.AliasKey)
+	}
+	return keys, nil
+}
+
+func (r *TeamRepository) AddAlias(ctx context.Context, sport string, aliasKey string, teamID string) error {
+	query := `INSERT INTO team_aliases (alias_key, sport, team_id, created_at) VALUES (?, ?, ?, ?)
+              ON CONFLICT(sport, alias_key) DO UPDATE SET team_id = excluded.team_id`
+	_, err := r.db.ExecContext(ctx, query, aliasKey, sport, teamID, time.Now().UTC())
+	if err != nil {
+		return fmt.Errorf("error adding alias '%s' for team %s: %w", aliasKey, teamID, err)
+	}
+	return nil
+}
+
+// EnqueueUnresolved queues the alias for review unless it is already queued.
+func (r *TeamRepository) EnqueueUnresolved(ctx context.Context, alias *data.UnresolvedTeamAlias) error {
+	query := `INSERT INTO unresolved_team_aliases (id, alias, alias_key, sport, event_id, candidate_team_ids, status, created_at)
+              VALUES (:id, :alias, :alias_key, :sport, :event_id, :candidate_team_ids, :status, :created_at)
+              ON CONFLICT(sport, alias_key) DO NOTHING`
+
+	_, err := r.db.NamedExecContext(ctx, query, alias)
+	if err != nil {
+		return fmt.Errorf("error enqueuing unresolved alias '%s': %w", alias.Alias, err)
+	}
+	return nil
+}
+
+func (r *TeamRepository) FindUnresolvedByStatus(ctx context.Context, status data.AliasStatus) ([]data.UnresolvedTeamAlias, error) {
+	aliases := make([]data.UnresolvedTeamAlias, 0)
+	query := `SELECT ` + aliasColumns + `
+              FROM unresolved_team_aliases
+              WHERE status = ?
+              ORDER BY created_at ASC`
+
+	err := r.db.SelectContext(ctx, &aliases, query, status)
+	if err != nil {
+		return nil, fmt.Errorf("error querying unresolved aliases: %w", err)
+	}
+	return aliases, nil
+}
+
+func (r *TeamRepository) FindUnresolvedByID(ctx context.Context, aliasID string) (*data.UnresolvedTeamAlias, error) {
+	var alias data.UnresolvedTeamAlias
+	query := `SELECT ` + aliasColumns + ` FROM unresolved_team_aliases WHERE id = ?`
+
+	err := r.db.GetContext(ctx, &alias, query, aliasID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error querying unresolved alias %s: %w", aliasID, err)
+	}
+	return &alias, nil
+}
+
+func (r *TeamRepository) MarkAliasResolved(ctx context.Context, aliasID string, teamID string, resolvedAt time.Time) error {
+	query := `UPDATE unresolved_team_aliases SET status = ?, team_id = ?, resolved_at = ? WHERE id = ? AND status = ?`
+	_, err := r.db.ExecContext(ctx, query, data.AliasResolved, teamID, resolvedAt, aliasID, data.AliasPending)
+	if err != nil {
+		return fmt.Errorf("error resolving alias %s: %w", aliasID, err)
+	}
+	return nil
+}
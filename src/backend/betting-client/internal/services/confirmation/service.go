@@ -0,0 +1,58 @@
+package confirmation
+
+import (
+	"context"
+
+	"github.com/Arlan-Z/def-betting-api/internal/data"
+	"go.uber.org/zap"
+)
+
+type ConfirmationUseCase interface {
+	GetPendingConfirmations(ctx context.Context) ([]data.ResultConfirmation, error)
+	Confirm(ctx context.Context, eventID string) (*data.ResultConfirmation, error)
+}
+
+type Service interface {
+	GetPendingConfirmations(ctx context.Context) ([]data.ResultConfirmation, error)
+	Confirm(ctx context.Context, eventID string) (*data.ResultConfirmation, error)
+}
+
+type service struct {
+	confirmationUseCase ConfirmationUseCase
+	logger              *zap.Logger
+}
+
+func NewService(uc ConfirmationUseCase, logger *zap.Logger) Service {
+	return &service{
+		confirmationUseCase: uc,
+		logger:              logger.Named("ConfirmationService"),
+	}
+}
+
+func (s *service) GetPendingConfirmations(ctx context.Context) ([]data.ResultConfirmation, error) {
+	log := s.logger.With(zap.String("method", "GetPendingConfirmations"))
+	log.Debug("Calling use case to get pending result confirmations")
+
+	confirmations, err := s.confirmationUseCase.GetPendingConfirmations(ctx)
+	if err != nil {
+		log.Warn("Use case returned error getting pending result confirmations", zap.Error(err))
+		return nil, err
+	}
+
+	log.Debug("Successfully retrieved pending result confirmations from use case", zap.Int("count", len(confirmations)))
+	return confirmations, nil
+}
+
+func (s *service) Confirm(ctx context.Context, eventID string) (*data.ResultConfirmation, error) {
+	log := s.logger.With(zap.String("method", "Confirm"), zap.String("eventId", eventID))
+	log.Info("Calling use case to confirm pending event result")
+
+	confirmation, err := s.confirmationUseCase.Confirm(ctx, eventID)
+	if err != nil {
+		log.Error("Use case returned error confirming pending event result", zap.Error(err))
+		return nil, err
+	}
+
+	log.Info("Pending event result confirmed via use case", zap.String("result", string(confirmation.Result)))
+	return confirmation, nil
+}
@@ -0,0 +1,58 @@
+package conflict
+
+import (
+	"context"
+
+	"github.com/Arlan-Z/def-betting-api/internal/data"
+	"go.uber.org/zap"
+)
+
+type ConflictUseCase interface {
+	GetOpenConflicts(ctx context.Context) ([]data.ResultConflict, error)
+	Confirm(ctx context.Context, eventID string, result string) (*data.ResultConflict, error)
+}
+
+type Service interface {
+	GetOpenConflicts(ctx context.Context) ([]data.ResultConflict, error)
+	Confirm(ctx context.Context, eventID string, result string) (*data.ResultConflict, error)
+}
+
+type service struct {
+	conflictUseCase ConflictUseCase
+	logger          *zap.Logger
+}
+
+func NewService(uc ConflictUseCase, logger *zap.Logger) Service {
+	return &service{
+		conflictUseCase: uc,
+		logger:          logger.Named("ConflictService"),
+	}
+}
+
+func (s *service) GetOpenConflicts(ctx context.Context) ([]data.ResultConflict, error) {
+	log := s.logger.With(zap.String("method", "GetOpenConflicts"))
+	log.Debug("Calling use case to get open result conflicts")
+
+	conflicts, err := s.conflictUseCase.GetOpenConflicts(ctx)
+	if err != nil {
+		log.Warn("Use case returned error getting open result conflicts", zap.Error(err))
+		return nil, err
+	}
+
+	log.Debug("Successfully retrieved open result conflicts from use case", zap.Int("count", len(conflicts)))
+	return conflicts, nil
+}
+
+func (s *service) Confirm(ctx context.Context, eventID string, result string) (*data.ResultConflict, error) {
+	log := s.logger.With(zap.String("method", "Confirm"), zap.String("eventId", eventID))
+	log.Info("Calling use case to confirm event result")
+
+	conflict, err := s.conflictUseCase.Confirm(ctx, eventID, result)
+	if err != nil {
+		log.Error("Use case returned error confirming event result", zap.Error(err))
+		return nil, err
+	}
+
+	log.Info("Event result confirmed via use case", zap.String("result", result))
+	return conflict, nil
+}
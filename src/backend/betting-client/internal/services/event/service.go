@@ -11,11 +11,13 @@ import (
 
 type EventUseCase interface {
 	GetActiveEvents(ctx context.Context) ([]data.Event, error)
+	SearchEvents(ctx context.Context, query string, limit int) ([]data.EventSearchResult, error)
 	FinalizeEvent(ctx context.Context, eventID string, actualResult data.Outcome) error
 }
 
 type Service interface {
 	GetActiveEvents(ctx context.Context) ([]data.Event, error)
+	SearchEvents(ctx context.Context, query string, limit int) ([]data.EventSearchResult, error)
 	FinalizeEvent(ctx context.Context, eventID string, result data.Outcome) error
 }
 
@@ -45,6 +47,20 @@ func (s *service) GetActiveEvents(ctx context.Context) ([]data.Event, error) {
 	return events, nil
 }
 
+func (s *service) SearchEvents(ctx context.Context, query string, limit int) ([]data.EventSearchResult, error) {
+	log := s.logger.With(zap.String("method", "SearchEvents"), zap.String("query", query))
+	log.Debug("Calling use case to search events")
+
+	results, err := s.eventUseCase.SearchEvents(ctx, query, limit)
+	if err != nil {
+		log.Warn("Use case returned error searching events", zap.Error(err))
+		return nil, err
+	}
+
+	log.Debug("Successfully searched events via use case", zap.Int("count", len(results)))
+	return results, nil
+}
+
 func (s *service) FinalizeEvent(ctx context.Context, eventID string, result data.Outcome) error {
 	log := s.logger.With(zap.String("method", "FinalizeEvent"), zap.String("eventId", eventID))
 	log.Info("Calling use case to finalize event")
@@ -0,0 +1,215 @@
+package leader
+
+import (
+	"context"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/Arlan-Z/def-betting-api/internal/data"
+	"github.com/Arlan-Z/def-betting-api/internal/pkg/fence"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// Elector decides which replica runs the background workers.
+type Elector interface {
+	// Run calls lead whenever this replica becomes the leader. The context passed to lead
+	// is canceled when leadership is lost, and Run waits for lead to return before
+	// campaigning again. Run returns once ctx is done.
+	Run(ctx context.Context, lead func(ctx context.Context))
+	Status() data.LeaderStatus
+}
+
+type LeaseRepository interface {
+	TryAcquire(ctx context.Context, name string, holder string, ttl time.Duration, now time.Time) (*data.Lease, error)
+	Renew(ctx context.Context, name string, holder string, token int64, ttl time.Duration, now time.Time) (bool, error)
+	Release(ctx context.Context, name string, holder string, token int64, now time.Time) error
+}
+
+// NewHolderID identifies this replica: the host name with a random suffix, so a
+// restarted process never mistakes the lease of its predecessor for its own.
+func NewHolderID() string {
+	host, err := os.Hostname()
+	if err != nil || host == "" {
+		host = "replica"
+	}
+	return host + "-" + uuid.NewString()[:8]
+}
+
+// LeaseElector elects the leader through a lease in the database. The leader renews
+// the lease every renewInterval, also while its workers drain after it gave up
+// leadership, and stops its workers when it cannot renew the lease before it expires.
+// Workers may still be finishing a sync cycle when the lease passes to another replica;
+// their settlement and payout writes are then rejected through the fencing token.
+type LeaseElector struct {
+	repo          LeaseRepository
+	name          string
+	holder        string
+	ttl           time.Duration
+	renewInterval time.Duration
+	logger        *zap.Logger
+
+	mu     sync.Mutex
+	status data.LeaderStatus
+}
+
+func NewLeaseElector(repo LeaseRepository, name string, holder string, ttl time.Duration, renewInterval time.Duration, logger *zap.Logger) *LeaseElector {
+	return &LeaseElector{
+		repo:          repo,
+		name:          name,
+		holder:        holder,
+		ttl:           ttl,
+		renewInterval: renewInterval,
+		logger:        logger.Named("LeaseElector").With(zap.String("lease", name), zap.String("holder", holder)),
+		status:        data.LeaderStatus{LeaseName: name, Holder: holder},
+	}
+}
+
+func (e *LeaseElector) Run(ctx context.Context, lead func(ctx context.Context)) {
+	e.logger.Info("Campaigning for leadership", zap.Duration("ttl", e.ttl), zap.Duration("renewInterval", e.renewInterval))
+
+	for {
+		lease, err := e.repo.TryAcquire(ctx, e.name, e.holder, e.ttl, time.Now().UTC())
+		if err != nil {
+			e.logger.Error("Failed to acquire leadership lease", zap.Error(err))
+		} else if lease.Holder == e.holder {
+			e.lead(ctx, *lease, lead)
+		} else {
+			e.setStatus(false, *lease)
+		}
+
+		timer := time.NewTimer(e.renewInterval)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			e.logger.Info("Stopping leader election due to context cancellation")
+			return
+		}
+	}
+}
+
+// lead runs the workers until the lease is lost, the workers return or ctx is done.
+func (e *LeaseElector) lead(ctx context.Context, lease data.Lease, lead func(ctx context.Context)) {
+	log := e.logger.With(zap.Int64("token", lease.Token))
+	log.Info("Became leader, starting background workers")
+	e.setStatus(true, lease)
+
+	// Settlement and payout writes of the workers check the token, so they are rejected
+	// once another replica took over the lease.
+	token := fence.Token{Lease: e.name, Holder: e.holder, Value: lease.Token}
+	leaderCtx, cancel := context.WithCancel(fence.WithToken(ctx, token))
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		lead(leaderCtx)
+	}()
+
+	// renew extends the lease and reports whether it is still held until the next renewal.
+	// The context may be done already while the workers drain, the lease is renewed anyway.
+	renew := func() bool {
+		now := time.Now().UTC()
+		renewed, err := e.repo.Renew(context.Background(), e.name, e.holder, lease.Token, e.ttl, now)
+		if err != nil {
+			// Keep leading while the lease is certainly ours, but stop before it can expire.
+			log.Error("Failed to renew leadership lease", zap.Error(err))
+			if now.Add(e.renewInterval).Before(lease.ExpiresAt) {
+				return true
+			}
+			log.Warn("Leadership lease is about to expire")
+			return false
+		}
+		if !renewed {
+			log.Warn("Leadership lease was taken over")
+			return false
+		}
+		lease.RenewedAt = now
+		lease.ExpiresAt = now.Add(e.ttl)
+		e.setStatus(true, lease)
+		return true
+	}
+
+	ticker := time.NewTicker(e.renewInterval)
+	defer ticker.Stop()
+
+	// stepDown stops the workers and waits for them to return. While the lease is held it
+	// keeps renewing it, so no other replica takes over before the workers are done.
+	stepDown := func(held bool) {
+		cancel()
+		for held {
+			select {
+			case <-done:
+				if err := e.repo.Release(context.Background(), e.name, e.holder, lease.Token, time.Now().UTC()); err != nil {
+					log.Error("Failed to release leadership lease", zap.Error(err))
+				}
+				e.setStatus(false, data.Lease{})
+				return
+			case <-ticker.C:
+				held = renew()
+			}
+		}
+		log.Warn("Leadership lease lost, waiting for background workers whose writes are now fenced")
+		e.setStatus(false, data.Lease{})
+		<-done
+	}
+
+	for {
+		select {
+		case <-ticker.C:
+			if !renew() {
+				log.Warn("Stopping background workers")
+				stepDown(false)
+				return
+			}
+		case <-done:
+			log.Warn("Background workers stopped, giving up leadership")
+			stepDown(true)
+			return
+		case <-ctx.Done():
+			log.Info("Giving up leadership due to context cancellation")
+			stepDown(true)
+			return
+		}
+	}
+}
+
+func (e *LeaseElector) setStatus(leader bool, lease data.Lease) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.status = data.LeaderStatus{LeaseName: e.name, Holder: e.holder, Leader: leader}
+	if lease.Holder == "" {
+		return
+	}
+	expiresAt := lease.ExpiresAt
+	e.status.CurrentLeader = lease.Holder
+	e.status.ExpiresAt = &expiresAt
+	if leader {
+		e.status.Token = lease.Token
+	}
+}
+
+func (e *LeaseElector) Status() data.LeaderStatus {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.status
+}
+
+// StaticElector makes this replica the leader unconditionally. It is used when leader
+// election is disabled, which is only safe with a single replica.
+type StaticElector struct {
+	holder string
+}
+
+func NewStaticElector(holder string) *StaticElector {
+	return &StaticElector{holder: holder}
+}
+
+func (e *StaticElector) Run(ctx context.Context, lead func(ctx context.Context)) {
+	lead(ctx)
+}
+
+func (e *StaticElector) Status() data.LeaderStatus {
+	return data.LeaderStatus{Holder: e.holder, Leader: true, CurrentLeader: e.holder}
+}
@@ -0,0 +1,278 @@
+package leader_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Arlan-Z/def-betting-api/internal/data"
+	"github.com/Arlan-Z/def-betting-api/internal/pkg/fence"
+	"github.com/Arlan-Z/def-betting-api/internal/services/leader"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const (
+	leaseName     = "background-workers"
+	holder        = "replica-a"
+	ttl           = 60 * time.Millisecond
+	renewInterval = 10 * time.Millisecond
+	waitFor       = 2 * time.Second
+)
+
+// fakeLeaseRepo keeps a single lease in memory with the semantics of the lease table.
+type fakeLeaseRepo struct {
+	mu       sync.Mutex
+	lease    *data.Lease
+	renewErr error
+	renewals int
+	released []int64
+}
+
+func (r *fakeLeaseRepo) TryAcquire(ctx context.Context, name string, holder string, ttl time.Duration, now time.Time) (*data.Lease, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	switch {
+	case r.lease == nil:
+		r.lease = &data.Lease{Name: name, Holder: holder, Token: 1, AcquiredAt: now}
+	case r.lease.Holder != holder && r.lease.ExpiresAt.After(now):
+		lease := *r.lease
+		return &lease, nil
+	case r.lease.Holder != holder:
+		r.lease.Holder = holder
+		r.lease.Token++
+		r.lease.AcquiredAt = now
+	}
+	r.lease.RenewedAt = now
+	r.lease.ExpiresAt = now.Add(ttl)
+	lease := *r.lease
+	return &lease, nil
+}
+
+func (r *fakeLeaseRepo) Renew(ctx context.Context, name string, holder string, token int64, ttl time.Duration, now time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.renewals++
+	if r.renewErr != nil {
+		return false, r.renewErr
+	}
+	if r.lease == nil || r.lease.Holder != holder || r.lease.Token != token || !r.lease.ExpiresAt.After(now) {
+		return false, nil
+	}
+	r.lease.RenewedAt = now
+	r.lease.ExpiresAt = now.Add(ttl)
+	return true, nil
+}
+
+func (r *fakeLeaseRepo) Release(ctx context.Context, name string, holder string, token int64, now time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.lease != nil && r.lease.Holder == holder && r.lease.Token == token {
+		r.lease.ExpiresAt = now
+		r.released = append(r.released, token)
+	}
+	return nil
+}
+
+// takeOver hands the lease to another replica, as if this one had missed its renewals.
+func (r *fakeLeaseRepo) takeOver(other string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.lease.Holder = other
+	r.lease.Token++
+	r.lease.ExpiresAt = time.Now().UTC().Add(time.Hour)
+}
+
+func (r *fakeLeaseRepo) failRenewals(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.renewErr = err
+}
+
+func (r *fakeLeaseRepo) renewCount() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.renewals
+}
+
+func (r *fakeLeaseRepo) releases() []int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]int64(nil), r.released...)
+}
+
+// fakeWorker records the context it was started with and runs until it is canceled.
+// drain delays its return after cancellation, like a sync cycle that is finishing.
+type fakeWorker struct {
+	drain   time.Duration
+	started chan context.Context
+	stopped chan struct{}
+}
+
+func newFakeWorker(drain time.Duration) *fakeWorker {
+	return &fakeWorker{drain: drain, started: make(chan context.Context, 1), stopped: make(chan struct{})}
+}
+
+func (w *fakeWorker) Start(ctx context.Context) {
+	w.started <- ctx
+	<-ctx.Done()
+	time.Sleep(w.drain)
+	close(w.stopped)
+}
+
+func startElector(t *testing.T, repo *fakeLeaseRepo, workers ...*fakeWorker) (*leader.LeaseElector, context.CancelFunc, chan struct{}) {
+	elector := leader.NewLeaseElector(repo, leaseName, holder, ttl, renewInterval, zap.NewNop())
+	ctx, cancel := context.WithCancel(context.Background())
+	runDone := make(chan struct{})
+	go func() {
+		defer close(runDone)
+		elector.Run(ctx, func(ctx context.Context) {
+			running := make([]leader.Worker, len(workers))
+			for i, w := range workers {
+				running[i] = w
+			}
+			leader.RunWorkers(ctx, running...)
+		})
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-runDone
+	})
+	return elector, cancel, runDone
+}
+
+func leaderContexts(t *testing.T, workers []*fakeWorker) []context.Context {
+	contexts := make([]context.Context, len(workers))
+	for i, w := range workers {
+		select {
+		case contexts[i] = <-w.started:
+		case <-time.After(waitFor):
+			t.Fatalf("worker %d was not started", i)
+		}
+	}
+	return contexts
+}
+
+func requireStopped(t *testing.T, workers []*fakeWorker) {
+	for i, w := range workers {
+		select {
+		case <-w.stopped:
+		case <-time.After(waitFor):
+			t.Fatalf("worker %d was not stopped", i)
+		}
+	}
+}
+
+func fourWorkers(drain time.Duration) []*fakeWorker {
+	// The event syncer, the market closer, the payout dispatcher and the event consumer.
+	return []*fakeWorker{newFakeWorker(drain), newFakeWorker(drain), newFakeWorker(drain), newFakeWorker(drain)}
+}
+
+func TestLeaseElector_WorkersRunWithTheFencingToken(t *testing.T) {
+	repo := &fakeLeaseRepo{}
+	workers := fourWorkers(0)
+	elector, _, _ := startElector(t, repo, workers...)
+
+	for _, ctx := range leaderContexts(t, workers) {
+		token, ok := fence.FromContext(ctx)
+		require.True(t, ok)
+		assert.Equal(t, fence.Token{Lease: leaseName, Holder: holder, Value: 1}, token)
+		assert.NoError(t, ctx.Err())
+	}
+
+	status := elector.Status()
+	assert.True(t, status.Leader)
+	assert.Equal(t, int64(1), status.Token)
+	assert.Equal(t, holder, status.CurrentLeader)
+
+	require.Eventually(t, func() bool { return repo.renewCount() >= 2 }, waitFor, renewInterval, "the leader renews its lease")
+	for _, w := range workers {
+		select {
+		case <-w.stopped:
+			t.Fatal("worker stopped while the lease is renewed")
+		default:
+		}
+	}
+}
+
+func TestLeaseElector_LeaseTakenOverStopsWorkers(t *testing.T) {
+	repo := &fakeLeaseRepo{}
+	workers := fourWorkers(0)
+	elector, _, _ := startElector(t, repo, workers...)
+	contexts := leaderContexts(t, workers)
+
+	repo.takeOver("replica-b")
+
+	requireStopped(t, workers)
+	for _, ctx := range contexts {
+		assert.ErrorIs(t, ctx.Err(), context.Canceled)
+	}
+	require.Eventually(t, func() bool {
+		status := elector.Status()
+		return !status.Leader && status.CurrentLeader == "replica-b"
+	}, waitFor, renewInterval)
+	assert.Empty(t, repo.releases(), "a lease held by another replica is not released")
+}
+
+func TestLeaseElector_FailedRenewalsStopWorkersBeforeTheLeaseExpires(t *testing.T) {
+	repo := &fakeLeaseRepo{}
+	workers := fourWorkers(0)
+	elector, _, _ := startElector(t, repo, workers...)
+	leaderContexts(t, workers)
+
+	failedAt := time.Now()
+	repo.failRenewals(errors.New("database is locked"))
+
+	requireStopped(t, workers)
+	assert.Less(t, time.Since(failedAt), ttl+renewInterval, "workers stop before the lease can pass to another replica")
+	assert.False(t, elector.Status().Leader)
+}
+
+func TestLeaseElector_LeaseIsRenewedWhileWorkersDrain(t *testing.T) {
+	repo := &fakeLeaseRepo{}
+	drain := 5 * renewInterval
+	workers := fourWorkers(drain)
+	_, cancel, runDone := startElector(t, repo, workers...)
+	leaderContexts(t, workers)
+
+	renewals := repo.renewCount()
+	cancel()
+
+	select {
+	case <-runDone:
+	case <-time.After(waitFor):
+		t.Fatal("elector did not return")
+	}
+	requireStopped(t, workers)
+	assert.Greater(t, repo.renewCount(), renewals+1, "the lease is renewed until the workers returned")
+	assert.Equal(t, []int64{1}, repo.releases(), "the lease is released once the workers returned")
+}
+
+func TestRunWorkers_ReturnsOnceEveryWorkerReturned(t *testing.T) {
+	workers := fourWorkers(renewInterval)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		leader.RunWorkers(ctx, workers[0], workers[1], workers[2], workers[3])
+	}()
+	leaderContexts(t, workers)
+
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(waitFor):
+		t.Fatal("RunWorkers did not return")
+	}
+	for _, w := range workers {
+		select {
+		case <-w.stopped:
+		default:
+			t.Fatal("RunWorkers returned before a worker")
+		}
+	}
+}
@@ -0,0 +1,25 @@
+package leader
+
+import (
+	"context"
+	"sync"
+)
+
+// Worker is a background worker that runs until its context is canceled.
+type Worker interface {
+	Start(ctx context.Context)
+}
+
+// RunWorkers starts the workers and returns once all of them have returned. Passed to
+// Elector.Run, it stops every worker when leadership is lost.
+func RunWorkers(ctx context.Context, workers ...Worker) {
+	var wg sync.WaitGroup
+	for _, worker := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			worker.Start(ctx)
+		}()
+	}
+	wg.Wait()
+}
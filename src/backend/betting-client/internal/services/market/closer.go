@@ -0,0 +1,91 @@
+package market
+
+import (
+	"context"
+	"time"
+
+	"github.com/Arlan-Z/def-betting-api/internal/data"
+	"go.uber.org/zap"
+)
+
+type EventRepository interface {
+	FindActiveEvents(ctx context.Context) ([]data.Event, error)
+	MarkBettingClosed(ctx context.Context, eventID string, closedAt time.Time) error
+}
+
+// MarketCloser closes betting on each event once its start time minus the
+// configured cutoff is reached.
+type MarketCloser struct {
+	eventRepo      EventRepository
+	cutoff         data.BetCutoffPolicy
+	rescanInterval time.Duration
+	logger         *zap.Logger
+}
+
+func NewMarketCloser(er EventRepository, cutoff data.BetCutoffPolicy, rescanInterval time.Duration, logger *zap.Logger) *MarketCloser {
+	return &MarketCloser{
+		eventRepo:      er,
+		cutoff:         cutoff,
+		rescanInterval: rescanInterval,
+		logger:         logger.Named("MarketCloser"),
+	}
+}
+
+func (c *MarketCloser) Start(ctx context.Context) {
+	c.logger.Info("Starting market closing worker",
+		zap.Duration("rescanInterval", c.rescanInterval),
+		zap.Duration("defaultCutoff", c.cutoff.Default),
+	)
+
+	for {
+		wait := c.rescanInterval
+		if next := c.closeDueMarkets(ctx); !next.IsZero() {
+			if untilNext := time.Until(next); untilNext < wait {
+				wait = untilNext
+			}
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			c.logger.Info("Stopping market closing worker due to context cancellation")
+			return
+		}
+	}
+}
+
+// closeDueMarkets closes every open event whose cutoff has passed and returns
+// the closing time of the next open event, or zero if there is none.
+func (c *MarketCloser) closeDueMarkets(ctx context.Context) time.Time {
+	events, err := c.eventRepo.FindActiveEvents(ctx)
+	if err != nil {
+		c.logger.Error("Failed to load open events", zap.Error(err))
+		return time.Time{}
+	}
+
+	now := time.Now().UTC()
+	var next time.Time
+	for _, event := range events {
+		closesAt := c.cutoff.ClosesAt(event)
+		if closesAt.After(now) {
+			if next.IsZero() || closesAt.Before(next) {
+				next = closesAt
+			}
+			continue
+		}
+
+		if err := c.eventRepo.MarkBettingClosed(ctx, event.ID, now); err != nil {
+			c.logger.Error("Failed to close betting for event", zap.String("eventId", event.ID), zap.Error(err))
+			continue
+		}
+		c.logger.Info("Betting closed for event",
+			zap.String("eventId", event.ID),
+			zap.String("type", event.Type),
+			zap.Time("eventStart", event.EventStartDate),
+			zap.Time("closesAt", closesAt),
+		)
+	}
+	return next
+}
@@ -41,6 +41,25 @@ func (_m *EventService) FinalizeEvent(ctx context.Context, eventID string, resul
 	return r0
 }
 
+func (_m *EventService) SearchEvents(ctx context.Context, query string, limit int) ([]data.EventSearchResult, error) {
+	ret := _m.Called(ctx, query, limit)
+	var r0 []data.EventSearchResult
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) []data.EventSearchResult); ok {
+		r0 = rf(ctx, query, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]data.EventSearchResult)
+		}
+	}
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, query, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
 func NewEventService(t interface {
 	mock.TestingT
 	Cleanup(func())
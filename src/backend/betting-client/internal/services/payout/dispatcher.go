@@ -0,0 +1,157 @@
+package payout
+
+import (
+	"context"
+	"time"
+
+	"github.com/Arlan-Z/def-betting-api/internal/data"
+	payoutclient "github.com/Arlan-Z/def-betting-api/internal/deliveries/payout/http"
+	"go.uber.org/zap"
+)
+
+// DefaultDispatchInterval is used when no positive interval is configured.
+const DefaultDispatchInterval = 5 * time.Second
+
+type OutboxRepository interface {
+	FindDue(ctx context.Context, now time.Time, limit int) ([]data.PayoutIntent, error)
+	MarkDelivered(ctx context.Context, intentID string, deliveredAt time.Time) (bool, error)
+	MarkFailed(ctx context.Context, intentID string, lastError string, failedAt time.Time) (bool, error)
+	RecordFailedAttempt(ctx context.Context, intentID string, lastError string, attemptedAt time.Time, nextAttemptAt time.Time) error
+}
+
+type DispatchPolicy struct {
+	Interval  time.Duration
+	BatchSize int
+	// MaxAttempts is how often an intent is tried before its bet is marked as Failed.
+	MaxAttempts int
+	// RetryBackoff is the wait after the first failed attempt; it doubles with every further
+	// one up to MaxBackoff. Without a MaxBackoff it does not grow.
+	RetryBackoff time.Duration
+	MaxBackoff   time.Duration
+	// Currency is the currency of all bet amounts, sent along with every payout.
+	Currency string
+}
+
+// Dispatcher delivers the payout intents that settlements put into the outbox. Its
+// progress lives in the outbox table, so a restart picks up where it stopped; an
+// intent delivered right before a crash may be delivered again.
+type Dispatcher struct {
+	outboxRepo   OutboxRepository
+	payoutClient payoutclient.PayoutClient
+	policy       DispatchPolicy
+	logger       *zap.Logger
+}
+
+func NewDispatcher(or OutboxRepository, pc payoutclient.PayoutClient, policy DispatchPolicy, logger *zap.Logger) *Dispatcher {
+	if policy.Interval <= 0 {
+		policy.Interval = DefaultDispatchInterval
+	}
+	if policy.MaxAttempts <= 0 {
+		policy.MaxAttempts = 1
+	}
+	if policy.BatchSize <= 0 {
+		policy.BatchSize = 1
+	}
+	return &Dispatcher{
+		outboxRepo:   or,
+		payoutClient: pc,
+		policy:       policy,
+		logger:       logger.Named("PayoutDispatcher"),
+	}
+}
+
+func (d *Dispatcher) Start(ctx context.Context) {
+	d.logger.Info("Starting payout dispatcher",
+		zap.Duration("interval", d.policy.Interval),
+		zap.Int("maxAttempts", d.policy.MaxAttempts),
+		zap.Duration("retryBackoff", d.policy.RetryBackoff),
+		zap.Duration("maxBackoff", d.policy.MaxBackoff),
+	)
+
+	ticker := time.NewTicker(d.policy.Interval)
+	defer ticker.Stop()
+
+	for {
+		d.dispatchDue(ctx)
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			d.logger.Info("Stopping payout dispatcher due to context cancellation")
+			return
+		}
+	}
+}
+
+// dispatchDue tries to deliver one batch of due intents.
+func (d *Dispatcher) dispatchDue(ctx context.Context) {
+	intents, err := d.outboxRepo.FindDue(ctx, time.Now().UTC(), d.policy.BatchSize)
+	if err != nil {
+		d.logger.Error("Failed to load due payouts", zap.Error(err))
+		return
+	}
+
+	for _, intent := range intents {
+		if ctx.Err() != nil {
+			break
+		}
+		d.deliver(ctx, intent)
+	}
+}
+
+func (d *Dispatcher) deliver(ctx context.Context, intent data.PayoutIntent) {
+	log := d.logger.With(
+		zap.String("payoutId", intent.ID),
+		zap.String("betId", intent.BetID),
+		zap.String("userId", intent.UserID),
+		zap.String("kind", string(intent.Kind)),
+		zap.Float64("amount", intent.Amount),
+	)
+
+	err := d.payoutClient.NotifyPayout(ctx, intent.Notification(d.policy.Currency))
+	now := time.Now().UTC()
+	if err == nil {
+		delivered, errMark := d.outboxRepo.MarkDelivered(ctx, intent.ID, now)
+		switch {
+		case errMark != nil:
+			// The intent stays pending and is delivered again on a later attempt.
+			log.Error("CRITICAL: Payout delivered but not recorded", zap.Error(errMark))
+		case !delivered:
+			log.Warn("Payout delivered, but it was already finished")
+		default:
+			log.Info("Payout delivered")
+		}
+		return
+	}
+	if ctx.Err() != nil {
+		return
+	}
+
+	attempts := intent.Attempts + 1
+	if attempts >= d.policy.MaxAttempts {
+		log.Error("Giving up on payout", zap.Int("attempts", attempts), zap.Error(err))
+		if _, errMark := d.outboxRepo.MarkFailed(ctx, intent.ID, err.Error(), now); errMark != nil {
+			log.Error("Failed to record failed payout", zap.Error(errMark))
+		}
+		return
+	}
+
+	nextAttemptAt := now.Add(d.backoff(attempts))
+	log.Warn("Payout delivery failed, will retry", zap.Int("attempts", attempts), zap.Time("nextAttemptAt", nextAttemptAt), zap.Error(err))
+	if errRecord := d.outboxRepo.RecordFailedAttempt(ctx, intent.ID, err.Error(), now, nextAttemptAt); errRecord != nil {
+		log.Error("Failed to record failed payout attempt", zap.Error(errRecord))
+	}
+}
+
+// backoff doubles the wait until it reaches MaxBackoff, so it cannot overflow however
+// many attempts an intent takes. Without a cap it stays at RetryBackoff.
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	if d.policy.MaxBackoff <= 0 {
+		return d.policy.RetryBackoff
+	}
+	wait := d.policy.RetryBackoff
+	for i := 1; i < attempts && wait < d.policy.MaxBackoff; i++ {
+		wait *= 2
+	}
+	return min(wait, d.policy.MaxBackoff)
+}
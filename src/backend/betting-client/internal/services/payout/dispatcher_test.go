@@ -0,0 +1,63 @@
+package payout_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Arlan-Z/def-betting-api/internal/data"
+	repomocks "github.com/Arlan-Z/def-betting-api/internal/repositories/mocks"
+	payoutsvc "github.com/Arlan-Z/def-betting-api/internal/services/payout"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+type failingPayoutClient struct{}
+
+func (failingPayoutClient) NotifyPayout(ctx context.Context, notification data.PayoutNotification) error {
+	return errors.New("payout service unavailable")
+}
+
+// nextWait runs one dispatch of an intent that failed attempts times before and returns
+// how long the dispatcher waits before the next attempt.
+func nextWait(t *testing.T, policy payoutsvc.DispatchPolicy, attempts int) time.Duration {
+	mockRepo := repomocks.NewOutboxRepository(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	intent := data.PayoutIntent{ID: "payout-1", BetID: "bet-1", Kind: data.PayoutWin, Status: data.OutboxPending, Attempts: attempts}
+	mockRepo.On("FindDue", mock.Anything, mock.AnythingOfType("time.Time"), policy.BatchSize).Return([]data.PayoutIntent{intent}, nil).Once()
+
+	var wait time.Duration
+	mockRepo.On("RecordFailedAttempt", mock.Anything, "payout-1", "payout service unavailable", mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).
+		Run(func(args mock.Arguments) {
+			wait = args.Get(4).(time.Time).Sub(args.Get(3).(time.Time))
+			cancel()
+		}).Return(nil).Once()
+
+	payoutsvc.NewDispatcher(mockRepo, failingPayoutClient{}, policy, zap.NewNop()).Start(ctx)
+	return wait
+}
+
+func TestDispatcher_BackoffDoublesUpToTheCap(t *testing.T) {
+	policy := payoutsvc.DispatchPolicy{Interval: time.Hour, BatchSize: 10, MaxAttempts: 1000, RetryBackoff: 10 * time.Second, MaxBackoff: time.Hour}
+
+	assert.Equal(t, 10*time.Second, nextWait(t, policy, 0))
+	assert.Equal(t, 20*time.Second, nextWait(t, policy, 1))
+	assert.Equal(t, 80*time.Second, nextWait(t, policy, 3))
+	assert.Equal(t, time.Hour, nextWait(t, policy, 9))
+}
+
+func TestDispatcher_BackoffDoesNotOverflowAfterManyAttempts(t *testing.T) {
+	policy := payoutsvc.DispatchPolicy{Interval: time.Hour, BatchSize: 10, MaxAttempts: 1000, RetryBackoff: 10 * time.Second, MaxBackoff: time.Hour}
+	for _, attempts := range []int{40, 63, 64, 100, 500} {
+		assert.Equal(t, time.Hour, nextWait(t, policy, attempts), "after %d attempts", attempts)
+	}
+
+	policy.MaxBackoff = 0
+	for _, attempts := range []int{0, 64, 500} {
+		assert.Equal(t, 10*time.Second, nextWait(t, policy, attempts), "without a cap, after %d attempts", attempts)
+	}
+}
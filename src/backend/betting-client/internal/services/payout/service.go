@@ -0,0 +1,88 @@
+package payout
+
+import (
+	"context"
+
+	"github.com/Arlan-Z/def-betting-api/internal/data"
+	"go.uber.org/zap"
+)
+
+type PayoutUseCase interface {
+	GetStuckPayouts(ctx context.Context) ([]data.PayoutIntent, error)
+	GetPayout(ctx context.Context, payoutID string) (*data.PayoutIntent, []data.PayoutAttempt, error)
+	Requeue(ctx context.Context, payoutID string) (*data.PayoutIntent, error)
+	Resolve(ctx context.Context, payoutID string, note string) (*data.PayoutIntent, error)
+}
+
+type Service interface {
+	GetStuckPayouts(ctx context.Context) ([]data.PayoutIntent, error)
+	GetPayout(ctx context.Context, payoutID string) (*data.PayoutIntent, []data.PayoutAttempt, error)
+	Requeue(ctx context.Context, payoutID string) (*data.PayoutIntent, error)
+	Resolve(ctx context.Context, payoutID string, note string) (*data.PayoutIntent, error)
+}
+
+type service struct {
+	payoutUseCase PayoutUseCase
+	logger        *zap.Logger
+}
+
+func NewService(uc PayoutUseCase, logger *zap.Logger) Service {
+	return &service{
+		payoutUseCase: uc,
+		logger:        logger.Named("PayoutService"),
+	}
+}
+
+func (s *service) GetStuckPayouts(ctx context.Context) ([]data.PayoutIntent, error) {
+	log := s.logger.With(zap.String("method", "GetStuckPayouts"))
+	log.Debug("Calling use case to get stuck payouts")
+
+	payouts, err := s.payoutUseCase.GetStuckPayouts(ctx)
+	if err != nil {
+		log.Warn("Use case returned error getting stuck payouts", zap.Error(err))
+		return nil, err
+	}
+
+	log.Debug("Successfully retrieved stuck payouts from use case", zap.Int("count", len(payouts)))
+	return payouts, nil
+}
+
+func (s *service) GetPayout(ctx context.Context, payoutID string) (*data.PayoutIntent, []data.PayoutAttempt, error) {
+	log := s.logger.With(zap.String("method", "GetPayout"), zap.String("payoutId", payoutID))
+	log.Debug("Calling use case to get payout")
+
+	payout, attempts, err := s.payoutUseCase.GetPayout(ctx, payoutID)
+	if err != nil {
+		log.Warn("Use case returned error getting payout", zap.Error(err))
+		return nil, nil, err
+	}
+	return payout, attempts, nil
+}
+
+func (s *service) Requeue(ctx context.Context, payoutID string) (*data.PayoutIntent, error) {
+	log := s.logger.With(zap.String("method", "Requeue"), zap.String("payoutId", payoutID))
+	log.Info("Calling use case to requeue payout")
+
+	payout, err := s.payoutUseCase.Requeue(ctx, payoutID)
+	if err != nil {
+		log.Error("Use case returned error requeueing payout", zap.Error(err))
+		return nil, err
+	}
+
+	log.Info("Payout requeued via use case")
+	return payout, nil
+}
+
+func (s *service) Resolve(ctx context.Context, payoutID string, note string) (*data.PayoutIntent, error) {
+	log := s.logger.With(zap.String("method", "Resolve"), zap.String("payoutId", payoutID))
+	log.Info("Calling use case to resolve payout")
+
+	payout, err := s.payoutUseCase.Resolve(ctx, payoutID, note)
+	if err != nil {
+		log.Error("Use case returned error resolving payout", zap.Error(err))
+		return nil, err
+	}
+
+	log.Info("Payout resolved via use case")
+	return payout, nil
+}
@@ -0,0 +1,103 @@
+package quarantine
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/Arlan-Z/def-betting-api/internal/data"
+	"go.uber.org/zap"
+)
+
+type QuarantineUseCase interface {
+	GetEntries(ctx context.Context, status data.QuarantineStatus) ([]data.QuarantinedEvent, error)
+	GetEntry(ctx context.Context, entryID string) (*data.QuarantinedEvent, error)
+	GetStats(ctx context.Context) ([]data.QuarantineStat, error)
+	Replay(ctx context.Context, entryID string, payload json.RawMessage, note string) (*data.QuarantinedEvent, error)
+	Dismiss(ctx context.Context, entryID string, note string) (*data.QuarantinedEvent, error)
+}
+
+type Service interface {
+	GetEntries(ctx context.Context, status data.QuarantineStatus) ([]data.QuarantinedEvent, error)
+	GetEntry(ctx context.Context, entryID string) (*data.QuarantinedEvent, error)
+	GetStats(ctx context.Context) ([]data.QuarantineStat, error)
+	Replay(ctx context.Context, entryID string, payload json.RawMessage, note string) (*data.QuarantinedEvent, error)
+	Dismiss(ctx context.Context, entryID string, note string) (*data.QuarantinedEvent, error)
+}
+
+type service struct {
+	quarantineUseCase QuarantineUseCase
+	logger            *zap.Logger
+}
+
+func NewService(uc QuarantineUseCase, logger *zap.Logger) Service {
+	return &service{
+		quarantineUseCase: uc,
+		logger:            logger.Named("QuarantineService"),
+	}
+}
+
+func (s *service) GetEntries(ctx context.Context, status data.QuarantineStatus) ([]data.QuarantinedEvent, error) {
+	log := s.logger.With(zap.String("method", "GetEntries"), zap.String("status", string(status)))
+	log.Debug("Calling use case to get quarantined events")
+
+	entries, err := s.quarantineUseCase.GetEntries(ctx, status)
+	if err != nil {
+		log.Warn("Use case returned error getting quarantined events", zap.Error(err))
+		return nil, err
+	}
+
+	log.Debug("Successfully retrieved quarantined events from use case", zap.Int("count", len(entries)))
+	return entries, nil
+}
+
+func (s *service) GetEntry(ctx context.Context, entryID string) (*data.QuarantinedEvent, error) {
+	log := s.logger.With(zap.String("method", "GetEntry"), zap.String("entryId", entryID))
+	log.Debug("Calling use case to get quarantined event")
+
+	entry, err := s.quarantineUseCase.GetEntry(ctx, entryID)
+	if err != nil {
+		log.Warn("Use case returned error getting quarantined event", zap.Error(err))
+		return nil, err
+	}
+	return entry, nil
+}
+
+func (s *service) GetStats(ctx context.Context) ([]data.QuarantineStat, error) {
+	log := s.logger.With(zap.String("method", "GetStats"))
+	log.Debug("Calling use case to count quarantined events")
+
+	stats, err := s.quarantineUseCase.GetStats(ctx)
+	if err != nil {
+		log.Warn("Use case returned error counting quarantined events", zap.Error(err))
+		return nil, err
+	}
+	return stats, nil
+}
+
+func (s *service) Replay(ctx context.Context, entryID string, payload json.RawMessage, note string) (*data.QuarantinedEvent, error) {
+	log := s.logger.With(zap.String("method", "Replay"), zap.String("entryId", entryID), zap.Bool("edited", len(payload) > 0))
+	log.Info("Calling use case to replay quarantined event")
+
+	entry, err := s.quarantineUseCase.Replay(ctx, entryID, payload, note)
+	if err != nil {
+		log.Error("Use case returned error replaying quarantined event", zap.Error(err))
+		return nil, err
+	}
+
+	log.Info("Quarantined event replayed via use case")
+	return entry, nil
+}
+
+func (s *service) Dismiss(ctx context.Context, entryID string, note string) (*data.QuarantinedEvent, error) {
+	log := s.logger.With(zap.String("method", "Dismiss"), zap.String("entryId", entryID))
+	log.Info("Calling use case to dismiss quarantined event")
+
+	entry, err := s.quarantineUseCase.Dismiss(ctx, entryID, note)
+	if err != nil {
+		log.Error("Use case returned error dismissing quarantined event", zap.Error(err))
+		return nil, err
+	}
+
+	log.Info("Quarantined event dismissed via use case")
+	return entry, nil
+}
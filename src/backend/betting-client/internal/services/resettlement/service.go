@@ -0,0 +1,72 @@
+package resettlement
+
+import (
+	"context"
+
+	"github.com/Arlan-Z/def-betting-api/internal/data"
+	"go.uber.org/zap"
+)
+
+type ResettlementUseCase interface {
+	Resettle(ctx context.Context, eventID string, result data.Outcome, triggeredBy string, reason string) (*data.Resettlement, error)
+	GetResettlements(ctx context.Context, eventID string) ([]data.Resettlement, error)
+	GetResettlement(ctx context.Context, resettlementID string) (*data.Resettlement, error)
+}
+
+type Service interface {
+	Resettle(ctx context.Context, eventID string, result data.Outcome, triggeredBy string, reason string) (*data.Resettlement, error)
+	GetResettlements(ctx context.Context, eventID string) ([]data.Resettlement, error)
+	GetResettlement(ctx context.Context, resettlementID string) (*data.Resettlement, error)
+}
+
+type service struct {
+	resettlementUseCase ResettlementUseCase
+	logger              *zap.Logger
+}
+
+func NewService(uc ResettlementUseCase, logger *zap.Logger) Service {
+	return &service{
+		resettlementUseCase: uc,
+		logger:              logger.Named("ResettlementService"),
+	}
+}
+
+func (s *service) Resettle(ctx context.Context, eventID string, result data.Outcome, triggeredBy string, reason string) (*data.Resettlement, error) {
+	log := s.logger.With(zap.String("method", "Resettle"), zap.String("eventId", eventID), zap.String("result", string(result)))
+	log.Info("Calling use case to resettle event")
+
+	resettlement, err := s.resettlementUseCase.Resettle(ctx, eventID, result, triggeredBy, reason)
+	if err != nil {
+		log.Error("Use case returned error resettling event", zap.Error(err))
+		return nil, err
+	}
+
+	log.Info("Event resettled via use case", zap.String("resettlementId", resettlement.ID), zap.String("status", string(resettlement.Status)))
+	return resettlement, nil
+}
+
+func (s *service) GetResettlements(ctx context.Context, eventID string) ([]data.Resettlement, error) {
+	log := s.logger.With(zap.String("method", "GetResettlements"), zap.String("eventId", eventID))
+	log.Debug("Calling use case to get resettlements of event")
+
+	resettlements, err := s.resettlementUseCase.GetResettlements(ctx, eventID)
+	if err != nil {
+		log.Warn("Use case returned error getting resettlements", zap.Error(err))
+		return nil, err
+	}
+
+	log.Debug("Successfully retrieved resettlements from use case", zap.Int("count", len(resettlements)))
+	return resettlements, nil
+}
+
+func (s *service) GetResettlement(ctx context.Context, resettlementID string) (*data.Resettlement, error) {
+	log := s.logger.With(zap.String("method", "GetResettlement"), zap.String("resettlementId", resettlementID))
+	log.Debug("Calling use case to get resettlement")
+
+	resettlement, err := s.resettlementUseCase.GetResettlement(ctx, resettlementID)
+	if err != nil {
+		log.Warn("Use case returned error getting resettlement", zap.Error(err))
+		return nil, err
+	}
+	return resettlement, nil
+}
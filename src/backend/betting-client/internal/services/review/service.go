@@ -0,0 +1,58 @@
+package review
+
+import (
+	"context"
+
+	"github.com/Arlan-Z/def-betting-api/internal/data"
+	"go.uber.org/zap"
+)
+
+type ReviewUseCase interface {
+	GetPendingReviews(ctx context.Context) ([]data.BetReview, error)
+	Resolve(ctx context.Context, reviewID string, action string, note string) (*data.BetReview, error)
+}
+
+type Service interface {
+	GetPendingReviews(ctx context.Context) ([]data.BetReview, error)
+	Resolve(ctx context.Context, reviewID string, action string, note string) (*data.BetReview, error)
+}
+
+type service struct {
+	reviewUseCase ReviewUseCase
+	logger        *zap.Logger
+}
+
+func NewService(uc ReviewUseCase, logger *zap.Logger) Service {
+	return &service{
+		reviewUseCase: uc,
+		logger:        logger.Named("ReviewService"),
+	}
+}
+
+func (s *service) GetPendingReviews(ctx context.Context) ([]data.BetReview, error) {
+	log := s.logger.With(zap.String("method", "GetPendingReviews"))
+	log.Debug("Calling use case to get pending reviews")
+
+	reviews, err := s.reviewUseCase.GetPendingReviews(ctx)
+	if err != nil {
+		log.Warn("Use case returned error getting pending reviews", zap.Error(err))
+		return nil, err
+	}
+
+	log.Debug("Successfully retrieved pending reviews from use case", zap.Int("count", len(reviews)))
+	return reviews, nil
+}
+
+func (s *service) Resolve(ctx context.Context, reviewID string, action string, note string) (*data.BetReview, error) {
+	log := s.logger.With(zap.String("method", "Resolve"), zap.String("reviewId", reviewID))
+	log.Info("Calling use case to resolve bet review")
+
+	review, err := s.reviewUseCase.Resolve(ctx, reviewID, action, note)
+	if err != nil {
+		log.Error("Use case returned error resolving bet review", zap.Error(err))
+		return nil, err
+	}
+
+	log.Info("Bet review resolved via use case", zap.String("status", string(review.Status)))
+	return review, nil
+}
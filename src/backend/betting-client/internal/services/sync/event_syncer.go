@@ -4,65 +4,224 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
+	stdsync "sync"
 	"time"
 
 	"github.com/Arlan-Z/def-betting-api/internal/app/store"
 	"github.com/Arlan-Z/def-betting-api/internal/data"
 	eventsource "github.com/Arlan-Z/def-betting-api/internal/deliveries/eventsource/http"
-
-	// Import usecase packages if specific exported errors need checking by type
-	// betuc "github.com/Arlan-Z/def-betting-api/internal/usecases/bet"
-	// eventuc "github.com/Arlan-Z/def-betting-api/internal/usecases/event"
+	"github.com/Arlan-Z/def-betting-api/internal/pkg/breaker"
+	"github.com/Arlan-Z/def-betting-api/internal/pkg/fence"
+	"github.com/Arlan-Z/def-betting-api/internal/pkg/workpool"
+	betuc "github.com/Arlan-Z/def-betting-api/internal/usecases/bet"
+	eventuc "github.com/Arlan-Z/def-betting-api/internal/usecases/event"
+	resettlementuc "github.com/Arlan-Z/def-betting-api/internal/usecases/resettlement"
+	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
+var (
+	ErrUnknownProvider = errors.New("unknown event provider")
+	ErrEventNotMapped  = errors.New("event is not mapped to any provider")
+	// ErrNotRunning is returned on replicas that do not run the sync worker: only the
+	// leader fetches, processes and settles events.
+	ErrNotRunning = errors.New("event syncer is not running on this replica")
+)
+
 type eventFinalizerUseCase interface {
 	FinalizeEvent(ctx context.Context, eventID string, actualResult data.Outcome) error
+	VoidEvent(ctx context.Context, eventID string, reason string) error
+	VoidBets(ctx context.Context, eventID string, bets []data.Bet, reason string) error
 }
 
 type betCancellerUseCase interface {
 	CancelBetsForEvent(ctx context.Context, eventID string) error
+	FlagLateBets(ctx context.Context, eventID string, startDate time.Time) ([]data.Bet, error)
+}
+
+type betReviewQueue interface {
+	Enqueue(ctx context.Context, bets []data.Bet, reason string) error
+}
+
+type eventEntityResolver interface {
+	ResolveEventEntities(ctx context.Context, event *data.Event) error
+}
+
+type eventResettler interface {
+	Resettle(ctx context.Context, eventID string, result data.Outcome, triggeredBy string, reason string) (*data.Resettlement, error)
 }
 
 type EventSyncer struct {
-	sourceClient eventsource.EventSourceClient
+	providers    []Provider
 	eventRepo    store.EventRepository
+	providerRepo store.ProviderRepository
+	runRepo      store.SyncRunRepository
+	quarantine   store.QuarantineRepository
 	eventUseCase eventFinalizerUseCase
 	betUseCase   betCancellerUseCase
-	syncInterval time.Duration
+	reviewQueue  betReviewQueue
+	entities     eventEntityResolver
+	resettler    eventResettler
+	policy       Policy
 	logger       *zap.Logger
+
+	// mu serializes sync cycles and pushed events, so they never merge into the same event concurrently.
+	mu stdsync.Mutex
+	// resolveMu serializes mapping events to internal IDs, teams and competitions, which
+	// creates rows that events processed in parallel look up.
+	resolveMu stdsync.Mutex
+
+	// manual receives provider indexes of triggered cycles. A provider is queued at most
+	// once and not while its cycle runs, so the buffer never fills.
+	manual  chan int
+	stateMu stdsync.Mutex
+	// leaderCtx is the context the worker runs in, nil while it does not run.
+	leaderCtx context.Context
+	queued    map[string]bool
+	running   map[string]bool
+
+	// startedAt stands in for the last successful fetch of providers that had none yet.
+	startedAt   time.Time
+	lastSuccess map[string]time.Time
+	stale       map[string]bool
+}
+
+// Provider is one event source, synced on its own interval. Its name namespaces the
+// provider's event IDs in the mapping to internal events.
+type Provider struct {
+	Name     string
+	Client   eventsource.EventSourceClient
+	Mapper   *data.EventMapper
+	Interval time.Duration
+	// Breaker is the circuit breaker of Client, if it has one. It is only read to report
+	// the provider's health.
+	Breaker *breaker.Breaker
+}
+
+// Policy controls how the syncer reacts to schedule changes reported by the source.
+type Policy struct {
+	// RescheduleThreshold is the start date shift after which an event is marked Postponed.
+	RescheduleThreshold time.Duration
+	// PostponedVoidAfter is how long after the postponement and the new start date bets of a
+	// postponed event without a result stay pending before being voided.
+	PostponedVoidAfter time.Duration
+	// LateBetPolicy decides what happens to bets placed after a start time that was
+	// corrected backwards: LateBetPolicyReview or LateBetPolicyVoid.
+	LateBetPolicy string
+	// Merge decides which provider wins for odds, schedule and results.
+	Merge data.MergeRules
+	// MatchWindow is how far apart the start dates of the same fixture reported by two
+	// providers may be. Zero disables matching across providers.
+	MatchWindow time.Duration
+	// RunRetention is how long sync runs are kept. Zero keeps them forever.
+	RunRetention time.Duration
+	// MissingSuspendAfter is how many consecutive full fetches every provider of an event
+	// must miss it before betting on it is suspended. Zero disables suspension.
+	MissingSuspendAfter int
+	// MissingVoidAfter is how long an event may stay suspended before its bets are voided.
+	// Zero leaves the bets pending until an admin acts.
+	MissingVoidAfter time.Duration
+	// ResultConfirmation decides how often or how long the source must report the same
+	// result before the event is finalized.
+	ResultConfirmation data.ResultConfirmationPolicy
+	// ResettleOnCorrection resettles finalized events whose result the source changed, once
+	// the new result is confirmed. Otherwise the local result is kept.
+	ResettleOnCorrection bool
+	// Cutoff is the betting cutoff the market closer applies, used to reopen betting on
+	// closed events whose start was moved later.
+	Cutoff data.BetCutoffPolicy
+	// StaleSuspendAfter is how long fetches of a provider may fail before betting is
+	// suspended on the events no other provider reports. Zero disables suspension.
+	StaleSuspendAfter time.Duration
+	// Workers is how many events of a batch are processed in parallel. Updates of the
+	// same provider event are always processed one after another, in feed order.
+	Workers int
+	// CycleTimeout bounds the processing of a batch. Events and settlements not started
+	// by then are left to the next cycle. Zero disables the deadline.
+	CycleTimeout time.Duration
 }
 
+const (
+	LateBetPolicyReview = "review"
+	LateBetPolicyVoid   = "void"
+)
+
+// Validate rejects settings the syncer would otherwise misread, e.g. a misspelt late bet
+// policy that would silently act as review.
+func (p Policy) Validate() error {
+	if p.LateBetPolicy != LateBetPolicyReview && p.LateBetPolicy != LateBetPolicyVoid {
+		return fmt.Errorf("unknown late bet policy '%s', expected '%s' or '%s'", p.LateBetPolicy, LateBetPolicyReview, LateBetPolicyVoid)
+	}
+	return nil
+}
+
+// MissingVoidReason is the void reason of events that vanished from the source feed.
+const MissingVoidReason = "missing from source"
+
+// CorrectionReason is recorded on resettlements started by the syncer.
+const CorrectionReason = "result corrected by source"
+
 func NewEventSyncer(
-	sc eventsource.EventSourceClient,
+	providers []Provider,
 	er store.EventRepository,
+	pr store.ProviderRepository,
+	rr store.SyncRunRepository,
+	qr store.QuarantineRepository,
 	euc eventFinalizerUseCase,
 	buc betCancellerUseCase,
-	interval time.Duration,
+	rq betReviewQueue,
+	ee eventEntityResolver,
+	rs eventResettler,
+	policy Policy,
 	logger *zap.Logger,
 ) *EventSyncer {
 	return &EventSyncer{
-		sourceClient: sc,
+		providers:    providers,
 		eventRepo:    er,
+		providerRepo: pr,
+		runRepo:      rr,
+		quarantine:   qr,
 		eventUseCase: euc,
 		betUseCase:   buc,
-		syncInterval: interval,
+		reviewQueue:  rq,
+		entities:     ee,
+		resettler:    rs,
+		policy:       policy,
 		logger:       logger.Named("EventSyncer"),
+		manual:       make(chan int, len(providers)),
+		queued:       make(map[string]bool),
+		running:      make(map[string]bool),
+		startedAt:    time.Now().UTC(),
+		lastSuccess:  make(map[string]time.Time),
+		stale:        make(map[string]bool),
 	}
 }
 
+// Start syncs every provider once and then on each provider's interval. Cycles run one
+// at a time, so providers never merge into the same event concurrently.
 func (s *EventSyncer) Start(ctx context.Context) {
-	s.logger.Info("Starting event synchronization worker", zap.Duration("interval", s.syncInterval))
-	ticker := time.NewTicker(s.syncInterval)
-	defer ticker.Stop()
+	s.logger.Info("Starting event synchronization worker", zap.Int("providers", len(s.providers)))
+	s.setActive(ctx)
+	defer s.setActive(nil)
 
-	s.runSync(ctx)
+	due := make(chan int)
+	for i, provider := range s.providers {
+		go s.schedule(ctx, i, provider.Interval, due)
+	}
+
+	for _, provider := range s.providers {
+		s.runSync(ctx, provider, data.SyncRunPoll)
+	}
 
 	for {
 		select {
-		case <-ticker.C:
-			s.logger.Debug("Ticker triggered event sync")
-			s.runSync(ctx)
+		case i := <-due:
+			s.logger.Debug("Ticker triggered event sync", zap.String("provider", s.providers[i].Name))
+			s.runSync(ctx, s.providers[i], data.SyncRunPoll)
+		case i := <-s.manual:
+			s.logger.Info("Manually triggered event sync", zap.String("provider", s.providers[i].Name))
+			s.runSync(ctx, s.providers[i], data.SyncRunManual)
 		case <-ctx.Done():
 			s.logger.Info("Stopping event synchronization worker due to context cancellation")
 			return
@@ -70,92 +229,1210 @@ func (s *EventSyncer) Start(ctx context.Context) {
 	}
 }
 
-func (s *EventSyncer) runSync(ctx context.Context) {
-	log := s.logger.With(zap.Time("sync_time", time.Now().UTC()))
-	log.Info("Running event synchronization cycle")
+func (s *EventSyncer) schedule(ctx context.Context, index int, interval time.Duration, due chan<- int) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			select {
+			case due <- index:
+			case <-ctx.Done():
+				return
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Trigger queues a full sync cycle of the provider, or of every provider when the name
+// is empty. A provider whose cycle is already queued or running is not queued again.
+func (s *EventSyncer) Trigger(providerName string) ([]data.SyncTriggerResult, error) {
+	indexes := make([]int, 0, len(s.providers))
+	for i, provider := range s.providers {
+		if providerName == "" || provider.Name == providerName {
+			indexes = append(indexes, i)
+		}
+	}
+	if len(indexes) == 0 {
+		return nil, ErrUnknownProvider
+	}
+
+	s.stateMu.Lock()
+	defer s.stateMu.Unlock()
+	if s.leaderCtx == nil {
+		return nil, ErrNotRunning
+	}
+
+	results := make([]data.SyncTriggerResult, 0, len(indexes))
+	for _, i := range indexes {
+		name := s.providers[i].Name
+		if s.queued[name] || s.running[name] {
+			results = append(results, data.SyncTriggerResult{Provider: name, Coalesced: true})
+			continue
+		}
+		s.queued[name] = true
+		s.manual <- i
+		results = append(results, data.SyncTriggerResult{Provider: name})
+	}
+	return results, nil
+}
+
+// setActive records the context the worker runs in, nil once it stopped. Triggers queued
+// when it stops are dropped, so a later start, possibly after regaining leadership, does
+// not run stale requests.
+func (s *EventSyncer) setActive(leaderCtx context.Context) {
+	s.stateMu.Lock()
+	defer s.stateMu.Unlock()
+	s.leaderCtx = leaderCtx
+	if leaderCtx != nil {
+		return
+	}
+	for {
+		select {
+		case <-s.manual:
+		default:
+			s.queued = make(map[string]bool)
+			return
+		}
+	}
+}
+
+// leaderContext returns ctx carrying the leader's fencing token and canceled when the
+// worker stops, so events delivered to the service are processed under the same lease as
+// synced ones. It returns ErrNotRunning on replicas that do not run the worker.
+func (s *EventSyncer) leaderContext(ctx context.Context) (context.Context, context.CancelFunc, error) {
+	s.stateMu.Lock()
+	leaderCtx := s.leaderCtx
+	s.stateMu.Unlock()
+	if leaderCtx == nil {
+		return nil, nil, ErrNotRunning
+	}
+
+	if token, ok := fence.FromContext(leaderCtx); ok {
+		ctx = fence.WithToken(ctx, token)
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	stop := context.AfterFunc(leaderCtx, cancel)
+	return ctx, func() {
+		stop()
+		cancel()
+	}, nil
+}
+
+func (s *EventSyncer) setRunning(provider string, running bool, kind data.SyncRunKind) {
+	s.stateMu.Lock()
+	defer s.stateMu.Unlock()
+	s.running[provider] = running
+	if kind == data.SyncRunManual {
+		s.queued[provider] = false
+	}
+}
+
+// runSync fetches and processes the provider's events. Manual cycles fetch the full feed,
+// ignoring the stored validators and cursor.
+func (s *EventSyncer) runSync(ctx context.Context, provider Provider, kind data.SyncRunKind) {
+	s.setRunning(provider.Name, true, kind)
+	defer s.setRunning(provider.Name, false, kind)
 
-	externalEvents, err := s.sourceClient.FetchActiveEvents(ctx)
+	log := s.logger.With(zap.String("provider", provider.Name), zap.Time("sync_time", time.Now().UTC()))
+	log.Info("Running event synchronization cycle", zap.String("kind", string(kind)))
+	run := s.startRun(ctx, log, provider.Name, kind)
+
+	var state *data.SourceSyncState
+	if kind != data.SyncRunManual {
+		var err error
+		state, err = s.providerRepo.FindSyncState(ctx, provider.Name)
+		if err != nil {
+			// A full fetch is always correct, it is only more expensive.
+			log.Warn("Failed to load sync state, fetching all events", zap.Error(err))
+			state = nil
+		}
+	}
+	if state == nil {
+		state = &data.SourceSyncState{Provider: provider.Name}
+	}
+
+	batch, err := provider.Client.FetchActiveEvents(ctx, *state)
 	if err != nil {
 		log.Error("Failed to fetch events from source API", zap.Error(err))
+		run.Error = err.Error()
+		s.finishRun(ctx, log, run)
+		s.recordFetchFailure(ctx, log, provider.Name)
 		return
 	}
-	log.Info("Fetched events from source API", zap.Int("count", len(externalEvents)))
+	s.recordFetchSuccess(log, provider.Name)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	externalEvents := batch.Events
+	run.FeedChecksum = batch.Checksum
+	run.NotModified = batch.NotModified
+	run.Incremental = batch.Incremental
+	run.Received = len(externalEvents)
+	if batch.NotModified {
+		log.Info("Source API reported no changes since last fetch")
+	} else {
+		log.Info("Fetched events from source API", zap.Int("count", len(externalEvents)), zap.Bool("incremental", batch.Incremental))
+	}
+
+	deadlineHit := s.processEvents(ctx, log, provider, externalEvents, run)
+
+	// Keep the previous state after errors, so the failed events are fetched again.
+	if !batch.NotModified && run.Failed == 0 {
+		batch.State.Provider = provider.Name
+		batch.State.UpdatedAt = time.Now().UTC()
+		if err := s.providerRepo.SaveSyncState(ctx, &batch.State); err != nil {
+			log.Error("Failed to save sync state", zap.Error(err))
+		}
+	}
+
+	// Only a full feed tells which events the provider stopped reporting, and only once
+	// all of them were processed.
+	if !batch.NotModified && !batch.Incremental {
+		if !deadlineHit {
+			s.recordMissedCycle(ctx, log, provider.Name, run.StartedAt)
+		}
+	} else {
+		// Events left out of the fetch are unchanged, so their pending results were seen again.
+		if err := s.providerRepo.RecordConfirmationCycle(ctx, provider.Name, run.ID, time.Now().UTC()); err != nil {
+			log.Error("Failed to count sync cycle towards pending result confirmations", zap.Error(err))
+		}
+	}
+	if deadlineHit {
+		log.Warn("Sync cycle deadline exceeded, remaining events and settlements are left to the next cycle",
+			zap.Duration("cycleTimeout", s.policy.CycleTimeout))
+	} else {
+		s.settleConfirmedResults(ctx, log, run)
+	}
+	run.MissingSuspended = s.suspendMissingEvents(ctx, log)
+	run.MissingVoided, run.MissingVoidErrors = s.voidMissingEvents(ctx, log)
+
+	run.PostponedVoided, run.PostponedVoidErrors = s.voidExpiredPostponements(ctx, log)
+	s.finishRun(ctx, log, run)
+	s.pruneRuns(ctx, log)
+}
+
+// Ingest processes events pushed by a provider the same way as fetched ones.
+func (s *EventSyncer) Ingest(ctx context.Context, providerName string, batch data.EventBatch) (data.IngestResult, error) {
+	run, err := s.ProcessBatch(ctx, providerName, data.SyncRunPush, batch)
+	if err != nil {
+		return data.IngestResult{}, err
+	}
+
+	return data.IngestResult{
+		RunID:     run.ID,
+		Received:  run.Received,
+		Upserted:  run.Upserted,
+		Unchanged: run.Unchanged,
+		Failed:    run.Failed,
+	}, nil
+}
+
+// ProcessBatch processes events delivered to the service, rather than fetched by it, the
+// same way as fetched ones and records them as a run of the given kind.
+func (s *EventSyncer) ProcessBatch(ctx context.Context, providerName string, kind data.SyncRunKind, batch data.EventBatch) (*data.SyncRun, error) {
+	var provider *Provider
+	for i := range s.providers {
+		if s.providers[i].Name == providerName {
+			provider = &s.providers[i]
+			break
+		}
+	}
+	if provider == nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownProvider, providerName)
+	}
+	ctx, cancel, err := s.leaderContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	log := s.logger.With(zap.String("provider", provider.Name), zap.String("operation", "ProcessBatch"), zap.String("kind", string(kind)))
+	log.Info("Processing delivered events", zap.Int("count", len(batch.Events)))
+	run := s.startRun(ctx, log, provider.Name, kind)
+	run.FeedChecksum = batch.Checksum
+	run.Received = len(batch.Events)
+
+	s.processEvents(ctx, log, *provider, batch.Events, run)
+	s.finishRun(ctx, log, run)
+	return run, nil
+}
 
-	successCount := 0
-	errorCount := 0
-	finalizeAttempts := 0
-	finalizeErrors := 0
-	cancelAttempts := 0
-	cancelErrors := 0
+// RefreshEvent fetches the event again from every provider that reported it and
+// processes it like a synced event. It returns one run per provider.
+func (s *EventSyncer) RefreshEvent(ctx context.Context, eventID string) ([]data.SyncRun, error) {
+	ctx, cancel, err := s.leaderContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
 
-	for _, extEvent := range externalEvents {
-		eventLog := log.With(zap.String("externalId", extEvent.APIEventID))
+	snapshots, err := s.providerRepo.FindSnapshotsByEvent(ctx, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load provider mappings of event %s: %w", eventID, err)
+	}
 
-		internalEvent, mapErr := data.MapExternalToInternalEvent(extEvent)
-		if mapErr != nil {
-			eventLog.Error("Failed to map external event to internal structure", zap.Error(mapErr))
-			errorCount++
+	log := s.logger.With(zap.String("eventId", eventID), zap.String("operation", "RefreshEvent"))
+	runs := make([]data.SyncRun, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		var provider *Provider
+		for i := range s.providers {
+			if s.providers[i].Name == snapshot.Provider {
+				provider = &s.providers[i]
+				break
+			}
+		}
+		if provider == nil {
+			// Mapping of a provider that is no longer configured.
 			continue
 		}
+		runs = append(runs, *s.refreshFromProvider(ctx, log.With(zap.String("provider", provider.Name)), *provider, snapshot.ProviderEventID))
+	}
+	if len(runs) == 0 {
+		return nil, ErrEventNotMapped
+	}
+	return runs, nil
+}
+
+func (s *EventSyncer) refreshFromProvider(ctx context.Context, log *zap.Logger, provider Provider, providerEventID string) *data.SyncRun {
+	run := s.startRun(ctx, log, provider.Name, data.SyncRunRefresh)
+
+	extEvent, err := provider.Client.FetchEvent(ctx, providerEventID)
+	if err != nil {
+		log.Error("Failed to fetch event from source API", zap.String("externalId", providerEventID), zap.Error(err))
+		run.Error = err.Error()
+		s.finishRun(ctx, log, run)
+		return run
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	run.Received = 1
+	s.processEvent(ctx, log.With(zap.String("externalId", extEvent.APIEventID)), provider, *extEvent, run)
+	s.finishRun(ctx, log, run)
+	return run
+}
+
+// Replay processes a quarantined event like a synced one. An event that still cannot be
+// mapped is rejected with its mapping error and not quarantined again.
+func (s *EventSyncer) Replay(ctx context.Context, providerName string, extEvent data.ExternalEventDTO) (*data.SyncRun, error) {
+	var provider *Provider
+	for i := range s.providers {
+		if s.providers[i].Name == providerName {
+			provider = &s.providers[i]
+			break
+		}
+	}
+	if provider == nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownProvider, providerName)
+	}
+	if _, err := provider.Mapper.Map(extEvent); err != nil {
+		return nil, err
+	}
+	ctx, cancel, err := s.leaderContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	log := s.logger.With(zap.String("provider", provider.Name), zap.String("operation", "Replay"), zap.String("externalId", extEvent.APIEventID))
+	run := s.startRun(ctx, log, provider.Name, data.SyncRunReplay)
+	run.Received = 1
+	s.processEvent(ctx, log, *provider, extEvent, run)
+	s.finishRun(ctx, log, run)
+	return run, nil
+}
+
+// startRun records the start of a run. Failing to record it does not stop the sync.
+func (s *EventSyncer) startRun(ctx context.Context, log *zap.Logger, provider string, kind data.SyncRunKind) *data.SyncRun {
+	run := &data.SyncRun{
+		ID:        uuid.NewString(),
+		Provider:  provider,
+		Kind:      kind,
+		Status:    data.SyncRunRunning,
+		StartedAt: time.Now().UTC(),
+	}
+	if err := s.runRepo.Create(ctx, run); err != nil {
+		log.Error("Failed to record start of sync run", zap.Error(err))
+	}
+	return run
+}
+
+func (s *EventSyncer) finishRun(ctx context.Context, log *zap.Logger, run *data.SyncRun) {
+	run.Finish(time.Now().UTC())
+	if err := s.runRepo.Finish(ctx, run); err != nil {
+		log.Error("Failed to record sync run", zap.Error(err))
+	}
+
+	log.Info("Event synchronization cycle finished",
+		zap.String("runId", run.ID),
+		zap.String("status", string(run.Status)),
+		zap.Int("processed", run.Received),
+		zap.Int("successful_upserts", run.Upserted),
+		zap.Int("unchanged", run.Unchanged),
+		zap.Int("mapping/upsert_errors", run.Failed),
+		zap.Int("finalize_attempts", run.FinalizeAttempts),
+		zap.Int("finalize_errors", run.FinalizeErrors),
+		zap.Int("cancel_attempts", run.CancelAttempts),
+		zap.Int("cancel_errors", run.CancelErrors),
+		zap.Int("result_conflicts", run.ResultConflicts),
+		zap.Int("postponed_voided", run.PostponedVoided),
+		zap.Int("postponed_void_errors", run.PostponedVoidErrors),
+		zap.Int("missing_suspended", run.MissingSuspended),
+		zap.Int("missing_voided", run.MissingVoided),
+		zap.Int("missing_void_errors", run.MissingVoidErrors),
+	)
+}
+
+func (s *EventSyncer) pruneRuns(ctx context.Context, log *zap.Logger) {
+	if s.policy.RunRetention <= 0 {
+		return
+	}
+	deleted, err := s.runRepo.DeleteStartedBefore(ctx, time.Now().UTC().Add(-s.policy.RunRetention))
+	if err != nil {
+		log.Error("Failed to delete old sync runs", zap.Error(err))
+		return
+	}
+	if deleted > 0 {
+		log.Debug("Deleted old sync runs", zap.Int64("count", deleted))
+	}
+}
+
+// settlement is the settling an event update asks for: handling bets placed after a start
+// time that was corrected backwards, finalizing or resettling the event with a confirmed
+// result, or canceling its bets. Settling writes every bet of the event, so it runs after
+// the odds of every event of a batch were stored.
+type settlement struct {
+	log          *zap.Logger
+	externalID   string
+	eventID      string
+	lateSince    *time.Time
+	confirmation *data.ResultConfirmation
+	cancelBets   bool
+}
+
+// processEvents processes the events of a batch on the worker pool and then settles the
+// events that asked for it. It reports whether the cycle deadline cut the batch short;
+// events and settlements it did not reach are recorded as failed and retried later.
+func (s *EventSyncer) processEvents(ctx context.Context, log *zap.Logger, provider Provider, events []data.ExternalEventDTO, run *data.SyncRun) bool {
+	cycleCtx := ctx
+	if s.policy.CycleTimeout > 0 {
+		var cancel context.CancelFunc
+		cycleCtx, cancel = context.WithTimeout(ctx, s.policy.CycleTimeout)
+		defer cancel()
+	}
+
+	var mu stdsync.Mutex
+	cut := false
+	settlements := make([]settlement, 0)
+	pending := make(map[string]int)
+	workpool.Run(cycleCtx, s.policy.Workers, events,
+		func(extEvent data.ExternalEventDTO) string { return extEvent.APIEventID },
+		func(ctx context.Context, extEvent data.ExternalEventDTO) {
+			tally := &data.SyncRun{ID: run.ID}
+			st := s.updateEvent(ctx, log.With(zap.String("externalId", extEvent.APIEventID)), provider, extEvent, tally)
+
+			mu.Lock()
+			defer mu.Unlock()
+			run.Merge(tally)
+			if st == nil {
+				return
+			}
+			// A later update of the same event replaces the settlement it asked for, but
+			// not the late bets an earlier one found.
+			if i, ok := pending[st.eventID]; ok {
+				if prev := settlements[i].lateSince; prev != nil && (st.lateSince == nil || prev.Before(*st.lateSince)) {
+					st.lateSince = prev
+				}
+				settlements[i] = *st
+				return
+			}
+			pending[st.eventID] = len(settlements)
+			settlements = append(settlements, *st)
+		},
+		func(extEvent data.ExternalEventDTO) {
+			mu.Lock()
+			defer mu.Unlock()
+			cut = true
+			run.RecordError(extEvent.APIEventID, "", data.SyncStageDeadline, cycleCtx.Err())
+		},
+	)
+
+	workpool.Run(cycleCtx, s.policy.Workers, settlements,
+		func(st settlement) string { return st.eventID },
+		func(ctx context.Context, st settlement) {
+			tally := &data.SyncRun{ID: run.ID}
+			s.settle(ctx, st, tally)
+
+			mu.Lock()
+			defer mu.Unlock()
+			run.Merge(tally)
+		},
+		func(st settlement) {
+			// Pending confirmations are settled by a later cycle, canceled events are
+			// canceled again when the source reports them next time. The corrected start
+			// time is not reported as a change again, so late bets are handled regardless.
+			st.log.Warn("Settlement left to the next cycle, deadline exceeded")
+			if st.lateSince != nil {
+				s.handleLateBets(ctx, st.log, st.eventID, *st.lateSince)
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			cut = true
+			run.RecordError(st.externalID, st.eventID, data.SyncStageDeadline, cycleCtx.Err())
+		},
+	)
+
+	return cut
+}
+
+// processEvent updates a single event and settles it right away.
+func (s *EventSyncer) processEvent(ctx context.Context, eventLog *zap.Logger, provider Provider, extEvent data.ExternalEventDTO, run *data.SyncRun) {
+	if st := s.updateEvent(ctx, eventLog, provider, extEvent, run); st != nil {
+		s.settle(ctx, *st, run)
+	}
+}
+
+// updateEvent records the provider's view of an event, merges it with the other
+// providers' views and applies the merged event locally. It returns the settlement the
+// update asks for, if any.
+func (s *EventSyncer) updateEvent(ctx context.Context, eventLog *zap.Logger, provider Provider, extEvent data.ExternalEventDTO, run *data.SyncRun) (st *settlement) {
+	providerEvent, mapErr := provider.Mapper.Map(extEvent)
+	if mapErr != nil {
+		eventLog.Error("Failed to map external event to internal structure",
+			zap.String("errorCode", string(data.MappingErrorCodeOf(mapErr))),
+			zap.Error(mapErr),
+		)
+		run.RecordError(extEvent.APIEventID, "", data.SyncStageMap, mapErr)
+		entry := data.NewQuarantinedEvent(provider.Name, extEvent, mapErr, time.Now().UTC())
+		if err := s.quarantine.Save(ctx, &entry); err != nil {
+			eventLog.Error("Failed to quarantine unmappable event", zap.Error(err))
+		}
+		// The provider still reports the event, so it must not count as missing.
+		if err := s.providerRepo.MarkSeen(ctx, provider.Name, extEvent.APIEventID, time.Now().UTC()); err != nil {
+			eventLog.Error("Failed to mark unmappable event as seen", zap.Error(err))
+		}
+		return nil
+	}
 
-		shouldFinalize := false
-		var finalizationResult data.Outcome
-		if internalEvent.EventResult != nil && !internalEvent.IsActive {
-			shouldFinalize = true
-			finalizationResult = *internalEvent.EventResult
+	s.resolveMu.Lock()
+	eventID, err := s.resolveEventID(ctx, eventLog, provider.Name, extEvent.APIEventID, providerEvent)
+	s.resolveMu.Unlock()
+	if err != nil {
+		eventLog.Error("Failed to map provider event ID to internal event", zap.Error(err))
+		run.RecordError(extEvent.APIEventID, "", data.SyncStageResolve, err)
+		return nil
+	}
+	eventLog = eventLog.With(zap.String("eventId", eventID))
+	providerEvent.ID = eventID
+
+	now := time.Now().UTC()
+	canceled := extEvent.Result != nil && *extEvent.Result == data.ResultCanceled
+	snapshot := data.NewProviderEventSnapshot(provider.Name, extEvent.APIEventID, providerEvent, canceled, now)
+	if err := s.providerRepo.SaveSnapshot(ctx, &snapshot); err != nil {
+		eventLog.Error("Failed to store provider snapshot of event", zap.Error(err))
+		run.RecordError(extEvent.APIEventID, eventID, data.SyncStageSnapshot, err)
+		return nil
+	}
+
+	snapshots, err := s.providerRepo.FindSnapshotsByEvent(ctx, eventID)
+	if err != nil {
+		eventLog.Error("Failed to load provider snapshots of event", zap.Error(err))
+		run.RecordError(extEvent.APIEventID, eventID, data.SyncStageSnapshot, err)
+		return nil
+	}
+	merged := s.policy.Merge.Merge(eventID, snapshots, now)
+	internalEvent := merged.Event
+	sourceResult := internalEvent.EventResult
+
+	existingEvent, findErr := s.eventRepo.FindByID(ctx, internalEvent.ID)
+	if findErr != nil {
+		eventLog.Error("Failed to load local copy of event", zap.Error(findErr))
+		run.RecordError(extEvent.APIEventID, eventID, data.SyncStageLoad, findErr)
+		return nil
+	}
+	if existingEvent != nil {
+		if lateSince := s.trackScheduleChange(ctx, eventLog, existingEvent, &internalEvent); lateSince != nil {
+			// Late bets are handled with the settlement, whatever else the update asks for.
+			defer func() {
+				if st == nil {
+					st = &settlement{log: eventLog, externalID: extEvent.APIEventID, eventID: eventID}
+				}
+				st.lateSince = lateSince
+			}()
 		}
+	}
+
+	settled := existingEvent != nil && (existingEvent.EventResult != nil || existingEvent.Status == data.EventStatusCanceled)
+	holdResult := false
+	if merged.ResultConflict && !settled {
+		holdResult = true
+	} else if merged.ResultConflict {
+		eventLog.Warn("Providers disagree on the result of an already settled event", zap.String("results", merged.ResultsSummary()))
+	} else if !settled {
+		conflict, err := s.providerRepo.FindResultConflict(ctx, internalEvent.ID)
+		if err != nil {
+			eventLog.Error("Failed to check result conflicts of event", zap.Error(err))
+			run.RecordError(extEvent.APIEventID, eventID, data.SyncStageLoad, err)
+			return nil
+		}
+		// Once providers disagreed, the result waits for manual confirmation even if they agree later.
+		holdResult = conflict != nil && conflict.ResolvedAt == nil
+	}
+
+	canceledBySource := merged.Canceled
+	if holdResult {
+		internalEvent.EventResult = nil
+		internalEvent.IsActive = true
+		canceledBySource = false
+	}
+	if existingEvent != nil && existingEvent.EventResult != nil {
+		// The local result is authoritative once the event was finalized.
+		internalEvent.EventResult = existingEvent.EventResult
+		internalEvent.IsActive = false
+	}
 
+	shouldFinalize := false
+	var finalizationResult data.Outcome
+	if internalEvent.EventResult != nil && !internalEvent.IsActive && (existingEvent == nil || existingEvent.EventResult == nil) {
+		shouldFinalize = true
+		finalizationResult = *internalEvent.EventResult
+		// Leave the result to FinalizeEvent, which refuses events that are already inactive or resulted.
+		internalEvent.EventResult = nil
+		internalEvent.IsActive = true
+	}
+
+	s.resolveMu.Lock()
+	err = s.entities.ResolveEventEntities(ctx, &internalEvent)
+	s.resolveMu.Unlock()
+	if err != nil {
+		// The event is still stored with its team names; linking is retried on the next cycle.
+		eventLog.Warn("Failed to resolve teams and competition of event", zap.Error(err))
+	}
+
+	internalEvent.ContentHash = internalEvent.ComputeContentHash()
+	if existingEvent != nil && existingEvent.ContentHash == internalEvent.ContentHash {
+		eventLog.Debug("Event unchanged since last sync, skipping upsert")
+		run.Unchanged++
+	} else {
 		upsertErr := s.eventRepo.Upsert(ctx, &internalEvent)
 		if upsertErr != nil {
 			eventLog.Error("Failed to upsert event into local database", zap.Error(upsertErr))
-			errorCount++
-			continue
+			run.RecordError(extEvent.APIEventID, eventID, data.SyncStageUpsert, upsertErr)
+			return nil
 		}
-		successCount++
+		run.Upserted++
+	}
 
-		if shouldFinalize {
-			eventLog.Info("Event detected as finalized by source API, attempting to trigger finalization", zap.String("result", string(finalizationResult)))
-			finalizeAttempts++
+	if existingEvent != nil && existingEvent.Status == data.EventStatusSuspended {
+		resumed, err := s.eventRepo.ResumeSuspended(ctx, internalEvent.ID)
+		if err != nil {
+			eventLog.Error("Failed to resume event that reappeared in the source feed", zap.Error(err))
+		} else if resumed {
+			eventLog.Info("Suspended event reappeared in the source feed, betting resumed")
+		}
+	}
 
-			finalizeErr := s.eventUseCase.FinalizeEvent(ctx, internalEvent.ID, finalizationResult)
+	if !shouldFinalize && !settled {
+		// The source withdrew the result or providers disagree on it, so there is nothing left to confirm.
+		if err := s.providerRepo.DiscardResultConfirmation(ctx, internalEvent.ID); err != nil {
+			eventLog.Error("Failed to discard pending result confirmation", zap.Error(err))
+		}
+	}
 
-			if finalizeErr != nil {
-				// TODO: Check if eventuc.ErrEventAlreadyFinalized is exported and use errors.Is
-				if finalizeErr.Error() == "событие уже завершено" { // Fallback check by string
-					eventLog.Info("Finalization attempt skipped: event already finalized locally.")
-				} else {
-					eventLog.Error("Error occurred during finalization triggered by syncer", zap.Error(finalizeErr))
-					finalizeErrors++
-				}
-			} else {
-				eventLog.Info("Finalization triggered by syncer completed successfully.")
+	if existingEvent != nil && existingEvent.EventResult != nil && !merged.ResultConflict {
+		return s.trackResultCorrection(ctx, eventLog, extEvent.APIEventID, internalEvent.ID, *existingEvent.EventResult, sourceResult, run, now)
+	}
+
+	if holdResult {
+		if merged.ResultConflict {
+			conflict := &data.ResultConflict{EventID: internalEvent.ID, Results: merged.ResultsSummary(), DetectedAt: now}
+			if err := s.providerRepo.OpenResultConflict(ctx, conflict); err != nil {
+				eventLog.Error("Failed to record result conflict", zap.Error(err))
+				run.RecordError(extEvent.APIEventID, eventID, data.SyncStageConflict, err)
 			}
-		} else if !internalEvent.IsActive && internalEvent.EventResult == nil {
-			eventLog.Info("Event detected as inactive without specific result (Canceled or ended)", zap.Stringp("apiResult", extEvent.Result))
-			if extEvent.Result != nil && *extEvent.Result == "Canceled" {
-				eventLog.Info("Event result is 'Canceled', attempting to cancel related bets.")
-				cancelAttempts++
-				cancelErr := s.betUseCase.CancelBetsForEvent(ctx, internalEvent.ID)
-				if cancelErr != nil && !errors.Is(cancelErr, sql.ErrNoRows) { // Ignore no rows found error
-					// TODO: Check if betuc.ErrBetCancellationFailed is exported and use errors.Is
-					eventLog.Error("Error occurred during bet cancellation for canceled event", zap.Error(cancelErr))
-					cancelErrors++
-				} else if cancelErr == nil {
-					eventLog.Info("Bets cancellation process initiated successfully for canceled event.")
-				} else { // It was sql.ErrNoRows
-					eventLog.Info("No pending bets found to cancel for canceled event.")
-				}
+			run.ResultConflicts++
+		}
+		eventLog.Warn("Providers disagree on the result, finalization waits for manual confirmation", zap.String("results", merged.ResultsSummary()))
+		return nil
+	}
+
+	if shouldFinalize {
+		eventLog.Info("Event detected as finalized by source API", zap.String("result", string(finalizationResult)))
+		confirmation, err := s.providerRepo.RecordResultSighting(ctx, internalEvent.ID, finalizationResult, run.ID, now)
+		if err != nil {
+			eventLog.Error("Failed to record result sighting", zap.Error(err))
+			run.RecordError(extEvent.APIEventID, eventID, data.SyncStageFinalize, err)
+			return nil
+		}
+		if confirmation == nil || confirmation.ConfirmedAt != nil {
+			eventLog.Info("Finalization attempt skipped: result already confirmed.")
+			return nil
+		}
+		if !s.policy.ResultConfirmation.Confirmed(*confirmation, now) {
+			eventLog.Info("Result awaits confirmation before finalization",
+				zap.Int("sightings", confirmation.Sightings),
+				zap.Time("firstSeenAt", confirmation.FirstSeenAt),
+			)
+			return nil
+		}
+		return &settlement{log: eventLog, externalID: extEvent.APIEventID, eventID: eventID, confirmation: confirmation}
+	} else if !internalEvent.IsActive && internalEvent.EventResult == nil {
+		eventLog.Info("Event detected as inactive without specific result (Canceled or ended)", zap.Bool("canceled", canceledBySource))
+		if canceledBySource {
+			return &settlement{log: eventLog, externalID: extEvent.APIEventID, eventID: eventID, cancelBets: true}
+		}
+	}
+	return nil
+}
+
+// settle runs the settlement an event update asked for. Late bets are handled first, so
+// they are not settled with the result.
+func (s *EventSyncer) settle(ctx context.Context, st settlement, run *data.SyncRun) {
+	if st.lateSince != nil {
+		s.handleLateBets(ctx, st.log, st.eventID, *st.lateSince)
+	}
+	if st.confirmation != nil {
+		s.finalizeConfirmed(ctx, st.log, st.externalID, *st.confirmation, run)
+		return
+	}
+	if !st.cancelBets {
+		return
+	}
+
+	st.log.Info("Event result is 'Canceled', attempting to cancel related bets.")
+	run.CancelAttempts++
+	cancelErr := s.betUseCase.CancelBetsForEvent(ctx, st.eventID)
+	if cancelErr != nil && !errors.Is(cancelErr, sql.ErrNoRows) { // Ignore no rows found error
+		// TODO: Check if betuc.ErrBetCancellationFailed is exported and use errors.Is
+		st.log.Error("Error occurred during bet cancellation for canceled event", zap.Error(cancelErr))
+		run.RecordError(st.externalID, st.eventID, data.SyncStageCancel, cancelErr)
+	} else if cancelErr == nil {
+		st.log.Info("Bets cancellation process initiated successfully for canceled event.")
+	} else { // It was sql.ErrNoRows
+		st.log.Info("No pending bets found to cancel for canceled event.")
+	}
+}
+
+// trackResultCorrection records a result of a finalized event that differs from the local
+// one and asks for resettlement once the new result met the confirmation policy.
+func (s *EventSyncer) trackResultCorrection(ctx context.Context, log *zap.Logger, externalID string, eventID string, localResult data.Outcome, sourceResult *data.Outcome, run *data.SyncRun, now time.Time) *settlement {
+	if !s.policy.ResettleOnCorrection || sourceResult == nil || *sourceResult == localResult {
+		// The source agrees with the local result (again), so a pending correction is dropped.
+		if err := s.providerRepo.DiscardResultConfirmation(ctx, eventID); err != nil {
+			log.Error("Failed to discard pending result correction", zap.Error(err))
+		}
+		return nil
+	}
+
+	log.Warn("Source reports a different result for a finalized event",
+		zap.String("localResult", string(localResult)),
+		zap.String("sourceResult", string(*sourceResult)),
+	)
+	confirmation, err := s.providerRepo.RecordResultSighting(ctx, eventID, *sourceResult, run.ID, now)
+	if err != nil {
+		log.Error("Failed to record result sighting", zap.Error(err))
+		run.RecordError(externalID, eventID, data.SyncStageResettle, err)
+		return nil
+	}
+	if confirmation == nil || confirmation.ConfirmedAt != nil {
+		return nil
+	}
+	if !s.policy.ResultConfirmation.Confirmed(*confirmation, now) {
+		log.Info("Result correction awaits confirmation before resettlement",
+			zap.Int("sightings", confirmation.Sightings),
+			zap.Time("firstSeenAt", confirmation.FirstSeenAt),
+		)
+		return nil
+	}
+	return &settlement{log: log, externalID: externalID, eventID: eventID, confirmation: confirmation}
+}
+
+// finalizeConfirmed settles an event whose result met the confirmation policy. An event
+// that is already finalized is resettled if the result differs.
+func (s *EventSyncer) finalizeConfirmed(ctx context.Context, log *zap.Logger, externalID string, confirmation data.ResultConfirmation, run *data.SyncRun) {
+	log.Info("Event result confirmed, attempting to trigger finalization",
+		zap.String("result", string(confirmation.Result)),
+		zap.Int("sightings", confirmation.Sightings),
+	)
+	run.FinalizeAttempts++
+
+	finalizeErr := s.eventUseCase.FinalizeEvent(ctx, confirmation.EventID, confirmation.Result)
+	if finalizeErr != nil {
+		if errors.Is(finalizeErr, eventuc.ErrEventAlreadyFinalized) && s.policy.ResettleOnCorrection {
+			s.resettleConfirmed(ctx, log, externalID, confirmation, run)
+		} else if errors.Is(finalizeErr, eventuc.ErrEventAlreadyFinalized) {
+			log.Info("Finalization attempt skipped: event already finalized locally.")
+			if err := s.providerRepo.DiscardResultConfirmation(ctx, confirmation.EventID); err != nil {
+				log.Error("Failed to discard result confirmation of finalized event", zap.Error(err))
 			}
+		} else {
+			log.Error("Error occurred during finalization triggered by syncer", zap.Error(finalizeErr))
+			run.RecordError(externalID, confirmation.EventID, data.SyncStageFinalize, finalizeErr)
 		}
+		return
 	}
+	log.Info("Finalization triggered by syncer completed successfully.")
 
-	log.Info("Event synchronization cycle finished",
-		zap.Int("processed", len(externalEvents)),
-		zap.Int("successful_upserts", successCount),
-		zap.Int("mapping/upsert_errors", errorCount),
-		zap.Int("finalize_attempts", finalizeAttempts),
-		zap.Int("finalize_errors", finalizeErrors),
-		zap.Int("cancel_attempts", cancelAttempts),
-		zap.Int("cancel_errors", cancelErrors),
+	if err := s.providerRepo.ConfirmResult(ctx, confirmation.EventID, data.ConfirmedBySync, time.Now().UTC()); err != nil {
+		log.Error("Failed to record result confirmation", zap.Error(err))
+	}
+}
+
+func (s *EventSyncer) resettleConfirmed(ctx context.Context, log *zap.Logger, externalID string, confirmation data.ResultConfirmation, run *data.SyncRun) {
+	resettlement, err := s.resettler.Resettle(ctx, confirmation.EventID, confirmation.Result, data.ResettledBySync, CorrectionReason)
+	if err != nil {
+		if errors.Is(err, resettlementuc.ErrSameResult) || errors.Is(err, resettlementuc.ErrEventNotSettled) {
+			log.Info("Resettlement skipped: event already has this result or was not settled with a result.")
+			if err := s.providerRepo.DiscardResultConfirmation(ctx, confirmation.EventID); err != nil {
+				log.Error("Failed to discard result confirmation of finalized event", zap.Error(err))
+			}
+			return
+		}
+		log.Error("Error occurred during resettlement triggered by syncer", zap.Error(err))
+		run.RecordError(externalID, confirmation.EventID, data.SyncStageResettle, err)
+		return
+	}
+
+	if resettlement.Status == data.ResettlementCompletedWithErrors {
+		run.RecordError(externalID, confirmation.EventID, data.SyncStageResettle,
+			fmt.Errorf("resettlement %s completed with %d errors", resettlement.ID, resettlement.Errors))
+	}
+	log.Info("Resettlement triggered by syncer completed.", zap.String("resettlementId", resettlement.ID), zap.String("status", string(resettlement.Status)))
+
+	if err := s.providerRepo.ConfirmResult(ctx, confirmation.EventID, data.ConfirmedBySync, time.Now().UTC()); err != nil {
+		log.Error("Failed to record result confirmation", zap.Error(err))
+	}
+}
+
+// settleConfirmedResults finalizes pending results that met the confirmation policy since
+// they were last reported, e.g. because they stayed stable long enough.
+func (s *EventSyncer) settleConfirmedResults(ctx context.Context, log *zap.Logger, run *data.SyncRun) {
+	confirmations, err := s.providerRepo.FindPendingResultConfirmations(ctx)
+	if err != nil {
+		log.Error("Failed to load pending result confirmations", zap.Error(err))
+		return
+	}
+
+	now := time.Now().UTC()
+	for _, confirmation := range confirmations {
+		if s.policy.ResultConfirmation.Confirmed(confirmation, now) {
+			s.finalizeConfirmed(ctx, log.With(zap.String("eventId", confirmation.EventID)), "", confirmation, run)
+		}
+	}
+}
+
+// resolveEventID returns the internal event a provider event belongs to: its existing
+// mapping, an event another provider reported for the same fixture, or a new ID.
+func (s *EventSyncer) resolveEventID(ctx context.Context, log *zap.Logger, provider string, providerEventID string, event data.Event) (string, error) {
+	snapshot, err := s.providerRepo.FindSnapshot(ctx, provider, providerEventID)
+	if err != nil {
+		return "", err
+	}
+	if snapshot != nil {
+		return snapshot.EventID, nil
+	}
+
+	matchedID, err := s.matchFixture(ctx, provider, event)
+	if err != nil {
+		return "", err
+	}
+	if matchedID != "" {
+		log.Info("Provider event matched to an event reported by another provider", zap.String("eventId", matchedID))
+		return matchedID, nil
+	}
+
+	// Keep the provider's ID when it is a free UUID, as the single source's IDs always were.
+	if _, err := uuid.Parse(providerEventID); err == nil {
+		existing, err := s.eventRepo.FindByID(ctx, providerEventID)
+		if err != nil {
+			return "", err
+		}
+		if existing == nil {
+			return providerEventID, nil
+		}
+	}
+	return uuid.NewSHA1(uuid.NameSpaceOID, []byte(provider+":"+providerEventID)).String(), nil
+}
+
+// matchFixture looks for an event of the same sport and teams starting within the match
+// window that the provider has not reported yet.
+func (s *EventSyncer) matchFixture(ctx context.Context, provider string, event data.Event) (string, error) {
+	if s.policy.MatchWindow <= 0 || len(s.providers) < 2 {
+		return "", nil
+	}
+
+	candidates, err := s.eventRepo.FindStartingBetween(ctx, event.Type,
+		event.EventStartDate.Add(-s.policy.MatchWindow), event.EventStartDate.Add(s.policy.MatchWindow))
+	if err != nil {
+		return "", err
+	}
+
+	home, away := data.NormalizeName(event.HomeTeam), data.NormalizeName(event.AwayTeam)
+	for _, candidate := range candidates {
+		if data.NormalizeName(candidate.HomeTeam) != home || data.NormalizeName(candidate.AwayTeam) != away {
+			continue
+		}
+		snapshots, err := s.providerRepo.FindSnapshotsByEvent(ctx, candidate.ID)
+		if err != nil {
+			return "", err
+		}
+		alreadyMapped := false
+		for _, snapshot := range snapshots {
+			if snapshot.Provider == provider {
+				alreadyMapped = true
+				break
+			}
+		}
+		if !alreadyMapped {
+			return candidate.ID, nil
+		}
+	}
+	return "", nil
+}
+
+// trackScheduleChange records any start/end date change reported by the source and
+// marks the event Postponed when its start moves later by more than the configured threshold.
+// When the start was corrected backwards into the past, it returns the corrected start,
+// after which bets were placed too late.
+func (s *EventSyncer) trackScheduleChange(ctx context.Context, log *zap.Logger, existing *data.Event, incoming *data.Event) *time.Time {
+	if existing.EventStartDate.Equal(incoming.EventStartDate) && existing.EventEndDate.Equal(incoming.EventEndDate) {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	change := &data.EventScheduleChange{
+		EventID:      existing.ID,
+		OldStartDate: existing.EventStartDate,
+		NewStartDate: incoming.EventStartDate,
+		OldEndDate:   existing.EventEndDate,
+		NewEndDate:   incoming.EventEndDate,
+		DetectedAt:   now,
+	}
+	if err := s.eventRepo.RecordScheduleChange(ctx, change); err != nil {
+		log.Error("Failed to record schedule change", zap.Error(err))
+	}
+
+	shift := incoming.EventStartDate.Sub(existing.EventStartDate)
+	log.Info("Event schedule changed by source",
+		zap.Time("oldStart", existing.EventStartDate),
+		zap.Time("newStart", incoming.EventStartDate),
+		zap.Duration("shift", shift),
 	)
+
+	var lateSince *time.Time
+	if incoming.EventStartDate.Before(existing.EventStartDate) && !incoming.EventStartDate.After(now) {
+		correctedStart := incoming.EventStartDate
+		lateSince = &correctedStart
+	}
+
+	if existing.EventResult != nil || existing.Status == data.EventStatusPostponed || existing.Status == data.EventStatusCanceled {
+		return lateSince
+	}
+	if s.policy.RescheduleThreshold <= 0 || shift <= s.policy.RescheduleThreshold {
+		s.reopenMovedMarket(ctx, log, existing, incoming, now)
+		return lateSince
+	}
+
+	if err := s.eventRepo.MarkPostponed(ctx, existing.ID, now); err != nil {
+		log.Error("Failed to mark rescheduled event as postponed", zap.Error(err))
+		return lateSince
+	}
+	log.Warn("Event rescheduled beyond threshold, marked as postponed", zap.Duration("threshold", s.policy.RescheduleThreshold))
+	return lateSince
+}
+
+// reopenMovedMarket reopens betting on a closed event whose start moved later, within the
+// reschedule threshold, so that its cutoff lies in the future again. The market closer
+// closes it again at the new cutoff.
+func (s *EventSyncer) reopenMovedMarket(ctx context.Context, log *zap.Logger, existing *data.Event, incoming *data.Event, now time.Time) {
+	if existing.Status != data.EventStatusClosed || !incoming.EventStartDate.After(existing.EventStartDate) {
+		return
+	}
+	closesAt := s.policy.Cutoff.ClosesAt(*incoming)
+	if !closesAt.After(now) {
+		return
+	}
+
+	reopened, err := s.eventRepo.ReopenBetting(ctx, existing.ID)
+	if err != nil {
+		log.Error("Failed to reopen betting on rescheduled event", zap.Error(err))
+		return
+	}
+	if reopened {
+		log.Info("Start moved later, betting reopened", zap.Time("closesAt", closesAt))
+	}
+}
+
+// handleLateBets flags bets placed after a start time that was corrected backwards and
+// either queues them for review or voids them, depending on the configured policy.
+func (s *EventSyncer) handleLateBets(ctx context.Context, log *zap.Logger, eventID string, correctedStart time.Time) {
+	lateBets, err := s.betUseCase.FlagLateBets(ctx, eventID, correctedStart)
+	if err != nil {
+		log.Error("Failed to flag bets placed after corrected start time", zap.Error(err))
+		return
+	}
+	if len(lateBets) == 0 {
+		return
+	}
+
+	log.Warn("Start time corrected backwards, found bets placed after the real start",
+		zap.Int("lateBets", len(lateBets)),
+		zap.String("policy", s.policy.LateBetPolicy),
+	)
+
+	if s.policy.LateBetPolicy == LateBetPolicyVoid {
+		if err := s.eventUseCase.VoidBets(ctx, eventID, lateBets, betuc.LateBetReason); err != nil {
+			log.Error("Failed to void late bets", zap.Error(err))
+		}
+		return
+	}
+
+	if err := s.reviewQueue.Enqueue(ctx, lateBets, betuc.LateBetReason); err != nil {
+		log.Error("Failed to queue late bets for review", zap.Error(err))
+	}
+}
+
+// voidExpiredPostponements voids and refunds bets of postponed events that have no result
+// the configured period after both the postponement and their new start date.
+func (s *EventSyncer) voidExpiredPostponements(ctx context.Context, log *zap.Logger) (int, int) {
+	if s.policy.PostponedVoidAfter <= 0 {
+		return 0, 0
+	}
+
+	cutoff := time.Now().UTC().Add(-s.policy.PostponedVoidAfter)
+	events, err := s.eventRepo.FindPostponedBefore(ctx, cutoff)
+	if err != nil {
+		log.Error("Failed to query expired postponed events", zap.Error(err))
+		return 0, 1
+	}
+
+	voided := 0
+	voidErrors := 0
+	for _, event := range events {
+		eventLog := log.With(zap.String("eventId", event.ID), zap.Timep("postponedAt", event.PostponedAt))
+		eventLog.Warn("Postponed event was not played in time, voiding its bets")
+		if err := s.eventUseCase.VoidEvent(ctx, event.ID, "postponed"); err != nil {
+			eventLog.Error("Error voiding expired postponed event", zap.Error(err))
+			voidErrors++
+			continue
+		}
+		voided++
+	}
+	return voided, voidErrors
+}
+
+func (s *EventSyncer) recordMissedCycle(ctx context.Context, log *zap.Logger, provider string, fetchStartedAt time.Time) {
+	missed, err := s.providerRepo.RecordMissedCycle(ctx, provider, fetchStartedAt)
+	if err != nil {
+		log.Error("Failed to record events missing from the feed", zap.Error(err))
+		return
+	}
+	if missed > 0 {
+		log.Warn("Unsettled events missing from the source feed", zap.Int64("count", missed))
+	}
+}
+
+// suspendMissingEvents stops betting on events that every provider stopped reporting
+// for the configured number of full fetches.
+func (s *EventSyncer) suspendMissingEvents(ctx context.Context, log *zap.Logger) int {
+	if s.policy.MissingSuspendAfter <= 0 {
+		return 0
+	}
+
+	events, err := s.providerRepo.FindMissingEvents(ctx, s.policy.MissingSuspendAfter)
+	if err != nil {
+		log.Error("Failed to query events missing from the source feed", zap.Error(err))
+		return 0
+	}
+
+	suspended := 0
+	now := time.Now().UTC()
+	for _, event := range events {
+		if event.Status != data.EventStatusScheduled && event.Status != data.EventStatusClosed {
+			continue
+		}
+		eventLog := log.With(zap.String("eventId", event.EventID), zap.Time("lastSeenAt", event.LastSeenAt), zap.Int("missedCycles", event.MissedCycles))
+		if err := s.eventRepo.MarkSuspended(ctx, event.EventID, now); err != nil {
+			eventLog.Error("Failed to suspend event missing from the source feed", zap.Error(err))
+			continue
+		}
+		// Admins find suspended events under /admin/sync/missing-events.
+		eventLog.Error("Event vanished from the source feed, betting suspended", zap.String("eventName", event.EventName))
+		suspended++
+	}
+	return suspended
+}
+
+// voidMissingEvents voids and refunds bets of events that stayed suspended longer than
+// the configured period.
+func (s *EventSyncer) voidMissingEvents(ctx context.Context, log *zap.Logger) (int, int) {
+	if s.policy.MissingVoidAfter <= 0 {
+		return 0, 0
+	}
+
+	cutoff := time.Now().UTC().Add(-s.policy.MissingVoidAfter)
+	events, err := s.eventRepo.FindSuspendedBefore(ctx, cutoff)
+	if err != nil {
+		log.Error("Failed to query long suspended events", zap.Error(err))
+		return 0, 1
+	}
+
+	voided := 0
+	voidErrors := 0
+	for _, event := range events {
+		eventLog := log.With(zap.String("eventId", event.ID), zap.Timep("suspendedAt", event.SuspendedAt))
+		if s.onlyStaleProviders(ctx, eventLog, event.ID) {
+			// The event was suspended because its source is down, not because it vanished.
+			continue
+		}
+		eventLog.Warn("Event stayed missing from the source feed, voiding its bets")
+		if err := s.eventUseCase.VoidEvent(ctx, event.ID, MissingVoidReason); err != nil {
+			eventLog.Error("Error voiding event missing from the source feed", zap.Error(err))
+			voidErrors++
+			continue
+		}
+		voided++
+	}
+	return voided, voidErrors
+}
+
+// SourceHealth reports the circuit breaker and staleness of every provider.
+func (s *EventSyncer) SourceHealth() []data.SourceHealth {
+	now := time.Now().UTC()
+
+	s.stateMu.Lock()
+	defer s.stateMu.Unlock()
+
+	health := make([]data.SourceHealth, 0, len(s.providers))
+	for _, provider := range s.providers {
+		h := data.SourceHealth{
+			Provider: provider.Name,
+			Breaker:  string(breaker.StateClosed),
+			Stale:    s.stale[provider.Name],
+		}
+		if lastSuccess, ok := s.lastSuccess[provider.Name]; ok {
+			h.LastSuccessAt = &lastSuccess
+		}
+		if provider.Breaker != nil {
+			snapshot := provider.Breaker.Snapshot(now)
+			h.Breaker = string(snapshot.State)
+			h.ConsecutiveFailures = snapshot.ConsecutiveFailures
+			h.OpenedAt = snapshot.OpenedAt
+		}
+		health = append(health, h)
+	}
+	return health
+}
+
+func (s *EventSyncer) recordFetchSuccess(log *zap.Logger, provider string) {
+	s.stateMu.Lock()
+	defer s.stateMu.Unlock()
+
+	s.lastSuccess[provider] = time.Now().UTC()
+	if s.stale[provider] {
+		// The sync state was reset when the provider went stale, so this fetch is a full
+		// one and resumes the events it still reports.
+		log.Info("Event source is reachable again, resuming its events")
+		s.stale[provider] = false
+	}
+}
+
+// recordFetchFailure suspends betting on the provider's events once its fetches have
+// failed for longer than the stale threshold, so no bets are taken on stale odds.
+func (s *EventSyncer) recordFetchFailure(ctx context.Context, log *zap.Logger, provider string) {
+	if s.policy.StaleSuspendAfter <= 0 {
+		return
+	}
+
+	now := time.Now().UTC()
+	s.stateMu.Lock()
+	lastSuccess, ok := s.lastSuccess[provider]
+	if !ok {
+		lastSuccess = s.startedAt
+	}
+	if s.stale[provider] || now.Sub(lastSuccess) < s.policy.StaleSuspendAfter {
+		s.stateMu.Unlock()
+		return
+	}
+	s.stale[provider] = true
+	staleProviders := s.staleProvidersLocked()
+	s.stateMu.Unlock()
+
+	log = log.With(zap.Time("lastSuccessAt", lastSuccess))
+	log.Error("Event source failed for too long, suspending betting on its events")
+
+	// Forget the validators and cursor, so the first fetch after recovery returns the
+	// full feed and resumes every event the provider still reports.
+	if err := s.providerRepo.SaveSyncState(ctx, &data.SourceSyncState{Provider: provider, UpdatedAt: now}); err != nil {
+		log.Error("Failed to reset sync state of stale provider", zap.Error(err))
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	eventIDs, err := s.providerRepo.FindEventsReportedOnlyBy(ctx, staleProviders)
+	if err != nil {
+		log.Error("Failed to query events of stale providers", zap.Error(err))
+		return
+	}
+	suspended := 0
+	for _, eventID := range eventIDs {
+		if err := s.eventRepo.MarkSuspended(ctx, eventID, now); err != nil {
+			log.Error("Failed to suspend event of stale provider", zap.String("eventId", eventID), zap.Error(err))
+			continue
+		}
+		suspended++
+	}
+	log.Warn("Suspended events of stale providers", zap.Strings("staleProviders", staleProviders), zap.Int("count", suspended))
+}
+
+func (s *EventSyncer) staleProvidersLocked() []string {
+	providers := make([]string, 0, len(s.stale))
+	for _, provider := range s.providers {
+		if s.stale[provider.Name] {
+			providers = append(providers, provider.Name)
+		}
+	}
+	return providers
+}
+
+// onlyStaleProviders reports whether every provider of the event is stale.
+func (s *EventSyncer) onlyStaleProviders(ctx context.Context, log *zap.Logger, eventID string) bool {
+	s.stateMu.Lock()
+	anyStale := len(s.staleProvidersLocked()) > 0
+	s.stateMu.Unlock()
+	if !anyStale {
+		return false
+	}
+
+	snapshots, err := s.providerRepo.FindSnapshotsByEvent(ctx, eventID)
+	if err != nil {
+		// Voiding cannot be undone, so an unknown source state keeps the bets pending.
+		log.Error("Failed to load provider mappings of suspended event", zap.Error(err))
+		return true
+	}
+	if len(snapshots) == 0 {
+		return false
+	}
+
+	s.stateMu.Lock()
+	defer s.stateMu.Unlock()
+	for _, snapshot := range snapshots {
+		if !s.stale[snapshot.Provider] {
+			return false
+		}
+	}
+	return true
 }
@@ -0,0 +1,625 @@
+package sync_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Arlan-Z/def-betting-api/internal/data"
+	repomocks "github.com/Arlan-Z/def-betting-api/internal/repositories/mocks"
+	syncsvc "github.com/Arlan-Z/def-betting-api/internal/services/sync"
+	betuc "github.com/Arlan-Z/def-betting-api/internal/usecases/bet"
+	eventuc "github.com/Arlan-Z/def-betting-api/internal/usecases/event"
+	resettlementuc "github.com/Arlan-Z/def-betting-api/internal/usecases/resettlement"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const providerName = "primary"
+
+type mockSource struct {
+	mock.Mock
+}
+
+func (m *mockSource) FetchActiveEvents(ctx context.Context, state data.SourceSyncState) (*data.EventBatch, error) {
+	args := m.Called(ctx, state)
+	batch, _ := args.Get(0).(*data.EventBatch)
+	return batch, args.Error(1)
+}
+
+func (m *mockSource) FetchEvent(ctx context.Context, sourceEventID string) (*data.ExternalEventDTO, error) {
+	args := m.Called(ctx, sourceEventID)
+	event, _ := args.Get(0).(*data.ExternalEventDTO)
+	return event, args.Error(1)
+}
+
+type mockFinalizer struct {
+	mock.Mock
+}
+
+func (m *mockFinalizer) FinalizeEvent(ctx context.Context, eventID string, actualResult data.Outcome) error {
+	return m.Called(ctx, eventID, actualResult).Error(0)
+}
+
+func (m *mockFinalizer) VoidEvent(ctx context.Context, eventID string, reason string) error {
+	return m.Called(ctx, eventID, reason).Error(0)
+}
+
+func (m *mockFinalizer) VoidBets(ctx context.Context, eventID string, bets []data.Bet, reason string) error {
+	return m.Called(ctx, eventID, bets, reason).Error(0)
+}
+
+type mockBetCanceller struct {
+	mock.Mock
+}
+
+func (m *mockBetCanceller) CancelBetsForEvent(ctx context.Context, eventID string) error {
+	return m.Called(ctx, eventID).Error(0)
+}
+
+func (m *mockBetCanceller) FlagLateBets(ctx context.Context, eventID string, startDate time.Time) ([]data.Bet, error) {
+	args := m.Called(ctx, eventID, startDate)
+	bets, _ := args.Get(0).([]data.Bet)
+	return bets, args.Error(1)
+}
+
+type mockReviewQueue struct {
+	mock.Mock
+}
+
+func (m *mockReviewQueue) Enqueue(ctx context.Context, bets []data.Bet, reason string) error {
+	return m.Called(ctx, bets, reason).Error(0)
+}
+
+type mockResettler struct {
+	mock.Mock
+}
+
+func (m *mockResettler) Resettle(ctx context.Context, eventID string, result data.Outcome, triggeredBy string, reason string) (*data.Resettlement, error) {
+	args := m.Called(ctx, eventID, result, triggeredBy, reason)
+	resettlement, _ := args.Get(0).(*data.Resettlement)
+	return resettlement, args.Error(1)
+}
+
+type noopEntityResolver struct{}
+
+func (noopEntityResolver) ResolveEventEntities(ctx context.Context, event *data.Event) error {
+	return nil
+}
+
+// syncerFixture runs an EventSyncer with a single provider against mocked repositories
+// and use cases. steps records snapshots, upserts and finalizations in the order they
+// happened.
+type syncerFixture struct {
+	syncer       *syncsvc.EventSyncer
+	source       *mockSource
+	eventRepo    *repomocks.EventRepository
+	providerRepo *repomocks.ProviderRepository
+	runRepo      *repomocks.SyncRunRepository
+	finalizer    *mockFinalizer
+	bets         *mockBetCanceller
+	reviews      *mockReviewQueue
+	resettler    *mockResettler
+	finished     chan *data.SyncRun
+
+	// pause, if set, is called before a step is recorded, to slow down some of them.
+	pause func(step string)
+
+	mu        sync.Mutex
+	steps     []string
+	snapshots map[string][]data.ProviderEventSnapshot
+}
+
+func newSyncerFixture(t *testing.T, policy syncsvc.Policy) *syncerFixture {
+	f := &syncerFixture{
+		source:       &mockSource{},
+		eventRepo:    repomocks.NewEventRepository(t),
+		providerRepo: repomocks.NewProviderRepository(t),
+		runRepo:      repomocks.NewSyncRunRepository(t),
+		finalizer:    &mockFinalizer{},
+		bets:         &mockBetCanceller{},
+		reviews:      &mockReviewQueue{},
+		resettler:    &mockResettler{},
+		finished:     make(chan *data.SyncRun, 10),
+		snapshots:    make(map[string][]data.ProviderEventSnapshot),
+	}
+	for _, m := range []*mock.Mock{&f.source.Mock, &f.finalizer.Mock, &f.bets.Mock, &f.reviews.Mock, &f.resettler.Mock} {
+		m.Test(t)
+	}
+	t.Cleanup(func() {
+		f.source.AssertExpectations(t)
+		f.finalizer.AssertExpectations(t)
+		f.bets.AssertExpectations(t)
+		f.reviews.AssertExpectations(t)
+		f.resettler.AssertExpectations(t)
+	})
+
+	f.runRepo.On("Create", mock.Anything, mock.Anything).Return(nil).Maybe()
+	f.runRepo.On("Finish", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		run := *args.Get(1).(*data.SyncRun)
+		f.finished <- &run
+	}).Return(nil).Maybe()
+
+	if policy.LateBetPolicy == "" {
+		policy.LateBetPolicy = syncsvc.LateBetPolicyReview
+	}
+	providers := []syncsvc.Provider{{
+		Name:     providerName,
+		Client:   f.source,
+		Mapper:   data.NewEventMapper(time.UTC, nil),
+		Interval: time.Hour,
+	}}
+	f.syncer = syncsvc.NewEventSyncer(providers, f.eventRepo, f.providerRepo, f.runRepo, repomocks.NewQuarantineRepository(t),
+		f.finalizer, f.bets, f.reviews, noopEntityResolver{}, f.resettler, policy, zap.NewNop())
+	return f
+}
+
+func (f *syncerFixture) record(step string) {
+	if f.pause != nil {
+		f.pause(step)
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.steps = append(f.steps, step)
+}
+
+func (f *syncerFixture) recorded() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.steps...)
+}
+
+// stubEvent maps the provider event to eventID and serves existing as its local copy.
+// Snapshots saved for the event are returned when the syncer merges it.
+func (f *syncerFixture) stubEvent(externalID string, eventID string, existing *data.Event) {
+	f.providerRepo.On("FindSnapshot", mock.Anything, providerName, externalID).
+		Return(&data.ProviderEventSnapshot{Provider: providerName, ProviderEventID: externalID, EventID: eventID}, nil).Maybe()
+	f.providerRepo.On("SaveSnapshot", mock.Anything, mock.MatchedBy(func(s *data.ProviderEventSnapshot) bool {
+		return s.ProviderEventID == externalID
+	})).Run(func(args mock.Arguments) {
+		snapshot := *args.Get(1).(*data.ProviderEventSnapshot)
+		f.record(fmt.Sprintf("snapshot:%s:%.1f", externalID, snapshot.HomeWinChance))
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.snapshots[eventID] = []data.ProviderEventSnapshot{snapshot}
+	}).Return(nil).Maybe()
+	f.providerRepo.On("FindSnapshotsByEvent", mock.Anything, eventID).
+		Return(func(ctx context.Context, eventID string) []data.ProviderEventSnapshot {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			return f.snapshots[eventID]
+		}, nil).Maybe()
+	f.eventRepo.On("FindByID", mock.Anything, eventID).Return(existing, nil).Maybe()
+}
+
+// stubCycle answers the calls every cycle makes. Expectations set by a test before take
+// precedence.
+func (f *syncerFixture) stubCycle() {
+	f.providerRepo.On("FindSyncState", mock.Anything, providerName).Return(nil, nil).Maybe()
+	f.providerRepo.On("SaveSyncState", mock.Anything, mock.Anything).Return(nil).Maybe()
+	f.providerRepo.On("RecordMissedCycle", mock.Anything, providerName, mock.Anything).Return(int64(0), nil).Maybe()
+	f.providerRepo.On("RecordConfirmationCycle", mock.Anything, providerName, mock.Anything, mock.Anything).Return(nil).Maybe()
+	f.providerRepo.On("FindPendingResultConfirmations", mock.Anything).Return(nil, nil).Maybe()
+	f.providerRepo.On("FindResultConflict", mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+	f.providerRepo.On("DiscardResultConfirmation", mock.Anything, mock.Anything).Return(nil).Maybe()
+	f.eventRepo.On("RecordScheduleChange", mock.Anything, mock.Anything).Return(nil).Maybe()
+	f.eventRepo.On("Upsert", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		f.record("upsert:" + args.Get(1).(*data.Event).ID)
+	}).Return(nil).Maybe()
+}
+
+// start runs the syncer until the test ends. It syncs the provider once right away.
+func (f *syncerFixture) start(t *testing.T) {
+	f.stubCycle()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		f.syncer.Start(ctx)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+}
+
+func (f *syncerFixture) waitRun(t *testing.T) *data.SyncRun {
+	select {
+	case run := <-f.finished:
+		return run
+	case <-time.After(2 * time.Second):
+		t.Fatal("sync run did not finish")
+		return nil
+	}
+}
+
+// runCycle syncs the batch and returns the finished run.
+func (f *syncerFixture) runCycle(t *testing.T, batch *data.EventBatch) *data.SyncRun {
+	f.source.On("FetchActiveEvents", mock.Anything, mock.Anything).Return(batch, nil).Once()
+	f.start(t)
+	return f.waitRun(t)
+}
+
+func strp(s string) *string {
+	return &s
+}
+
+func floatp(f float64) *float64 {
+	return &f
+}
+
+// feedEvent is a provider event starting at start and lasting two hours.
+func feedEvent(externalID string, start time.Time, homeWinChance float64) data.ExternalEventDTO {
+	return data.ExternalEventDTO{
+		APIEventID:      externalID,
+		Name:            "Home vs Away",
+		TeamHome:        "Home",
+		TeamAway:        "Away",
+		CoefficientHome: floatp(homeWinChance),
+		CoefficientAway: floatp(3.0),
+		CoefficientDraw: floatp(3.5),
+		StartsAt:        start.Format(time.RFC3339),
+		EndsAt:          start.Add(2 * time.Hour).Format(time.RFC3339),
+		SportType:       "football",
+	}
+}
+
+// localEvent is the stored copy of an event scheduled like feedEvent.
+func localEvent(eventID string, start time.Time, status data.EventStatus) *data.Event {
+	return &data.Event{
+		ID:             eventID,
+		EventName:      "Home vs Away",
+		HomeTeam:       "Home",
+		AwayTeam:       "Away",
+		EventStartDate: start,
+		EventEndDate:   start.Add(2 * time.Hour),
+		Type:           "football",
+		IsActive:       true,
+		Status:         status,
+	}
+}
+
+func startsIn(d time.Duration) time.Time {
+	return time.Now().UTC().Truncate(time.Second).Add(d)
+}
+
+func sameTime(expected time.Time) interface{} {
+	return mock.MatchedBy(func(actual time.Time) bool { return actual.Equal(expected) })
+}
+
+func TestPolicy_Validate(t *testing.T) {
+	assert.NoError(t, syncsvc.Policy{LateBetPolicy: syncsvc.LateBetPolicyReview}.Validate())
+	assert.NoError(t, syncsvc.Policy{LateBetPolicy: syncsvc.LateBetPolicyVoid}.Validate())
+	assert.Error(t, syncsvc.Policy{LateBetPolicy: "viod"}.Validate())
+	assert.Error(t, syncsvc.Policy{}.Validate())
+}
+
+func TestEventSyncer_UpdatesOfAnEventAreProcessedInFeedOrder(t *testing.T) {
+	f := newSyncerFixture(t, syncsvc.Policy{Workers: 4})
+	start := startsIn(24 * time.Hour)
+
+	odds := []float64{1.5, 1.6, 1.7}
+	batch := &data.EventBatch{}
+	for _, chance := range odds {
+		for i := 1; i <= 4; i++ {
+			batch.Events = append(batch.Events, feedEvent(fmt.Sprintf("ext-%d", i), start, chance))
+		}
+	}
+	for i := 1; i <= 4; i++ {
+		f.stubEvent(fmt.Sprintf("ext-%d", i), fmt.Sprintf("event-%d", i), nil)
+	}
+	// The first update of each event is the slowest, so a later one would overtake it
+	// if updates of the same event ran in parallel.
+	f.pause = func(step string) {
+		if strings.HasSuffix(step, ":1.5") {
+			time.Sleep(20 * time.Millisecond)
+		}
+	}
+
+	run := f.runCycle(t, batch)
+
+	assert.Equal(t, 12, run.Upserted)
+	assert.Equal(t, 0, run.Failed)
+	seen := map[string][]string{}
+	for _, step := range f.recorded() {
+		if parts := strings.Split(step, ":"); parts[0] == "snapshot" {
+			seen[parts[1]] = append(seen[parts[1]], parts[2])
+		}
+	}
+	for i := 1; i <= 4; i++ {
+		assert.Equal(t, []string{"1.5", "1.6", "1.7"}, seen[fmt.Sprintf("ext-%d", i)])
+	}
+}
+
+func TestEventSyncer_SettlesOnceEveryEventOfTheBatchIsUpdated(t *testing.T) {
+	f := newSyncerFixture(t, syncsvc.Policy{Workers: 4})
+	start := startsIn(-3 * time.Hour)
+	later := startsIn(24 * time.Hour)
+
+	finished := feedEvent("ext-a", start, 2.0)
+	finished.Result = strp("HomeWin")
+	batch := &data.EventBatch{Events: []data.ExternalEventDTO{
+		finished,
+		feedEvent("ext-b", later, 2.0),
+		feedEvent("ext-c", later, 2.0),
+		feedEvent("ext-d", later, 2.0),
+	}}
+	f.stubEvent("ext-a", "event-a", localEvent("event-a", start, data.EventStatusClosed))
+	for _, id := range []string{"b", "c", "d"} {
+		f.stubEvent("ext-"+id, "event-"+id, nil)
+	}
+	f.pause = func(step string) {
+		if step == "upsert:event-b" || step == "upsert:event-c" || step == "upsert:event-d" {
+			time.Sleep(20 * time.Millisecond)
+		}
+	}
+
+	f.providerRepo.On("RecordResultSighting", mock.Anything, "event-a", data.HomeWin, mock.Anything, mock.Anything).
+		Return(&data.ResultConfirmation{EventID: "event-a", Result: data.HomeWin, Sightings: 1}, nil).Once()
+	f.finalizer.On("FinalizeEvent", mock.Anything, "event-a", data.HomeWin).Run(func(mock.Arguments) {
+		f.record("finalize:event-a")
+	}).Return(nil).Once()
+	f.providerRepo.On("ConfirmResult", mock.Anything, "event-a", data.ConfirmedBySync, mock.Anything).Return(nil).Once()
+
+	run := f.runCycle(t, batch)
+
+	assert.Equal(t, 1, run.FinalizeAttempts)
+	assert.Equal(t, 0, run.FinalizeErrors)
+	steps := f.recorded()
+	require.Equal(t, "finalize:event-a", steps[len(steps)-1], "settling waits for the odds of every event: %v", steps)
+	assert.Contains(t, steps, "upsert:event-a")
+	assert.Contains(t, steps, "upsert:event-d")
+}
+
+func TestEventSyncer_CycleTimeoutLeavesTheRestToTheNextCycle(t *testing.T) {
+	f := newSyncerFixture(t, syncsvc.Policy{Workers: 1, CycleTimeout: 50 * time.Millisecond})
+	start := startsIn(-3 * time.Hour)
+	scheduled := startsIn(time.Hour)
+	corrected := startsIn(-30 * time.Minute)
+
+	finished := feedEvent("ext-a", start, 2.0)
+	finished.Result = strp("HomeWin")
+	batch := &data.EventBatch{Events: []data.ExternalEventDTO{
+		finished,
+		feedEvent("ext-b", corrected, 2.0),
+		feedEvent("ext-c", scheduled, 2.0),
+	}}
+	f.stubEvent("ext-a", "event-a", localEvent("event-a", start, data.EventStatusClosed))
+	f.stubEvent("ext-b", "event-b", localEvent("event-b", scheduled, data.EventStatusScheduled))
+	f.stubEvent("ext-c", "event-c", nil)
+	f.pause = func(step string) {
+		if step == "upsert:event-b" {
+			time.Sleep(100 * time.Millisecond)
+		}
+	}
+
+	f.providerRepo.On("RecordResultSighting", mock.Anything, "event-a", data.HomeWin, mock.Anything, mock.Anything).
+		Return(&data.ResultConfirmation{EventID: "event-a", Result: data.HomeWin, Sightings: 1}, nil).Once()
+	// The start of event-b was corrected into the past: its late bets are handled even
+	// though its settlement is cut off.
+	f.bets.On("FlagLateBets", mock.Anything, "event-b", sameTime(corrected)).Return(nil, nil).Once()
+
+	run := f.runCycle(t, batch)
+
+	assert.Equal(t, 3, run.Failed)
+	stages := map[string]string{}
+	for _, e := range run.Errors {
+		stages[e.ExternalID] = e.Stage
+	}
+	assert.Equal(t, map[string]string{"ext-a": data.SyncStageDeadline, "ext-b": data.SyncStageDeadline, "ext-c": data.SyncStageDeadline}, stages)
+	assert.NotContains(t, f.recorded(), "snapshot:ext-c:2.0")
+	f.finalizer.AssertNotCalled(t, "FinalizeEvent", mock.Anything, mock.Anything, mock.Anything)
+	f.providerRepo.AssertNotCalled(t, "SaveSyncState", mock.Anything, mock.Anything)
+	f.providerRepo.AssertNotCalled(t, "RecordMissedCycle", mock.Anything, mock.Anything, mock.Anything)
+	f.providerRepo.AssertNotCalled(t, "FindPendingResultConfirmations", mock.Anything)
+}
+
+func TestEventSyncer_PostponesEventsRescheduledBeyondThreshold(t *testing.T) {
+	f := newSyncerFixture(t, syncsvc.Policy{Workers: 1, RescheduleThreshold: time.Hour, PostponedVoidAfter: 24 * time.Hour})
+	scheduled := startsIn(2 * time.Hour)
+	moved := startsIn(5 * time.Hour)
+
+	f.stubEvent("ext-a", "event-a", localEvent("event-a", scheduled, data.EventStatusScheduled))
+	f.eventRepo.On("RecordScheduleChange", mock.Anything, mock.MatchedBy(func(c *data.EventScheduleChange) bool {
+		return c.EventID == "event-a" && c.OldStartDate.Equal(scheduled) && c.NewStartDate.Equal(moved)
+	})).Return(nil).Once()
+	f.eventRepo.On("MarkPostponed", mock.Anything, "event-a", mock.AnythingOfType("time.Time")).Return(nil).Once()
+	f.eventRepo.On("FindPostponedBefore", mock.Anything, mock.AnythingOfType("time.Time")).
+		Return([]data.Event{{ID: "postponed-1"}, {ID: "postponed-2"}}, nil).Once()
+	f.finalizer.On("VoidEvent", mock.Anything, "postponed-1", "postponed").Return(nil).Once()
+	f.finalizer.On("VoidEvent", mock.Anything, "postponed-2", "postponed").Return(errors.New("database is locked")).Once()
+
+	run := f.runCycle(t, &data.EventBatch{Events: []data.ExternalEventDTO{feedEvent("ext-a", moved, 2.0)}})
+
+	assert.Equal(t, 1, run.PostponedVoided)
+	assert.Equal(t, 1, run.PostponedVoidErrors)
+	assert.Equal(t, data.SyncRunCompletedWithErrors, run.Status)
+}
+
+func TestEventSyncer_HandlesBetsPlacedAfterACorrectedStart(t *testing.T) {
+	lateBets := []data.Bet{{ID: "bet-1", EventID: "event-a"}, {ID: "bet-2", EventID: "event-a"}}
+
+	for _, policy := range []string{syncsvc.LateBetPolicyReview, syncsvc.LateBetPolicyVoid} {
+		t.Run(policy, func(t *testing.T) {
+			f := newSyncerFixture(t, syncsvc.Policy{Workers: 1, LateBetPolicy: policy})
+			scheduled := startsIn(time.Hour)
+			corrected := startsIn(-30 * time.Minute)
+
+			f.stubEvent("ext-a", "event-a", localEvent("event-a", scheduled, data.EventStatusScheduled))
+			f.bets.On("FlagLateBets", mock.Anything, "event-a", sameTime(corrected)).Return(lateBets, nil).Once()
+			if policy == syncsvc.LateBetPolicyVoid {
+				f.finalizer.On("VoidBets", mock.Anything, "event-a", lateBets, betuc.LateBetReason).Return(nil).Once()
+			} else {
+				f.reviews.On("Enqueue", mock.Anything, lateBets, betuc.LateBetReason).Return(nil).Once()
+			}
+
+			run := f.runCycle(t, &data.EventBatch{Events: []data.ExternalEventDTO{feedEvent("ext-a", corrected, 2.0)}})
+
+			assert.Equal(t, 0, run.Failed)
+			f.eventRepo.AssertNotCalled(t, "MarkPostponed", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestEventSyncer_SuspendsAndVoidsMissingEvents(t *testing.T) {
+	f := newSyncerFixture(t, syncsvc.Policy{Workers: 1, MissingSuspendAfter: 3, MissingVoidAfter: 48 * time.Hour})
+
+	f.providerRepo.On("RecordMissedCycle", mock.Anything, providerName, mock.AnythingOfType("time.Time")).Return(int64(3), nil).Once()
+	f.providerRepo.On("FindMissingEvents", mock.Anything, 3).Return([]data.MissingEvent{
+		{EventID: "scheduled", Status: data.EventStatusScheduled, MissedCycles: 3},
+		{EventID: "closed", Status: data.EventStatusClosed, MissedCycles: 4},
+		{EventID: "postponed", Status: data.EventStatusPostponed, MissedCycles: 5},
+	}, nil).Once()
+	f.eventRepo.On("MarkSuspended", mock.Anything, "scheduled", mock.AnythingOfType("time.Time")).Return(nil).Once()
+	f.eventRepo.On("MarkSuspended", mock.Anything, "closed", mock.AnythingOfType("time.Time")).Return(nil).Once()
+	f.eventRepo.On("FindSuspendedBefore", mock.Anything, mock.AnythingOfType("time.Time")).
+		Return([]data.Event{{ID: "suspended-1"}, {ID: "suspended-2"}}, nil).Once()
+	f.finalizer.On("VoidEvent", mock.Anything, "suspended-1", syncsvc.MissingVoidReason).Return(nil).Once()
+	f.finalizer.On("VoidEvent", mock.Anything, "suspended-2", syncsvc.MissingVoidReason).Return(errors.New("database is locked")).Once()
+
+	run := f.runCycle(t, &data.EventBatch{})
+
+	assert.Equal(t, 2, run.MissingSuspended)
+	assert.Equal(t, 1, run.MissingVoided)
+	assert.Equal(t, 1, run.MissingVoidErrors)
+	f.eventRepo.AssertNotCalled(t, "MarkSuspended", mock.Anything, "postponed", mock.Anything)
+}
+
+func TestEventSyncer_IncrementalFetchDoesNotCountMissedCycles(t *testing.T) {
+	f := newSyncerFixture(t, syncsvc.Policy{Workers: 1})
+
+	f.providerRepo.On("RecordConfirmationCycle", mock.Anything, providerName, mock.Anything, mock.AnythingOfType("time.Time")).Return(nil).Once()
+
+	f.runCycle(t, &data.EventBatch{Incremental: true})
+
+	f.providerRepo.AssertNotCalled(t, "RecordMissedCycle", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestEventSyncer_SettlesConfirmedResults(t *testing.T) {
+	confirmation := data.ResultConfirmation{EventID: "event-a", Result: data.AwayWin, Sightings: 2, FirstSeenAt: time.Now().UTC()}
+	completed := &data.Resettlement{ID: "resettlement-1", Status: data.ResettlementCompleted}
+	withErrors := &data.Resettlement{ID: "resettlement-1", Status: data.ResettlementCompletedWithErrors, Errors: 1}
+
+	tests := []struct {
+		name           string
+		policy         syncsvc.Policy
+		finalizeErr    error
+		resettlement   *data.Resettlement
+		resettleErr    error
+		confirmed      bool
+		discarded      bool
+		finalizeErrors int
+	}{
+		{name: "awaiting confirmation", policy: syncsvc.Policy{ResultConfirmation: data.ResultConfirmationPolicy{Cycles: 3}}},
+		{name: "finalized", confirmed: true},
+		{name: "finalize fails", finalizeErr: errors.New("database is locked"), finalizeErrors: 1},
+		{name: "already finalized", finalizeErr: eventuc.ErrEventAlreadyFinalized, discarded: true},
+		{name: "resettled", policy: syncsvc.Policy{ResettleOnCorrection: true}, finalizeErr: eventuc.ErrEventAlreadyFinalized, resettlement: completed, confirmed: true},
+		{name: "resettled with errors", policy: syncsvc.Policy{ResettleOnCorrection: true}, finalizeErr: eventuc.ErrEventAlreadyFinalized, resettlement: withErrors, confirmed: true, finalizeErrors: 1},
+		{name: "same result", policy: syncsvc.Policy{ResettleOnCorrection: true}, finalizeErr: eventuc.ErrEventAlreadyFinalized, resettleErr: resettlementuc.ErrSameResult, discarded: true},
+		{name: "resettlement fails", policy: syncsvc.Policy{ResettleOnCorrection: true}, finalizeErr: eventuc.ErrEventAlreadyFinalized, resettleErr: errors.New("database is locked"), finalizeErrors: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.policy.Workers = 1
+			f := newSyncerFixture(t, tt.policy)
+
+			f.providerRepo.On("FindPendingResultConfirmations", mock.Anything).Return([]data.ResultConfirmation{confirmation}, nil).Once()
+			if tt.name != "awaiting confirmation" {
+				f.finalizer.On("FinalizeEvent", mock.Anything, "event-a", data.AwayWin).Return(tt.finalizeErr).Once()
+			}
+			if tt.resettlement != nil || tt.resettleErr != nil {
+				f.resettler.On("Resettle", mock.Anything, "event-a", data.AwayWin, data.ResettledBySync, syncsvc.CorrectionReason).
+					Return(tt.resettlement, tt.resettleErr).Once()
+			}
+			if tt.confirmed {
+				f.providerRepo.On("ConfirmResult", mock.Anything, "event-a", data.ConfirmedBySync, mock.AnythingOfType("time.Time")).Return(nil).Once()
+			}
+
+			run := f.runCycle(t, &data.EventBatch{})
+
+			assert.Equal(t, tt.finalizeErrors, run.FinalizeErrors)
+			if tt.discarded {
+				f.providerRepo.AssertCalled(t, "DiscardResultConfirmation", mock.Anything, "event-a")
+			} else {
+				f.providerRepo.AssertNotCalled(t, "DiscardResultConfirmation", mock.Anything, "event-a")
+			}
+			if !tt.confirmed {
+				f.providerRepo.AssertNotCalled(t, "ConfirmResult", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestEventSyncer_ResettlesCorrectedResult(t *testing.T) {
+	f := newSyncerFixture(t, syncsvc.Policy{Workers: 1, ResettleOnCorrection: true})
+	start := startsIn(-3 * time.Hour)
+
+	local := localEvent("event-a", start, data.EventStatusClosed)
+	homeWin := data.HomeWin
+	local.EventResult = &homeWin
+	local.IsActive = false
+	corrected := feedEvent("ext-a", start, 2.0)
+	corrected.Result = strp("AwayWin")
+
+	f.stubEvent("ext-a", "event-a", local)
+	f.providerRepo.On("RecordResultSighting", mock.Anything, "event-a", data.AwayWin, mock.Anything, mock.Anything).
+		Return(&data.ResultConfirmation{EventID: "event-a", Result: data.AwayWin, Sightings: 1}, nil).Once()
+	f.finalizer.On("FinalizeEvent", mock.Anything, "event-a", data.AwayWin).Return(eventuc.ErrEventAlreadyFinalized).Once()
+	f.resettler.On("Resettle", mock.Anything, "event-a", data.AwayWin, data.ResettledBySync, syncsvc.CorrectionReason).
+		Return(&data.Resettlement{ID: "resettlement-1", Status: data.ResettlementCompleted}, nil).Once()
+	f.providerRepo.On("ConfirmResult", mock.Anything, "event-a", data.ConfirmedBySync, mock.AnythingOfType("time.Time")).Return(nil).Once()
+
+	run := f.runCycle(t, &data.EventBatch{Events: []data.ExternalEventDTO{corrected}})
+
+	assert.Equal(t, 1, run.FinalizeAttempts)
+	assert.Equal(t, 0, run.FinalizeErrors)
+}
+
+func TestEventSyncer_SuspendsEventsOfAStaleProvider(t *testing.T) {
+	f := newSyncerFixture(t, syncsvc.Policy{Workers: 1, StaleSuspendAfter: time.Millisecond})
+	time.Sleep(5 * time.Millisecond)
+
+	suspended := make(chan string, 2)
+	f.source.On("FetchActiveEvents", mock.Anything, mock.Anything).Return(nil, errors.New("connection refused")).Once()
+	f.providerRepo.On("SaveSyncState", mock.Anything, mock.MatchedBy(func(state *data.SourceSyncState) bool {
+		return *state == data.SourceSyncState{Provider: providerName, UpdatedAt: state.UpdatedAt}
+	})).Return(nil).Once()
+	f.providerRepo.On("FindEventsReportedOnlyBy", mock.Anything, []string{providerName}).Return([]string{"event-a", "event-b"}, nil).Once()
+	for _, eventID := range []string{"event-a", "event-b"} {
+		f.eventRepo.On("MarkSuspended", mock.Anything, eventID, mock.AnythingOfType("time.Time")).Run(func(args mock.Arguments) {
+			suspended <- args.String(1)
+		}).Return(nil).Once()
+	}
+	f.source.On("FetchActiveEvents", mock.Anything, mock.Anything).Return(&data.EventBatch{}, nil).Once()
+
+	f.start(t)
+	run := f.waitRun(t)
+	assert.Equal(t, data.SyncRunFailed, run.Status)
+	for i := 0; i < 2; i++ {
+		select {
+		case <-suspended:
+		case <-time.After(2 * time.Second):
+			t.Fatal("events of the stale provider were not suspended")
+		}
+	}
+	health := f.syncer.SourceHealth()
+	require.Len(t, health, 1)
+	assert.True(t, health[0].Stale)
+	assert.Nil(t, health[0].LastSuccessAt)
+
+	_, err := f.syncer.Trigger(providerName)
+	require.NoError(t, err)
+	f.waitRun(t)
+
+	health = f.syncer.SourceHealth()
+	assert.False(t, health[0].Stale)
+	assert.NotNil(t, health[0].LastSuccessAt)
+}
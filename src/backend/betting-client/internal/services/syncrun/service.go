@@ -0,0 +1,72 @@
+package syncrun
+
+import (
+	"context"
+
+	"github.com/Arlan-Z/def-betting-api/internal/data"
+	"go.uber.org/zap"
+)
+
+type SyncRunUseCase interface {
+	GetRuns(ctx context.Context, provider string, limit int) ([]data.SyncRun, error)
+	GetRun(ctx context.Context, runID string) (*data.SyncRun, error)
+	GetMissingEvents(ctx context.Context) ([]data.MissingEvent, error)
+}
+
+type Service interface {
+	GetRuns(ctx context.Context, provider string, limit int) ([]data.SyncRun, error)
+	GetRun(ctx context.Context, runID string) (*data.SyncRun, error)
+	GetMissingEvents(ctx context.Context) ([]data.MissingEvent, error)
+}
+
+type service struct {
+	syncRunUseCase SyncRunUseCase
+	logger         *zap.Logger
+}
+
+func NewService(uc SyncRunUseCase, logger *zap.Logger) Service {
+	return &service{
+		syncRunUseCase: uc,
+		logger:         logger.Named("SyncRunService"),
+	}
+}
+
+func (s *service) GetRuns(ctx context.Context, provider string, limit int) ([]data.SyncRun, error) {
+	log := s.logger.With(zap.String("method", "GetRuns"), zap.String("provider", provider))
+	log.Debug("Calling use case to get sync runs")
+
+	runs, err := s.syncRunUseCase.GetRuns(ctx, provider, limit)
+	if err != nil {
+		log.Warn("Use case returned error getting sync runs", zap.Error(err))
+		return nil, err
+	}
+
+	log.Debug("Successfully retrieved sync runs from use case", zap.Int("count", len(runs)))
+	return runs, nil
+}
+
+func (s *service) GetRun(ctx context.Context, runID string) (*data.SyncRun, error) {
+	log := s.logger.With(zap.String("method", "GetRun"), zap.String("runId", runID))
+	log.Debug("Calling use case to get sync run")
+
+	run, err := s.syncRunUseCase.GetRun(ctx, runID)
+	if err != nil {
+		log.Warn("Use case returned error getting sync run", zap.Error(err))
+		return nil, err
+	}
+	return run, nil
+}
+
+func (s *service) GetMissingEvents(ctx context.Context) ([]data.MissingEvent, error) {
+	log := s.logger.With(zap.String("method", "GetMissingEvents"))
+	log.Debug("Calling use case to get missing events")
+
+	events, err := s.syncRunUseCase.GetMissingEvents(ctx)
+	if err != nil {
+		log.Warn("Use case returned error getting missing events", zap.Error(err))
+		return nil, err
+	}
+
+	log.Debug("Successfully retrieved missing events from use case", zap.Int("count", len(events)))
+	return events, nil
+}
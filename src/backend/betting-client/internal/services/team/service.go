@@ -0,0 +1,106 @@
+package team
+
+import (
+	"context"
+
+	"github.com/Arlan-Z/def-betting-api/internal/data"
+	"go.uber.org/zap"
+)
+
+type TeamUseCase interface {
+	GetCompetitions(ctx context.Context, sport string) ([]data.Competition, error)
+	GetTeams(ctx context.Context, sport string) ([]data.Team, error)
+	GetTeamFixtures(ctx context.Context, teamID string) ([]data.Event, error)
+	GetPendingAliases(ctx context.Context) ([]data.UnresolvedTeamAlias, error)
+	ResolveAlias(ctx context.Context, aliasID string, teamID string, createTeam bool) (*data.UnresolvedTeamAlias, error)
+}
+
+type Service interface {
+	GetCompetitions(ctx context.Context, sport string) ([]data.Competition, error)
+	GetTeams(ctx context.Context, sport string) ([]data.Team, error)
+	GetTeamFixtures(ctx context.Context, teamID string) ([]data.Event, error)
+	GetPendingAliases(ctx context.Context) ([]data.UnresolvedTeamAlias, error)
+	ResolveAlias(ctx context.Context, aliasID string, teamID string, createTeam bool) (*data.UnresolvedTeamAlias, error)
+}
+
+type service struct {
+	teamUseCase TeamUseCase
+	logger      *zap.Logger
+}
+
+func NewService(uc TeamUseCase, logger *zap.Logger) Service {
+	return &service{
+		teamUseCase: uc,
+		logger:      logger.Named("TeamService"),
+	}
+}
+
+func (s *service) GetCompetitions(ctx context.Context, sport string) ([]data.Competition, error) {
+	log := s.logger.With(zap.String("method", "GetCompetitions"), zap.String("sport", sport))
+	log.Debug("Calling use case to get competitions")
+
+	competitions, err := s.teamUseCase.GetCompetitions(ctx, sport)
+	if err != nil {
+		log.Warn("Use case returned error getting competitions", zap.Error(err))
+		return nil, err
+	}
+
+	log.Debug("Successfully retrieved competitions from use case", zap.Int("count", len(competitions)))
+	return competitions, nil
+}
+
+func (s *service) GetTeams(ctx context.Context, sport string) ([]data.Team, error) {
+	log := s.logger.With(zap.String("method", "GetTeams"), zap.String("sport", sport))
+	log.Debug("Calling use case to get teams")
+
+	teams, err := s.teamUseCase.GetTeams(ctx, sport)
+	if err != nil {
+		log.Warn("Use case returned error getting teams", zap.Error(err))
+		return nil, err
+	}
+
+	log.Debug("Successfully retrieved teams from use case", zap.Int("count", len(teams)))
+	return teams, nil
+}
+
+func (s *service) GetTeamFixtures(ctx context.Context, teamID string) ([]data.Event, error) {
+	log := s.logger.With(zap.String("method", "GetTeamFixtures"), zap.String("teamId", teamID))
+	log.Debug("Calling use case to get team fixtures")
+
+	events, err := s.teamUseCase.GetTeamFixtures(ctx, teamID)
+	if err != nil {
+		log.Warn("Use case returned error getting team fixtures", zap.Error(err))
+		return nil, err
+	}
+
+	log.Debug("Successfully retrieved team fixtures from use case", zap.Int("count", len(events)))
+	return events, nil
+}
+
+func (s *service) GetPendingAliases(ctx context.Context) ([]data.UnresolvedTeamAlias, error) {
+	log := s.logger.With(zap.String("method", "GetPendingAliases"))
+	log.Debug("Calling use case to get unresolved team aliases")
+
+	aliases, err := s.teamUseCase.GetPendingAliases(ctx)
+	if err != nil {
+		log.Warn("Use case returned error getting unresolved team aliases", zap.Error(err))
+		return nil, err
+	}
+
+	log.Debug("Successfully retrieved unresolved team aliases from use case", zap.Int("count", len(aliases)))
+	return aliases, nil
+}
+
+func (s *service) ResolveAlias(ctx context.Context, aliasID string, teamID string, createTeam bool) (*data.UnresolvedTeamAlias, error) {
+	log := s.logger.With(zap.String("method", "ResolveAlias"), zap.String("aliasId", aliasID))
+	log.Info("Calling use case to resolve team alias")
+
+	alias, err := s.teamUseCase.ResolveAlias(ctx, aliasID, teamID, createTeam)
+	if err != nil {
+		log.Error("Use case returned error resolving team alias", zap.Error(err))
+		return nil, err
+	}
+
+	log.Info("Team alias resolved via use case", zap.Stringp("teamId", alias.TeamID))
+	return alias, nil
+}
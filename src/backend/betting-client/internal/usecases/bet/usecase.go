@@ -20,6 +20,8 @@ var (
 	ErrBetCancellationFailed = errors.New("couldn't cancel one or more bets")
 )
 
+const LateBetReason = "placed after corrected start time"
+
 type EventRepository interface {
 	FindByID(ctx context.Context, eventID string) (*data.Event, error)
 }
@@ -28,18 +30,21 @@ type BetRepository interface {
 	Save(ctx context.Context, bet *data.Bet) error
 	FindPendingByEventID(ctx context.Context, eventID string) ([]data.Bet, error)
 	UpdateStatus(ctx context.Context, betID string, status data.BetStatus) error
+	FlagPlacedAfter(ctx context.Context, eventID string, after time.Time, reason string) ([]data.Bet, error)
 }
 
 type UseCase struct {
 	betRepo   BetRepository
 	eventRepo EventRepository
+	cutoff    data.BetCutoffPolicy
 	logger    *zap.Logger
 }
 
-func NewUseCase(br BetRepository, er EventRepository, logger *zap.Logger) *UseCase {
+func NewUseCase(br BetRepository, er EventRepository, cutoff data.BetCutoffPolicy, logger *zap.Logger) *UseCase {
 	return &UseCase{
 		betRepo:   br,
 		eventRepo: er,
+		cutoff:    cutoff,
 		logger:    logger.Named("BetUseCase"), // Added logger name
 	}
 }
@@ -62,10 +67,13 @@ func (uc *UseCase) PlaceBet(ctx context.Context, req data.PlaceBetRequest) (*dat
 	}
 
 	now := time.Now().UTC()
-	if !event.IsActive || now.After(event.EventEndDate) || now.After(event.EventStartDate) {
+	closesAt := uc.cutoff.ClosesAt(*event)
+	if !event.IsOpenForBetting() || now.After(event.EventEndDate) || now.After(closesAt) {
 		log.Warn("Attempt to bet on inactive or started/finished event",
 			zap.Bool("isActive", event.IsActive),
+			zap.String("status", string(event.Status)),
 			zap.Time("eventStart", event.EventStartDate),
+			zap.Time("bettingClosesAt", closesAt),
 			zap.Time("eventEnd", event.EventEndDate),
 		)
 		return nil, ErrEventNotActive
@@ -136,3 +144,20 @@ func (uc *UseCase) CancelBetsForEvent(ctx context.Context, eventID string) error
 	log.Info("All pending bids have been successfully cancelled", zap.Int("count", canceledCount))
 	return nil
 }
+
+// FlagLateBets flags pending bets placed after the (corrected) start of the event
+// for voiding and returns the newly flagged bets.
+func (uc *UseCase) FlagLateBets(ctx context.Context, eventID string, startDate time.Time) ([]data.Bet, error) {
+	log := uc.logger.With(zap.String("eventId", eventID), zap.String("operation", "FlagLateBets"), zap.Time("startDate", startDate))
+
+	flagged, err := uc.betRepo.FlagPlacedAfter(ctx, eventID, startDate, LateBetReason)
+	if err != nil {
+		log.Error("Error flagging late bets", zap.Error(err))
+		return nil, fmt.Errorf("internal error when flagging late bets")
+	}
+
+	if len(flagged) > 0 {
+		log.Warn("Bets placed after the corrected start time were flagged for voiding", zap.Int("count", len(flagged)))
+	}
+	return flagged, nil
+}
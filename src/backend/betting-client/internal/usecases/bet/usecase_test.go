@@ -22,7 +22,7 @@ func TestBetUseCase_PlaceBet_Success(t *testing.T) {
 	mockBetRepo := repomocks.NewBetRepository(t)
 	mockEventRepo := repomocks.NewEventRepository(t)
 	logger := zap.NewNop()
-	uc := betuc.NewUseCase(mockBetRepo, mockEventRepo, logger)
+	uc := betuc.NewUseCase(mockBetRepo, mockEventRepo, data.BetCutoffPolicy{}, logger)
 
 	ctx := context.Background()
 	userID := uuid.NewString()
@@ -91,7 +91,7 @@ func TestBetUseCase_PlaceBet_EventNotFound(t *testing.T) {
 	mockBetRepo := repomocks.NewBetRepository(t)
 	mockEventRepo := repomocks.NewEventRepository(t)
 	logger := zap.NewNop()
-	uc := betuc.NewUseCase(mockBetRepo, mockEventRepo, logger)
+	uc := betuc.NewUseCase(mockBetRepo, mockEventRepo, data.BetCutoffPolicy{}, logger)
 
 	ctx := context.Background()
 	eventID := uuid.NewString()
@@ -113,7 +113,7 @@ func TestBetUseCase_PlaceBet_EventNotActive_Ended(t *testing.T) {
 	mockBetRepo := repomocks.NewBetRepository(t)
 	mockEventRepo := repomocks.NewEventRepository(t)
 	logger := zap.NewNop()
-	uc := betuc.NewUseCase(mockBetRepo, mockEventRepo, logger)
+	uc := betuc.NewUseCase(mockBetRepo, mockEventRepo, data.BetCutoffPolicy{}, logger)
 
 	ctx := context.Background()
 	eventID := uuid.NewString()
@@ -141,7 +141,7 @@ func TestBetUseCase_PlaceBet_EventNotActive_ExplicitFlag(t *testing.T) {
 	mockBetRepo := repomocks.NewBetRepository(t)
 	mockEventRepo := repomocks.NewEventRepository(t)
 	logger := zap.NewNop()
-	uc := betuc.NewUseCase(mockBetRepo, mockEventRepo, logger)
+	uc := betuc.NewUseCase(mockBetRepo, mockEventRepo, data.BetCutoffPolicy{}, logger)
 
 	ctx := context.Background()
 	eventID := uuid.NewString()
@@ -169,7 +169,7 @@ func TestBetUseCase_PlaceBet_EventNotActive_Started(t *testing.T) {
 	mockBetRepo := repomocks.NewBetRepository(t)
 	mockEventRepo := repomocks.NewEventRepository(t)
 	logger := zap.NewNop()
-	uc := betuc.NewUseCase(mockBetRepo, mockEventRepo, logger)
+	uc := betuc.NewUseCase(mockBetRepo, mockEventRepo, data.BetCutoffPolicy{}, logger)
 
 	ctx := context.Background()
 	eventID := uuid.NewString()
@@ -197,7 +197,7 @@ func TestBetUseCase_PlaceBet_SaveError(t *testing.T) {
 	mockBetRepo := repomocks.NewBetRepository(t)
 	mockEventRepo := repomocks.NewEventRepository(t)
 	logger := zap.NewNop()
-	uc := betuc.NewUseCase(mockBetRepo, mockEventRepo, logger)
+	uc := betuc.NewUseCase(mockBetRepo, mockEventRepo, data.BetCutoffPolicy{}, logger)
 
 	ctx := context.Background()
 	eventID := uuid.NewString()
@@ -232,7 +232,7 @@ func TestBetUseCase_PlaceBet_RecordsCorrectOdds(t *testing.T) {
 	mockBetRepo := repomocks.NewBetRepository(t)
 	mockEventRepo := repomocks.NewEventRepository(t)
 	logger := zap.NewNop()
-	uc := betuc.NewUseCase(mockBetRepo, mockEventRepo, logger)
+	uc := betuc.NewUseCase(mockBetRepo, mockEventRepo, data.BetCutoffPolicy{}, logger)
 
 	ctx := context.Background()
 	eventID := uuid.NewString()
@@ -276,7 +276,7 @@ func TestBetUseCase_CancelBetsForEvent_Success(t *testing.T) {
 	mockBetRepo := repomocks.NewBetRepository(t)
 	mockEventRepo := repomocks.NewEventRepository(t)
 	logger := zap.NewNop()
-	uc := betuc.NewUseCase(mockBetRepo, mockEventRepo, logger)
+	uc := betuc.NewUseCase(mockBetRepo, mockEventRepo, data.BetCutoffPolicy{}, logger)
 
 	ctx := context.Background()
 	eventID := uuid.NewString()
@@ -302,7 +302,7 @@ func TestBetUseCase_CancelBetsForEvent_NoPendingBets(t *testing.T) {
 	mockBetRepo := repomocks.NewBetRepository(t)
 	mockEventRepo := repomocks.NewEventRepository(t)
 	logger := zap.NewNop()
-	uc := betuc.NewUseCase(mockBetRepo, mockEventRepo, logger)
+	uc := betuc.NewUseCase(mockBetRepo, mockEventRepo, data.BetCutoffPolicy{}, logger)
 
 	ctx := context.Background()
 	eventID := uuid.NewString()
@@ -321,7 +321,7 @@ func TestBetUseCase_CancelBetsForEvent_FindPendingErrorNoRows(t *testing.T) {
 	mockBetRepo := repomocks.NewBetRepository(t)
 	mockEventRepo := repomocks.NewEventRepository(t)
 	logger := zap.NewNop()
-	uc := betuc.NewUseCase(mockBetRepo, mockEventRepo, logger)
+	uc := betuc.NewUseCase(mockBetRepo, mockEventRepo, data.BetCutoffPolicy{}, logger)
 
 	ctx := context.Background()
 	eventID := uuid.NewString()
@@ -339,7 +339,7 @@ func TestBetUseCase_CancelBetsForEvent_UpdateError(t *testing.T) {
 	mockBetRepo := repomocks.NewBetRepository(t)
 	mockEventRepo := repomocks.NewEventRepository(t)
 	logger := zap.NewNop()
-	uc := betuc.NewUseCase(mockBetRepo, mockEventRepo, logger)
+	uc := betuc.NewUseCase(mockBetRepo, mockEventRepo, data.BetCutoffPolicy{}, logger)
 
 	ctx := context.Background()
 	eventID := uuid.NewString()
@@ -366,3 +366,82 @@ func TestBetUseCase_CancelBetsForEvent_UpdateError(t *testing.T) {
 
 	mockBetRepo.AssertExpectations(t)
 }
+
+func TestBetUseCase_PlaceBet_SportCutoffReached(t *testing.T) {
+	mockBetRepo := repomocks.NewBetRepository(t)
+	mockEventRepo := repomocks.NewEventRepository(t)
+	logger := zap.NewNop()
+	cutoff := data.BetCutoffPolicy{
+		Default: time.Minute,
+		BySport: map[string]time.Duration{"football": 10 * time.Minute},
+	}
+	uc := betuc.NewUseCase(mockBetRepo, mockEventRepo, cutoff, logger)
+
+	ctx := context.Background()
+	eventID := uuid.NewString()
+	req := data.PlaceBetRequest{EventID: eventID, UserID: uuid.NewString(), Amount: 10, PredictedOutcome: data.HomeWin}
+
+	// Starts in 5 minutes: open under the default cutoff, closed under the football cutoff.
+	footballEvent := &data.Event{
+		ID:             eventID,
+		IsActive:       true,
+		Type:           "Football",
+		EventStartDate: time.Now().Add(5 * time.Minute),
+		EventEndDate:   time.Now().Add(2 * time.Hour),
+	}
+	mockEventRepo.On("FindByID", ctx, eventID).Return(footballEvent, nil).Once()
+
+	createdBet, err := uc.PlaceBet(ctx, req)
+
+	require.Error(t, err)
+	require.Nil(t, createdBet)
+	assert.True(t, errors.Is(err, betuc.ErrEventNotActive), "Expected error ErrEventNotActive because the sport cutoff has passed")
+	mockBetRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestBetUseCase_PlaceBet_EventClosed(t *testing.T) {
+	mockBetRepo := repomocks.NewBetRepository(t)
+	mockEventRepo := repomocks.NewEventRepository(t)
+	logger := zap.NewNop()
+	uc := betuc.NewUseCase(mockBetRepo, mockEventRepo, data.BetCutoffPolicy{}, logger)
+
+	ctx := context.Background()
+	eventID := uuid.NewString()
+	req := data.PlaceBetRequest{EventID: eventID, UserID: uuid.NewString(), Amount: 10, PredictedOutcome: data.Draw}
+
+	closedEvent := &data.Event{
+		ID:             eventID,
+		IsActive:       true,
+		Status:         data.EventStatusClosed,
+		EventStartDate: time.Now().Add(time.Hour),
+		EventEndDate:   time.Now().Add(2 * time.Hour),
+	}
+	mockEventRepo.On("FindByID", ctx, eventID).Return(closedEvent, nil).Once()
+
+	_, err := uc.PlaceBet(ctx, req)
+
+	assert.True(t, errors.Is(err, betuc.ErrEventNotActive), "Expected error ErrEventNotActive because betting is closed")
+	mockBetRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestBetUseCase_FlagLateBets(t *testing.T) {
+	mockBetRepo := repomocks.NewBetRepository(t)
+	mockEventRepo := repomocks.NewEventRepository(t)
+	logger := zap.NewNop()
+	uc := betuc.NewUseCase(mockBetRepo, mockEventRepo, data.BetCutoffPolicy{}, logger)
+
+	ctx := context.Background()
+	eventID := uuid.NewString()
+	correctedStart := time.Now().Add(-30 * time.Minute)
+
+	lateBets := []data.Bet{
+		{ID: uuid.NewString(), EventID: eventID, Status: data.StatusPending},
+		{ID: uuid.NewString(), EventID: eventID, Status: data.StatusPending},
+	}
+	mockBetRepo.On("FlagPlacedAfter", ctx, eventID, correctedStart, betuc.LateBetReason).Return(lateBets, nil).Once()
+
+	flagged, err := uc.FlagLateBets(ctx, eventID, correctedStart)
+
+	require.NoError(t, err)
+	assert.Equal(t, lateBets, flagged)
+}
@@ -0,0 +1,99 @@
+package confirmation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Arlan-Z/def-betting-api/internal/data"
+	eventuc "github.com/Arlan-Z/def-betting-api/internal/usecases/event"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrConfirmationNotFound = errors.New("pending result confirmation not found")
+	ErrAlreadyConfirmed     = errors.New("result already confirmed")
+)
+
+type ConfirmationRepository interface {
+	FindResultConfirmation(ctx context.Context, eventID string) (*data.ResultConfirmation, error)
+	FindPendingResultConfirmations(ctx context.Context) ([]data.ResultConfirmation, error)
+	ConfirmResult(ctx context.Context, eventID string, confirmedBy string, confirmedAt time.Time) error
+}
+
+type EventFinalizer interface {
+	FinalizeEvent(ctx context.Context, eventID string, actualResult data.Outcome) error
+}
+
+type EventResettler interface {
+	Resettle(ctx context.Context, eventID string, result data.Outcome, triggeredBy string, reason string) (*data.Resettlement, error)
+}
+
+// CorrectionReason is recorded on resettlements of corrections confirmed by an admin.
+const CorrectionReason = "result correction confirmed by admin"
+
+type UseCase struct {
+	confirmationRepo ConfirmationRepository
+	finalizer        EventFinalizer
+	resettler        EventResettler
+	logger           *zap.Logger
+}
+
+func NewUseCase(cr ConfirmationRepository, finalizer EventFinalizer, resettler EventResettler, logger *zap.Logger) *UseCase {
+	return &UseCase{
+		confirmationRepo: cr,
+		finalizer:        finalizer,
+		resettler:        resettler,
+		logger:           logger.Named("ConfirmationUseCase"),
+	}
+}
+
+func (uc *UseCase) GetPendingConfirmations(ctx context.Context) ([]data.ResultConfirmation, error) {
+	confirmations, err := uc.confirmationRepo.FindPendingResultConfirmations(ctx)
+	if err != nil {
+		uc.logger.Error("Error getting pending result confirmations from repository", zap.Error(err))
+		return nil, fmt.Errorf("failed to get list of result confirmations")
+	}
+	return confirmations, nil
+}
+
+// Confirm finalizes an event with the result reported by the source without waiting for
+// the confirmation policy. A corrected result of a finalized event resettles it.
+func (uc *UseCase) Confirm(ctx context.Context, eventID string) (*data.ResultConfirmation, error) {
+	log := uc.logger.With(zap.String("eventId", eventID), zap.String("operation", "Confirm"))
+	log.Info("Use Case: Confirming pending event result")
+
+	confirmation, err := uc.confirmationRepo.FindResultConfirmation(ctx, eventID)
+	if err != nil {
+		log.Error("Error retrieving result confirmation", zap.Error(err))
+		return nil, fmt.Errorf("internal error searching for result confirmation")
+	}
+	if confirmation == nil {
+		return nil, ErrConfirmationNotFound
+	}
+	if confirmation.ConfirmedAt != nil {
+		return nil, ErrAlreadyConfirmed
+	}
+
+	err = uc.finalizer.FinalizeEvent(ctx, eventID, confirmation.Result)
+	if errors.Is(err, eventuc.ErrEventAlreadyFinalized) {
+		_, err = uc.resettler.Resettle(ctx, eventID, confirmation.Result, data.ResettledByAdmin, CorrectionReason)
+	}
+	if err != nil {
+		log.Error("Error settling event with pending result", zap.Error(err))
+		return nil, err
+	}
+
+	confirmedAt := time.Now().UTC()
+	confirmedBy := data.ConfirmedByAdmin
+	if err := uc.confirmationRepo.ConfirmResult(ctx, eventID, confirmedBy, confirmedAt); err != nil {
+		log.Error("Error storing result confirmation", zap.Error(err))
+		return nil, fmt.Errorf("internal error confirming result")
+	}
+
+	confirmation.ConfirmedAt = &confirmedAt
+	confirmation.ConfirmedBy = &confirmedBy
+	log.Info("Pending result confirmed", zap.String("result", string(confirmation.Result)))
+	return confirmation, nil
+}
@@ -0,0 +1,113 @@
+package confirmation_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Arlan-Z/def-betting-api/internal/data"
+	repomocks "github.com/Arlan-Z/def-betting-api/internal/repositories/mocks"
+	confirmationuc "github.com/Arlan-Z/def-betting-api/internal/usecases/confirmation"
+	eventuc "github.com/Arlan-Z/def-betting-api/internal/usecases/event"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type mockFinalizer struct {
+	mock.Mock
+}
+
+func (m *mockFinalizer) FinalizeEvent(ctx context.Context, eventID string, actualResult data.Outcome) error {
+	return m.Called(ctx, eventID, actualResult).Error(0)
+}
+
+func (m *mockFinalizer) Resettle(ctx context.Context, eventID string, result data.Outcome, triggeredBy string, reason string) (*data.Resettlement, error) {
+	args := m.Called(ctx, eventID, result, triggeredBy, reason)
+	resettlement, _ := args.Get(0).(*data.Resettlement)
+	return resettlement, args.Error(1)
+}
+
+func TestConfirmationUseCase_Confirm_FinalizesPendingResult(t *testing.T) {
+	mockRepo := repomocks.NewProviderRepository(t)
+	finalizer := &mockFinalizer{}
+	uc := confirmationuc.NewUseCase(mockRepo, finalizer, finalizer, zap.NewNop())
+
+	ctx := context.Background()
+	pending := &data.ResultConfirmation{EventID: "event-1", Result: data.AwayWin, Sightings: 1, FirstSeenAt: time.Now().UTC()}
+	mockRepo.On("FindResultConfirmation", ctx, "event-1").Return(pending, nil).Once()
+	finalizer.On("FinalizeEvent", ctx, "event-1", data.AwayWin).Return(nil).Once()
+	mockRepo.On("ConfirmResult", ctx, "event-1", data.ConfirmedByAdmin, mock.AnythingOfType("time.Time")).Return(nil).Once()
+
+	confirmed, err := uc.Confirm(ctx, "event-1")
+
+	require.NoError(t, err)
+	require.NotNil(t, confirmed.ConfirmedAt)
+	assert.Equal(t, data.ConfirmedByAdmin, *confirmed.ConfirmedBy)
+	finalizer.AssertExpectations(t)
+}
+
+func TestConfirmationUseCase_Confirm_FinalizeErrorKeepsResultPending(t *testing.T) {
+	mockRepo := repomocks.NewProviderRepository(t)
+	finalizer := &mockFinalizer{}
+	uc := confirmationuc.NewUseCase(mockRepo, finalizer, finalizer, zap.NewNop())
+
+	ctx := context.Background()
+	pending := &data.ResultConfirmation{EventID: "event-1", Result: data.Draw, Sightings: 1, FirstSeenAt: time.Now().UTC()}
+	mockRepo.On("FindResultConfirmation", ctx, "event-1").Return(pending, nil).Once()
+	finalizer.On("FinalizeEvent", ctx, "event-1", data.Draw).Return(eventuc.ErrEventNotFound).Once()
+
+	_, err := uc.Confirm(ctx, "event-1")
+
+	require.ErrorIs(t, err, eventuc.ErrEventNotFound)
+	mockRepo.AssertNotCalled(t, "ConfirmResult", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestConfirmationUseCase_Confirm_CorrectionResettlesFinalizedEvent(t *testing.T) {
+	mockRepo := repomocks.NewProviderRepository(t)
+	finalizer := &mockFinalizer{}
+	uc := confirmationuc.NewUseCase(mockRepo, finalizer, finalizer, zap.NewNop())
+
+	ctx := context.Background()
+	pending := &data.ResultConfirmation{EventID: "event-1", Result: data.Draw, Sightings: 1, FirstSeenAt: time.Now().UTC()}
+	mockRepo.On("FindResultConfirmation", ctx, "event-1").Return(pending, nil).Once()
+	finalizer.On("FinalizeEvent", ctx, "event-1", data.Draw).Return(eventuc.ErrEventAlreadyFinalized).Once()
+	finalizer.On("Resettle", ctx, "event-1", data.Draw, data.ResettledByAdmin, confirmationuc.CorrectionReason).
+		Return(&data.Resettlement{ID: "resettlement-1", Status: data.ResettlementCompleted}, nil).Once()
+	mockRepo.On("ConfirmResult", ctx, "event-1", data.ConfirmedByAdmin, mock.AnythingOfType("time.Time")).Return(nil).Once()
+
+	confirmed, err := uc.Confirm(ctx, "event-1")
+
+	require.NoError(t, err)
+	require.NotNil(t, confirmed.ConfirmedAt)
+	finalizer.AssertExpectations(t)
+}
+
+func TestConfirmationUseCase_Confirm_AlreadyConfirmed(t *testing.T) {
+	mockRepo := repomocks.NewProviderRepository(t)
+	finalizer := &mockFinalizer{}
+	uc := confirmationuc.NewUseCase(mockRepo, finalizer, finalizer, zap.NewNop())
+
+	ctx := context.Background()
+	confirmedAt := time.Now().UTC()
+	confirmation := &data.ResultConfirmation{EventID: "event-1", Result: data.HomeWin, ConfirmedAt: &confirmedAt}
+	mockRepo.On("FindResultConfirmation", ctx, "event-1").Return(confirmation, nil).Once()
+
+	_, err := uc.Confirm(ctx, "event-1")
+
+	require.ErrorIs(t, err, confirmationuc.ErrAlreadyConfirmed)
+	finalizer.AssertNotCalled(t, "FinalizeEvent", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestConfirmationUseCase_Confirm_NotFound(t *testing.T) {
+	mockRepo := repomocks.NewProviderRepository(t)
+	uc := confirmationuc.NewUseCase(mockRepo, &mockFinalizer{}, &mockFinalizer{}, zap.NewNop())
+
+	ctx := context.Background()
+	mockRepo.On("FindResultConfirmation", ctx, "event-1").Return(nil, nil).Once()
+
+	_, err := uc.Confirm(ctx, "event-1")
+
+	require.ErrorIs(t, err, confirmationuc.ErrConfirmationNotFound)
+}
@@ -0,0 +1,99 @@
+package conflict
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Arlan-Z/def-betting-api/internal/data"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrConflictNotFound        = errors.New("result conflict not found")
+	ErrConflictAlreadyResolved = errors.New("result conflict already resolved")
+	ErrInvalidConfirmedResult  = errors.New("invalid confirmed result")
+)
+
+// CancelReason is recorded on bets voided because a conflict was confirmed as canceled.
+const CancelReason = "canceled after provider result conflict"
+
+type ConflictRepository interface {
+	FindResultConflict(ctx context.Context, eventID string) (*data.ResultConflict, error)
+	FindOpenResultConflicts(ctx context.Context) ([]data.ResultConflict, error)
+	ResolveResultConflict(ctx context.Context, eventID string, result string, resolvedAt time.Time) error
+}
+
+type EventFinalizer interface {
+	FinalizeEvent(ctx context.Context, eventID string, actualResult data.Outcome) error
+	VoidEvent(ctx context.Context, eventID string, reason string) error
+}
+
+type UseCase struct {
+	conflictRepo ConflictRepository
+	finalizer    EventFinalizer
+	logger       *zap.Logger
+}
+
+func NewUseCase(cr ConflictRepository, finalizer EventFinalizer, logger *zap.Logger) *UseCase {
+	return &UseCase{
+		conflictRepo: cr,
+		finalizer:    finalizer,
+		logger:       logger.Named("ConflictUseCase"),
+	}
+}
+
+func (uc *UseCase) GetOpenConflicts(ctx context.Context) ([]data.ResultConflict, error) {
+	conflicts, err := uc.conflictRepo.FindOpenResultConflicts(ctx)
+	if err != nil {
+		uc.logger.Error("Error getting open result conflicts from repository", zap.Error(err))
+		return nil, fmt.Errorf("failed to get list of result conflicts")
+	}
+	return conflicts, nil
+}
+
+// Confirm settles an event whose providers disagreed on the result: an outcome
+// finalizes it, "Canceled" voids its bets and refunds the stakes.
+func (uc *UseCase) Confirm(ctx context.Context, eventID string, result string) (*data.ResultConflict, error) {
+	log := uc.logger.With(zap.String("eventId", eventID), zap.String("operation", "Confirm"), zap.String("result", result))
+	log.Info("Use Case: Confirming result of conflicting event")
+
+	conflict, err := uc.conflictRepo.FindResultConflict(ctx, eventID)
+	if err != nil {
+		log.Error("Error retrieving result conflict", zap.Error(err))
+		return nil, fmt.Errorf("internal error searching for conflict")
+	}
+	if conflict == nil {
+		return nil, ErrConflictNotFound
+	}
+	if conflict.ResolvedAt != nil {
+		return nil, ErrConflictAlreadyResolved
+	}
+
+	switch result {
+	case string(data.HomeWin), string(data.AwayWin), string(data.Draw):
+		if err := uc.finalizer.FinalizeEvent(ctx, eventID, data.Outcome(result)); err != nil {
+			log.Error("Error finalizing event with confirmed result", zap.Error(err))
+			return nil, err
+		}
+	case data.ResultCanceled:
+		if err := uc.finalizer.VoidEvent(ctx, eventID, CancelReason); err != nil {
+			log.Error("Error voiding event confirmed as canceled", zap.Error(err))
+			return nil, err
+		}
+	default:
+		return nil, ErrInvalidConfirmedResult
+	}
+
+	resolvedAt := time.Now().UTC()
+	if err := uc.conflictRepo.ResolveResultConflict(ctx, eventID, result, resolvedAt); err != nil {
+		log.Error("Error storing conflict resolution", zap.Error(err))
+		return nil, fmt.Errorf("internal error resolving conflict")
+	}
+
+	conflict.ResolvedAt = &resolvedAt
+	conflict.ResolvedResult = &result
+	log.Info("Result conflict resolved")
+	return conflict, nil
+}
@@ -0,0 +1,91 @@
+package conflict_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Arlan-Z/def-betting-api/internal/data"
+	repomocks "github.com/Arlan-Z/def-betting-api/internal/repositories/mocks"
+	conflictuc "github.com/Arlan-Z/def-betting-api/internal/usecases/conflict"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type mockFinalizer struct {
+	mock.Mock
+}
+
+func (m *mockFinalizer) FinalizeEvent(ctx context.Context, eventID string, actualResult data.Outcome) error {
+	return m.Called(ctx, eventID, actualResult).Error(0)
+}
+
+func (m *mockFinalizer) VoidEvent(ctx context.Context, eventID string, reason string) error {
+	return m.Called(ctx, eventID, reason).Error(0)
+}
+
+func TestConflictUseCase_Confirm_FinalizesWithConfirmedOutcome(t *testing.T) {
+	mockRepo := repomocks.NewProviderRepository(t)
+	finalizer := &mockFinalizer{}
+	uc := conflictuc.NewUseCase(mockRepo, finalizer, zap.NewNop())
+
+	ctx := context.Background()
+	conflict := &data.ResultConflict{EventID: "event-1", Results: "a=HomeWin,b=Draw", DetectedAt: time.Now().UTC()}
+	mockRepo.On("FindResultConflict", ctx, "event-1").Return(conflict, nil).Once()
+	finalizer.On("FinalizeEvent", ctx, "event-1", data.Draw).Return(nil).Once()
+	mockRepo.On("ResolveResultConflict", ctx, "event-1", "Draw", mock.AnythingOfType("time.Time")).Return(nil).Once()
+
+	resolved, err := uc.Confirm(ctx, "event-1", "Draw")
+
+	require.NoError(t, err)
+	require.NotNil(t, resolved.ResolvedAt)
+	assert.Equal(t, "Draw", *resolved.ResolvedResult)
+	finalizer.AssertExpectations(t)
+}
+
+func TestConflictUseCase_Confirm_CanceledVoidsEvent(t *testing.T) {
+	mockRepo := repomocks.NewProviderRepository(t)
+	finalizer := &mockFinalizer{}
+	uc := conflictuc.NewUseCase(mockRepo, finalizer, zap.NewNop())
+
+	ctx := context.Background()
+	conflict := &data.ResultConflict{EventID: "event-1", Results: "a=HomeWin,b=Canceled", DetectedAt: time.Now().UTC()}
+	mockRepo.On("FindResultConflict", ctx, "event-1").Return(conflict, nil).Once()
+	finalizer.On("VoidEvent", ctx, "event-1", conflictuc.CancelReason).Return(nil).Once()
+	mockRepo.On("ResolveResultConflict", ctx, "event-1", data.ResultCanceled, mock.AnythingOfType("time.Time")).Return(nil).Once()
+
+	_, err := uc.Confirm(ctx, "event-1", data.ResultCanceled)
+
+	require.NoError(t, err)
+	finalizer.AssertExpectations(t)
+}
+
+func TestConflictUseCase_Confirm_AlreadyResolved(t *testing.T) {
+	mockRepo := repomocks.NewProviderRepository(t)
+	finalizer := &mockFinalizer{}
+	uc := conflictuc.NewUseCase(mockRepo, finalizer, zap.NewNop())
+
+	ctx := context.Background()
+	resolvedAt := time.Now().UTC()
+	conflict := &data.ResultConflict{EventID: "event-1", Results: "a=HomeWin,b=Draw", ResolvedAt: &resolvedAt}
+	mockRepo.On("FindResultConflict", ctx, "event-1").Return(conflict, nil).Once()
+
+	_, err := uc.Confirm(ctx, "event-1", "HomeWin")
+
+	assert.ErrorIs(t, err, conflictuc.ErrConflictAlreadyResolved)
+	finalizer.AssertNotCalled(t, "FinalizeEvent", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestConflictUseCase_Confirm_NotFound(t *testing.T) {
+	mockRepo := repomocks.NewProviderRepository(t)
+	uc := conflictuc.NewUseCase(mockRepo, &mockFinalizer{}, zap.NewNop())
+
+	ctx := context.Background()
+	mockRepo.On("FindResultConflict", ctx, "missing").Return(nil, nil).Once()
+
+	_, err := uc.Confirm(ctx, "missing", "HomeWin")
+
+	assert.ErrorIs(t, err, conflictuc.ErrConflictNotFound)
+}
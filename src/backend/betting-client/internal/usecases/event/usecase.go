@@ -4,10 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"math"
+	"strings"
+	"time"
 
 	"github.com/Arlan-Z/def-betting-api/internal/data"
-	payoutclient "github.com/Arlan-Z/def-betting-api/internal/deliveries/payout/http"
 	"go.uber.org/zap"
 )
 
@@ -16,35 +16,45 @@ var (
 	ErrEventAlreadyFinalized     = errors.New("event already finalized")
 	ErrEventNotFinishedYet       = errors.New("event has not finished yet based on time")
 	ErrInvalidFinalizationResult = errors.New("invalid result for event finalization")
-	ErrPayoutNotificationFailed  = errors.New("failed to notify payout service")
 	ErrBetUpdateFailed           = errors.New("failed to update bet status")
+	ErrBetNotFound               = errors.New("bet not found")
+	ErrBetNotPending             = errors.New("bet is not pending")
+	ErrInvalidSearchQuery        = errors.New("search query must contain at least one letter or digit")
+)
+
+const (
+	DefaultSearchLimit = 20
+	MaxSearchLimit     = 100
 )
 
 type EventRepository interface {
 	FindActiveEvents(ctx context.Context) ([]data.Event, error)
 	FindByID(ctx context.Context, eventID string) (*data.Event, error)
 	UpdateResultAndStatus(ctx context.Context, eventID string, result data.Outcome) error
+	MarkCanceled(ctx context.Context, eventID string) error
+	Search(ctx context.Context, terms []string, limit int) ([]data.EventSearchResult, error)
 }
 
 type BetRepository interface {
+	FindByID(ctx context.Context, betID string) (*data.Bet, error)
 	FindPendingByEventID(ctx context.Context, eventID string) ([]data.Bet, error)
-	UpdateStatusAndPayout(ctx context.Context, betID string, status data.BetStatus, payout float64) error
-	UpdateStatus(ctx context.Context, betID string, status data.BetStatus) error
+	SettleWithPayout(ctx context.Context, betID string, from data.BetStatus, status data.BetStatus, payout float64, intent *data.PayoutIntent) (bool, error)
 }
 
+// UseCase settles bets against event results. Payouts and refunds are not sent from here:
+// they are queued in the outbox together with the bet update and delivered by the payout
+// dispatcher.
 type UseCase struct {
-	eventRepo    EventRepository
-	betRepo      BetRepository
-	payoutClient payoutclient.PayoutClient
-	logger       *zap.Logger
+	eventRepo EventRepository
+	betRepo   BetRepository
+	logger    *zap.Logger
 }
 
-func NewUseCase(er EventRepository, br BetRepository, pc payoutclient.PayoutClient, logger *zap.Logger) *UseCase {
+func NewUseCase(er EventRepository, br BetRepository, logger *zap.Logger) *UseCase {
 	return &UseCase{
-		eventRepo:    er,
-		betRepo:      br,
-		payoutClient: pc,
-		logger:       logger.Named("EventUseCase"), // Added logger name
+		eventRepo: er,
+		betRepo:   br,
+		logger:    logger.Named("EventUseCase"), // Added logger name
 	}
 }
 
@@ -61,6 +71,30 @@ func (uc *UseCase) GetActiveEvents(ctx context.Context) ([]data.Event, error) {
 	return events, nil
 }
 
+// SearchEvents finds active events whose name, teams, sport or competition contain
+// words starting with every word of the query. Case and diacritics are ignored.
+func (uc *UseCase) SearchEvents(ctx context.Context, query string, limit int) ([]data.EventSearchResult, error) {
+	terms := strings.Fields(data.NormalizeName(query))
+	if len(terms) == 0 {
+		return nil, ErrInvalidSearchQuery
+	}
+	if limit <= 0 {
+		limit = DefaultSearchLimit
+	}
+	if limit > MaxSearchLimit {
+		limit = MaxSearchLimit
+	}
+
+	results, err := uc.eventRepo.Search(ctx, terms, limit)
+	if err != nil {
+		uc.logger.Error("Error searching events in repository", zap.Strings("terms", terms), zap.Error(err))
+		return nil, fmt.Errorf("failed to search events")
+	}
+
+	uc.logger.Debug("Use Case: Events searched", zap.Strings("terms", terms), zap.Int("count", len(results)))
+	return results, nil
+}
+
 func (uc *UseCase) FinalizeEvent(ctx context.Context, eventID string, actualResult data.Outcome) error {
 	// span := opentracing.StartSpan("FinalizeEventUseCase")
 	// ctx = opentracing.ContextWithSpan(ctx, span)
@@ -101,65 +135,23 @@ func (uc *UseCase) FinalizeEvent(ctx context.Context, eventID string, actualResu
 
 	var finalizationErrors []error
 	processedBetsCount := 0
-	successfulPayouts := 0
+	queuedPayouts := 0
 
 	for _, bet := range pendingBets {
-		betLogger := uc.logger.With(zap.String("betId", bet.ID), zap.String("userId", bet.UserID))
-		var newStatus data.BetStatus
-		var payoutAmount float64 = 0
-
-		if bet.PredictedOutcome == actualResult {
-			newStatus = data.StatusWon
-			switch actualResult {
-			case data.HomeWin:
-				payoutAmount = bet.Amount * bet.RecordedHomeWinChance
-			case data.AwayWin:
-				payoutAmount = bet.Amount * bet.RecordedAwayWinChance
-			case data.Draw:
-				payoutAmount = bet.Amount * bet.RecordedDrawChance
-			}
-			payoutAmount = math.Round(payoutAmount*100) / 100
-
-			betLogger.Info("Bet won", zap.Float64("payoutAmount", payoutAmount))
-		} else {
-			newStatus = data.StatusLost
-			betLogger.Info("Bet lost")
+		if bet.VoidFlaggedAt != nil {
+			uc.logger.Info("Bet is flagged for voiding, leaving it pending until reviewed", zap.String("betId", bet.ID))
+			continue
 		}
 
-		err = uc.betRepo.UpdateStatusAndPayout(ctx, bet.ID, newStatus, payoutAmount)
+		queued, err := uc.settleBet(ctx, bet, actualResult)
 		if err != nil {
-			betLogger.Error("Error updating bet status in DB", zap.Error(err))
-			finalizationErrors = append(finalizationErrors, fmt.Errorf("%w (ID: %s): %v", ErrBetUpdateFailed, bet.ID, err))
+			finalizationErrors = append(finalizationErrors, err)
 			continue
 		}
-
-		if newStatus == data.StatusWon && payoutAmount > 0 {
-			notification := data.PayoutNotification{
-				UserID: bet.UserID,
-				Amount: payoutAmount,
-			}
-			err = uc.payoutClient.NotifyPayout(ctx, notification)
-			if err != nil {
-				betLogger.Error("Error notifying payout service", zap.Error(err))
-				finalizationErrors = append(finalizationErrors, fmt.Errorf("%w (BetID: %s): %v", ErrPayoutNotificationFailed, bet.ID, err))
-
-				errUpdate := uc.betRepo.UpdateStatus(ctx, bet.ID, data.StatusFailed)
-				if errUpdate != nil {
-					betLogger.Error("CRITICAL: Error updating status to Failed after payout failure", zap.Error(errUpdate))
-					finalizationErrors = append(finalizationErrors, fmt.Errorf("%w (ID: %s, status -> Failed): %v", ErrBetUpdateFailed, bet.ID, errUpdate))
-				}
-			} else {
-				betLogger.Info("Payout service notified successfully")
-				errUpdate := uc.betRepo.UpdateStatus(ctx, bet.ID, data.StatusPaid)
-				if errUpdate != nil {
-					betLogger.Error("Error updating status to Paid after successful payout", zap.Error(errUpdate))
-					finalizationErrors = append(finalizationErrors, fmt.Errorf("%w (ID: %s, status -> Paid): %v", ErrBetUpdateFailed, bet.ID, errUpdate))
-				} else {
-					successfulPayouts++
-				}
-			}
-		}
 		processedBetsCount++
+		if queued {
+			queuedPayouts++
+		}
 	}
 
 	err = uc.eventRepo.UpdateResultAndStatus(ctx, eventID, actualResult)
@@ -171,7 +163,7 @@ func (uc *UseCase) FinalizeEvent(ctx context.Context, eventID string, actualResu
 	}
 
 	if len(finalizationErrors) > 0 {
-		uc.logger.Error("Event finalization completed with errors", zap.String("eventId", eventID), zap.Int("processedBets", processedBetsCount), zap.Int("successfulPayouts", successfulPayouts), zap.Errors("errors", finalizationErrors))
+		uc.logger.Error("Event finalization completed with errors", zap.String("eventId", eventID), zap.Int("processedBets", processedBetsCount), zap.Int("queuedPayouts", queuedPayouts), zap.Errors("errors", finalizationErrors))
 		errMsg := fmt.Sprintf("event finalization %s completed with %d errors: ", eventID, len(finalizationErrors))
 		for i, e := range finalizationErrors {
 			errMsg += e.Error()
@@ -182,6 +174,166 @@ func (uc *UseCase) FinalizeEvent(ctx context.Context, eventID string, actualResu
 		return errors.New(errMsg)
 	}
 
-	uc.logger.Info("Event finalized successfully", zap.String("eventId", eventID), zap.Int("processedBets", processedBetsCount), zap.Int("successfulPayouts", successfulPayouts))
+	uc.logger.Info("Event finalized successfully", zap.String("eventId", eventID), zap.Int("processedBets", processedBetsCount), zap.Int("queuedPayouts", queuedPayouts))
+	return nil
+}
+
+// settleBet marks the bet as won or lost against the result and, for winnings, queues
+// the payout in the same transaction. It reports whether a payout was queued.
+func (uc *UseCase) settleBet(ctx context.Context, bet data.Bet, actualResult data.Outcome) (bool, error) {
+	betLogger := uc.logger.With(zap.String("betId", bet.ID), zap.String("userId", bet.UserID))
+	var newStatus data.BetStatus
+	var payoutAmount float64 = 0
+	var intent *data.PayoutIntent
+
+	if bet.PredictedOutcome == actualResult {
+		newStatus = data.StatusWon
+		payoutAmount = bet.PayoutFor(actualResult)
+		if payoutAmount > 0 {
+			intent = data.NewPayoutIntent(bet, data.PayoutWin, payoutAmount, time.Now().UTC())
+		}
+
+		betLogger.Info("Bet won", zap.Float64("payoutAmount", payoutAmount))
+	} else {
+		newStatus = data.StatusLost
+		betLogger.Info("Bet lost")
+	}
+
+	settled, err := uc.betRepo.SettleWithPayout(ctx, bet.ID, data.StatusPending, newStatus, payoutAmount, intent)
+	if err != nil {
+		betLogger.Error("Error updating bet status in DB", zap.Error(err))
+		return false, fmt.Errorf("%w (ID: %s): %v", ErrBetUpdateFailed, bet.ID, err)
+	}
+	if !settled {
+		betLogger.Warn("Bet is no longer pending, it was settled concurrently")
+		return false, nil
+	}
+	if intent != nil {
+		betLogger.Info("Payout queued", zap.String("payoutId", intent.ID))
+	}
+	return intent != nil, nil
+}
+
+// SettleBet settles a single pending bet against the result of its already finalized
+// event, e.g. after a flagged bet has been reviewed and kept.
+func (uc *UseCase) SettleBet(ctx context.Context, betID string) error {
+	log := uc.logger.With(zap.String("betId", betID), zap.String("operation", "SettleBet"))
+
+	bet, err := uc.betRepo.FindByID(ctx, betID)
+	if err != nil {
+		log.Error("Error retrieving bet for settlement", zap.Error(err))
+		return fmt.Errorf("internal error retrieving bet")
+	}
+	if bet == nil {
+		return ErrBetNotFound
+	}
+	if bet.Status != data.StatusPending {
+		log.Warn("Attempt to settle bet that is not pending", zap.String("status", string(bet.Status)))
+		return ErrBetNotPending
+	}
+
+	event, err := uc.eventRepo.FindByID(ctx, bet.EventID)
+	if err != nil {
+		log.Error("Error retrieving event for settlement", zap.Error(err))
+		return fmt.Errorf("internal error searching for event")
+	}
+	if event == nil {
+		return ErrEventNotFound
+	}
+	if event.EventResult == nil {
+		log.Info("Event has no result yet, bet will be settled on finalization")
+		return nil
+	}
+
+	_, err = uc.settleBet(ctx, *bet, *event.EventResult)
+	return err
+}
+
+// VoidEvent voids every pending bet of the event, queues refunds of the stakes and
+// marks the event as canceled.
+func (uc *UseCase) VoidEvent(ctx context.Context, eventID string, reason string) error {
+	log := uc.logger.With(zap.String("eventId", eventID), zap.String("operation", "VoidEvent"), zap.String("reason", reason))
+	log.Info("Use Case: Voiding event")
+
+	event, err := uc.eventRepo.FindByID(ctx, eventID)
+	if err != nil {
+		log.Error("Error retrieving event for voiding", zap.Error(err))
+		return fmt.Errorf("internal error searching for event")
+	}
+	if event == nil {
+		log.Warn("Attempt to void non-existent event")
+		return ErrEventNotFound
+	}
+	if event.EventResult != nil {
+		log.Warn("Attempt to void event that already has a result")
+		return ErrEventAlreadyFinalized
+	}
+
+	pendingBets, err := uc.betRepo.FindPendingByEventID(ctx, eventID)
+	if err != nil {
+		log.Error("Error retrieving pending bets", zap.Error(err))
+		return fmt.Errorf("internal error retrieving bets")
+	}
+	log.Info("Found pending bets to void", zap.Int("count", len(pendingBets)))
+
+	var voidErrors []error
+	for _, bet := range pendingBets {
+		if err := uc.voidBet(ctx, bet); err != nil {
+			voidErrors = append(voidErrors, err)
+		}
+	}
+
+	if err := uc.eventRepo.MarkCanceled(ctx, eventID); err != nil {
+		log.Error("Failed to mark voided event as canceled", zap.Error(err))
+		voidErrors = append([]error{fmt.Errorf("failed to cancel event %s in DB: %w", eventID, err)}, voidErrors...)
+	}
+
+	if len(voidErrors) > 0 {
+		log.Error("Event voiding completed with errors", zap.Errors("errors", voidErrors))
+		return fmt.Errorf("event voiding %s completed with %d errors: %w", eventID, len(voidErrors), errors.Join(voidErrors...))
+	}
+
+	log.Info("Event voided successfully", zap.Int("voidedBets", len(pendingBets)))
+	return nil
+}
+
+func (uc *UseCase) voidBet(ctx context.Context, bet data.Bet) error {
+	betLogger := uc.logger.With(zap.String("betId", bet.ID), zap.String("userId", bet.UserID))
+
+	intent := data.NewPayoutIntent(bet, data.PayoutRefund, bet.Amount, time.Now().UTC())
+	voided, err := uc.betRepo.SettleWithPayout(ctx, bet.ID, data.StatusPending, data.StatusVoided, bet.Amount, intent)
+	if err != nil {
+		betLogger.Error("Error updating bet status to Voided", zap.Error(err))
+		return fmt.Errorf("%w (ID: %s): %v", ErrBetUpdateFailed, bet.ID, err)
+	}
+	if !voided {
+		betLogger.Warn("Bet is no longer pending, it was settled concurrently")
+		return nil
+	}
+
+	betLogger.Info("Bet voided and refund queued", zap.Float64("amount", bet.Amount), zap.String("payoutId", intent.ID))
+	return nil
+}
+
+// VoidBets voids the given bets and queues their refunds of an event without touching the event itself.
+func (uc *UseCase) VoidBets(ctx context.Context, eventID string, bets []data.Bet, reason string) error {
+	log := uc.logger.With(zap.String("eventId", eventID), zap.String("operation", "VoidBets"), zap.String("reason", reason))
+	log.Info("Use Case: Voiding bets", zap.Int("count", len(bets)))
+
+	var voidErrors []error
+	for _, bet := range bets {
+		if bet.Status != data.StatusPending {
+			log.Warn("Skipping bet that is no longer pending", zap.String("betId", bet.ID), zap.String("status", string(bet.Status)))
+			continue
+		}
+		if err := uc.voidBet(ctx, bet); err != nil {
+			voidErrors = append(voidErrors, err)
+		}
+	}
+
+	if len(voidErrors) > 0 {
+		log.Error("Voiding bets completed with errors", zap.Errors("errors", voidErrors))
+		return fmt.Errorf("voiding bets of event %s completed with %d errors: %w", eventID, len(voidErrors), errors.Join(voidErrors...))
+	}
 	return nil
 }
@@ -7,7 +7,6 @@ import (
 	"time"
 
 	"github.com/Arlan-Z/def-betting-api/internal/data"
-	payoutmocks "github.com/Arlan-Z/def-betting-api/internal/deliveries/payout/http/mocks"
 	repomocks "github.com/Arlan-Z/def-betting-api/internal/repositories/mocks"
 	eventuc "github.com/Arlan-Z/def-betting-api/internal/usecases/event"
 	"github.com/google/uuid"
@@ -17,13 +16,23 @@ import (
 	"go.uber.org/zap"
 )
 
+// noPayout matches a settlement that queues no payout.
+var noPayout = (*data.PayoutIntent)(nil)
+
+// payoutIntent matches the pending outbox entry queued together with a bet update.
+func payoutIntent(betID string, userID string, kind data.PayoutKind, amount float64) interface{} {
+	return mock.MatchedBy(func(intent *data.PayoutIntent) bool {
+		return intent != nil && intent.BetID == betID && intent.UserID == userID &&
+			intent.Kind == kind && intent.Amount == amount && intent.Status == data.OutboxPending
+	})
+}
+
 func TestEventUseCase_GetActiveEvents(t *testing.T) {
 	mockEventRepo := repomocks.NewEventRepository(t)
 	mockBetRepo := repomocks.NewBetRepository(t)
-	mockPayoutClient := payoutmocks.NewPayoutClient(t)
 	logger := zap.NewNop()
 
-	uc := eventuc.NewUseCase(mockEventRepo, mockBetRepo, mockPayoutClient, logger)
+	uc := eventuc.NewUseCase(mockEventRepo, mockBetRepo, logger)
 
 	ctx := context.Background()
 	expectedEvents := []data.Event{
@@ -43,10 +52,9 @@ func TestEventUseCase_GetActiveEvents(t *testing.T) {
 func TestEventUseCase_GetActiveEvents_RepoError(t *testing.T) {
 	mockEventRepo := repomocks.NewEventRepository(t)
 	mockBetRepo := repomocks.NewBetRepository(t)
-	mockPayoutClient := payoutmocks.NewPayoutClient(t)
 	logger := zap.NewNop()
 
-	uc := eventuc.NewUseCase(mockEventRepo, mockBetRepo, mockPayoutClient, logger)
+	uc := eventuc.NewUseCase(mockEventRepo, mockBetRepo, logger)
 
 	ctx := context.Background()
 	repoError := errors.New("database is down")
@@ -65,10 +73,9 @@ func TestEventUseCase_GetActiveEvents_RepoError(t *testing.T) {
 func TestEventUseCase_FinalizeEvent_Success_HomeWin(t *testing.T) {
 	mockEventRepo := repomocks.NewEventRepository(t)
 	mockBetRepo := repomocks.NewBetRepository(t)
-	mockPayoutClient := payoutmocks.NewPayoutClient(t)
 	logger := zap.NewNop()
 
-	uc := eventuc.NewUseCase(mockEventRepo, mockBetRepo, mockPayoutClient, logger)
+	uc := eventuc.NewUseCase(mockEventRepo, mockBetRepo, logger)
 
 	ctx := context.Background()
 	eventID := uuid.NewString()
@@ -100,10 +107,8 @@ func TestEventUseCase_FinalizeEvent_Success_HomeWin(t *testing.T) {
 
 	mockEventRepo.On("FindByID", ctx, eventID).Return(activeEvent, nil).Once()
 	mockBetRepo.On("FindPendingByEventID", ctx, eventID).Return(pendingBets, nil).Once()
-	mockBetRepo.On("UpdateStatusAndPayout", ctx, betIDWin, data.StatusWon, expectedPayout).Return(nil).Once()
-	mockPayoutClient.On("NotifyPayout", ctx, data.PayoutNotification{UserID: userID, Amount: expectedPayout}).Return(nil).Once()
-	mockBetRepo.On("UpdateStatus", ctx, betIDWin, data.StatusPaid).Return(nil).Once()
-	mockBetRepo.On("UpdateStatusAndPayout", ctx, betIDLoss, data.StatusLost, 0.0).Return(nil).Once()
+	mockBetRepo.On("SettleWithPayout", ctx, betIDWin, data.StatusPending, data.StatusWon, expectedPayout, payoutIntent(betIDWin, userID, data.PayoutWin, expectedPayout)).Return(true, nil).Once()
+	mockBetRepo.On("SettleWithPayout", ctx, betIDLoss, data.StatusPending, data.StatusLost, 0.0, noPayout).Return(true, nil).Once()
 	mockEventRepo.On("UpdateResultAndStatus", ctx, eventID, actualResult).Return(nil).Once()
 
 	err := uc.FinalizeEvent(ctx, eventID, actualResult)
@@ -111,25 +116,17 @@ func TestEventUseCase_FinalizeEvent_Success_HomeWin(t *testing.T) {
 	require.NoError(t, err)
 	mockEventRepo.AssertExpectations(t)
 	mockBetRepo.AssertExpectations(t)
-	mockPayoutClient.AssertExpectations(t)
 }
 
-func TestEventUseCase_FinalizeEvent_PayoutFailed(t *testing.T) {
+func TestEventUseCase_FinalizeEvent_SkipsBetSettledConcurrently(t *testing.T) {
 	mockEventRepo := repomocks.NewEventRepository(t)
 	mockBetRepo := repomocks.NewBetRepository(t)
-	mockPayoutClient := payoutmocks.NewPayoutClient(t)
-	logger := zap.NewNop()
-
-	uc := eventuc.NewUseCase(mockEventRepo, mockBetRepo, mockPayoutClient, logger)
+	uc := eventuc.NewUseCase(mockEventRepo, mockBetRepo, zap.NewNop())
 
 	ctx := context.Background()
 	eventID := uuid.NewString()
-	betIDWin := uuid.NewString()
-	actualResult := data.HomeWin
-
-	activeEvent := &data.Event{ID: eventID, IsActive: true, EventResult: nil}
-	winningBet := data.Bet{
-		ID:                    betIDWin,
+	bet := data.Bet{
+		ID:                    uuid.NewString(),
 		UserID:                uuid.NewString(),
 		EventID:               eventID,
 		Amount:                10.0,
@@ -137,35 +134,24 @@ func TestEventUseCase_FinalizeEvent_PayoutFailed(t *testing.T) {
 		RecordedHomeWinChance: 2.0,
 		Status:                data.StatusPending,
 	}
-	pendingBets := []data.Bet{winningBet}
-	expectedPayout := 20.0
-	payoutError := errors.New("payout service unavailable")
-
-	mockEventRepo.On("FindByID", ctx, eventID).Return(activeEvent, nil).Once()
-	mockBetRepo.On("FindPendingByEventID", ctx, eventID).Return(pendingBets, nil).Once()
-	mockBetRepo.On("UpdateStatusAndPayout", ctx, betIDWin, data.StatusWon, expectedPayout).Return(nil).Once()
-	mockPayoutClient.On("NotifyPayout", ctx, mock.Anything).Return(payoutError).Once()
-	mockBetRepo.On("UpdateStatus", ctx, betIDWin, data.StatusFailed).Return(nil).Once()
-	mockEventRepo.On("UpdateResultAndStatus", ctx, eventID, actualResult).Return(nil).Once()
 
-	err := uc.FinalizeEvent(ctx, eventID, actualResult)
+	mockEventRepo.On("FindByID", ctx, eventID).Return(&data.Event{ID: eventID, IsActive: true}, nil).Once()
+	mockBetRepo.On("FindPendingByEventID", ctx, eventID).Return([]data.Bet{bet}, nil).Once()
+	// Another finalization settled the bet between reading and settling it.
+	mockBetRepo.On("SettleWithPayout", ctx, bet.ID, data.StatusPending, data.StatusWon, 20.0, mock.Anything).Return(false, nil).Once()
+	mockEventRepo.On("UpdateResultAndStatus", ctx, eventID, data.HomeWin).Return(nil).Once()
 
-	require.Error(t, err)
-	assert.Contains(t, err.Error(), eventuc.ErrPayoutNotificationFailed.Error())
-	assert.Contains(t, err.Error(), payoutError.Error())
+	err := uc.FinalizeEvent(ctx, eventID, data.HomeWin)
 
-	mockEventRepo.AssertExpectations(t)
-	mockBetRepo.AssertExpectations(t)
-	mockPayoutClient.AssertExpectations(t)
+	require.NoError(t, err)
 }
 
 func TestEventUseCase_FinalizeEvent_AlreadyFinalized(t *testing.T) {
 	mockEventRepo := repomocks.NewEventRepository(t)
 	mockBetRepo := repomocks.NewBetRepository(t)
-	mockPayoutClient := payoutmocks.NewPayoutClient(t)
 	logger := zap.NewNop()
 
-	uc := eventuc.NewUseCase(mockEventRepo, mockBetRepo, mockPayoutClient, logger)
+	uc := eventuc.NewUseCase(mockEventRepo, mockBetRepo, logger)
 
 	ctx := context.Background()
 	eventID := uuid.NewString()
@@ -181,7 +167,6 @@ func TestEventUseCase_FinalizeEvent_AlreadyFinalized(t *testing.T) {
 
 	mockEventRepo.AssertExpectations(t)
 	mockBetRepo.AssertNotCalled(t, "FindPendingByEventID", mock.Anything, mock.Anything)
-	mockPayoutClient.AssertNotCalled(t, "NotifyPayout", mock.Anything, mock.Anything)
 }
 
 // --- New Test Cases ---
@@ -189,10 +174,9 @@ func TestEventUseCase_FinalizeEvent_AlreadyFinalized(t *testing.T) {
 func TestEventUseCase_FinalizeEvent_ErrorEventRepoFindByID(t *testing.T) {
 	mockEventRepo := repomocks.NewEventRepository(t)
 	mockBetRepo := repomocks.NewBetRepository(t)
-	mockPayoutClient := payoutmocks.NewPayoutClient(t)
 	logger := zap.NewNop()
 
-	uc := eventuc.NewUseCase(mockEventRepo, mockBetRepo, mockPayoutClient, logger)
+	uc := eventuc.NewUseCase(mockEventRepo, mockBetRepo, logger)
 
 	ctx := context.Background()
 	eventID := uuid.NewString()
@@ -208,17 +192,15 @@ func TestEventUseCase_FinalizeEvent_ErrorEventRepoFindByID(t *testing.T) {
 
 	mockEventRepo.AssertExpectations(t)
 	mockBetRepo.AssertNotCalled(t, "FindPendingByEventID", mock.Anything, mock.Anything)
-	mockPayoutClient.AssertNotCalled(t, "NotifyPayout", mock.Anything, mock.Anything)
 	mockEventRepo.AssertNotCalled(t, "UpdateResultAndStatus", mock.Anything, mock.Anything, mock.Anything)
 }
 
 func TestEventUseCase_FinalizeEvent_ErrorBetRepoFindPending(t *testing.T) {
 	mockEventRepo := repomocks.NewEventRepository(t)
 	mockBetRepo := repomocks.NewBetRepository(t)
-	mockPayoutClient := payoutmocks.NewPayoutClient(t)
 	logger := zap.NewNop()
 
-	uc := eventuc.NewUseCase(mockEventRepo, mockBetRepo, mockPayoutClient, logger)
+	uc := eventuc.NewUseCase(mockEventRepo, mockBetRepo, logger)
 
 	ctx := context.Background()
 	eventID := uuid.NewString()
@@ -236,18 +218,16 @@ func TestEventUseCase_FinalizeEvent_ErrorBetRepoFindPending(t *testing.T) {
 
 	mockEventRepo.AssertExpectations(t)
 	mockBetRepo.AssertExpectations(t)
-	mockPayoutClient.AssertNotCalled(t, "NotifyPayout", mock.Anything, mock.Anything)
-	mockBetRepo.AssertNotCalled(t, "UpdateStatusAndPayout", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockBetRepo.AssertNotCalled(t, "SettleWithPayout", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 	mockEventRepo.AssertNotCalled(t, "UpdateResultAndStatus", mock.Anything, mock.Anything, mock.Anything)
 }
 
 func TestEventUseCase_FinalizeEvent_ErrorEventRepoUpdateStatus(t *testing.T) {
 	mockEventRepo := repomocks.NewEventRepository(t)
 	mockBetRepo := repomocks.NewBetRepository(t)
-	mockPayoutClient := payoutmocks.NewPayoutClient(t)
 	logger := zap.NewNop()
 
-	uc := eventuc.NewUseCase(mockEventRepo, mockBetRepo, mockPayoutClient, logger)
+	uc := eventuc.NewUseCase(mockEventRepo, mockBetRepo, logger)
 
 	ctx := context.Background()
 	eventID := uuid.NewString()
@@ -268,16 +248,14 @@ func TestEventUseCase_FinalizeEvent_ErrorEventRepoUpdateStatus(t *testing.T) {
 
 	mockEventRepo.AssertExpectations(t)
 	mockBetRepo.AssertExpectations(t)
-	mockPayoutClient.AssertNotCalled(t, "NotifyPayout", mock.Anything, mock.Anything)
 }
 
-func TestEventUseCase_FinalizeEvent_ErrorBetRepoUpdateStatusAndPayout(t *testing.T) {
+func TestEventUseCase_FinalizeEvent_ErrorBetRepoSettle(t *testing.T) {
 	mockEventRepo := repomocks.NewEventRepository(t)
 	mockBetRepo := repomocks.NewBetRepository(t)
-	mockPayoutClient := payoutmocks.NewPayoutClient(t)
 	logger := zap.NewNop()
 
-	uc := eventuc.NewUseCase(mockEventRepo, mockBetRepo, mockPayoutClient, logger)
+	uc := eventuc.NewUseCase(mockEventRepo, mockBetRepo, logger)
 
 	ctx := context.Background()
 	eventID := uuid.NewString()
@@ -297,7 +275,7 @@ func TestEventUseCase_FinalizeEvent_ErrorBetRepoUpdateStatusAndPayout(t *testing
 
 	mockEventRepo.On("FindByID", ctx, eventID).Return(activeEvent, nil).Once()
 	mockBetRepo.On("FindPendingByEventID", ctx, eventID).Return(pendingBets, nil).Once()
-	mockBetRepo.On("UpdateStatusAndPayout", ctx, betIDLoss, data.StatusLost, 0.0).Return(updateBetError).Once()
+	mockBetRepo.On("SettleWithPayout", ctx, betIDLoss, data.StatusPending, data.StatusLost, 0.0, noPayout).Return(false, updateBetError).Once()
 	mockEventRepo.On("UpdateResultAndStatus", ctx, eventID, actualResult).Return(nil).Once() // Event status update should still happen
 
 	err := uc.FinalizeEvent(ctx, eventID, actualResult)
@@ -308,128 +286,147 @@ func TestEventUseCase_FinalizeEvent_ErrorBetRepoUpdateStatusAndPayout(t *testing
 
 	mockEventRepo.AssertExpectations(t)
 	mockBetRepo.AssertExpectations(t)
-	mockPayoutClient.AssertNotCalled(t, "NotifyPayout", mock.Anything, mock.Anything)
 }
 
-func TestEventUseCase_FinalizeEvent_ErrorBetRepoUpdateStatusToPaid(t *testing.T) {
+func TestEventUseCase_FinalizeEvent_NoPendingBets(t *testing.T) {
 	mockEventRepo := repomocks.NewEventRepository(t)
 	mockBetRepo := repomocks.NewBetRepository(t)
-	mockPayoutClient := payoutmocks.NewPayoutClient(t)
 	logger := zap.NewNop()
 
-	uc := eventuc.NewUseCase(mockEventRepo, mockBetRepo, mockPayoutClient, logger)
+	uc := eventuc.NewUseCase(mockEventRepo, mockBetRepo, logger)
 
 	ctx := context.Background()
 	eventID := uuid.NewString()
-	userID := uuid.NewString()
-	betIDWin := uuid.NewString()
-	actualResult := data.HomeWin
+	actualResult := data.Draw
 	activeEvent := &data.Event{ID: eventID, IsActive: true, EventResult: nil}
-	winningBet := data.Bet{
-		ID:                    betIDWin,
-		UserID:                userID,
-		EventID:               eventID,
-		Amount:                10.0,
-		PredictedOutcome:      data.HomeWin,
-		RecordedHomeWinChance: 2.0,
-		Status:                data.StatusPending,
-	}
-	pendingBets := []data.Bet{winningBet}
-	expectedPayout := 20.0
-	updateStatusError := errors.New("failed to update status to paid")
+	pendingBets := []data.Bet{} // Empty slice
 
 	mockEventRepo.On("FindByID", ctx, eventID).Return(activeEvent, nil).Once()
 	mockBetRepo.On("FindPendingByEventID", ctx, eventID).Return(pendingBets, nil).Once()
-	mockBetRepo.On("UpdateStatusAndPayout", ctx, betIDWin, data.StatusWon, expectedPayout).Return(nil).Once()
-	mockPayoutClient.On("NotifyPayout", ctx, data.PayoutNotification{UserID: userID, Amount: expectedPayout}).Return(nil).Once()
-	mockBetRepo.On("UpdateStatus", ctx, betIDWin, data.StatusPaid).Return(updateStatusError).Once() // Error here
-	mockEventRepo.On("UpdateResultAndStatus", ctx, eventID, actualResult).Return(nil).Once()        // Event status update should still happen
+	mockEventRepo.On("UpdateResultAndStatus", ctx, eventID, actualResult).Return(nil).Once()
 
 	err := uc.FinalizeEvent(ctx, eventID, actualResult)
 
-	require.Error(t, err)
-	assert.Contains(t, err.Error(), eventuc.ErrBetUpdateFailed.Error())
-	assert.Contains(t, err.Error(), updateStatusError.Error())
-
+	require.NoError(t, err)
 	mockEventRepo.AssertExpectations(t)
 	mockBetRepo.AssertExpectations(t)
-	mockPayoutClient.AssertExpectations(t)
+	mockBetRepo.AssertNotCalled(t, "SettleWithPayout", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 }
 
-func TestEventUseCase_FinalizeEvent_ErrorBetRepoUpdateStatusToFailed(t *testing.T) {
+func TestEventUseCase_VoidEvent_RefundsPendingBets(t *testing.T) {
 	mockEventRepo := repomocks.NewEventRepository(t)
 	mockBetRepo := repomocks.NewBetRepository(t)
-	mockPayoutClient := payoutmocks.NewPayoutClient(t)
 	logger := zap.NewNop()
 
-	uc := eventuc.NewUseCase(mockEventRepo, mockBetRepo, mockPayoutClient, logger)
+	uc := eventuc.NewUseCase(mockEventRepo, mockBetRepo, logger)
 
 	ctx := context.Background()
 	eventID := uuid.NewString()
-	betIDWin := uuid.NewString()
-	actualResult := data.HomeWin
-	activeEvent := &data.Event{ID: eventID, IsActive: true, EventResult: nil}
-	winningBet := data.Bet{
-		ID:                    betIDWin,
-		UserID:                uuid.NewString(),
-		EventID:               eventID,
-		Amount:                10.0,
-		PredictedOutcome:      data.HomeWin,
-		RecordedHomeWinChance: 2.0,
-		Status:                data.StatusPending,
-	}
-	pendingBets := []data.Bet{winningBet}
-	expectedPayout := 20.0
-	payoutError := errors.New("payout service unavailable")
-	updateStatusError := errors.New("failed to update status to failed") // Error here
+	userID := uuid.NewString()
+	betID := uuid.NewString()
 
-	mockEventRepo.On("FindByID", ctx, eventID).Return(activeEvent, nil).Once()
+	postponedEvent := &data.Event{ID: eventID, IsActive: true, Status: data.EventStatusPostponed}
+	pendingBets := []data.Bet{{ID: betID, UserID: userID, EventID: eventID, Amount: 15.0, PredictedOutcome: data.Draw, Status: data.StatusPending}}
+
+	mockEventRepo.On("FindByID", ctx, eventID).Return(postponedEvent, nil).Once()
 	mockBetRepo.On("FindPendingByEventID", ctx, eventID).Return(pendingBets, nil).Once()
-	mockBetRepo.On("UpdateStatusAndPayout", ctx, betIDWin, data.StatusWon, expectedPayout).Return(nil).Once()
-	mockPayoutClient.On("NotifyPayout", ctx, mock.Anything).Return(payoutError).Once()
-	mockBetRepo.On("UpdateStatus", ctx, betIDWin, data.StatusFailed).Return(updateStatusError).Once() // Error here
-	mockEventRepo.On("UpdateResultAndStatus", ctx, eventID, actualResult).Return(nil).Once()          // Event status update should still happen
+	mockBetRepo.On("SettleWithPayout", ctx, betID, data.StatusPending, data.StatusVoided, 15.0, payoutIntent(betID, userID, data.PayoutRefund, 15.0)).Return(true, nil).Once()
+	mockEventRepo.On("MarkCanceled", ctx, eventID).Return(nil).Once()
 
-	err := uc.FinalizeEvent(ctx, eventID, actualResult)
+	err := uc.VoidEvent(ctx, eventID, "postponed")
+
+	require.NoError(t, err)
+}
+
+func TestEventUseCase_VoidEvent_BetUpdateFailed(t *testing.T) {
+	mockEventRepo := repomocks.NewEventRepository(t)
+	mockBetRepo := repomocks.NewBetRepository(t)
+	logger := zap.NewNop()
+
+	uc := eventuc.NewUseCase(mockEventRepo, mockBetRepo, logger)
+
+	ctx := context.Background()
+	eventID := uuid.NewString()
+	betID := uuid.NewString()
+
+	postponedEvent := &data.Event{ID: eventID, IsActive: true, Status: data.EventStatusPostponed}
+	pendingBets := []data.Bet{{ID: betID, UserID: uuid.NewString(), EventID: eventID, Amount: 7.5, Status: data.StatusPending}}
+
+	mockEventRepo.On("FindByID", ctx, eventID).Return(postponedEvent, nil).Once()
+	mockBetRepo.On("FindPendingByEventID", ctx, eventID).Return(pendingBets, nil).Once()
+	mockBetRepo.On("SettleWithPayout", ctx, betID, data.StatusPending, data.StatusVoided, 7.5, mock.Anything).Return(false, errors.New("database is locked")).Once()
+	mockEventRepo.On("MarkCanceled", ctx, eventID).Return(nil).Once()
+
+	err := uc.VoidEvent(ctx, eventID, "postponed")
 
 	require.Error(t, err)
-	// Should contain both the payout error and the status update error
-	assert.Contains(t, err.Error(), eventuc.ErrPayoutNotificationFailed.Error())
-	assert.Contains(t, err.Error(), eventuc.ErrBetUpdateFailed.Error())
-	assert.Contains(t, err.Error(), payoutError.Error())
-	assert.Contains(t, err.Error(), updateStatusError.Error())
+	require.True(t, errors.Is(err, eventuc.ErrBetUpdateFailed), "Expected error ErrBetUpdateFailed")
+}
 
-	mockEventRepo.AssertExpectations(t)
-	mockBetRepo.AssertExpectations(t)
-	mockPayoutClient.AssertExpectations(t)
+func TestEventUseCase_VoidEvent_AlreadyResulted(t *testing.T) {
+	mockEventRepo := repomocks.NewEventRepository(t)
+	mockBetRepo := repomocks.NewBetRepository(t)
+	logger := zap.NewNop()
+
+	uc := eventuc.NewUseCase(mockEventRepo, mockBetRepo, logger)
+
+	ctx := context.Background()
+	eventID := uuid.NewString()
+	result := data.HomeWin
+
+	mockEventRepo.On("FindByID", ctx, eventID).Return(&data.Event{ID: eventID, EventResult: &result}, nil).Once()
+
+	err := uc.VoidEvent(ctx, eventID, "postponed")
+
+	require.True(t, errors.Is(err, eventuc.ErrEventAlreadyFinalized), "Expected error ErrEventAlreadyFinalized")
+	mockBetRepo.AssertNotCalled(t, "FindPendingByEventID", mock.Anything, mock.Anything)
 }
 
-func TestEventUseCase_FinalizeEvent_NoPendingBets(t *testing.T) {
+func TestEventUseCase_FinalizeEvent_SkipsBetsFlaggedForVoiding(t *testing.T) {
 	mockEventRepo := repomocks.NewEventRepository(t)
 	mockBetRepo := repomocks.NewBetRepository(t)
-	mockPayoutClient := payoutmocks.NewPayoutClient(t)
 	logger := zap.NewNop()
 
-	uc := eventuc.NewUseCase(mockEventRepo, mockBetRepo, mockPayoutClient, logger)
+	uc := eventuc.NewUseCase(mockEventRepo, mockBetRepo, logger)
 
 	ctx := context.Background()
 	eventID := uuid.NewString()
-	actualResult := data.Draw
-	activeEvent := &data.Event{ID: eventID, IsActive: true, EventResult: nil}
-	pendingBets := []data.Bet{} // Empty slice
+	flaggedAt := time.Now().UTC()
+	reason := "placed after corrected start time"
 
-	mockEventRepo.On("FindByID", ctx, eventID).Return(activeEvent, nil).Once()
-	mockBetRepo.On("FindPendingByEventID", ctx, eventID).Return(pendingBets, nil).Once()
-	mockEventRepo.On("UpdateResultAndStatus", ctx, eventID, actualResult).Return(nil).Once()
+	flaggedBet := data.Bet{ID: uuid.NewString(), UserID: uuid.NewString(), EventID: eventID, Amount: 10.0, PredictedOutcome: data.HomeWin, RecordedHomeWinChance: 2.0, Status: data.StatusPending, VoidFlaggedAt: &flaggedAt, VoidFlagReason: &reason}
+	regularBet := data.Bet{ID: uuid.NewString(), UserID: uuid.NewString(), EventID: eventID, Amount: 5.0, PredictedOutcome: data.AwayWin, Status: data.StatusPending}
 
-	err := uc.FinalizeEvent(ctx, eventID, actualResult)
+	mockEventRepo.On("FindByID", ctx, eventID).Return(&data.Event{ID: eventID, IsActive: true}, nil).Once()
+	mockBetRepo.On("FindPendingByEventID", ctx, eventID).Return([]data.Bet{flaggedBet, regularBet}, nil).Once()
+	mockBetRepo.On("SettleWithPayout", ctx, regularBet.ID, data.StatusPending, data.StatusLost, 0.0, noPayout).Return(true, nil).Once()
+	mockEventRepo.On("UpdateResultAndStatus", ctx, eventID, data.HomeWin).Return(nil).Once()
+
+	err := uc.FinalizeEvent(ctx, eventID, data.HomeWin)
 
 	require.NoError(t, err)
-	mockEventRepo.AssertExpectations(t)
-	mockBetRepo.AssertExpectations(t)
-	mockPayoutClient.AssertNotCalled(t, "NotifyPayout", mock.Anything, mock.Anything)
-	mockBetRepo.AssertNotCalled(t, "UpdateStatusAndPayout", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
-	mockBetRepo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything)
+	mockBetRepo.AssertNotCalled(t, "SettleWithPayout", ctx, flaggedBet.ID, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestEventUseCase_VoidBets_SkipsSettledBets(t *testing.T) {
+	mockEventRepo := repomocks.NewEventRepository(t)
+	mockBetRepo := repomocks.NewBetRepository(t)
+	logger := zap.NewNop()
+
+	uc := eventuc.NewUseCase(mockEventRepo, mockBetRepo, logger)
+
+	ctx := context.Background()
+	eventID := uuid.NewString()
+	userID := uuid.NewString()
+	lateBet := data.Bet{ID: uuid.NewString(), UserID: userID, EventID: eventID, Amount: 20.0, Status: data.StatusPending}
+	settledBet := data.Bet{ID: uuid.NewString(), UserID: uuid.NewString(), EventID: eventID, Amount: 5.0, Status: data.StatusLost}
+
+	mockBetRepo.On("SettleWithPayout", ctx, lateBet.ID, data.StatusPending, data.StatusVoided, 20.0, payoutIntent(lateBet.ID, userID, data.PayoutRefund, 20.0)).Return(true, nil).Once()
+
+	err := uc.VoidBets(ctx, eventID, []data.Bet{lateBet, settledBet}, "placed after corrected start time")
+
+	require.NoError(t, err)
+	mockEventRepo.AssertNotCalled(t, "MarkCanceled", mock.Anything, mock.Anything)
 }
 
 // OMG THIS IS SO LONG
@@ -0,0 +1,130 @@
+package payout
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Arlan-Z/def-betting-api/internal/data"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrPayoutNotFound  = errors.New("payout not found")
+	ErrPayoutNotFailed = errors.New("payout has not failed")
+)
+
+type OutboxRepository interface {
+	FindByID(ctx context.Context, intentID string) (*data.PayoutIntent, error)
+	FindStuck(ctx context.Context) ([]data.PayoutIntent, error)
+	CountStuck(ctx context.Context) (int, error)
+	FindAttempts(ctx context.Context, intentID string) ([]data.PayoutAttempt, error)
+	Requeue(ctx context.Context, intentID string, now time.Time) (bool, error)
+	Resolve(ctx context.Context, intentID string, note string, resolvedAt time.Time) (bool, error)
+}
+
+type UseCase struct {
+	outboxRepo OutboxRepository
+	logger     *zap.Logger
+}
+
+func NewUseCase(or OutboxRepository, logger *zap.Logger) *UseCase {
+	return &UseCase{
+		outboxRepo: or,
+		logger:     logger.Named("PayoutUseCase"),
+	}
+}
+
+// GetStuckPayouts lists payouts that failed for good or are still being retried after a failure.
+func (uc *UseCase) GetStuckPayouts(ctx context.Context) ([]data.PayoutIntent, error) {
+	payouts, err := uc.outboxRepo.FindStuck(ctx)
+	if err != nil {
+		uc.logger.Error("Error getting stuck payouts from repository", zap.Error(err))
+		return nil, fmt.Errorf("failed to get list of stuck payouts")
+	}
+	return payouts, nil
+}
+
+// CountStuckPayouts returns how many payouts GetStuckPayouts would list.
+func (uc *UseCase) CountStuckPayouts(ctx context.Context) (int, error) {
+	count, err := uc.outboxRepo.CountStuck(ctx)
+	if err != nil {
+		uc.logger.Error("Error counting stuck payouts in repository", zap.Error(err))
+		return 0, fmt.Errorf("failed to count stuck payouts")
+	}
+	return count, nil
+}
+
+// GetPayout returns the payout together with its delivery attempts.
+func (uc *UseCase) GetPayout(ctx context.Context, payoutID string) (*data.PayoutIntent, []data.PayoutAttempt, error) {
+	log := uc.logger.With(zap.String("payoutId", payoutID))
+
+	payout, err := uc.findPayout(ctx, log, payoutID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	attempts, err := uc.outboxRepo.FindAttempts(ctx, payoutID)
+	if err != nil {
+		log.Error("Error getting payout attempts from repository", zap.Error(err))
+		return nil, nil, fmt.Errorf("internal error searching for payout attempts")
+	}
+	return payout, attempts, nil
+}
+
+// Requeue hands a failed payout back to the dispatcher with a fresh attempt budget.
+func (uc *UseCase) Requeue(ctx context.Context, payoutID string) (*data.PayoutIntent, error) {
+	log := uc.logger.With(zap.String("payoutId", payoutID), zap.String("operation", "Requeue"))
+	log.Info("Use Case: Requeueing stuck payout")
+
+	if _, err := uc.findPayout(ctx, log, payoutID); err != nil {
+		return nil, err
+	}
+
+	requeued, err := uc.outboxRepo.Requeue(ctx, payoutID, time.Now().UTC())
+	if err != nil {
+		log.Error("Error requeueing payout", zap.Error(err))
+		return nil, fmt.Errorf("internal error requeueing payout")
+	}
+	if !requeued {
+		return nil, ErrPayoutNotFailed
+	}
+
+	log.Info("Stuck payout requeued")
+	return uc.findPayout(ctx, log, payoutID)
+}
+
+// Resolve closes a failed payout that was settled outside the service. The note records how.
+func (uc *UseCase) Resolve(ctx context.Context, payoutID string, note string) (*data.PayoutIntent, error) {
+	log := uc.logger.With(zap.String("payoutId", payoutID), zap.String("operation", "Resolve"))
+	log.Info("Use Case: Resolving stuck payout")
+
+	if _, err := uc.findPayout(ctx, log, payoutID); err != nil {
+		return nil, err
+	}
+
+	resolved, err := uc.outboxRepo.Resolve(ctx, payoutID, note, time.Now().UTC())
+	if err != nil {
+		log.Error("Error resolving payout", zap.Error(err))
+		return nil, fmt.Errorf("internal error resolving payout")
+	}
+	if !resolved {
+		return nil, ErrPayoutNotFailed
+	}
+
+	log.Info("Stuck payout resolved by hand")
+	return uc.findPayout(ctx, log, payoutID)
+}
+
+func (uc *UseCase) findPayout(ctx context.Context, log *zap.Logger, payoutID string) (*data.PayoutIntent, error) {
+	payout, err := uc.outboxRepo.FindByID(ctx, payoutID)
+	if err != nil {
+		log.Error("Error retrieving payout", zap.Error(err))
+		return nil, fmt.Errorf("internal error searching for payout")
+	}
+	if payout == nil {
+		return nil, ErrPayoutNotFound
+	}
+	return payout, nil
+}
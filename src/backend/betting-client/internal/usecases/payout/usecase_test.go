@@ -0,0 +1,138 @@
+package payout_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Arlan-Z/def-betting-api/internal/data"
+	repomocks "github.com/Arlan-Z/def-betting-api/internal/repositories/mocks"
+	payoutuc "github.com/Arlan-Z/def-betting-api/internal/usecases/payout"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func failedPayout() *data.PayoutIntent {
+	lastError := "payout service unavailable"
+	return &data.PayoutIntent{
+		ID:        "payout-1",
+		BetID:     "bet-1",
+		UserID:    "user-1",
+		Amount:    25,
+		Kind:      data.PayoutWin,
+		Status:    data.OutboxFailed,
+		Attempts:  8,
+		LastError: &lastError,
+	}
+}
+
+func TestPayoutUseCase_GetPayout_WithHistory(t *testing.T) {
+	mockRepo := repomocks.NewOutboxRepository(t)
+	uc := payoutuc.NewUseCase(mockRepo, zap.NewNop())
+
+	ctx := context.Background()
+	attempts := []data.PayoutAttempt{{PayoutID: "payout-1", Attempt: 1}, {PayoutID: "payout-1", Attempt: 2}}
+	mockRepo.On("FindByID", ctx, "payout-1").Return(failedPayout(), nil).Once()
+	mockRepo.On("FindAttempts", ctx, "payout-1").Return(attempts, nil).Once()
+
+	payout, history, err := uc.GetPayout(ctx, "payout-1")
+
+	require.NoError(t, err)
+	assert.Equal(t, "payout-1", payout.ID)
+	assert.Len(t, history, 2)
+}
+
+func TestPayoutUseCase_CountStuckPayouts(t *testing.T) {
+	mockRepo := repomocks.NewOutboxRepository(t)
+	uc := payoutuc.NewUseCase(mockRepo, zap.NewNop())
+
+	ctx := context.Background()
+	mockRepo.On("CountStuck", ctx).Return(3, nil).Once()
+
+	count, err := uc.CountStuckPayouts(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+}
+
+func TestPayoutUseCase_GetPayout_NotFound(t *testing.T) {
+	mockRepo := repomocks.NewOutboxRepository(t)
+	uc := payoutuc.NewUseCase(mockRepo, zap.NewNop())
+
+	ctx := context.Background()
+	mockRepo.On("FindByID", ctx, "missing").Return(nil, nil).Once()
+
+	_, _, err := uc.GetPayout(ctx, "missing")
+
+	assert.ErrorIs(t, err, payoutuc.ErrPayoutNotFound)
+}
+
+func TestPayoutUseCase_Requeue(t *testing.T) {
+	mockRepo := repomocks.NewOutboxRepository(t)
+	uc := payoutuc.NewUseCase(mockRepo, zap.NewNop())
+
+	ctx := context.Background()
+	requeued := failedPayout()
+	requeued.Status = data.OutboxPending
+	requeued.Attempts = 0
+	mockRepo.On("FindByID", ctx, "payout-1").Return(failedPayout(), nil).Once()
+	mockRepo.On("Requeue", ctx, "payout-1", mock.AnythingOfType("time.Time")).Return(true, nil).Once()
+	mockRepo.On("FindByID", ctx, "payout-1").Return(requeued, nil).Once()
+
+	payout, err := uc.Requeue(ctx, "payout-1")
+
+	require.NoError(t, err)
+	assert.Equal(t, data.OutboxPending, payout.Status)
+	assert.Zero(t, payout.Attempts)
+}
+
+func TestPayoutUseCase_Requeue_NotStuck(t *testing.T) {
+	mockRepo := repomocks.NewOutboxRepository(t)
+	uc := payoutuc.NewUseCase(mockRepo, zap.NewNop())
+
+	ctx := context.Background()
+	delivered := failedPayout()
+	delivered.Status = data.OutboxDelivered
+	mockRepo.On("FindByID", ctx, "payout-1").Return(delivered, nil).Once()
+	mockRepo.On("Requeue", ctx, "payout-1", mock.AnythingOfType("time.Time")).Return(false, nil).Once()
+
+	_, err := uc.Requeue(ctx, "payout-1")
+
+	assert.ErrorIs(t, err, payoutuc.ErrPayoutNotFailed)
+}
+
+func TestPayoutUseCase_Resolve(t *testing.T) {
+	mockRepo := repomocks.NewOutboxRepository(t)
+	uc := payoutuc.NewUseCase(mockRepo, zap.NewNop())
+
+	ctx := context.Background()
+	note := "paid by bank transfer"
+	resolved := failedPayout()
+	resolved.Status = data.OutboxResolved
+	resolved.ResolutionNote = &note
+	mockRepo.On("FindByID", ctx, "payout-1").Return(failedPayout(), nil).Once()
+	mockRepo.On("Resolve", ctx, "payout-1", note, mock.AnythingOfType("time.Time")).Return(true, nil).Once()
+	mockRepo.On("FindByID", ctx, "payout-1").Return(resolved, nil).Once()
+
+	payout, err := uc.Resolve(ctx, "payout-1", note)
+
+	require.NoError(t, err)
+	assert.Equal(t, data.OutboxResolved, payout.Status)
+	assert.Equal(t, note, *payout.ResolutionNote)
+}
+
+func TestPayoutUseCase_Resolve_RepositoryError(t *testing.T) {
+	mockRepo := repomocks.NewOutboxRepository(t)
+	uc := payoutuc.NewUseCase(mockRepo, zap.NewNop())
+
+	ctx := context.Background()
+	mockRepo.On("FindByID", ctx, "payout-1").Return(failedPayout(), nil).Once()
+	mockRepo.On("Resolve", ctx, "payout-1", "note", mock.AnythingOfType("time.Time")).Return(false, errors.New("db down")).Once()
+
+	_, err := uc.Resolve(ctx, "payout-1", "note")
+
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, payoutuc.ErrPayoutNotFailed)
+}
@@ -0,0 +1,161 @@
+package quarantine
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Arlan-Z/def-betting-api/internal/data"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrEntryNotFound        = errors.New("quarantined event not found")
+	ErrEntryAlreadyResolved = errors.New("quarantined event already resolved")
+	ErrInvalidStatus        = errors.New("invalid quarantine status")
+	ErrInvalidPayload       = errors.New("invalid event payload")
+	ErrReplayFailed         = errors.New("replay of quarantined event failed")
+)
+
+type QuarantineRepository interface {
+	FindByID(ctx context.Context, entryID string) (*data.QuarantinedEvent, error)
+	FindByStatus(ctx context.Context, status data.QuarantineStatus) ([]data.QuarantinedEvent, error)
+	Resolve(ctx context.Context, entryID string, status data.QuarantineStatus, payload string, note string, resolvedAt time.Time) error
+	CountByErrorCode(ctx context.Context) ([]data.QuarantineStat, error)
+}
+
+type EventReplayer interface {
+	Replay(ctx context.Context, providerName string, extEvent data.ExternalEventDTO) (*data.SyncRun, error)
+}
+
+type UseCase struct {
+	quarantineRepo QuarantineRepository
+	replayer       EventReplayer
+	logger         *zap.Logger
+}
+
+func NewUseCase(qr QuarantineRepository, replayer EventReplayer, logger *zap.Logger) *UseCase {
+	return &UseCase{
+		quarantineRepo: qr,
+		replayer:       replayer,
+		logger:         logger.Named("QuarantineUseCase"),
+	}
+}
+
+// GetEntries lists entries of the status, pending ones when the status is empty.
+func (uc *UseCase) GetEntries(ctx context.Context, status data.QuarantineStatus) ([]data.QuarantinedEvent, error) {
+	switch status {
+	case "":
+		status = data.QuarantinePending
+	case data.QuarantinePending, data.QuarantineReplayed, data.QuarantineDismissed:
+	default:
+		return nil, ErrInvalidStatus
+	}
+
+	entries, err := uc.quarantineRepo.FindByStatus(ctx, status)
+	if err != nil {
+		uc.logger.Error("Error getting quarantined events from repository", zap.Error(err))
+		return nil, fmt.Errorf("failed to get list of quarantined events")
+	}
+	return entries, nil
+}
+
+func (uc *UseCase) GetEntry(ctx context.Context, entryID string) (*data.QuarantinedEvent, error) {
+	entry, err := uc.quarantineRepo.FindByID(ctx, entryID)
+	if err != nil {
+		uc.logger.Error("Error getting quarantined event from repository", zap.String("entryId", entryID), zap.Error(err))
+		return nil, fmt.Errorf("internal error searching for quarantined event")
+	}
+	if entry == nil {
+		return nil, ErrEntryNotFound
+	}
+	return entry, nil
+}
+
+func (uc *UseCase) GetStats(ctx context.Context) ([]data.QuarantineStat, error) {
+	stats, err := uc.quarantineRepo.CountByErrorCode(ctx)
+	if err != nil {
+		uc.logger.Error("Error counting quarantined events", zap.Error(err))
+		return nil, fmt.Errorf("failed to count quarantined events")
+	}
+	return stats, nil
+}
+
+// Replay processes the entry's payload, or the edited payload when one is given, through
+// the sync pipeline. The entry stays pending if the event still cannot be processed.
+func (uc *UseCase) Replay(ctx context.Context, entryID string, payload json.RawMessage, note string) (*data.QuarantinedEvent, error) {
+	log := uc.logger.With(zap.String("entryId", entryID), zap.String("operation", "Replay"))
+	log.Info("Use Case: Replaying quarantined event")
+
+	entry, err := uc.pendingEntry(ctx, log, entryID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(payload) == 0 {
+		payload = json.RawMessage(entry.Payload)
+	}
+	var extEvent data.ExternalEventDTO
+	if err := json.Unmarshal(payload, &extEvent); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPayload, err)
+	}
+	if entry.ExternalID != "" && extEvent.APIEventID != entry.ExternalID {
+		// Another ID would map the payload to another event.
+		return nil, fmt.Errorf("%w: id must stay '%s'", ErrInvalidPayload, entry.ExternalID)
+	}
+
+	run, err := uc.replayer.Replay(ctx, entry.Provider, extEvent)
+	if err != nil {
+		log.Warn("Quarantined event still cannot be processed", zap.Error(err))
+		return nil, fmt.Errorf("%w: %w", ErrReplayFailed, err)
+	}
+	if run.Failed > 0 {
+		log.Warn("Replayed event could not be stored", zap.String("runId", run.ID))
+		return nil, fmt.Errorf("%w: see sync run %s", ErrReplayFailed, run.ID)
+	}
+
+	return uc.resolve(ctx, log, entry, data.QuarantineReplayed, string(payload), note)
+}
+
+func (uc *UseCase) Dismiss(ctx context.Context, entryID string, note string) (*data.QuarantinedEvent, error) {
+	log := uc.logger.With(zap.String("entryId", entryID), zap.String("operation", "Dismiss"))
+	log.Info("Use Case: Dismissing quarantined event")
+
+	entry, err := uc.pendingEntry(ctx, log, entryID)
+	if err != nil {
+		return nil, err
+	}
+	return uc.resolve(ctx, log, entry, data.QuarantineDismissed, entry.Payload, note)
+}
+
+func (uc *UseCase) pendingEntry(ctx context.Context, log *zap.Logger, entryID string) (*data.QuarantinedEvent, error) {
+	entry, err := uc.quarantineRepo.FindByID(ctx, entryID)
+	if err != nil {
+		log.Error("Error retrieving quarantined event", zap.Error(err))
+		return nil, fmt.Errorf("internal error searching for quarantined event")
+	}
+	if entry == nil {
+		return nil, ErrEntryNotFound
+	}
+	if entry.Status != data.QuarantinePending {
+		return nil, ErrEntryAlreadyResolved
+	}
+	return entry, nil
+}
+
+func (uc *UseCase) resolve(ctx context.Context, log *zap.Logger, entry *data.QuarantinedEvent, status data.QuarantineStatus, payload string, note string) (*data.QuarantinedEvent, error) {
+	resolvedAt := time.Now().UTC()
+	if err := uc.quarantineRepo.Resolve(ctx, entry.ID, status, payload, note, resolvedAt); err != nil {
+		log.Error("Error storing quarantine resolution", zap.Error(err))
+		return nil, fmt.Errorf("internal error resolving quarantined event")
+	}
+
+	entry.Status = status
+	entry.Payload = payload
+	entry.Note = note
+	entry.ResolvedAt = &resolvedAt
+	log.Info("Quarantined event resolved", zap.String("status", string(status)))
+	return entry, nil
+}
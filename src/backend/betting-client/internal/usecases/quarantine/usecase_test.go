@@ -0,0 +1,109 @@
+package quarantine_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/Arlan-Z/def-betting-api/internal/data"
+	repomocks "github.com/Arlan-Z/def-betting-api/internal/repositories/mocks"
+	quarantineuc "github.com/Arlan-Z/def-betting-api/internal/usecases/quarantine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type mockReplayer struct {
+	mock.Mock
+}
+
+func (m *mockReplayer) Replay(ctx context.Context, providerName string, extEvent data.ExternalEventDTO) (*data.SyncRun, error) {
+	args := m.Called(ctx, providerName, extEvent)
+	run, _ := args.Get(0).(*data.SyncRun)
+	return run, args.Error(1)
+}
+
+func pendingEntry() *data.QuarantinedEvent {
+	return &data.QuarantinedEvent{
+		ID:         "entry-1",
+		Provider:   "primary",
+		ExternalID: "ext-1",
+		Payload:    `{"id":"ext-1","eventStartDate":"tomorrow"}`,
+		ErrorCode:  data.MappingErrInvalidStartDate,
+		Status:     data.QuarantinePending,
+	}
+}
+
+func TestQuarantineUseCase_Replay_EditedPayload(t *testing.T) {
+	mockRepo := repomocks.NewQuarantineRepository(t)
+	replayer := &mockReplayer{}
+	uc := quarantineuc.NewUseCase(mockRepo, replayer, zap.NewNop())
+
+	ctx := context.Background()
+	edited := json.RawMessage(`{"id":"ext-1","eventStartDate":"2030-06-01T18:00:00Z"}`)
+	mockRepo.On("FindByID", ctx, "entry-1").Return(pendingEntry(), nil).Once()
+	replayer.On("Replay", ctx, "primary", mock.MatchedBy(func(ext data.ExternalEventDTO) bool {
+		return ext.APIEventID == "ext-1" && ext.StartsAt == "2030-06-01T18:00:00Z"
+	})).Return(&data.SyncRun{ID: "run-1", Received: 1, Upserted: 1}, nil).Once()
+	mockRepo.On("Resolve", ctx, "entry-1", data.QuarantineReplayed, string(edited), "fixed date", mock.AnythingOfType("time.Time")).Return(nil).Once()
+
+	entry, err := uc.Replay(ctx, "entry-1", edited, "fixed date")
+
+	require.NoError(t, err)
+	assert.Equal(t, data.QuarantineReplayed, entry.Status)
+	assert.NotNil(t, entry.ResolvedAt)
+	replayer.AssertExpectations(t)
+}
+
+func TestQuarantineUseCase_Replay_StillUnmappableStaysPending(t *testing.T) {
+	mockRepo := repomocks.NewQuarantineRepository(t)
+	replayer := &mockReplayer{}
+	uc := quarantineuc.NewUseCase(mockRepo, replayer, zap.NewNop())
+
+	ctx := context.Background()
+	mockRepo.On("FindByID", ctx, "entry-1").Return(pendingEntry(), nil).Once()
+	replayer.On("Replay", ctx, "primary", mock.Anything).
+		Return(nil, &data.MappingError{Code: data.MappingErrInvalidStartDate, Field: "eventStartDate", Value: "tomorrow"}).Once()
+
+	_, err := uc.Replay(ctx, "entry-1", nil, "")
+
+	require.ErrorIs(t, err, quarantineuc.ErrReplayFailed)
+	assert.Contains(t, err.Error(), "eventStartDate")
+	mockRepo.AssertNotCalled(t, "Resolve", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestQuarantineUseCase_Replay_RejectsChangedID(t *testing.T) {
+	mockRepo := repomocks.NewQuarantineRepository(t)
+	uc := quarantineuc.NewUseCase(mockRepo, &mockReplayer{}, zap.NewNop())
+
+	ctx := context.Background()
+	mockRepo.On("FindByID", ctx, "entry-1").Return(pendingEntry(), nil).Once()
+
+	_, err := uc.Replay(ctx, "entry-1", json.RawMessage(`{"id":"ext-2"}`), "")
+
+	require.ErrorIs(t, err, quarantineuc.ErrInvalidPayload)
+}
+
+func TestQuarantineUseCase_Dismiss_AlreadyResolved(t *testing.T) {
+	mockRepo := repomocks.NewQuarantineRepository(t)
+	uc := quarantineuc.NewUseCase(mockRepo, &mockReplayer{}, zap.NewNop())
+
+	ctx := context.Background()
+	entry := pendingEntry()
+	entry.Status = data.QuarantineDismissed
+	mockRepo.On("FindByID", ctx, "entry-1").Return(entry, nil).Once()
+
+	_, err := uc.Dismiss(ctx, "entry-1", "")
+
+	require.True(t, errors.Is(err, quarantineuc.ErrEntryAlreadyResolved))
+}
+
+func TestQuarantineUseCase_GetEntries_InvalidStatus(t *testing.T) {
+	uc := quarantineuc.NewUseCase(repomocks.NewQuarantineRepository(t), &mockReplayer{}, zap.NewNop())
+
+	_, err := uc.GetEntries(context.Background(), "Lost")
+
+	require.ErrorIs(t, err, quarantineuc.ErrInvalidStatus)
+}
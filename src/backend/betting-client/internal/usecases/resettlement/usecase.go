@@ -0,0 +1,208 @@
+package resettlement
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Arlan-Z/def-betting-api/internal/data"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrEventNotFound        = errors.New("event not found")
+	ErrEventNotSettled      = errors.New("event has no result to correct")
+	ErrSameResult           = errors.New("event already has this result")
+	ErrInvalidResult        = errors.New("invalid result for resettlement")
+	ErrResettlementNotFound = errors.New("resettlement not found")
+)
+
+type EventRepository interface {
+	FindByID(ctx context.Context, eventID string) (*data.Event, error)
+	ReplaceResult(ctx context.Context, eventID string, result data.Outcome) error
+}
+
+type BetRepository interface {
+	FindSettledByEventID(ctx context.Context, eventID string) ([]data.Bet, error)
+	SettleWithPayout(ctx context.Context, betID string, from data.BetStatus, status data.BetStatus, payout float64, intent *data.PayoutIntent) (bool, error)
+}
+
+type ResettlementRepository interface {
+	Create(ctx context.Context, resettlement *data.Resettlement) error
+	Finish(ctx context.Context, resettlement *data.Resettlement) error
+	FindByID(ctx context.Context, resettlementID string) (*data.Resettlement, error)
+	FindByEvent(ctx context.Context, eventID string) ([]data.Resettlement, error)
+}
+
+type UseCase struct {
+	eventRepo        EventRepository
+	betRepo          BetRepository
+	resettlementRepo ResettlementRepository
+	logger           *zap.Logger
+
+	// mu keeps admins and the syncer from resettling at the same time.
+	mu sync.Mutex
+}
+
+func NewUseCase(er EventRepository, br BetRepository, rr ResettlementRepository, logger *zap.Logger) *UseCase {
+	return &UseCase{
+		eventRepo:        er,
+		betRepo:          br,
+		resettlementRepo: rr,
+		logger:           logger.Named("ResettlementUseCase"),
+	}
+}
+
+// Resettle settles a finalized event again with a corrected result: bets that lose under
+// the new result have their payouts clawed back, bets that win are paid. Bet errors do not
+// stop the resettlement; they are recorded with the bet and in the final status.
+func (uc *UseCase) Resettle(ctx context.Context, eventID string, result data.Outcome, triggeredBy string, reason string) (*data.Resettlement, error) {
+	log := uc.logger.With(zap.String("eventId", eventID), zap.String("operation", "Resettle"), zap.String("result", string(result)), zap.String("triggeredBy", triggeredBy))
+	log.Info("Use Case: Resettling event")
+
+	if result != data.HomeWin && result != data.AwayWin && result != data.Draw {
+		return nil, ErrInvalidResult
+	}
+
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	event, err := uc.eventRepo.FindByID(ctx, eventID)
+	if err != nil {
+		log.Error("Error retrieving event for resettlement", zap.Error(err))
+		return nil, fmt.Errorf("internal error searching for event")
+	}
+	if event == nil {
+		return nil, ErrEventNotFound
+	}
+	if event.EventResult == nil {
+		return nil, ErrEventNotSettled
+	}
+	if *event.EventResult == result {
+		return nil, ErrSameResult
+	}
+
+	bets, err := uc.betRepo.FindSettledByEventID(ctx, eventID)
+	if err != nil {
+		log.Error("Error retrieving settled bets", zap.Error(err))
+		return nil, fmt.Errorf("internal error retrieving bets")
+	}
+
+	resettlement := &data.Resettlement{
+		ID:             uuid.NewString(),
+		EventID:        eventID,
+		PreviousResult: *event.EventResult,
+		NewResult:      result,
+		TriggeredBy:    triggeredBy,
+		Reason:         reason,
+		Status:         data.ResettlementRunning,
+		StartedAt:      time.Now().UTC(),
+		Bets:           make([]data.ResettlementBet, 0),
+	}
+	if err := uc.resettlementRepo.Create(ctx, resettlement); err != nil {
+		log.Error("Error recording start of resettlement", zap.Error(err))
+		return nil, fmt.Errorf("internal error recording resettlement")
+	}
+	log = log.With(zap.String("resettlementId", resettlement.ID))
+
+	// The new result goes in first, so bets settled later (e.g. after review) use it.
+	if err := uc.eventRepo.ReplaceResult(ctx, eventID, result); err != nil {
+		log.Error("Error replacing event result", zap.Error(err))
+		resettlement.Errors++
+		resettlement.Finish(time.Now().UTC())
+		if errFinish := uc.resettlementRepo.Finish(ctx, resettlement); errFinish != nil {
+			log.Error("Error recording failed resettlement", zap.Error(errFinish))
+		}
+		return nil, fmt.Errorf("internal error replacing event result")
+	}
+
+	for _, bet := range bets {
+		change := uc.resettleBet(ctx, bet, result)
+		if !change.Changed() {
+			resettlement.BetsUnchanged++
+			continue
+		}
+		resettlement.BetsChanged++
+		if change.Error != "" {
+			resettlement.Errors++
+		} else if change.Adjustment > 0 {
+			resettlement.PaidOut += change.Adjustment
+		} else {
+			resettlement.ClawedBack -= change.Adjustment
+		}
+		resettlement.Bets = append(resettlement.Bets, change)
+	}
+
+	resettlement.Finish(time.Now().UTC())
+	if err := uc.resettlementRepo.Finish(ctx, resettlement); err != nil {
+		// The bets are already adjusted, so the resettlement is reported even if its audit record is incomplete.
+		log.Error("CRITICAL: Error recording finished resettlement", zap.Error(err))
+	}
+
+	log.Info("Event resettled",
+		zap.String("status", string(resettlement.Status)),
+		zap.Int("betsChanged", resettlement.BetsChanged),
+		zap.Float64("paidOut", resettlement.PaidOut),
+		zap.Float64("clawedBack", resettlement.ClawedBack),
+		zap.Int("errors", resettlement.Errors),
+	)
+	return resettlement, nil
+}
+
+// resettleBet moves a bet to its state under the new result and queues the payout
+// difference for the payout service. A change with an error was not applied.
+func (uc *UseCase) resettleBet(ctx context.Context, bet data.Bet, result data.Outcome) data.ResettlementBet {
+	betLogger := uc.logger.With(zap.String("betId", bet.ID), zap.String("userId", bet.UserID))
+	change := data.PlanBetResettlement(bet, result)
+	if !change.Changed() {
+		return change
+	}
+
+	var intent *data.PayoutIntent
+	if change.Adjustment != 0 {
+		kind := data.PayoutAdjustment
+		if change.NewStatus == data.StatusWon {
+			kind = data.PayoutWin
+		}
+		intent = data.NewPayoutIntent(bet, kind, change.Adjustment, time.Now().UTC())
+	}
+
+	settled, err := uc.betRepo.SettleWithPayout(ctx, bet.ID, bet.Status, change.NewStatus, change.NewPayout, intent)
+	if err != nil {
+		betLogger.Error("Error updating bet during resettlement", zap.Error(err))
+		change.Error = err.Error()
+		return change
+	}
+	if !settled {
+		// The plan is based on the status read before, e.g. a win that was paid out since.
+		betLogger.Warn("Bet changed during resettlement, adjustment not applied", zap.String("status", string(bet.Status)))
+		change.Error = fmt.Sprintf("bet changed from status %s during resettlement", bet.Status)
+		return change
+	}
+	betLogger.Info("Bet resettled", zap.String("status", string(change.NewStatus)), zap.Float64("adjustment", change.Adjustment))
+	return change
+}
+
+func (uc *UseCase) GetResettlements(ctx context.Context, eventID string) ([]data.Resettlement, error) {
+	resettlements, err := uc.resettlementRepo.FindByEvent(ctx, eventID)
+	if err != nil {
+		uc.logger.Error("Error getting resettlements from repository", zap.String("eventId", eventID), zap.Error(err))
+		return nil, fmt.Errorf("failed to get list of resettlements")
+	}
+	return resettlements, nil
+}
+
+func (uc *UseCase) GetResettlement(ctx context.Context, resettlementID string) (*data.Resettlement, error) {
+	resettlement, err := uc.resettlementRepo.FindByID(ctx, resettlementID)
+	if err != nil {
+		uc.logger.Error("Error getting resettlement from repository", zap.String("resettlementId", resettlementID), zap.Error(err))
+		return nil, fmt.Errorf("failed to get resettlement")
+	}
+	if resettlement == nil {
+		return nil, ErrResettlementNotFound
+	}
+	return resettlement, nil
+}
@@ -0,0 +1,121 @@
+package resettlement_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Arlan-Z/def-betting-api/internal/data"
+	repomocks "github.com/Arlan-Z/def-betting-api/internal/repositories/mocks"
+	resettlementuc "github.com/Arlan-Z/def-betting-api/internal/usecases/resettlement"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func settledEvent(result data.Outcome) *data.Event {
+	return &data.Event{ID: "event-1", EventResult: &result, IsActive: false}
+}
+
+func settledBet(id string, predicted data.Outcome, status data.BetStatus, payout float64) data.Bet {
+	return data.Bet{
+		ID:                    id,
+		UserID:                "user-" + id,
+		EventID:               "event-1",
+		Amount:                10,
+		PredictedOutcome:      predicted,
+		RecordedHomeWinChance: 2,
+		RecordedAwayWinChance: 3,
+		RecordedDrawChance:    4,
+		Status:                status,
+		PayoutAmount:          payout,
+	}
+}
+
+// payoutIntent matches the outbox entry queued for the payout difference of a bet.
+func payoutIntent(userID string, kind data.PayoutKind, amount float64) interface{} {
+	return mock.MatchedBy(func(intent *data.PayoutIntent) bool {
+		return intent != nil && intent.UserID == userID && intent.Kind == kind && intent.Amount == amount
+	})
+}
+
+func TestResettlementUseCase_Resettle_ClawsBackAndPays(t *testing.T) {
+	mockEventRepo := repomocks.NewEventRepository(t)
+	mockBetRepo := repomocks.NewBetRepository(t)
+	mockResettlementRepo := repomocks.NewResettlementRepository(t)
+	uc := resettlementuc.NewUseCase(mockEventRepo, mockBetRepo, mockResettlementRepo, zap.NewNop())
+
+	ctx := context.Background()
+	bets := []data.Bet{
+		settledBet("won", data.HomeWin, data.StatusPaid, 20),
+		settledBet("lost", data.AwayWin, data.StatusLost, 0),
+		settledBet("still-lost", data.Draw, data.StatusLost, 0),
+	}
+	mockEventRepo.On("FindByID", ctx, "event-1").Return(settledEvent(data.HomeWin), nil).Once()
+	mockBetRepo.On("FindSettledByEventID", ctx, "event-1").Return(bets, nil).Once()
+	mockResettlementRepo.On("Create", ctx, mock.AnythingOfType("*data.Resettlement")).Return(nil).Once()
+	mockEventRepo.On("ReplaceResult", ctx, "event-1", data.AwayWin).Return(nil).Once()
+
+	mockBetRepo.On("SettleWithPayout", ctx, "won", data.StatusPaid, data.StatusLost, 0.0, payoutIntent("user-won", data.PayoutAdjustment, -20)).Return(true, nil).Once()
+	mockBetRepo.On("SettleWithPayout", ctx, "lost", data.StatusLost, data.StatusWon, 30.0, payoutIntent("user-lost", data.PayoutWin, 30)).Return(true, nil).Once()
+
+	mockResettlementRepo.On("Finish", ctx, mock.MatchedBy(func(r *data.Resettlement) bool {
+		return r.Status == data.ResettlementCompleted && len(r.Bets) == 2
+	})).Return(nil).Once()
+
+	resettlement, err := uc.Resettle(ctx, "event-1", data.AwayWin, data.ResettledByAdmin, "wrong result")
+
+	require.NoError(t, err)
+	assert.Equal(t, data.HomeWin, resettlement.PreviousResult)
+	assert.Equal(t, data.AwayWin, resettlement.NewResult)
+	assert.Equal(t, 2, resettlement.BetsChanged)
+	assert.Equal(t, 1, resettlement.BetsUnchanged)
+	assert.Equal(t, 30.0, resettlement.PaidOut)
+	assert.Equal(t, 20.0, resettlement.ClawedBack)
+	require.Len(t, resettlement.Bets, 2)
+	assert.Equal(t, data.StatusLost, resettlement.Bets[0].NewStatus)
+	assert.Equal(t, -20.0, resettlement.Bets[0].Adjustment)
+	assert.Equal(t, data.StatusWon, resettlement.Bets[1].NewStatus)
+}
+
+func TestResettlementUseCase_Resettle_BetUpdateFailureIsRecorded(t *testing.T) {
+	mockEventRepo := repomocks.NewEventRepository(t)
+	mockBetRepo := repomocks.NewBetRepository(t)
+	mockResettlementRepo := repomocks.NewResettlementRepository(t)
+	uc := resettlementuc.NewUseCase(mockEventRepo, mockBetRepo, mockResettlementRepo, zap.NewNop())
+
+	ctx := context.Background()
+	mockEventRepo.On("FindByID", ctx, "event-1").Return(settledEvent(data.HomeWin), nil).Once()
+	mockBetRepo.On("FindSettledByEventID", ctx, "event-1").Return([]data.Bet{settledBet("lost", data.Draw, data.StatusLost, 0)}, nil).Once()
+	mockResettlementRepo.On("Create", ctx, mock.AnythingOfType("*data.Resettlement")).Return(nil).Once()
+	mockEventRepo.On("ReplaceResult", ctx, "event-1", data.Draw).Return(nil).Once()
+	mockBetRepo.On("SettleWithPayout", ctx, "lost", data.StatusLost, data.StatusWon, 40.0, mock.Anything).Return(false, errors.New("database is locked")).Once()
+	mockResettlementRepo.On("Finish", ctx, mock.AnythingOfType("*data.Resettlement")).Return(nil).Once()
+
+	resettlement, err := uc.Resettle(ctx, "event-1", data.Draw, data.ResettledBySync, "result corrected by source")
+
+	require.NoError(t, err)
+	assert.Equal(t, data.ResettlementCompletedWithErrors, resettlement.Status)
+	assert.Equal(t, 1, resettlement.Errors)
+	assert.Equal(t, 0.0, resettlement.PaidOut)
+	require.Len(t, resettlement.Bets, 1)
+	assert.Contains(t, resettlement.Bets[0].Error, "database is locked")
+}
+
+func TestResettlementUseCase_Resettle_RejectsUnsettledOrSameResult(t *testing.T) {
+	mockEventRepo := repomocks.NewEventRepository(t)
+	uc := resettlementuc.NewUseCase(mockEventRepo, repomocks.NewBetRepository(t), repomocks.NewResettlementRepository(t), zap.NewNop())
+
+	ctx := context.Background()
+	mockEventRepo.On("FindByID", ctx, "event-1").Return(&data.Event{ID: "event-1", IsActive: true}, nil).Once()
+	_, err := uc.Resettle(ctx, "event-1", data.Draw, data.ResettledByAdmin, "")
+	require.ErrorIs(t, err, resettlementuc.ErrEventNotSettled)
+
+	mockEventRepo.On("FindByID", ctx, "event-1").Return(settledEvent(data.Draw), nil).Once()
+	_, err = uc.Resettle(ctx, "event-1", data.Draw, data.ResettledByAdmin, "")
+	require.ErrorIs(t, err, resettlementuc.ErrSameResult)
+
+	_, err = uc.Resettle(ctx, "event-1", data.Outcome("Canceled"), data.ResettledByAdmin, "")
+	require.ErrorIs(t, err, resettlementuc.ErrInvalidResult)
+}
@@ -0,0 +1,180 @@
+package review
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Arlan-Z/def-betting-api/internal/data"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrReviewNotFound        = errors.New("bet review not found")
+	ErrReviewAlreadyResolved = errors.New("bet review already resolved")
+	ErrInvalidReviewAction   = errors.New("invalid review action")
+)
+
+const (
+	ActionVoid = "void"
+	ActionKeep = "keep"
+)
+
+type ReviewRepository interface {
+	Enqueue(ctx context.Context, review *data.BetReview) error
+	FindByStatus(ctx context.Context, status data.ReviewStatus) ([]data.BetReview, error)
+	FindByID(ctx context.Context, reviewID string) (*data.BetReview, error)
+	Resolve(ctx context.Context, reviewID string, status data.ReviewStatus, note string, resolvedAt time.Time) error
+}
+
+type BetRepository interface {
+	FindByID(ctx context.Context, betID string) (*data.Bet, error)
+	ClearVoidFlag(ctx context.Context, betID string) error
+}
+
+type BetSettler interface {
+	VoidBets(ctx context.Context, eventID string, bets []data.Bet, reason string) error
+	SettleBet(ctx context.Context, betID string) error
+}
+
+type UseCase struct {
+	reviewRepo ReviewRepository
+	betRepo    BetRepository
+	settler    BetSettler
+	logger     *zap.Logger
+}
+
+func NewUseCase(rr ReviewRepository, br BetRepository, settler BetSettler, logger *zap.Logger) *UseCase {
+	return &UseCase{
+		reviewRepo: rr,
+		betRepo:    br,
+		settler:    settler,
+		logger:     logger.Named("ReviewUseCase"),
+	}
+}
+
+// Enqueue puts the bets into the review queue. Bets that are already queued are skipped.
+func (uc *UseCase) Enqueue(ctx context.Context, bets []data.Bet, reason string) error {
+	log := uc.logger.With(zap.String("operation", "Enqueue"), zap.String("reason", reason))
+
+	var enqueueErrors []error
+	for _, bet := range bets {
+		review := &data.BetReview{
+			ID:        uuid.NewString(),
+			BetID:     bet.ID,
+			EventID:   bet.EventID,
+			Reason:    reason,
+			Status:    data.ReviewPending,
+			CreatedAt: time.Now().UTC(),
+		}
+		if err := uc.reviewRepo.Enqueue(ctx, review); err != nil {
+			log.Error("Error enqueuing bet for review", zap.String("betId", bet.ID), zap.Error(err))
+			enqueueErrors = append(enqueueErrors, err)
+		}
+	}
+
+	if len(enqueueErrors) > 0 {
+		return fmt.Errorf("failed to enqueue %d of %d bets for review: %w", len(enqueueErrors), len(bets), errors.Join(enqueueErrors...))
+	}
+
+	log.Info("Bets queued for review", zap.Int("count", len(bets)))
+	return nil
+}
+
+func (uc *UseCase) GetPendingReviews(ctx context.Context) ([]data.BetReview, error) {
+	reviews, err := uc.reviewRepo.FindByStatus(ctx, data.ReviewPending)
+	if err != nil {
+		uc.logger.Error("Error getting pending reviews from repository", zap.Error(err))
+		return nil, fmt.Errorf("failed to get list of pending reviews")
+	}
+	return reviews, nil
+}
+
+// Resolve either voids and refunds the reviewed bet, or keeps it and settles it
+// normally if its event already has a result. A bet that is no longer pending, e.g. after
+// its event was canceled, is left alone: the review is closed as Voided if the bet was
+// voided and voiding was asked for, and as Dismissed otherwise.
+func (uc *UseCase) Resolve(ctx context.Context, reviewID string, action string, note string) (*data.BetReview, error) {
+	log := uc.logger.With(zap.String("reviewId", reviewID), zap.String("operation", "Resolve"), zap.String("action", action))
+	log.Info("Use Case: Resolving bet review")
+
+	review, err := uc.reviewRepo.FindByID(ctx, reviewID)
+	if err != nil {
+		log.Error("Error retrieving bet review", zap.Error(err))
+		return nil, fmt.Errorf("internal error searching for review")
+	}
+	if review == nil {
+		return nil, ErrReviewNotFound
+	}
+	if review.Status != data.ReviewPending {
+		return nil, ErrReviewAlreadyResolved
+	}
+
+	bet, err := uc.betRepo.FindByID(ctx, review.BetID)
+	if err != nil {
+		log.Error("Error retrieving reviewed bet", zap.Error(err))
+		return nil, fmt.Errorf("internal error searching for bet")
+	}
+	if bet == nil {
+		return nil, fmt.Errorf("reviewed bet %s not found", review.BetID)
+	}
+
+	var newStatus data.ReviewStatus
+	switch {
+	case action != ActionVoid && action != ActionKeep:
+		return nil, ErrInvalidReviewAction
+	case bet.Status != data.StatusPending:
+		log.Info("Reviewed bet is no longer pending, closing review", zap.String("betStatus", string(bet.Status)))
+		newStatus = closedReviewStatus(action, bet.Status)
+	case action == ActionVoid:
+		if err := uc.settler.VoidBets(ctx, bet.EventID, []data.Bet{*bet}, review.Reason); err != nil {
+			log.Error("Error voiding reviewed bet", zap.Error(err))
+			return nil, err
+		}
+		// The bet may have been settled between reading and voiding it, in which case
+		// VoidBets left it alone.
+		voided, err := uc.betRepo.FindByID(ctx, bet.ID)
+		if err != nil || voided == nil {
+			log.Error("Error retrieving voided bet", zap.Error(err))
+			return nil, fmt.Errorf("internal error searching for bet")
+		}
+		if voided.Status != data.StatusVoided && voided.Status != data.StatusRefunded {
+			log.Warn("Reviewed bet was settled before it could be voided", zap.String("betStatus", string(voided.Status)))
+		}
+		newStatus = closedReviewStatus(action, voided.Status)
+	case action == ActionKeep:
+		if err := uc.betRepo.ClearVoidFlag(ctx, bet.ID); err != nil {
+			log.Error("Error clearing void flag of reviewed bet", zap.Error(err))
+			return nil, fmt.Errorf("internal error updating bet")
+		}
+		if err := uc.settler.SettleBet(ctx, bet.ID); err != nil {
+			log.Error("Error settling kept bet", zap.Error(err))
+			return nil, err
+		}
+		newStatus = data.ReviewKept
+	}
+
+	resolvedAt := time.Now().UTC()
+	if err := uc.reviewRepo.Resolve(ctx, reviewID, newStatus, note, resolvedAt); err != nil {
+		log.Error("Error storing review resolution", zap.Error(err))
+		return nil, fmt.Errorf("internal error resolving review")
+	}
+
+	review.Status = newStatus
+	review.ResolvedAt = &resolvedAt
+	if note != "" {
+		review.ResolutionNote = &note
+	}
+	log.Info("Bet review resolved", zap.String("status", string(newStatus)))
+	return review, nil
+}
+
+// closedReviewStatus is the status of a review whose bet is no longer pending.
+func closedReviewStatus(action string, betStatus data.BetStatus) data.ReviewStatus {
+	if action == ActionVoid && (betStatus == data.StatusVoided || betStatus == data.StatusRefunded) {
+		return data.ReviewVoided
+	}
+	return data.ReviewDismissed
+}
@@ -0,0 +1,176 @@
+package review_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Arlan-Z/def-betting-api/internal/data"
+	repomocks "github.com/Arlan-Z/def-betting-api/internal/repositories/mocks"
+	reviewuc "github.com/Arlan-Z/def-betting-api/internal/usecases/review"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type mockSettler struct {
+	mock.Mock
+}
+
+func (m *mockSettler) VoidBets(ctx context.Context, eventID string, bets []data.Bet, reason string) error {
+	return m.Called(ctx, eventID, bets, reason).Error(0)
+}
+
+func (m *mockSettler) SettleBet(ctx context.Context, betID string) error {
+	return m.Called(ctx, betID).Error(0)
+}
+
+const lateReason = "placed after corrected start time"
+
+func pendingReview() *data.BetReview {
+	return &data.BetReview{ID: "review-1", BetID: "bet-1", EventID: "event-1", Reason: lateReason, Status: data.ReviewPending}
+}
+
+func betWithStatus(status data.BetStatus) *data.Bet {
+	return &data.Bet{ID: "bet-1", EventID: "event-1", Status: status}
+}
+
+func newUseCase(t *testing.T) (*reviewuc.UseCase, *repomocks.ReviewRepository, *repomocks.BetRepository, *mockSettler) {
+	reviewRepo := repomocks.NewReviewRepository(t)
+	betRepo := repomocks.NewBetRepository(t)
+	settler := &mockSettler{}
+	t.Cleanup(func() { settler.AssertExpectations(t) })
+	return reviewuc.NewUseCase(reviewRepo, betRepo, settler, zap.NewNop()), reviewRepo, betRepo, settler
+}
+
+func TestReviewUseCase_Resolve_VoidPendingBet(t *testing.T) {
+	uc, reviewRepo, betRepo, settler := newUseCase(t)
+	ctx := context.Background()
+
+	reviewRepo.On("FindByID", ctx, "review-1").Return(pendingReview(), nil).Once()
+	betRepo.On("FindByID", ctx, "bet-1").Return(betWithStatus(data.StatusPending), nil).Once()
+	settler.On("VoidBets", ctx, "event-1", []data.Bet{*betWithStatus(data.StatusPending)}, lateReason).Return(nil).Once()
+	betRepo.On("FindByID", ctx, "bet-1").Return(betWithStatus(data.StatusVoided), nil).Once()
+	reviewRepo.On("Resolve", ctx, "review-1", data.ReviewVoided, "late", mock.AnythingOfType("time.Time")).Return(nil).Once()
+
+	review, err := uc.Resolve(ctx, "review-1", reviewuc.ActionVoid, "late")
+
+	require.NoError(t, err)
+	assert.Equal(t, data.ReviewVoided, review.Status)
+	assert.Equal(t, "late", *review.ResolutionNote)
+}
+
+func TestReviewUseCase_Resolve_VoidBetSettledWhileVoiding(t *testing.T) {
+	uc, reviewRepo, betRepo, settler := newUseCase(t)
+	ctx := context.Background()
+
+	reviewRepo.On("FindByID", ctx, "review-1").Return(pendingReview(), nil).Once()
+	betRepo.On("FindByID", ctx, "bet-1").Return(betWithStatus(data.StatusPending), nil).Once()
+	settler.On("VoidBets", ctx, "event-1", mock.Anything, lateReason).Return(nil).Once()
+	betRepo.On("FindByID", ctx, "bet-1").Return(betWithStatus(data.StatusWon), nil).Once()
+	reviewRepo.On("Resolve", ctx, "review-1", data.ReviewDismissed, "", mock.AnythingOfType("time.Time")).Return(nil).Once()
+
+	review, err := uc.Resolve(ctx, "review-1", reviewuc.ActionVoid, "")
+
+	require.NoError(t, err)
+	assert.Equal(t, data.ReviewDismissed, review.Status)
+}
+
+func TestReviewUseCase_Resolve_VoidBetAlreadyVoided(t *testing.T) {
+	for _, status := range []data.BetStatus{data.StatusVoided, data.StatusRefunded} {
+		t.Run(string(status), func(t *testing.T) {
+			uc, reviewRepo, betRepo, settler := newUseCase(t)
+			ctx := context.Background()
+
+			reviewRepo.On("FindByID", ctx, "review-1").Return(pendingReview(), nil).Once()
+			betRepo.On("FindByID", ctx, "bet-1").Return(betWithStatus(status), nil).Once()
+			reviewRepo.On("Resolve", ctx, "review-1", data.ReviewVoided, "", mock.AnythingOfType("time.Time")).Return(nil).Once()
+
+			review, err := uc.Resolve(ctx, "review-1", reviewuc.ActionVoid, "")
+
+			require.NoError(t, err)
+			assert.Equal(t, data.ReviewVoided, review.Status)
+			settler.AssertNotCalled(t, "VoidBets", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestReviewUseCase_Resolve_BetNoLongerPendingIsDismissed(t *testing.T) {
+	cases := []struct {
+		action    string
+		betStatus data.BetStatus
+	}{
+		{reviewuc.ActionVoid, data.StatusWon},
+		{reviewuc.ActionVoid, data.StatusPaid},
+		{reviewuc.ActionKeep, data.StatusLost},
+		{reviewuc.ActionKeep, data.StatusRefunded},
+	}
+	for _, tc := range cases {
+		t.Run(tc.action+"/"+string(tc.betStatus), func(t *testing.T) {
+			uc, reviewRepo, betRepo, settler := newUseCase(t)
+			ctx := context.Background()
+
+			reviewRepo.On("FindByID", ctx, "review-1").Return(pendingReview(), nil).Once()
+			betRepo.On("FindByID", ctx, "bet-1").Return(betWithStatus(tc.betStatus), nil).Once()
+			reviewRepo.On("Resolve", ctx, "review-1", data.ReviewDismissed, "", mock.AnythingOfType("time.Time")).Return(nil).Once()
+
+			review, err := uc.Resolve(ctx, "review-1", tc.action, "")
+
+			require.NoError(t, err)
+			assert.Equal(t, data.ReviewDismissed, review.Status)
+			settler.AssertNotCalled(t, "VoidBets", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			settler.AssertNotCalled(t, "SettleBet", mock.Anything, mock.Anything)
+			betRepo.AssertNotCalled(t, "ClearVoidFlag", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestReviewUseCase_Resolve_KeepPendingBet(t *testing.T) {
+	uc, reviewRepo, betRepo, settler := newUseCase(t)
+	ctx := context.Background()
+
+	reviewRepo.On("FindByID", ctx, "review-1").Return(pendingReview(), nil).Once()
+	betRepo.On("FindByID", ctx, "bet-1").Return(betWithStatus(data.StatusPending), nil).Once()
+	betRepo.On("ClearVoidFlag", ctx, "bet-1").Return(nil).Once()
+	settler.On("SettleBet", ctx, "bet-1").Return(nil).Once()
+	reviewRepo.On("Resolve", ctx, "review-1", data.ReviewKept, "", mock.AnythingOfType("time.Time")).Return(nil).Once()
+
+	review, err := uc.Resolve(ctx, "review-1", reviewuc.ActionKeep, "")
+
+	require.NoError(t, err)
+	assert.Equal(t, data.ReviewKept, review.Status)
+	assert.Nil(t, review.ResolutionNote)
+}
+
+func TestReviewUseCase_Resolve_Errors(t *testing.T) {
+	t.Run("not found", func(t *testing.T) {
+		uc, reviewRepo, _, _ := newUseCase(t)
+		reviewRepo.On("FindByID", mock.Anything, "review-1").Return(nil, nil).Once()
+
+		_, err := uc.Resolve(context.Background(), "review-1", reviewuc.ActionVoid, "")
+
+		assert.ErrorIs(t, err, reviewuc.ErrReviewNotFound)
+	})
+
+	t.Run("already resolved", func(t *testing.T) {
+		uc, reviewRepo, _, _ := newUseCase(t)
+		resolved := pendingReview()
+		resolved.Status = data.ReviewKept
+		reviewRepo.On("FindByID", mock.Anything, "review-1").Return(resolved, nil).Once()
+
+		_, err := uc.Resolve(context.Background(), "review-1", reviewuc.ActionVoid, "")
+
+		assert.ErrorIs(t, err, reviewuc.ErrReviewAlreadyResolved)
+	})
+
+	t.Run("invalid action", func(t *testing.T) {
+		uc, reviewRepo, betRepo, _ := newUseCase(t)
+		reviewRepo.On("FindByID", mock.Anything, "review-1").Return(pendingReview(), nil).Once()
+		betRepo.On("FindByID", mock.Anything, "bet-1").Return(betWithStatus(data.StatusWon), nil).Once()
+
+		_, err := uc.Resolve(context.Background(), "review-1", "viod", "")
+
+		assert.ErrorIs(t, err, reviewuc.ErrInvalidReviewAction)
+		reviewRepo.AssertNotCalled(t, "Resolve", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
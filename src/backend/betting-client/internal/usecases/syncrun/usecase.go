@@ -0,0 +1,94 @@
+package syncrun
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Arlan-Z/def-betting-api/internal/data"
+	"go.uber.org/zap"
+)
+
+var ErrRunNotFound = errors.New("sync run not found")
+
+const (
+	DefaultRunsLimit = 50
+	MaxRunsLimit     = 200
+)
+
+type SyncRunRepository interface {
+	FindByID(ctx context.Context, runID string) (*data.SyncRun, error)
+	FindRecent(ctx context.Context, provider string, limit int) ([]data.SyncRun, error)
+	FindLastSuccessful(ctx context.Context) (*data.SyncRun, error)
+}
+
+type MissingEventRepository interface {
+	FindMissingEvents(ctx context.Context, minMissedCycles int) ([]data.MissingEvent, error)
+}
+
+type UseCase struct {
+	runRepo     SyncRunRepository
+	missingRepo MissingEventRepository
+	logger      *zap.Logger
+}
+
+func NewUseCase(rr SyncRunRepository, mr MissingEventRepository, logger *zap.Logger) *UseCase {
+	return &UseCase{
+		runRepo:     rr,
+		missingRepo: mr,
+		logger:      logger.Named("SyncRunUseCase"),
+	}
+}
+
+// GetRuns returns the latest runs, newest first. An empty provider returns runs of all providers.
+func (uc *UseCase) GetRuns(ctx context.Context, provider string, limit int) ([]data.SyncRun, error) {
+	if limit <= 0 {
+		limit = DefaultRunsLimit
+	}
+	if limit > MaxRunsLimit {
+		limit = MaxRunsLimit
+	}
+
+	runs, err := uc.runRepo.FindRecent(ctx, provider, limit)
+	if err != nil {
+		uc.logger.Error("Error getting sync runs from repository", zap.Error(err))
+		return nil, fmt.Errorf("failed to get list of sync runs")
+	}
+	return runs, nil
+}
+
+func (uc *UseCase) GetRun(ctx context.Context, runID string) (*data.SyncRun, error) {
+	run, err := uc.runRepo.FindByID(ctx, runID)
+	if err != nil {
+		uc.logger.Error("Error getting sync run from repository", zap.String("runId", runID), zap.Error(err))
+		return nil, fmt.Errorf("internal error searching for sync run")
+	}
+	if run == nil {
+		return nil, ErrRunNotFound
+	}
+	return run, nil
+}
+
+// LastSuccessfulSync returns when the last successful run finished, or nil if none did.
+func (uc *UseCase) LastSuccessfulSync(ctx context.Context) (*time.Time, error) {
+	run, err := uc.runRepo.FindLastSuccessful(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if run == nil {
+		return nil, nil
+	}
+	return run.FinishedAt, nil
+}
+
+// GetMissingEvents returns the unsettled events that no provider reported in its last
+// full fetch, including the ones already suspended.
+func (uc *UseCase) GetMissingEvents(ctx context.Context) ([]data.MissingEvent, error) {
+	events, err := uc.missingRepo.FindMissingEvents(ctx, 1)
+	if err != nil {
+		uc.logger.Error("Error getting missing events from repository", zap.Error(err))
+		return nil, fmt.Errorf("failed to get list of missing events")
+	}
+	return events, nil
+}
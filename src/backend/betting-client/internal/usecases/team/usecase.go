@@ -0,0 +1,310 @@
+package team
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Arlan-Z/def-betting-api/internal/data"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrTeamNotFound           = errors.New("team not found")
+	ErrAliasNotFound          = errors.New("team alias not found")
+	ErrAliasAlreadyResolved   = errors.New("team alias already resolved")
+	ErrInvalidAliasResolution = errors.New("alias must be resolved to an existing team of the same sport or to a new team")
+)
+
+type TeamRepository interface {
+	Create(ctx context.Context, team *data.Team) error
+	FindByID(ctx context.Context, teamID string) (*data.Team, error)
+	FindBySport(ctx context.Context, sport string) ([]data.Team, error)
+	FindByAlias(ctx context.Context, sport string, aliasKey string) (*data.Team, error)
+	FindAliasKeys(ctx context.Context, sport string) (map[string][]string, error)
+	AddAlias(ctx context.Context, sport string, aliasKey string, teamID string) error
+	EnqueueUnresolved(ctx context.Context, alias *data.UnresolvedTeamAlias) error
+	FindUnresolvedByStatus(ctx context.Context, status data.AliasStatus) ([]data.UnresolvedTeamAlias, error)
+	FindUnresolvedByID(ctx context.Context, aliasID string) (*data.UnresolvedTeamAlias, error)
+	MarkAliasResolved(ctx context.Context, aliasID string, teamID string, resolvedAt time.Time) error
+}
+
+type CompetitionRepository interface {
+	Create(ctx context.Context, competition *data.Competition) error
+	FindByKey(ctx context.Context, sport string, nameKey string) (*data.Competition, error)
+	FindBySport(ctx context.Context, sport string) ([]data.Competition, error)
+}
+
+type EventRepository interface {
+	FindUpcomingByTeam(ctx context.Context, teamID string, from time.Time) ([]data.Event, error)
+	AssignTeamByName(ctx context.Context, sport string, name string, teamID string) (int64, error)
+}
+
+type UseCase struct {
+	teamRepo        TeamRepository
+	competitionRepo CompetitionRepository
+	eventRepo       EventRepository
+	logger          *zap.Logger
+}
+
+func NewUseCase(tr TeamRepository, cr CompetitionRepository, er EventRepository, logger *zap.Logger) *UseCase {
+	return &UseCase{
+		teamRepo:        tr,
+		competitionRepo: cr,
+		eventRepo:       er,
+		logger:          logger.Named("TeamUseCase"),
+	}
+}
+
+// ResolveEventEntities links the event to its competition and teams. Unknown
+// competitions and teams are created; a team name that could refer to one of
+// several known teams is queued for review and left unlinked.
+func (uc *UseCase) ResolveEventEntities(ctx context.Context, event *data.Event) error {
+	if event.Competition != "" {
+		competitionID, err := uc.resolveCompetition(ctx, event.Type, event.Competition)
+		if err != nil {
+			return err
+		}
+		event.CompetitionID = competitionID
+	}
+
+	homeTeamID, err := uc.resolveTeam(ctx, event.Type, event.HomeTeam, event.ID)
+	if err != nil {
+		return err
+	}
+	event.HomeTeamID = homeTeamID
+
+	awayTeamID, err := uc.resolveTeam(ctx, event.Type, event.AwayTeam, event.ID)
+	if err != nil {
+		return err
+	}
+	event.AwayTeamID = awayTeamID
+	return nil
+}
+
+func (uc *UseCase) resolveCompetition(ctx context.Context, sport string, name string) (*string, error) {
+	nameKey := data.NormalizeName(name)
+	competition, err := uc.competitionRepo.FindByKey(ctx, sport, nameKey)
+	if err != nil {
+		return nil, err
+	}
+	if competition != nil {
+		return &competition.ID, nil
+	}
+
+	competition = &data.Competition{
+		ID:        uuid.NewString(),
+		Name:      name,
+		NameKey:   nameKey,
+		Sport:     sport,
+		CreatedAt: time.Now().UTC(),
+	}
+	if err := uc.competitionRepo.Create(ctx, competition); err != nil {
+		return nil, err
+	}
+	uc.logger.Info("Created competition from source", zap.String("competitionId", competition.ID), zap.String("name", name), zap.String("sport", sport))
+	return &competition.ID, nil
+}
+
+func (uc *UseCase) resolveTeam(ctx context.Context, sport string, name string, eventID string) (*string, error) {
+	aliasKey := data.NormalizeName(name)
+	if aliasKey == "" {
+		return nil, nil
+	}
+
+	team, err := uc.teamRepo.FindByAlias(ctx, sport, aliasKey)
+	if err != nil {
+		return nil, err
+	}
+	if team != nil {
+		return &team.ID, nil
+	}
+
+	knownAliases, err := uc.teamRepo.FindAliasKeys(ctx, sport)
+	if err != nil {
+		return nil, err
+	}
+	candidates := similarTeams(aliasKey, knownAliases)
+	if len(candidates) > 0 {
+		unresolved := &data.UnresolvedTeamAlias{
+			ID:               uuid.NewString(),
+			Alias:            name,
+			AliasKey:         aliasKey,
+			Sport:            sport,
+			EventID:          eventID,
+			CandidateTeamIDs: strings.Join(candidates, ","),
+			Status:           data.AliasPending,
+			CreatedAt:        time.Now().UTC(),
+		}
+		if err := uc.teamRepo.EnqueueUnresolved(ctx, unresolved); err != nil {
+			return nil, err
+		}
+		uc.logger.Warn("Team name matches several known teams, queued for review",
+			zap.String("alias", name),
+			zap.String("sport", sport),
+			zap.Strings("candidateTeamIds", candidates),
+		)
+		return nil, nil
+	}
+
+	team = &data.Team{
+		ID:        uuid.NewString(),
+		Name:      name,
+		Sport:     sport,
+		CreatedAt: time.Now().UTC(),
+	}
+	if err := uc.teamRepo.Create(ctx, team); err != nil {
+		return nil, err
+	}
+	uc.logger.Info("Created team from source", zap.String("teamId", team.ID), zap.String("name", name), zap.String("sport", sport))
+	return &team.ID, nil
+}
+
+// similarTeams returns the teams having an alias whose words are all contained in
+// the given key or vice versa, e.g. "real madrid" and "real madrid cf".
+func similarTeams(aliasKey string, knownAliases map[string][]string) []string {
+	candidates := make([]string, 0)
+	for teamID, keys := range knownAliases {
+		for _, key := range keys {
+			if containsWords(aliasKey, key) || containsWords(key, aliasKey) {
+				candidates = append(candidates, teamID)
+				break
+			}
+		}
+	}
+	return candidates
+}
+
+func containsWords(key string, sub string) bool {
+	words := make(map[string]bool)
+	for _, w := range strings.Fields(key) {
+		words[w] = true
+	}
+	for _, w := range strings.Fields(sub) {
+		if !words[w] {
+			return false
+		}
+	}
+	return true
+}
+
+func (uc *UseCase) GetCompetitions(ctx context.Context, sport string) ([]data.Competition, error) {
+	competitions, err := uc.competitionRepo.FindBySport(ctx, sport)
+	if err != nil {
+		uc.logger.Error("Error getting competitions from repository", zap.Error(err))
+		return nil, fmt.Errorf("failed to get list of competitions")
+	}
+	return competitions, nil
+}
+
+func (uc *UseCase) GetTeams(ctx context.Context, sport string) ([]data.Team, error) {
+	teams, err := uc.teamRepo.FindBySport(ctx, sport)
+	if err != nil {
+		uc.logger.Error("Error getting teams from repository", zap.Error(err))
+		return nil, fmt.Errorf("failed to get list of teams")
+	}
+	return teams, nil
+}
+
+// GetTeamFixtures returns the upcoming active events the team plays in.
+func (uc *UseCase) GetTeamFixtures(ctx context.Context, teamID string) ([]data.Event, error) {
+	log := uc.logger.With(zap.String("teamId", teamID), zap.String("operation", "GetTeamFixtures"))
+
+	team, err := uc.teamRepo.FindByID(ctx, teamID)
+	if err != nil {
+		log.Error("Error retrieving team", zap.Error(err))
+		return nil, fmt.Errorf("internal error searching for team")
+	}
+	if team == nil {
+		return nil, ErrTeamNotFound
+	}
+
+	events, err := uc.eventRepo.FindUpcomingByTeam(ctx, teamID, time.Now().UTC())
+	if err != nil {
+		log.Error("Error getting fixtures from repository", zap.Error(err))
+		return nil, fmt.Errorf("failed to get list of fixtures")
+	}
+	return events, nil
+}
+
+func (uc *UseCase) GetPendingAliases(ctx context.Context) ([]data.UnresolvedTeamAlias, error) {
+	aliases, err := uc.teamRepo.FindUnresolvedByStatus(ctx, data.AliasPending)
+	if err != nil {
+		uc.logger.Error("Error getting unresolved aliases from repository", zap.Error(err))
+		return nil, fmt.Errorf("failed to get list of unresolved aliases")
+	}
+	return aliases, nil
+}
+
+// ResolveAlias registers a queued alias for an existing team, or creates a new team
+// named after it, and links the events that were synced with that name.
+func (uc *UseCase) ResolveAlias(ctx context.Context, aliasID string, teamID string, createTeam bool) (*data.UnresolvedTeamAlias, error) {
+	log := uc.logger.With(zap.String("aliasId", aliasID), zap.String("operation", "ResolveAlias"))
+	log.Info("Use Case: Resolving team alias")
+
+	alias, err := uc.teamRepo.FindUnresolvedByID(ctx, aliasID)
+	if err != nil {
+		log.Error("Error retrieving unresolved alias", zap.Error(err))
+		return nil, fmt.Errorf("internal error searching for alias")
+	}
+	if alias == nil {
+		return nil, ErrAliasNotFound
+	}
+	if alias.Status != data.AliasPending {
+		return nil, ErrAliasAlreadyResolved
+	}
+
+	if createTeam {
+		team := &data.Team{
+			ID:        uuid.NewString(),
+			Name:      alias.Alias,
+			Sport:     alias.Sport,
+			CreatedAt: time.Now().UTC(),
+		}
+		if err := uc.teamRepo.Create(ctx, team); err != nil {
+			log.Error("Error creating team for alias", zap.Error(err))
+			return nil, fmt.Errorf("internal error creating team")
+		}
+		teamID = team.ID
+	} else {
+		if teamID == "" {
+			return nil, ErrInvalidAliasResolution
+		}
+		team, err := uc.teamRepo.FindByID(ctx, teamID)
+		if err != nil {
+			log.Error("Error retrieving team", zap.Error(err))
+			return nil, fmt.Errorf("internal error searching for team")
+		}
+		if team == nil {
+			return nil, ErrTeamNotFound
+		}
+		if !strings.EqualFold(team.Sport, alias.Sport) {
+			return nil, ErrInvalidAliasResolution
+		}
+		if err := uc.teamRepo.AddAlias(ctx, alias.Sport, alias.AliasKey, teamID); err != nil {
+			log.Error("Error registering alias", zap.Error(err))
+			return nil, fmt.Errorf("internal error registering alias")
+		}
+	}
+
+	resolvedAt := time.Now().UTC()
+	if err := uc.teamRepo.MarkAliasResolved(ctx, aliasID, teamID, resolvedAt); err != nil {
+		log.Error("Error storing alias resolution", zap.Error(err))
+		return nil, fmt.Errorf("internal error resolving alias")
+	}
+
+	linked, err := uc.eventRepo.AssignTeamByName(ctx, alias.Sport, alias.Alias, teamID)
+	if err != nil {
+		// The next sync links the events anyway, since the alias is now known.
+		log.Warn("Error linking events to resolved team", zap.Error(err))
+	}
+
+	alias.Status = data.AliasResolved
+	alias.TeamID = &teamID
+	alias.ResolvedAt = &resolvedAt
+	log.Info("Team alias resolved", zap.String("teamId", teamID), zap.Int64("linkedEvents", linked))
+	return alias, nil
+}
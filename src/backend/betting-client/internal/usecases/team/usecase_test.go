@@ -0,0 +1,143 @@
+package team_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Arlan-Z/def-betting-api/internal/data"
+	repomocks "github.com/Arlan-Z/def-betting-api/internal/repositories/mocks"
+	teamuc "github.com/Arlan-Z/def-betting-api/internal/usecases/team"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestTeamUseCase_ResolveEventEntities_MatchesAliasesAndCreatesUnknown(t *testing.T) {
+	mockTeamRepo := repomocks.NewTeamRepository(t)
+	mockCompetitionRepo := repomocks.NewCompetitionRepository(t)
+	mockEventRepo := repomocks.NewEventRepository(t)
+	uc := teamuc.NewUseCase(mockTeamRepo, mockCompetitionRepo, mockEventRepo, zap.NewNop())
+
+	ctx := context.Background()
+	kairat := &data.Team{ID: uuid.NewString(), Name: "Kairat", Sport: "Football"}
+	competition := &data.Competition{ID: uuid.NewString(), Name: "Premier League", NameKey: "premier league", Sport: "Football"}
+	event := &data.Event{ID: uuid.NewString(), HomeTeam: "FC  Kairat", AwayTeam: "Astana", Type: "Football", Competition: "Premier-League"}
+
+	mockCompetitionRepo.On("FindByKey", ctx, "Football", "premier league").Return(competition, nil).Once()
+	mockTeamRepo.On("FindByAlias", ctx, "Football", "fc kairat").Return(kairat, nil).Once()
+	mockTeamRepo.On("FindByAlias", ctx, "Football", "astana").Return(nil, nil).Once()
+	mockTeamRepo.On("FindAliasKeys", ctx, "Football").Return(map[string][]string{kairat.ID: {"kairat", "fc kairat"}}, nil).Once()
+
+	var created *data.Team
+	mockTeamRepo.On("Create", ctx, mock.MatchedBy(func(team *data.Team) bool {
+		created = team
+		return team.Name == "Astana" && team.Sport == "Football" && team.ID != ""
+	})).Return(nil).Once()
+
+	err := uc.ResolveEventEntities(ctx, event)
+
+	require.NoError(t, err)
+	require.NotNil(t, event.CompetitionID)
+	assert.Equal(t, competition.ID, *event.CompetitionID)
+	require.NotNil(t, event.HomeTeamID)
+	assert.Equal(t, kairat.ID, *event.HomeTeamID)
+	require.NotNil(t, event.AwayTeamID)
+	assert.Equal(t, created.ID, *event.AwayTeamID)
+}
+
+func TestTeamUseCase_ResolveEventEntities_QueuesAmbiguousAlias(t *testing.T) {
+	mockTeamRepo := repomocks.NewTeamRepository(t)
+	mockCompetitionRepo := repomocks.NewCompetitionRepository(t)
+	mockEventRepo := repomocks.NewEventRepository(t)
+	uc := teamuc.NewUseCase(mockTeamRepo, mockCompetitionRepo, mockEventRepo, zap.NewNop())
+
+	ctx := context.Background()
+	realMadrid := &data.Team{ID: uuid.NewString(), Name: "Real Madrid", Sport: "Football"}
+	barcelona := &data.Team{ID: uuid.NewString(), Name: "Barcelona", Sport: "Football"}
+	event := &data.Event{ID: uuid.NewString(), HomeTeam: "Real Madrid CF", AwayTeam: "Barcelona", Type: "Football"}
+
+	mockTeamRepo.On("FindByAlias", ctx, "Football", "real madrid cf").Return(nil, nil).Once()
+	mockTeamRepo.On("FindAliasKeys", ctx, "Football").Return(map[string][]string{
+		realMadrid.ID: {"real madrid"},
+		barcelona.ID:  {"barcelona"},
+	}, nil).Once()
+	mockTeamRepo.On("EnqueueUnresolved", ctx, mock.MatchedBy(func(alias *data.UnresolvedTeamAlias) bool {
+		return alias.Alias == "Real Madrid CF" &&
+			alias.AliasKey == "real madrid cf" &&
+			alias.EventID == event.ID &&
+			alias.CandidateTeamIDs == realMadrid.ID &&
+			alias.Status == data.AliasPending
+	})).Return(nil).Once()
+	mockTeamRepo.On("FindByAlias", ctx, "Football", "barcelona").Return(barcelona, nil).Once()
+
+	err := uc.ResolveEventEntities(ctx, event)
+
+	require.NoError(t, err)
+	assert.Nil(t, event.HomeTeamID, "Ambiguous names must stay unlinked until reviewed")
+	require.NotNil(t, event.AwayTeamID)
+	assert.Equal(t, barcelona.ID, *event.AwayTeamID)
+	assert.Nil(t, event.CompetitionID)
+	mockTeamRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestTeamUseCase_ResolveAlias_ExistingTeam(t *testing.T) {
+	mockTeamRepo := repomocks.NewTeamRepository(t)
+	mockCompetitionRepo := repomocks.NewCompetitionRepository(t)
+	mockEventRepo := repomocks.NewEventRepository(t)
+	uc := teamuc.NewUseCase(mockTeamRepo, mockCompetitionRepo, mockEventRepo, zap.NewNop())
+
+	ctx := context.Background()
+	realMadrid := &data.Team{ID: uuid.NewString(), Name: "Real Madrid", Sport: "Football"}
+	alias := &data.UnresolvedTeamAlias{ID: uuid.NewString(), Alias: "Real Madrid CF", AliasKey: "real madrid cf", Sport: "football", Status: data.AliasPending}
+
+	mockTeamRepo.On("FindUnresolvedByID", ctx, alias.ID).Return(alias, nil).Once()
+	mockTeamRepo.On("FindByID", ctx, realMadrid.ID).Return(realMadrid, nil).Once()
+	mockTeamRepo.On("AddAlias", ctx, "football", "real madrid cf", realMadrid.ID).Return(nil).Once()
+	mockTeamRepo.On("MarkAliasResolved", ctx, alias.ID, realMadrid.ID, mock.AnythingOfType("time.Time")).Return(nil).Once()
+	mockEventRepo.On("AssignTeamByName", ctx, "football", "Real Madrid CF", realMadrid.ID).Return(int64(2), nil).Once()
+
+	resolved, err := uc.ResolveAlias(ctx, alias.ID, realMadrid.ID, false)
+
+	require.NoError(t, err)
+	assert.Equal(t, data.AliasResolved, resolved.Status)
+	require.NotNil(t, resolved.TeamID)
+	assert.Equal(t, realMadrid.ID, *resolved.TeamID)
+	assert.NotNil(t, resolved.ResolvedAt)
+}
+
+func TestTeamUseCase_ResolveAlias_OtherSport(t *testing.T) {
+	mockTeamRepo := repomocks.NewTeamRepository(t)
+	mockCompetitionRepo := repomocks.NewCompetitionRepository(t)
+	mockEventRepo := repomocks.NewEventRepository(t)
+	uc := teamuc.NewUseCase(mockTeamRepo, mockCompetitionRepo, mockEventRepo, zap.NewNop())
+
+	ctx := context.Background()
+	barys := &data.Team{ID: uuid.NewString(), Name: "Barys", Sport: "Hockey"}
+	alias := &data.UnresolvedTeamAlias{ID: uuid.NewString(), Alias: "Barys Astana", AliasKey: "barys astana", Sport: "Football", Status: data.AliasPending}
+
+	mockTeamRepo.On("FindUnresolvedByID", ctx, alias.ID).Return(alias, nil).Once()
+	mockTeamRepo.On("FindByID", ctx, barys.ID).Return(barys, nil).Once()
+
+	resolved, err := uc.ResolveAlias(ctx, alias.ID, barys.ID, false)
+
+	require.ErrorIs(t, err, teamuc.ErrInvalidAliasResolution)
+	assert.Nil(t, resolved)
+	mockTeamRepo.AssertNotCalled(t, "MarkAliasResolved", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTeamUseCase_ResolveAlias_AlreadyResolved(t *testing.T) {
+	mockTeamRepo := repomocks.NewTeamRepository(t)
+	mockCompetitionRepo := repomocks.NewCompetitionRepository(t)
+	mockEventRepo := repomocks.NewEventRepository(t)
+	uc := teamuc.NewUseCase(mockTeamRepo, mockCompetitionRepo, mockEventRepo, zap.NewNop())
+
+	ctx := context.Background()
+	alias := &data.UnresolvedTeamAlias{ID: uuid.NewString(), Status: data.AliasResolved}
+	mockTeamRepo.On("FindUnresolvedByID", ctx, alias.ID).Return(alias, nil).Once()
+
+	_, err := uc.ResolveAlias(ctx, alias.ID, "", true)
+
+	require.ErrorIs(t, err, teamuc.ErrAliasAlreadyResolved)
+}
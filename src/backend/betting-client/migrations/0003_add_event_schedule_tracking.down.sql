@@ -0,0 +1,5 @@
+DROP INDEX idx_events_status;
+DROP INDEX idx_event_schedule_changes_event_id;
+DROP TABLE event_schedule_changes;
+ALTER TABLE events DROP COLUMN postponed_at;
+ALTER TABLE events DROP COLUMN status;
@@ -0,0 +1,15 @@
+ALTER TABLE events ADD COLUMN status TEXT NOT NULL DEFAULT 'Scheduled'; -- 'Scheduled', 'Postponed', 'Canceled'
+ALTER TABLE events ADD COLUMN postponed_at DATETIME;
+
+CREATE TABLE event_schedule_changes (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    event_id TEXT NOT NULL,
+    old_start_date DATETIME NOT NULL,
+    new_start_date DATETIME NOT NULL,
+    old_end_date DATETIME NOT NULL,
+    new_end_date DATETIME NOT NULL,
+    detected_at DATETIME NOT NULL,
+    FOREIGN KEY (event_id) REFERENCES events(id)
+);
+CREATE INDEX idx_event_schedule_changes_event_id ON event_schedule_changes(event_id);
+CREATE INDEX idx_events_status ON events(status);
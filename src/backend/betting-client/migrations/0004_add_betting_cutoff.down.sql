@@ -0,0 +1,3 @@
+ALTER TABLE bets DROP COLUMN void_flag_reason;
+ALTER TABLE bets DROP COLUMN void_flagged_at;
+ALTER TABLE events DROP COLUMN betting_closed_at;
@@ -0,0 +1,3 @@
+ALTER TABLE events ADD COLUMN betting_closed_at DATETIME;
+ALTER TABLE bets ADD COLUMN void_flagged_at DATETIME;
+ALTER TABLE bets ADD COLUMN void_flag_reason TEXT;
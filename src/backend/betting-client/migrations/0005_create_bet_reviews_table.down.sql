@@ -0,0 +1,2 @@
+DROP INDEX idx_bet_reviews_status;
+DROP TABLE bet_reviews;
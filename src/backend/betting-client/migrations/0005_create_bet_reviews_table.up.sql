@@ -0,0 +1,13 @@
+CREATE TABLE bet_reviews (
+    id TEXT PRIMARY KEY,
+    bet_id TEXT NOT NULL UNIQUE,
+    event_id TEXT NOT NULL,
+    reason TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'Pending', -- 'Pending', 'Voided', 'Kept'
+    created_at DATETIME NOT NULL,
+    resolved_at DATETIME,
+    resolution_note TEXT,
+    FOREIGN KEY (bet_id) REFERENCES bets(id),
+    FOREIGN KEY (event_id) REFERENCES events(id)
+);
+CREATE INDEX idx_bet_reviews_status ON bet_reviews(status);
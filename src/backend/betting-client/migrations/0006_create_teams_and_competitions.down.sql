@@ -0,0 +1,14 @@
+DROP INDEX idx_events_away_team_id;
+DROP INDEX idx_events_home_team_id;
+DROP INDEX idx_events_competition_id;
+ALTER TABLE events DROP COLUMN away_team_id;
+ALTER TABLE events DROP COLUMN home_team_id;
+ALTER TABLE events DROP COLUMN competition_id;
+ALTER TABLE events DROP COLUMN competition;
+
+DROP INDEX idx_unresolved_team_aliases_status;
+DROP TABLE unresolved_team_aliases;
+DROP INDEX idx_team_aliases_team_id;
+DROP TABLE team_aliases;
+DROP TABLE teams;
+DROP TABLE competitions;
@@ -0,0 +1,49 @@
+CREATE TABLE competitions (
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    name_key TEXT NOT NULL, -- normalized name used for matching
+    sport TEXT NOT NULL COLLATE NOCASE,
+    created_at DATETIME NOT NULL,
+    UNIQUE (sport, name_key)
+);
+
+CREATE TABLE teams (
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    sport TEXT NOT NULL COLLATE NOCASE,
+    created_at DATETIME NOT NULL
+);
+
+CREATE TABLE team_aliases (
+    alias_key TEXT NOT NULL, -- normalized spelling sent by the source
+    sport TEXT NOT NULL COLLATE NOCASE,
+    team_id TEXT NOT NULL,
+    created_at DATETIME NOT NULL,
+    PRIMARY KEY (sport, alias_key),
+    FOREIGN KEY (team_id) REFERENCES teams(id)
+);
+CREATE INDEX idx_team_aliases_team_id ON team_aliases(team_id);
+
+CREATE TABLE unresolved_team_aliases (
+    id TEXT PRIMARY KEY,
+    alias TEXT NOT NULL,
+    alias_key TEXT NOT NULL,
+    sport TEXT NOT NULL COLLATE NOCASE,
+    event_id TEXT NOT NULL, -- event on which the alias was first seen, possibly not stored yet
+    candidate_team_ids TEXT NOT NULL DEFAULT '', -- comma separated teams the alias may refer to
+    status TEXT NOT NULL DEFAULT 'Pending', -- 'Pending', 'Resolved'
+    team_id TEXT,
+    created_at DATETIME NOT NULL,
+    resolved_at DATETIME,
+    UNIQUE (sport, alias_key),
+    FOREIGN KEY (team_id) REFERENCES teams(id)
+);
+CREATE INDEX idx_unresolved_team_aliases_status ON unresolved_team_aliases(status);
+
+ALTER TABLE events ADD COLUMN competition TEXT NOT NULL DEFAULT '';
+ALTER TABLE events ADD COLUMN competition_id TEXT REFERENCES competitions(id);
+ALTER TABLE events ADD COLUMN home_team_id TEXT REFERENCES teams(id);
+ALTER TABLE events ADD COLUMN away_team_id TEXT REFERENCES teams(id);
+CREATE INDEX idx_events_competition_id ON events(competition_id);
+CREATE INDEX idx_events_home_team_id ON events(home_team_id);
+CREATE INDEX idx_events_away_team_id ON events(away_team_id);
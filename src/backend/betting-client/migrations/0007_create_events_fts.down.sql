@@ -0,0 +1,2 @@
+DROP TRIGGER events_fts_after_delete;
+DROP TABLE events_fts;
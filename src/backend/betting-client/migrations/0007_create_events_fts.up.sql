@@ -0,0 +1,19 @@
+-- FTS4 rather than FTS5: go-sqlite3 only compiles FTS5 with the sqlite_fts5 build tag.
+-- The docid of each row is the rowid of the indexed event.
+CREATE VIRTUAL TABLE events_fts USING fts4(
+    event_name,
+    home_team,
+    away_team,
+    type,
+    competition,
+    prefix="2,3",
+    tokenize=unicode61 "remove_diacritics=2"
+);
+
+INSERT INTO events_fts (docid, event_name, home_team, away_team, type, competition)
+SELECT rowid, event_name, home_team, away_team, type, competition FROM events;
+
+CREATE TRIGGER events_fts_after_delete AFTER DELETE ON events
+BEGIN
+    DELETE FROM events_fts WHERE docid = old.rowid;
+END;
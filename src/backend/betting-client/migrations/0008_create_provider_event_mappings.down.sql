@@ -0,0 +1,3 @@
+DROP TABLE event_result_conflicts;
+DROP INDEX idx_provider_event_mappings_event_id;
+DROP TABLE provider_event_mappings;
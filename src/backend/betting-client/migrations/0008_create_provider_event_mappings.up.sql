@@ -0,0 +1,38 @@
+CREATE TABLE provider_event_mappings (
+    provider TEXT NOT NULL,
+    provider_event_id TEXT NOT NULL,
+    event_id TEXT NOT NULL,
+    -- Latest values reported by the provider, merged into events by priority
+    event_name TEXT NOT NULL DEFAULT '',
+    home_team TEXT NOT NULL DEFAULT '',
+    away_team TEXT NOT NULL DEFAULT '',
+    home_win_chance REAL NOT NULL DEFAULT 0,
+    away_win_chance REAL NOT NULL DEFAULT 0,
+    draw_chance REAL NOT NULL DEFAULT 0,
+    event_start_date DATETIME NOT NULL,
+    event_end_date DATETIME NOT NULL,
+    event_result TEXT, -- 'HomeWin', 'AwayWin', 'Draw'
+    canceled BOOLEAN NOT NULL DEFAULT 0,
+    type TEXT NOT NULL DEFAULT '',
+    competition TEXT NOT NULL DEFAULT '',
+    received_at DATETIME NOT NULL,
+    PRIMARY KEY (provider, provider_event_id)
+);
+CREATE INDEX idx_provider_event_mappings_event_id ON provider_event_mappings(event_id);
+
+-- Events synced before providers were introduced came from the single source, whose IDs we kept.
+INSERT INTO provider_event_mappings (provider, provider_event_id, event_id, event_name, home_team, away_team,
+                                     home_win_chance, away_win_chance, draw_chance, event_start_date, event_end_date,
+                                     event_result, canceled, type, competition, received_at)
+SELECT 'default', id, id, event_name, home_team, away_team, home_win_chance, away_win_chance, draw_chance,
+       event_start_date, event_end_date, event_result, status = 'Canceled', type, competition, CURRENT_TIMESTAMP
+FROM events;
+
+CREATE TABLE event_result_conflicts (
+    event_id TEXT PRIMARY KEY,
+    results TEXT NOT NULL, -- provider=result pairs, e.g. 'primary=HomeWin,backup=Draw'
+    detected_at DATETIME NOT NULL,
+    resolved_at DATETIME,
+    resolved_result TEXT,
+    FOREIGN KEY (event_id) REFERENCES events(id)
+);
@@ -0,0 +1,2 @@
+DROP TABLE source_sync_state;
+ALTER TABLE events DROP COLUMN content_hash;
@@ -0,0 +1,10 @@
+ALTER TABLE events ADD COLUMN content_hash TEXT NOT NULL DEFAULT ''; -- Hash of the synced fields, empty until the next sync
+
+-- Validators and cursors returned by each provider on its last fully processed fetch
+CREATE TABLE source_sync_state (
+    provider TEXT PRIMARY KEY,
+    etag TEXT NOT NULL DEFAULT '',
+    last_modified TEXT NOT NULL DEFAULT '',
+    cursor TEXT NOT NULL DEFAULT '',
+    updated_at DATETIME NOT NULL
+);
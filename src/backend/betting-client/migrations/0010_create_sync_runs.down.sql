@@ -0,0 +1,2 @@
+DROP TABLE sync_run_errors;
+DROP TABLE sync_runs;
@@ -0,0 +1,37 @@
+CREATE TABLE sync_runs (
+    id TEXT PRIMARY KEY,
+    provider TEXT NOT NULL,
+    kind TEXT NOT NULL, -- 'poll', 'push'
+    status TEXT NOT NULL, -- 'Running', 'Succeeded', 'CompletedWithErrors', 'Failed'
+    started_at DATETIME NOT NULL,
+    finished_at DATETIME,
+    feed_checksum TEXT NOT NULL DEFAULT '',
+    not_modified BOOLEAN NOT NULL DEFAULT 0,
+    incremental BOOLEAN NOT NULL DEFAULT 0,
+    received INTEGER NOT NULL DEFAULT 0,
+    upserted INTEGER NOT NULL DEFAULT 0,
+    unchanged INTEGER NOT NULL DEFAULT 0,
+    failed INTEGER NOT NULL DEFAULT 0,
+    finalize_attempts INTEGER NOT NULL DEFAULT 0,
+    finalize_errors INTEGER NOT NULL DEFAULT 0,
+    cancel_attempts INTEGER NOT NULL DEFAULT 0,
+    cancel_errors INTEGER NOT NULL DEFAULT 0,
+    result_conflicts INTEGER NOT NULL DEFAULT 0,
+    postponed_voided INTEGER NOT NULL DEFAULT 0,
+    postponed_void_errors INTEGER NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT ''
+);
+CREATE INDEX idx_sync_runs_started_at ON sync_runs(started_at);
+CREATE INDEX idx_sync_runs_status_finished_at ON sync_runs(status, finished_at);
+
+CREATE TABLE sync_run_errors (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    run_id TEXT NOT NULL,
+    external_id TEXT NOT NULL DEFAULT '',
+    event_id TEXT NOT NULL DEFAULT '',
+    stage TEXT NOT NULL,
+    message TEXT NOT NULL,
+    occurred_at DATETIME NOT NULL,
+    FOREIGN KEY (run_id) REFERENCES sync_runs(id) ON DELETE CASCADE
+);
+CREATE INDEX idx_sync_run_errors_run_id ON sync_run_errors(run_id);
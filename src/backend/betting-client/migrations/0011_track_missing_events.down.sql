@@ -0,0 +1,6 @@
+ALTER TABLE sync_runs DROP COLUMN missing_void_errors;
+ALTER TABLE sync_runs DROP COLUMN missing_voided;
+ALTER TABLE sync_runs DROP COLUMN missing_suspended;
+ALTER TABLE events DROP COLUMN suspended_at;
+ALTER TABLE provider_event_mappings DROP COLUMN missed_cycles;
+ALTER TABLE provider_event_mappings DROP COLUMN last_seen_at;
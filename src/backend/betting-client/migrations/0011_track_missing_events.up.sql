@@ -0,0 +1,9 @@
+ALTER TABLE provider_event_mappings ADD COLUMN last_seen_at DATETIME;
+ALTER TABLE provider_event_mappings ADD COLUMN missed_cycles INTEGER NOT NULL DEFAULT 0;
+UPDATE provider_event_mappings SET last_seen_at = received_at;
+
+ALTER TABLE events ADD COLUMN suspended_at DATETIME; -- set while status is 'Suspended'
+
+ALTER TABLE sync_runs ADD COLUMN missing_suspended INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE sync_runs ADD COLUMN missing_voided INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE sync_runs ADD COLUMN missing_void_errors INTEGER NOT NULL DEFAULT 0;
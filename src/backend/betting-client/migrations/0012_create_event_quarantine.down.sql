@@ -0,0 +1,3 @@
+DROP INDEX idx_event_quarantine_status_last_seen_at;
+DROP INDEX idx_event_quarantine_pending;
+DROP TABLE event_quarantine;
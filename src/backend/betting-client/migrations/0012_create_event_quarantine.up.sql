@@ -0,0 +1,17 @@
+CREATE TABLE event_quarantine (
+    id TEXT PRIMARY KEY,
+    provider TEXT NOT NULL,
+    external_id TEXT NOT NULL DEFAULT '',
+    payload TEXT NOT NULL, -- raw JSON as received, or as edited by the admin who replayed it
+    error_code TEXT NOT NULL, -- 'invalid_start_date', 'invalid_end_date', 'unknown_result', 'invalid_payload'
+    error_message TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'Pending', -- 'Pending', 'Replayed', 'Dismissed'
+    occurrences INTEGER NOT NULL DEFAULT 1,
+    first_seen_at DATETIME NOT NULL,
+    last_seen_at DATETIME NOT NULL,
+    resolved_at DATETIME,
+    note TEXT NOT NULL DEFAULT ''
+);
+-- A provider event that keeps failing updates its pending entry instead of adding new ones
+CREATE UNIQUE INDEX idx_event_quarantine_pending ON event_quarantine(provider, external_id) WHERE status = 'Pending';
+CREATE INDEX idx_event_quarantine_status_last_seen_at ON event_quarantine(status, last_seen_at);
@@ -0,0 +1,2 @@
+DROP INDEX idx_event_result_confirmations_pending;
+DROP TABLE event_result_confirmations;
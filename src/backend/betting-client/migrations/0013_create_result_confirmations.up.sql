@@ -0,0 +1,12 @@
+CREATE TABLE event_result_confirmations (
+    event_id TEXT PRIMARY KEY,
+    result TEXT NOT NULL, -- 'HomeWin', 'AwayWin', 'Draw'
+    sightings INTEGER NOT NULL DEFAULT 1, -- sync runs in a row that reported this result
+    first_seen_at DATETIME NOT NULL,
+    last_seen_at DATETIME NOT NULL,
+    last_run_id TEXT NOT NULL DEFAULT '',
+    confirmed_at DATETIME,
+    confirmed_by TEXT, -- 'sync' or 'admin'
+    FOREIGN KEY (event_id) REFERENCES events(id)
+);
+CREATE INDEX idx_event_result_confirmations_pending ON event_result_confirmations(first_seen_at) WHERE confirmed_at IS NULL;
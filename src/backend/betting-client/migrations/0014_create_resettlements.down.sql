@@ -0,0 +1,4 @@
+DROP INDEX idx_resettlement_bets_resettlement_id;
+DROP TABLE resettlement_bets;
+DROP INDEX idx_resettlements_event_id;
+DROP TABLE resettlements;
@@ -0,0 +1,35 @@
+CREATE TABLE resettlements (
+    id TEXT PRIMARY KEY,
+    event_id TEXT NOT NULL,
+    previous_result TEXT NOT NULL,
+    new_result TEXT NOT NULL,
+    triggered_by TEXT NOT NULL, -- 'admin', 'sync'
+    reason TEXT NOT NULL DEFAULT '',
+    status TEXT NOT NULL, -- 'Running', 'Completed', 'CompletedWithErrors'
+    started_at DATETIME NOT NULL,
+    finished_at DATETIME,
+    bets_changed INTEGER NOT NULL DEFAULT 0,
+    bets_unchanged INTEGER NOT NULL DEFAULT 0,
+    paid_out REAL NOT NULL DEFAULT 0,
+    clawed_back REAL NOT NULL DEFAULT 0,
+    errors INTEGER NOT NULL DEFAULT 0,
+    FOREIGN KEY (event_id) REFERENCES events(id)
+);
+CREATE INDEX idx_resettlements_event_id ON resettlements(event_id, started_at);
+
+CREATE TABLE resettlement_bets (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    resettlement_id TEXT NOT NULL,
+    bet_id TEXT NOT NULL,
+    user_id TEXT NOT NULL,
+    predicted_outcome TEXT NOT NULL,
+    previous_status TEXT NOT NULL,
+    new_status TEXT NOT NULL,
+    previous_payout REAL NOT NULL,
+    new_payout REAL NOT NULL,
+    adjustment REAL NOT NULL, -- sent to the payout service, negative for clawbacks
+    error TEXT NOT NULL DEFAULT '',
+    FOREIGN KEY (resettlement_id) REFERENCES resettlements(id) ON DELETE CASCADE,
+    FOREIGN KEY (bet_id) REFERENCES bets(id)
+);
+CREATE INDEX idx_resettlement_bets_resettlement_id ON resettlement_bets(resettlement_id);
@@ -0,0 +1,8 @@
+CREATE TABLE leases (
+    name TEXT PRIMARY KEY,
+    holder TEXT NOT NULL,
+    token INTEGER NOT NULL, -- fencing token, increased whenever another holder takes the lease
+    acquired_at DATETIME NOT NULL,
+    renewed_at DATETIME NOT NULL,
+    expires_at DATETIME NOT NULL
+);
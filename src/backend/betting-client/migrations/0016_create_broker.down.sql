@@ -0,0 +1,4 @@
+DROP INDEX idx_consumed_messages_processed_at;
+DROP TABLE consumed_messages;
+DROP TABLE broker_offsets;
+DROP TABLE broker_messages;
@@ -0,0 +1,28 @@
+CREATE TABLE broker_messages (
+    topic TEXT NOT NULL,
+    msg_offset INTEGER NOT NULL,
+    id TEXT NOT NULL,
+    msg_key TEXT NOT NULL DEFAULT '',
+    value BLOB NOT NULL,
+    headers TEXT NOT NULL DEFAULT '{}', -- JSON object
+    published_at DATETIME NOT NULL,
+    PRIMARY KEY (topic, msg_offset)
+);
+
+CREATE TABLE broker_offsets (
+    consumer_group TEXT NOT NULL,
+    topic TEXT NOT NULL,
+    next_offset INTEGER NOT NULL,
+    updated_at DATETIME NOT NULL,
+    PRIMARY KEY (consumer_group, topic)
+);
+
+CREATE TABLE consumed_messages (
+    consumer_group TEXT NOT NULL,
+    message_id TEXT NOT NULL,
+    topic TEXT NOT NULL,
+    msg_offset INTEGER NOT NULL,
+    processed_at DATETIME NOT NULL,
+    PRIMARY KEY (consumer_group, message_id)
+);
+CREATE INDEX idx_consumed_messages_processed_at ON consumed_messages(processed_at);
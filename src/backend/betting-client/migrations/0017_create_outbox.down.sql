@@ -0,0 +1,3 @@
+DROP INDEX idx_outbox_bet_id;
+DROP INDEX idx_outbox_due;
+DROP TABLE outbox;
@@ -0,0 +1,16 @@
+CREATE TABLE outbox (
+    id TEXT PRIMARY KEY,
+    bet_id TEXT NOT NULL,
+    user_id TEXT NOT NULL,
+    amount REAL NOT NULL, -- negative amounts claw back an earlier payout
+    kind TEXT NOT NULL, -- 'win', 'refund', 'adjustment'
+    status TEXT NOT NULL DEFAULT 'Pending', -- 'Pending', 'Delivered', 'Failed', 'Canceled'
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at DATETIME NOT NULL,
+    last_error TEXT,
+    created_at DATETIME NOT NULL,
+    finished_at DATETIME,
+    FOREIGN KEY (bet_id) REFERENCES bets(id)
+);
+CREATE INDEX idx_outbox_due ON outbox(status, next_attempt_at);
+CREATE INDEX idx_outbox_bet_id ON outbox(bet_id);
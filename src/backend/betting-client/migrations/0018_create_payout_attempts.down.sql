@@ -0,0 +1,4 @@
+ALTER TABLE outbox DROP COLUMN resolution_note;
+DROP INDEX idx_payout_attempts_bet_id;
+DROP INDEX idx_payout_attempts_payout_id;
+DROP TABLE payout_attempts;
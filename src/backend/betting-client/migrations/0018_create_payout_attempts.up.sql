@@ -0,0 +1,27 @@
+CREATE TABLE payout_attempts (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    payout_id TEXT NOT NULL,
+    bet_id TEXT NOT NULL,
+    attempt INTEGER NOT NULL, -- counts on across requeues
+    attempted_at DATETIME NOT NULL,
+    error TEXT, -- NULL if the payout was delivered
+    FOREIGN KEY (payout_id) REFERENCES outbox(id)
+);
+CREATE INDEX idx_payout_attempts_payout_id ON payout_attempts(payout_id);
+CREATE INDEX idx_payout_attempts_bet_id ON payout_attempts(bet_id);
+
+ALTER TABLE outbox ADD COLUMN resolution_note TEXT;
+
+-- Bets whose payout failed before the outbox existed were never retried. They are added
+-- as failed payouts, so they can be requeued or resolved like later ones.
+INSERT INTO outbox (id, bet_id, user_id, amount, kind, status, attempts, next_attempt_at, last_error, created_at, finished_at)
+SELECT lower(hex(randomblob(4)) || '-' || hex(randomblob(2)) || '-4' || substr(hex(randomblob(2)), 2) || '-' ||
+             substr('89ab', 1 + (abs(random()) % 4), 1) || substr(hex(randomblob(2)), 2) || '-' || hex(randomblob(6))),
+       b.id, b.user_id, b.payout_amount,
+       CASE WHEN e.event_result = b.predicted_outcome THEN 'win' ELSE 'refund' END,
+       'Failed', 1, CURRENT_TIMESTAMP, 'payout notification failed before the outbox was introduced',
+       CURRENT_TIMESTAMP, CURRENT_TIMESTAMP
+FROM bets b
+JOIN events e ON e.id = b.event_id
+WHERE b.status = 'Failed' AND b.payout_amount > 0
+  AND NOT EXISTS (SELECT 1 FROM outbox o WHERE o.bet_id = b.id);
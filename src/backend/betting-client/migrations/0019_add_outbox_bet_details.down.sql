@@ -0,0 +1,4 @@
+ALTER TABLE outbox DROP COLUMN stake;
+ALTER TABLE outbox DROP COLUMN odds;
+ALTER TABLE outbox DROP COLUMN predicted_outcome;
+ALTER TABLE outbox DROP COLUMN event_id;
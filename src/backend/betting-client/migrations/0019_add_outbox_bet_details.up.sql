@@ -0,0 +1,17 @@
+-- Bet details sent with the payout, taken when the payout is queued.
+ALTER TABLE outbox ADD COLUMN event_id TEXT NOT NULL DEFAULT '';
+ALTER TABLE outbox ADD COLUMN predicted_outcome TEXT NOT NULL DEFAULT '';
+ALTER TABLE outbox ADD COLUMN odds REAL NOT NULL DEFAULT 0;
+ALTER TABLE outbox ADD COLUMN stake REAL NOT NULL DEFAULT 0;
+
+UPDATE outbox SET
+    event_id = (SELECT b.event_id FROM bets b WHERE b.id = outbox.bet_id),
+    predicted_outcome = (SELECT b.predicted_outcome FROM bets b WHERE b.id = outbox.bet_id),
+    odds = (SELECT CASE b.predicted_outcome
+                       WHEN 'HomeWin' THEN b.recorded_home_win_chance
+                       WHEN 'AwayWin' THEN b.recorded_away_win_chance
+                       WHEN 'Draw' THEN b.recorded_draw_chance
+                       ELSE 0
+                   END
+            FROM bets b WHERE b.id = outbox.bet_id),
+    stake = (SELECT b.amount FROM bets b WHERE b.id = outbox.bet_id);
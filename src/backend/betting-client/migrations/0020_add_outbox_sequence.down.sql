@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_outbox_bet_kind_sequence;
+ALTER TABLE outbox DROP COLUMN sequence;
@@ -0,0 +1,5 @@
+-- Numbers the payouts of a bet per kind, so the idempotency key can be built from the
+-- bet instead of the random payout ID. Payouts queued before keep 0 and their old key,
+-- since some of them may already have reached the payout service under it.
+ALTER TABLE outbox ADD COLUMN sequence INTEGER NOT NULL DEFAULT 0;
+CREATE UNIQUE INDEX idx_outbox_bet_kind_sequence ON outbox(bet_id, kind, sequence) WHERE sequence > 0;